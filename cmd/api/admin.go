@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	defaultAdminListLimit = 50
+	maxAdminListLimit     = 1000
+)
+
+type adminEntryResponse struct {
+	cache.Entry
+	Payload json.RawMessage `json:"payload"`
+}
+
+type adminPurgeResponse struct {
+	Purged int `json:"purged"`
+}
+
+type adminErrorResponse struct {
+	Message string `json:"message"`
+}
+
+func writeAdminJSON(w http.ResponseWriter, r *http.Request, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.FromContext(r.Context()).Errorw("Error writing response",
+			"error", err,
+		)
+	}
+}
+
+func writeAdminError(w http.ResponseWriter, r *http.Request, statusCode int, message string) {
+	writeAdminJSON(w, r, statusCode, adminErrorResponse{
+		Message: message,
+	})
+}
+
+// adminAuthenticated only lets through requests with the admin token in their Authorization header
+func adminAuthenticated(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestToken, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(requestToken), []byte(token)) != 1 {
+				writeAdminError(w, r, http.StatusUnauthorized, "Unauthorized")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+type adminHandler struct {
+	admin cache.Admin
+}
+
+func (h *adminHandler) inspect(w http.ResponseWriter, r *http.Request) {
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		writeAdminError(w, r, http.StatusBadRequest, "Missing key")
+		return
+	}
+
+	entry, err := h.admin.Inspect(r.Context(), key)
+	if err != nil {
+		logger.FromContext(r.Context()).Errorw("Error inspecting cache entry",
+			"key", key,
+			"error", err,
+		)
+		writeAdminError(w, r, http.StatusInternalServerError, "Error inspecting cache entry")
+		return
+	}
+	if entry == nil {
+		writeAdminError(w, r, http.StatusNotFound, "No cache entry with this key")
+		return
+	}
+
+	writeAdminJSON(w, r, http.StatusOK, adminEntryResponse{
+		Entry:   *entry,
+		Payload: entry.MarshalPayload(),
+	})
+}
+
+func (h *adminHandler) purge(w http.ResponseWriter, r *http.Request) {
+	keys := r.URL.Query()["key"]
+	if len(keys) == 0 {
+		writeAdminError(w, r, http.StatusBadRequest, "Missing key")
+		return
+	}
+
+	purged, err := h.admin.Purge(r.Context(), keys...)
+	if err != nil {
+		logger.FromContext(r.Context()).Errorw("Error purging cache entries",
+			"keys", keys,
+			"error", err,
+		)
+		writeAdminError(w, r, http.StatusInternalServerError, "Error purging cache entries")
+		return
+	}
+
+	logger.FromContext(r.Context()).Infow("Purged cache entries",
+		"keys", keys,
+		"purged", purged,
+	)
+	writeAdminJSON(w, r, http.StatusOK, adminPurgeResponse{
+		Purged: purged,
+	})
+}
+
+func (h *adminHandler) purgePrefix(w http.ResponseWriter, r *http.Request) {
+	prefix := strings.TrimSuffix(r.URL.Query().Get("prefix"), ":")
+	if prefix == "" {
+		writeAdminError(w, r, http.StatusBadRequest, "Missing prefix")
+		return
+	}
+
+	purged, err := h.admin.PurgePrefix(r.Context(), prefix)
+	if err != nil {
+		logger.FromContext(r.Context()).Errorw("Error purging cache prefix",
+			"prefix", prefix,
+			"error", err,
+		)
+		writeAdminError(w, r, http.StatusInternalServerError, "Error purging cache prefix")
+		return
+	}
+
+	logger.FromContext(r.Context()).Infow("Purged cache prefix",
+		"prefix", prefix,
+		"purged", purged,
+	)
+	writeAdminJSON(w, r, http.StatusOK, adminPurgeResponse{
+		Purged: purged,
+	})
+}
+
+func (h *adminHandler) list(w http.ResponseWriter, r *http.Request) {
+	order := cache.ListOrder(r.URL.Query().Get("order"))
+	switch order {
+	case "":
+		order = cache.ListRecent
+	case cache.ListRecent, cache.ListBiggest:
+	default:
+		writeAdminError(w, r, http.StatusBadRequest, "Invalid order, expected recent or biggest")
+		return
+	}
+
+	limit := defaultAdminListLimit
+	if limitString := r.URL.Query().Get("limit"); limitString != "" {
+		var err error
+		limit, err = strconv.Atoi(limitString)
+		if err != nil || limit <= 0 || limit > maxAdminListLimit {
+			writeAdminError(w, r, http.StatusBadRequest, "Invalid limit, expected a number from 1 to "+strconv.Itoa(maxAdminListLimit))
+			return
+		}
+	}
+
+	entries, err := h.admin.List(r.Context(), order, limit)
+	if err != nil {
+		logger.FromContext(r.Context()).Errorw("Error listing cache entries",
+			"error", err,
+		)
+		writeAdminError(w, r, http.StatusInternalServerError, "Error listing cache entries")
+		return
+	}
+
+	writeAdminJSON(w, r, http.StatusOK, entries)
+}
+
+// newAdminRouter creates the router of the cache admin API.
+// Routes purging and refreshing links are added by the default resolver.
+func newAdminRouter(cfg config.APIConfig, admin cache.Admin) *chi.Mux {
+	router := chi.NewRouter()
+	router.Use(adminAuthenticated(cfg.AdminToken))
+
+	h := &adminHandler{
+		admin: admin,
+	}
+
+	router.Get("/cache/entry", h.inspect)
+	router.Delete("/cache/entry", h.purge)
+	router.Delete("/cache/prefix", h.purgePrefix)
+	router.Get("/cache/entries", h.list)
+
+	return router
+}
+
+func listenAdmin(ctx context.Context, cfg config.APIConfig, router http.Handler) {
+	log := logger.FromContext(ctx)
+
+	srv := &http.Server{
+		Handler:     router,
+		Addr:        cfg.AdminBindAddress,
+		ReadTimeout: 15 * time.Second,
+		BaseContext: func(l net.Listener) context.Context {
+			return ctx
+		},
+	}
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil {
+			log.Errorw("Error hosting cache admin API",
+				"address", cfg.AdminBindAddress,
+				"error", err,
+			)
+		}
+	}()
+}
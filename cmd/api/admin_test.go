@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	qt "github.com/frankban/quicktest"
+)
+
+type adminTestLoader struct{}
+
+func (l *adminTestLoader) Load(ctx context.Context, key string, r *http.Request) ([]byte, *int, *string, time.Duration, error) {
+	return []byte(`{"status":200,"tooltip":"` + key + `"}`), nil, nil, cache.NoSpecialDur, nil
+}
+
+func TestAdminRouter(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+	cfg := config.APIConfig{
+		AdminToken: "secret",
+	}
+
+	linkCache := cache.NewMemoryCache(cfg, cache.NewPrefixKeyProvider("admintest"), &adminTestLoader{}, time.Minute)
+	for _, key := range []string{"a", "b", "c"} {
+		_, err := linkCache.Get(ctx, key, nil)
+		c.Assert(err, qt.IsNil)
+	}
+
+	ts := httptest.NewUnstartedServer(newAdminRouter(cfg, cache.NewMemoryAdmin()))
+	ts.Config.BaseContext = func(l net.Listener) context.Context {
+		return ctx
+	}
+	ts.Start()
+	defer ts.Close()
+
+	request := func(c *qt.C, method string, path string, token string) *http.Response {
+		req, err := http.NewRequestWithContext(ctx, method, ts.URL+path, nil)
+		c.Assert(err, qt.IsNil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res, err := http.DefaultClient.Do(req)
+		c.Assert(err, qt.IsNil)
+		c.Cleanup(func() { res.Body.Close() })
+		return res
+	}
+
+	c.Run("Unauthorized", func(c *qt.C) {
+		for _, token := range []string{"", "wrong"} {
+			res := request(c, http.MethodGet, "/cache/entries", token)
+			c.Assert(res.StatusCode, qt.Equals, http.StatusUnauthorized)
+		}
+	})
+
+	c.Run("Inspect", func(c *qt.C) {
+		res := request(c, http.MethodGet, "/cache/entry?key=admintest:a", "secret")
+		c.Assert(res.StatusCode, qt.Equals, http.StatusOK)
+
+		var entry struct {
+			Key     string          `json:"key"`
+			Payload json.RawMessage `json:"payload"`
+		}
+		c.Assert(json.NewDecoder(res.Body).Decode(&entry), qt.IsNil)
+		c.Assert(entry.Key, qt.Equals, "admintest:a")
+		c.Assert(string(entry.Payload), qt.Equals, `{"status":200,"tooltip":"a"}`)
+
+		res = request(c, http.MethodGet, "/cache/entry?key=admintest:missing", "secret")
+		c.Assert(res.StatusCode, qt.Equals, http.StatusNotFound)
+
+		res = request(c, http.MethodGet, "/cache/entry", "secret")
+		c.Assert(res.StatusCode, qt.Equals, http.StatusBadRequest)
+	})
+
+	c.Run("List", func(c *qt.C) {
+		res := request(c, http.MethodGet, "/cache/entries?order=biggest&limit=2", "secret")
+		c.Assert(res.StatusCode, qt.Equals, http.StatusOK)
+
+		var entries []cache.Entry
+		c.Assert(json.NewDecoder(res.Body).Decode(&entries), qt.IsNil)
+		c.Assert(entries, qt.HasLen, 2)
+
+		for _, path := range []string{"/cache/entries?order=oldest", "/cache/entries?limit=0", "/cache/entries?limit=1001"} {
+			res = request(c, http.MethodGet, path, "secret")
+			c.Assert(res.StatusCode, qt.Equals, http.StatusBadRequest, qt.Commentf(path))
+		}
+	})
+
+	c.Run("Purge", func(c *qt.C) {
+		res := request(c, http.MethodDelete, "/cache/entry?key=admintest:a&key=admintest:missing", "secret")
+		c.Assert(res.StatusCode, qt.Equals, http.StatusOK)
+
+		var purged adminPurgeResponse
+		c.Assert(json.NewDecoder(res.Body).Decode(&purged), qt.IsNil)
+		c.Assert(purged.Purged, qt.Equals, 1)
+		c.Assert(linkCache.GetOnly(ctx, "a"), qt.IsNil)
+	})
+
+	c.Run("Purge prefix", func(c *qt.C) {
+		res := request(c, http.MethodDelete, "/cache/prefix?prefix=admintest:", "secret")
+		c.Assert(res.StatusCode, qt.Equals, http.StatusOK)
+
+		var purged adminPurgeResponse
+		c.Assert(json.NewDecoder(res.Body).Decode(&purged), qt.IsNil)
+		c.Assert(purged.Purged, qt.Equals, 2)
+		c.Assert(linkCache.GetOnly(ctx, "b"), qt.IsNil)
+		c.Assert(linkCache.GetOnly(ctx, "c"), qt.IsNil)
+	})
+}
@@ -99,18 +99,55 @@ func main() {
 	// attach logger to context
 	ctx := logger.OnContext(context.Background(), log)
 
-	pool, err := db.NewPool(ctx, cfg.DSN)
-	if err != nil {
-		log.Fatalw("Error initializing DB pool",
-			"error", err,
+	if !cache.IsValidBackend(cfg.CacheBackend) {
+		log.Fatalw("Unknown cache backend",
+			"cacheBackend", cfg.CacheBackend,
 		)
 	}
 
-	runMigrations(ctx, pool)
+	// The pool is only set up when using the PostgreSQL cache backend
+	var pool db.Pool
+
+	switch cfg.CacheBackend {
+	case cache.BackendMemory:
+		log.Infow("Using in-memory cache, cached values will not persist between restarts")
+
+	case cache.BackendBolt:
+		if err := cache.InitializeBolt(cfg.CacheBoltPath); err != nil {
+			log.Fatalw("Error opening bolt database",
+				"path", cfg.CacheBoltPath,
+				"error", err,
+			)
+		}
+		defer cache.ShutdownBolt()
+
+		go cache.StartBoltCacheClearer(ctx)
+
+	case cache.BackendRedis:
+		// Redis expires entries on its own, so no cache clearer is needed
+		if err := cache.InitializeRedis(ctx, cfg); err != nil {
+			log.Fatalw("Error connecting to redis",
+				"address", cfg.CacheRedisAddress,
+				"error", err,
+			)
+		}
+		defer cache.ShutdownRedis()
+
+	default:
+		pool, err = db.NewPool(ctx, cfg.DSN)
+		if err != nil {
+			log.Fatalw("Error initializing DB pool",
+				"error", err,
+			)
+		}
 
-	go cache.StartCacheClearer(ctx, pool)
+		runMigrations(ctx, pool)
+
+		go cache.StartCacheClearer(ctx, pool)
+	}
 
 	resolver.InitializeStaticResponses(ctx, cfg)
+	resolver.InitializeAddressFilter(ctx, cfg)
 	thumbnail.InitializeConfig(cfg)
 	defer thumbnail.Shutdown()
 
@@ -134,10 +171,25 @@ func main() {
 		listenPrometheus(cfg)
 	}
 
+	// The admin router is nil unless the cache admin API is enabled
+	var adminRouter chi.Router
+	if cfg.EnableAdmin {
+		if cfg.AdminToken == "" {
+			log.Warnw("[Config] admin-token is missing, won't host the cache admin API")
+		} else {
+			adminRouter = newAdminRouter(cfg, cache.NewDefaultAdmin(ctx, cfg, pool))
+		}
+	}
+
 	handleRoot(router)
 	handleHealth(router)
 	handleLegal(router)
-	defaultresolver.Initialize(ctx, cfg, pool, router, helixClient)
+	defaultresolver.Initialize(ctx, cfg, pool, router, adminRouter, helixClient)
+
+	if adminRouter != nil {
+		// Host the cache admin API on cfg.AdminBindAddress (127.0.0.1:9383 by default)
+		listenAdmin(ctx, cfg, adminRouter)
+	}
 
 	listen(ctx, cfg.BindAddress, mountRouter(router, cfg, log), log)
 }
@@ -0,0 +1,38 @@
+//go:build !test || migrationtest
+
+package migration
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+func init() {
+	// The version of this migration
+	const migrationVersion = 4
+
+	Register(
+		migrationVersion,
+		func(ctx context.Context, tx pgx.Tx) error {
+			// The Up action of this migration
+			// Entries with a stale_until are kept around after cached_until, and may be served while
+			// they are being refreshed
+			_, err := tx.Exec(ctx, `
+ALTER TABLE cache
+	ADD stale_until TIMESTAMP
+;`)
+
+			return err
+		},
+		func(ctx context.Context, tx pgx.Tx) error {
+			// The Down action of this migration
+			_, err := tx.Exec(ctx, `
+ALTER TABLE cache
+	DROP stale_until
+;`)
+
+			return err
+		},
+	)
+}
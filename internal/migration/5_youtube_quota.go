@@ -0,0 +1,35 @@
+//go:build !test || migrationtest
+
+package migration
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+func init() {
+	// The version of this migration
+	const migrationVersion = 5
+
+	Register(
+		migrationVersion,
+		func(ctx context.Context, tx pgx.Tx) error {
+			// The Up action of this migration
+			// Units of the YouTube API quota used on each day, days start at midnight Pacific Time
+			_, err := tx.Exec(ctx, `
+CREATE TABLE youtube_quota (
+	day DATE PRIMARY KEY,
+	units INTEGER NOT NULL DEFAULT 0
+);`)
+
+			return err
+		},
+		func(ctx context.Context, tx pgx.Tx) error {
+			// The Down action of this migration
+			_, err := tx.Exec(ctx, `DROP TABLE youtube_quota;`)
+
+			return err
+		},
+	)
+}
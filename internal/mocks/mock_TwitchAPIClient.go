@@ -12,6 +12,7 @@ package mocks
 import (
 	reflect "reflect"
 
+	twitchapiclient "github.com/Chatterino/api/internal/twitchapiclient"
 	helix "github.com/nicklaw5/helix"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -40,6 +41,21 @@ func (m *MockTwitchAPIClient) EXPECT() *MockTwitchAPIClientMockRecorder {
 	return m.recorder
 }
 
+// GetChannelFollowers mocks base method.
+func (m *MockTwitchAPIClient) GetChannelFollowers(params *twitchapiclient.ChannelFollowersParams) (*twitchapiclient.ChannelFollowersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelFollowers", params)
+	ret0, _ := ret[0].(*twitchapiclient.ChannelFollowersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelFollowers indicates an expected call of GetChannelFollowers.
+func (mr *MockTwitchAPIClientMockRecorder) GetChannelFollowers(params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelFollowers", reflect.TypeOf((*MockTwitchAPIClient)(nil).GetChannelFollowers), params)
+}
+
 // GetClips mocks base method.
 func (m *MockTwitchAPIClient) GetClips(params *helix.ClipsParams) (*helix.ClipsResponse, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +71,21 @@ func (mr *MockTwitchAPIClientMockRecorder) GetClips(params any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClips", reflect.TypeOf((*MockTwitchAPIClient)(nil).GetClips), params)
 }
 
+// GetSchedule mocks base method.
+func (m *MockTwitchAPIClient) GetSchedule(params *twitchapiclient.ScheduleParams) (*twitchapiclient.ScheduleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", params)
+	ret0, _ := ret[0].(*twitchapiclient.ScheduleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockTwitchAPIClientMockRecorder) GetSchedule(params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockTwitchAPIClient)(nil).GetSchedule), params)
+}
+
 // GetStreams mocks base method.
 func (m *MockTwitchAPIClient) GetStreams(params *helix.StreamsParams) (*helix.StreamsResponse, error) {
 	m.ctrl.T.Helper()
@@ -84,3 +115,33 @@ func (mr *MockTwitchAPIClientMockRecorder) GetUsers(params any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockTwitchAPIClient)(nil).GetUsers), params)
 }
+
+// GetVideos mocks base method.
+func (m *MockTwitchAPIClient) GetVideos(params *helix.VideosParams) (*helix.VideosResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVideos", params)
+	ret0, _ := ret[0].(*helix.VideosResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVideos indicates an expected call of GetVideos.
+func (mr *MockTwitchAPIClientMockRecorder) GetVideos(params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideos", reflect.TypeOf((*MockTwitchAPIClient)(nil).GetVideos), params)
+}
+
+// SearchCategories mocks base method.
+func (m *MockTwitchAPIClient) SearchCategories(params *twitchapiclient.SearchCategoriesParams) (*twitchapiclient.SearchCategoriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchCategories", params)
+	ret0, _ := ret[0].(*twitchapiclient.SearchCategoriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchCategories indicates an expected call of SearchCategories.
+func (mr *MockTwitchAPIClientMockRecorder) SearchCategories(params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCategories", reflect.TypeOf((*MockTwitchAPIClient)(nil).SearchCategories), params)
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 )
 
@@ -110,7 +111,7 @@ func (l *EmoteLoader) Load(ctx context.Context, emoteHash string, r *http.Reques
 
 	// Build a tooltip using the tooltip template (see tooltipTemplate) with the data we massaged above
 	var tooltip bytes.Buffer
-	if err := tmpl.Execute(&tooltip, data); err != nil {
+	if err := tmpl.Execute(&tooltip, i18n.FromContext(ctx), data); err != nil {
 		return resolver.Errorf("betterttv template error: %s", err)
 	}
 
@@ -118,6 +119,15 @@ func (l *EmoteLoader) Load(ctx context.Context, emoteHash string, r *http.Reques
 		Status:    200,
 		Tooltip:   url.PathEscape(tooltip.String()),
 		Thumbnail: thumbnailURL,
+		Data: &resolver.ResponseData{
+			Kind:   resolver.DataKindEmote,
+			Title:  data.Code,
+			Author: data.Uploader,
+			Fields: map[string]string{
+				"provider": "BetterTTV",
+				"type":     data.Type,
+			},
+		},
 	}, resolver.NoSpecialDur, nil
 }
 
@@ -48,8 +48,8 @@ func NewEmoteResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, e
 	emoteLoader := NewEmoteLoader(emoteAPIURL)
 
 	r := &EmoteResolver{
-		emoteCache: cache.NewPostgreSQLCache(
-			ctx, cfg, pool, cache.NewPrefixKeyProvider("betterttv:emote"),
+		emoteCache: cache.NewDefaultCache(
+			ctx, cfg, pool, cache.NewLocalizedKeyProvider("betterttv:emote"),
 			resolver.NewResponseMarshaller(emoteLoader), cfg.BttvEmoteCacheDuration),
 	}
 
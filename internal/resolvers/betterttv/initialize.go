@@ -3,11 +3,11 @@ package betterttv
 import (
 	"context"
 	"errors"
-	"html/template"
 	"regexp"
 
 	"github.com/Chatterino/api/internal/db"
 	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	"github.com/Chatterino/api/pkg/utils"
 )
@@ -17,8 +17,8 @@ const (
 
 	tooltipTemplate = `<div style="text-align: left;">` +
 		`<b>{{.Code}}</b><br>` +
-		`<b>{{.Type}} BetterTTV Emote</b><br>` +
-		`<b>By:</b> {{.Uploader}}` +
+		`<b>{{t "%s BetterTTV Emote" (t .Type)}}</b><br>` +
+		`<b>{{t "By"}}:</b> {{.Uploader}}` +
 		`</div>`
 )
 
@@ -33,7 +33,7 @@ var (
 
 	emotePathRegex = regexp.MustCompile(`/emotes/([a-f0-9]+)`)
 
-	tmpl = template.Must(template.New("betterttvEmoteTooltip").Parse(tooltipTemplate))
+	tmpl = i18n.MustTemplate("betterttvEmoteTooltip", tooltipTemplate)
 )
 
 func Initialize(ctx context.Context, cfg config.APIConfig, pool db.Pool, resolvers *[]resolver.Resolver) {
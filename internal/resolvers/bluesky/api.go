@@ -0,0 +1,33 @@
+package bluesky
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/resolver"
+)
+
+// buildURL resolves the XRPC method against the base URL, e.g. "app.bsky.feed.getPostThread"
+func buildURL(baseURL *url.URL, method string, query url.Values) string {
+	return baseURL.ResolveReference(&url.URL{Path: method, RawQuery: query.Encode()}).String()
+}
+
+// checkXRPCError handles the errors XRPC methods return as bad requests. Posts that don't exist
+// and handles that can't be resolved are reported as notFound.
+func checkXRPCError(notFound *resolver.Response) func(resp *http.Response) (bool, *resolver.Response, time.Duration, error) {
+	return func(resp *http.Response) (bool, *resolver.Response, time.Duration, error) {
+		if resp.StatusCode != http.StatusBadRequest {
+			return false, nil, cache.NoSpecialDur, nil
+		}
+
+		var errResp errorResponse
+		if err := resolver.DecodeJSON(resp.Body, &errResp); err == nil && (errResp.Error == "NotFound" || errResp.Error == "InvalidRequest") {
+			return true, notFound, cache.NoSpecialDur, nil
+		}
+
+		response, cacheDuration, err := resolver.Errorf("Bluesky API returned error %s: %s", errResp.Error, errResp.Message)
+		return true, response, cacheDuration, err
+	}
+}
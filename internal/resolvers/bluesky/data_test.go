@@ -0,0 +1,86 @@
+package bluesky
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+	"github.com/go-chi/chi/v5"
+)
+
+var posts = map[string][]byte{}
+
+const createdAt = "2024-03-01T12:30:00.000Z"
+
+func init() {
+	thread := func(post string) []byte {
+		return []byte(fmt.Sprintf(`{"thread":{"$type":"app.bsky.feed.defs#threadViewPost","post":%s,"replies":[]}}`, post))
+	}
+
+	posts["at://bsky.app/app.bsky.feed.post/3text"] = thread(`{"uri":"at://did:plc:z72i7hdynmk6r22z27h6tvur/app.bsky.feed.post/3text","author":{"did":"did:plc:z72i7hdynmk6r22z27h6tvur","handle":"bsky.app","displayName":"Bluesky","avatar":"https://cdn.bsky.app/img/avatar/plain/did:plc:z72i7hdynmk6r22z27h6tvur/avatar@jpeg"},"record":{"$type":"app.bsky.feed.post","text":"Hello <world>\nSecond line","createdAt":"` + createdAt + `"},"replyCount":12,"repostCount":3456,"likeCount":78901,"quoteCount":5,"labels":[]}`)
+	posts["at://bsky.app/app.bsky.feed.post/3image"] = thread(`{"uri":"at://did:plc:z72i7hdynmk6r22z27h6tvur/app.bsky.feed.post/3image","author":{"handle":"bsky.app","displayName":""},"record":{"text":"","createdAt":"` + createdAt + `"},"embed":{"$type":"app.bsky.embed.images#view","images":[{"thumb":"https://cdn.bsky.app/img/feed_thumbnail/plain/1@jpeg","fullsize":"https://cdn.bsky.app/img/feed_fullsize/plain/1@jpeg"}]},"replyCount":0,"repostCount":0,"likeCount":1,"quoteCount":0}`)
+	posts["at://bsky.app/app.bsky.feed.post/3video"] = thread(`{"uri":"at://did:plc:z72i7hdynmk6r22z27h6tvur/app.bsky.feed.post/3video","author":{"handle":"bsky.app","displayName":"Bluesky"},"record":{"text":"Video","createdAt":"` + createdAt + `"},"embed":{"$type":"app.bsky.embed.video#view","playlist":"https://video.bsky.app/watch/playlist.m3u8","thumbnail":"https://video.bsky.app/watch/thumbnail.jpg"}}`)
+	posts["at://bsky.app/app.bsky.feed.post/3quote"] = thread(`{"uri":"at://did:plc:z72i7hdynmk6r22z27h6tvur/app.bsky.feed.post/3quote","author":{"handle":"bsky.app","displayName":"Bluesky"},"record":{"text":"Quote","createdAt":"` + createdAt + `"},"embed":{"$type":"app.bsky.embed.recordWithMedia#view","record":{"record":{}},"media":{"$type":"app.bsky.embed.external#view","external":{"uri":"https://example.com","title":"Example","thumb":"https://cdn.bsky.app/img/feed_thumbnail/plain/external@jpeg"}}}}`)
+	posts["at://bsky.app/app.bsky.feed.post/3nsfw"] = thread(`{"uri":"at://did:plc:z72i7hdynmk6r22z27h6tvur/app.bsky.feed.post/3nsfw","author":{"handle":"bsky.app","displayName":"Bluesky"},"record":{"text":"NSFW","createdAt":"` + createdAt + `"},"embed":{"$type":"app.bsky.embed.images#view","images":[{"thumb":"https://cdn.bsky.app/img/feed_thumbnail/plain/nsfw@jpeg"}]},"labels":[{"src":"did:plc:z72i7hdynmk6r22z27h6tvur","val":"porn"}]}`)
+	posts["at://bsky.app/app.bsky.feed.post/3gallery"] = thread(`{"uri":"at://did:plc:z72i7hdynmk6r22z27h6tvur/app.bsky.feed.post/3gallery","author":{"handle":"bsky.app","displayName":"Bluesky"},"record":{"text":"Gallery","createdAt":"` + createdAt + `"},"embed":{"$type":"app.bsky.embed.images#view","images":[{"thumb":"TESTSERVER/img/1.png"},{"thumb":"TESTSERVER/img/2.png"}]}}`)
+	posts["at://did:plc:z72i7hdynmk6r22z27h6tvur/app.bsky.feed.post/3did"] = posts["at://bsky.app/app.bsky.feed.post/3text"]
+	posts["at://bsky.app/app.bsky.feed.post/3deleted"] = []byte(`{"thread":{"$type":"app.bsky.feed.defs#notFoundPost","uri":"at://bsky.app/app.bsky.feed.post/3deleted","notFound":true}}`)
+	posts["at://bsky.app/app.bsky.feed.post/3bad"] = []byte(`xD`)
+}
+
+// testServer serves getPostThread for the posts above. The images of posts are served under /img/.
+func testServer() *httptest.Server {
+	r := chi.NewRouter()
+	r.Get("/xrpc/app.bsky.feed.getPostThread", func(w http.ResponseWriter, r *http.Request) {
+		uri := r.URL.Query().Get("uri")
+
+		if r.URL.Query().Get("depth") != "0" || r.URL.Query().Get("parentHeight") != "0" {
+			http.Error(w, "depth and parentHeight must be 0", http.StatusBadRequest)
+			return
+		}
+
+		switch {
+		case strings.HasSuffix(uri, "/3ratelimited"):
+			http.Error(w, `{"error":"RateLimitExceeded","message":"Rate Limit Exceeded"}`, http.StatusTooManyRequests)
+		case strings.HasPrefix(uri, "at://unknown.handle/"):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"InvalidRequest","message":"Unable to resolve handle"}`))
+		case strings.HasSuffix(uri, "/3internal"):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"InternalServerError","message":"Internal Server Error"}`))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			if response, ok := posts[uri]; ok {
+				w.Write(bytes.ReplaceAll(response, []byte("TESTSERVER"), []byte("http://"+r.Host)))
+			} else {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"NotFound","message":"Post not found: ` + uri + `"}`))
+			}
+		}
+	})
+	r.Get("/img/*", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte(`not an image`))
+	})
+
+	return httptest.NewServer(r)
+}
+
+// runResolver runs the resolver and decodes the response it returned
+func runResolver(c *qt.C, ctx context.Context, r resolver.Resolver, link string) resolver.Response {
+	response, err := r.Run(ctx, utils.MustParseURL(link), nil)
+	c.Assert(err, qt.IsNil)
+
+	var data resolver.Response
+	c.Assert(json.Unmarshal(response.Payload, &data), qt.IsNil)
+	return data
+}
@@ -0,0 +1,39 @@
+package bluesky
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+const blueskyPostTooltipString = `<div style="text-align: left;">` +
+	`<b>{{.Name}} (@{{.Handle}})</b>` +
+	`{{ if .Text }}<br><span style="white-space: pre-wrap; word-wrap: break-word;">{{.Text}}</span>{{ end }}` +
+	`<br><span style="color: #808892;">{{t "%s likes" .Likes}}&nbsp;•&nbsp;{{t "%s reposts" .Reposts}}&nbsp;•&nbsp;{{t "%s replies" .Replies}}&nbsp;•&nbsp;{{.Timestamp}}</span>` +
+	`{{ if .NSFW }}<li><b><span style="color: red;">NSFW</span></b></li>{{ end }}` +
+	`</div>`
+
+var (
+	errInvalidBlueskyPost = errors.New("invalid Bluesky post link")
+
+	blueskyPostTooltip = i18n.MustTemplate("blueskyPostTooltip", blueskyPostTooltipString)
+
+	domains = map[string]struct{}{
+		"bsky.app":     {},
+		"www.bsky.app": {},
+	}
+)
+
+// Initialize registers the Bluesky post resolver. Posts are loaded from the public AppView API,
+// which doesn't need any credentials. Collages of posts with several images are stored in collageCache.
+func Initialize(ctx context.Context, cfg config.APIConfig, pool db.Pool, resolvers *[]resolver.Resolver, collageCache cache.DependentCache) {
+	apiURL := utils.MustParseURL("https://public.api.bsky.app/xrpc/")
+
+	*resolvers = append(*resolvers, NewPostResolver(ctx, cfg, pool, apiURL, collageCache))
+}
@@ -0,0 +1,102 @@
+package bluesky
+
+import "time"
+
+const (
+	threadViewPost   = "app.bsky.feed.defs#threadViewPost"
+	embedImages      = "app.bsky.embed.images#view"
+	embedVideo       = "app.bsky.embed.video#view"
+	embedExternal    = "app.bsky.embed.external#view"
+	embedRecordMedia = "app.bsky.embed.recordWithMedia#view"
+)
+
+type threadResponse struct {
+	Thread struct {
+		// threadViewPost, notFoundPost or blockedPost
+		Type string    `json:"$type"`
+		Post *postView `json:"post"`
+	} `json:"thread"`
+}
+
+// errorResponse is returned by XRPC methods along with a 4xx status code
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+type profileView struct {
+	Handle      string `json:"handle"`
+	DisplayName string `json:"displayName"`
+	Avatar      string `json:"avatar"`
+}
+
+type label struct {
+	Val string `json:"val"`
+}
+
+type postView struct {
+	URI    string      `json:"uri"`
+	Author profileView `json:"author"`
+	Record struct {
+		Text      string    `json:"text"`
+		CreatedAt time.Time `json:"createdAt"`
+	} `json:"record"`
+	Embed       *embedView `json:"embed"`
+	ReplyCount  uint64     `json:"replyCount"`
+	RepostCount uint64     `json:"repostCount"`
+	LikeCount   uint64     `json:"likeCount"`
+	QuoteCount  uint64     `json:"quoteCount"`
+	Labels      []label    `json:"labels"`
+}
+
+type embedView struct {
+	Type string `json:"$type"`
+
+	// Set for images
+	Images []struct {
+		Thumb    string `json:"thumb"`
+		Fullsize string `json:"fullsize"`
+	} `json:"images"`
+
+	// Set for videos
+	Thumbnail string `json:"thumbnail"`
+
+	// Set for link cards
+	External *struct {
+		Thumb string `json:"thumb"`
+	} `json:"external"`
+
+	// Set for quotes with media attached
+	Media *embedView `json:"media"`
+}
+
+// imageURLs returns the URLs of the images shown for the embed, if any
+func (e *embedView) imageURLs() []string {
+	if e == nil {
+		return nil
+	}
+
+	switch e.Type {
+	case embedImages:
+		urls := make([]string, 0, len(e.Images))
+		for _, image := range e.Images {
+			urls = append(urls, image.Thumb)
+		}
+		return urls
+
+	case embedVideo:
+		if e.Thumbnail != "" {
+			return []string{e.Thumbnail}
+		}
+
+	case embedExternal:
+		if e.External != nil && e.External.Thumb != "" {
+			return []string{e.External.Thumb}
+		}
+
+	case embedRecordMedia:
+		return e.Media.imageURLs()
+	}
+
+	return nil
+}
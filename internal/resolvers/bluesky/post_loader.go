@@ -0,0 +1,170 @@
+package bluesky
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/thumbnail"
+)
+
+// Labels of posts whose media must not be shown as thumbnail
+var nsfwLabels = map[string]struct{}{
+	"porn":          {},
+	"sexual":        {},
+	"nudity":        {},
+	"graphic-media": {},
+	"gore":          {},
+}
+
+type blueskyPostTooltipData struct {
+	Name      string
+	Handle    string
+	Text      string
+	Likes     string
+	Reposts   string
+	Replies   string
+	Timestamp string
+	NSFW      bool
+}
+
+type PostLoader struct {
+	apiURL   *url.URL
+	upstream *resolver.Upstream
+
+	baseURL              string
+	postCacheKeyProvider cache.KeyProvider
+	collageCache         cache.DependentCache
+	maxThumbnailSize     uint
+}
+
+// Posts are cached per language, so are their collages (see the Twitter resolver)
+var collageKeyProvider = cache.NewLocalizedKeyProvider("bluesky:collage")
+
+func buildCollageKey(ctx context.Context, key string) string {
+	return collageKeyProvider.CacheKey(ctx, key)
+}
+
+// Load loads a post, keyed by the handle (or DID) of its author and its record key, e.g. "bsky.app/3l3qo2vuowo2b"
+func (l *PostLoader) Load(ctx context.Context, key string, r *http.Request) (*resolver.Response, time.Duration, error) {
+	log := logger.FromContext(ctx)
+
+	log.Debugw("[Bluesky] Get post",
+		"key", key,
+	)
+
+	handle, rkey, ok := strings.Cut(key, "/")
+	if !ok {
+		return noBlueskyPostFound, cache.NoSpecialDur, nil
+	}
+
+	query := url.Values{
+		"uri":          {"at://" + handle + "/app.bsky.feed.post/" + rkey},
+		"depth":        {"0"},
+		"parentHeight": {"0"},
+	}
+
+	var thread threadResponse
+	apiURL := buildURL(l.apiURL, "app.bsky.feed.getPostThread", query)
+	if response, cacheDuration, err := resolver.RequestJSON(ctx, resolver.JSONRequest{
+		Upstream:      l.upstream,
+		API:           "Bluesky API",
+		URL:           apiURL,
+		NotFound:      noBlueskyPostFound,
+		CheckResponse: checkXRPCError(noBlueskyPostFound),
+	}, &thread); response != nil || err != nil {
+		return response, cacheDuration, err
+	}
+
+	// Deleted posts and posts of users who blocked everyone logged out are returned as notFoundPost and blockedPost
+	if thread.Thread.Type != threadViewPost || thread.Thread.Post == nil {
+		return noBlueskyPostFound, cache.NoSpecialDur, nil
+	}
+
+	post := thread.Thread.Post
+	lang := i18n.FromContext(ctx)
+
+	nsfw := false
+	for _, label := range post.Labels {
+		if _, ok := nsfwLabels[label.Val]; ok {
+			nsfw = true
+		}
+	}
+
+	name := post.Author.DisplayName
+	if name == "" {
+		name = post.Author.Handle
+	}
+
+	data := blueskyPostTooltipData{
+		Name:      name,
+		Handle:    post.Author.Handle,
+		Text:      post.Record.Text,
+		Likes:     humanize.NumberIn(lang, post.LikeCount),
+		Reposts:   humanize.NumberIn(lang, post.RepostCount),
+		Replies:   humanize.NumberIn(lang, post.ReplyCount),
+		Timestamp: humanize.CreationDateTimeIn(lang, post.Record.CreatedAt),
+		NSFW:      nsfw,
+	}
+
+	var tooltip bytes.Buffer
+	if err := blueskyPostTooltip.Execute(&tooltip, lang, data); err != nil {
+		return resolver.Errorf("Bluesky post template error: %s", err)
+	}
+
+	thumbnail := ""
+	if !nsfw {
+		thumbnail = l.buildThumbnailURL(ctx, key, post.Embed.imageURLs(), r)
+	}
+
+	return &resolver.Response{
+		Status:    http.StatusOK,
+		Tooltip:   url.PathEscape(tooltip.String()),
+		Thumbnail: thumbnail,
+		Data: &resolver.ResponseData{
+			Kind:        resolver.DataKindPost,
+			Description: post.Record.Text,
+			Author:      name,
+			Likes:       post.LikeCount,
+			Comments:    post.ReplyCount,
+			Published:   post.Record.CreatedAt.Format(time.RFC3339),
+			NSFW:        nsfw,
+			Fields: map[string]string{
+				"handle":  post.Author.Handle,
+				"reposts": strconv.FormatUint(post.RepostCount, 10),
+				"quotes":  strconv.FormatUint(post.QuoteCount, 10),
+			},
+		},
+	}, cache.NoSpecialDur, nil
+}
+
+// buildThumbnailURL uses the only image of a post as its thumbnail, and composes a collage if it has several
+func (l *PostLoader) buildThumbnailURL(ctx context.Context, key string, imageURLs []string, r *http.Request) string {
+	switch len(imageURLs) {
+	case 0:
+		return ""
+	case 1:
+		return imageURLs[0]
+	}
+
+	collage := &thumbnail.Collage{
+		BaseURL:      l.baseURL,
+		CollageCache: l.collageCache,
+		ParentKey:    l.postCacheKeyProvider.CacheKey(ctx, key),
+		CollageKey:   buildCollageKey(ctx, key),
+		Options: thumbnail.CollageOptions{
+			MaxSize: l.maxThumbnailSize,
+		},
+	}
+
+	return collage.BuildThumbnailURL(ctx, imageURLs, r)
+}
@@ -0,0 +1,78 @@
+package bluesky
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+// Matches post links, e.g. /profile/bsky.app/post/3l3qo2vuowo2b or /profile/did:plc:z72i7hdynmk6r22z27h6tvur/post/3l3qo2vuowo2b
+var postRegex = regexp.MustCompile(`^\/profile\/([a-zA-Z0-9.:-]+)\/post\/([a-zA-Z0-9]+)\/?$`)
+
+type PostResolver struct {
+	postCache cache.Cache
+}
+
+// postKey returns the cache key of the post the URL links to, or an empty string if it's not a post link
+func postKey(url *url.URL) string {
+	if !utils.IsDomains(url, domains) {
+		return ""
+	}
+
+	match := postRegex.FindStringSubmatch(url.Path)
+	if len(match) != 3 {
+		return ""
+	}
+
+	// Handles are case-insensitive, record keys aren't
+	return strings.ToLower(match[1]) + "/" + match[2]
+}
+
+func (r *PostResolver) Check(ctx context.Context, url *url.URL) (context.Context, bool) {
+	return ctx, postKey(url) != ""
+}
+
+func (r *PostResolver) Run(ctx context.Context, url *url.URL, req *http.Request) (*cache.Response, error) {
+	key := postKey(url)
+	if key == "" {
+		return nil, errInvalidBlueskyPost
+	}
+
+	return r.postCache.Get(ctx, key, req)
+}
+
+func (r *PostResolver) Name() string {
+	return "bluesky:post"
+}
+
+func NewPostResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, apiURL *url.URL, collageCache cache.DependentCache) *PostResolver {
+	postCacheKeyProvider := cache.NewLocalizedKeyProvider("bluesky:post")
+
+	postLoader := &PostLoader{
+		apiURL:   apiURL,
+		upstream: resolver.NewUpstream(cfg, "bluesky"),
+
+		baseURL:              cfg.BaseURL,
+		postCacheKeyProvider: postCacheKeyProvider,
+		collageCache:         collageCache,
+		maxThumbnailSize:     cfg.MaxThumbnailSize,
+	}
+
+	postCache := cache.NewDefaultCache(ctx, cfg, pool, postCacheKeyProvider,
+		resolver.NewResponseMarshaller(postLoader), cfg.BlueskyPostCacheDuration)
+	postCache.RegisterDependent(ctx, collageCache)
+
+	r := &PostResolver{
+		postCache: postCache,
+	}
+
+	return r
+}
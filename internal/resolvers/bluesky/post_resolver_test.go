@@ -0,0 +1,176 @@
+package bluesky
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+)
+
+// runResolver runs the resolver and decodes the response it returned
+func TestPostResolver(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		BaseURL:          "https://api.example.com",
+		CacheBackend:     cache.BackendMemory,
+		MaxThumbnailSize: 300,
+	}
+	ts := testServer()
+	defer ts.Close()
+	apiURL := utils.MustParseURL(ts.URL + "/xrpc/")
+	collageCache := cache.NewMemoryDependentCache(ctx, cfg, cache.NewPrefixKeyProvider("bluesky:collage"))
+
+	postResolver := NewPostResolver(ctx, cfg, nil, apiURL, collageCache)
+
+	c.Assert(postResolver, qt.IsNotNil)
+
+	c.Run("Name", func(c *qt.C) {
+		c.Assert(postResolver.Name(), qt.Equals, "bluesky:post")
+	})
+
+	c.Run("Check", func(c *qt.C) {
+		type checkTest struct {
+			input    *url.URL
+			expected bool
+		}
+
+		tests := []checkTest{
+			{utils.MustParseURL("https://bsky.app/profile/bsky.app/post/3l3qo2vuowo2b"), true},
+			{utils.MustParseURL("https://bsky.app/profile/did:plc:z72i7hdynmk6r22z27h6tvur/post/3l3qo2vuowo2b/"), true},
+			{utils.MustParseURL("https://www.bsky.app/profile/Bsky.App/post/3l3qo2vuowo2b?ref=share"), true},
+			{utils.MustParseURL("https://bsky.app/profile/bsky.app"), false},
+			{utils.MustParseURL("https://bsky.app/profile/bsky.app/post/"), false},
+			{utils.MustParseURL("https://bsky.app/profile/bsky.app/post/3l3qo2vuowo2b/liked-by"), false},
+			{utils.MustParseURL("https://notbsky.app/profile/bsky.app/post/3l3qo2vuowo2b"), false},
+		}
+
+		for _, test := range tests {
+			c.Run(test.input.String(), func(c *qt.C) {
+				_, output := postResolver.Check(ctx, test.input)
+				c.Assert(output, qt.Equals, test.expected)
+			})
+		}
+	})
+
+	c.Run("Run", func(c *qt.C) {
+		c.Run("Post", func(c *qt.C) {
+			data := runResolver(c, ctx, postResolver, "https://bsky.app/profile/Bsky.App/post/3text")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "")
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Equals, `<div style="text-align: left;">`+
+				`<b>Bluesky (@bsky.app)</b>`+
+				`<br><span style="white-space: pre-wrap; word-wrap: break-word;">Hello &lt;world&gt;`+"\n"+`Second line</span>`+
+				`<br><span style="color: #808892;">78,901 likes&nbsp;•&nbsp;3,456 reposts&nbsp;•&nbsp;12 replies&nbsp;•&nbsp;01 Mar 2024 • 12:30 UTC</span>`+
+				`</div>`)
+
+			c.Assert(data.Data, qt.DeepEquals, &resolver.ResponseData{
+				Kind:        resolver.DataKindPost,
+				Description: "Hello <world>\nSecond line",
+				Author:      "Bluesky",
+				Likes:       78901,
+				Comments:    12,
+				Published:   "2024-03-01T12:30:00Z",
+				Fields: map[string]string{
+					"handle":  "bsky.app",
+					"reposts": "3456",
+					"quotes":  "5",
+				},
+			})
+		})
+
+		c.Run("DID", func(c *qt.C) {
+			data := runResolver(c, ctx, postResolver, "https://bsky.app/profile/did:plc:z72i7hdynmk6r22z27h6tvur/post/3did")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Data.Author, qt.Equals, "Bluesky")
+		})
+
+		c.Run("Image", func(c *qt.C) {
+			data := runResolver(c, ctx, postResolver, "https://bsky.app/profile/bsky.app/post/3image")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "https://cdn.bsky.app/img/feed_thumbnail/plain/1@jpeg")
+
+			// Posts without a display name and text only show the handle
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Contains, `<b>bsky.app (@bsky.app)</b><br><span style="color: #808892;">1 likes`)
+		})
+
+		c.Run("Video", func(c *qt.C) {
+			data := runResolver(c, ctx, postResolver, "https://bsky.app/profile/bsky.app/post/3video")
+			c.Assert(data.Thumbnail, qt.Equals, "https://video.bsky.app/watch/thumbnail.jpg")
+		})
+
+		c.Run("Quote with link card", func(c *qt.C) {
+			data := runResolver(c, ctx, postResolver, "https://bsky.app/profile/bsky.app/post/3quote")
+			c.Assert(data.Thumbnail, qt.Equals, "https://cdn.bsky.app/img/feed_thumbnail/plain/external@jpeg")
+		})
+
+		c.Run("Gallery", func(c *qt.C) {
+			// None of the images can be decoded, so no collage is built
+			data := runResolver(c, ctx, postResolver, "https://bsky.app/profile/bsky.app/post/3gallery")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "")
+		})
+
+		c.Run("NSFW", func(c *qt.C) {
+			data := runResolver(c, ctx, postResolver, "https://bsky.app/profile/bsky.app/post/3nsfw")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "")
+			c.Assert(data.Data.NSFW, qt.IsTrue)
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Contains, `<li><b><span style="color: red;">NSFW</span></b></li>`)
+		})
+
+		c.Run("German", func(c *qt.C) {
+			data := runResolver(c, i18n.OnContext(ctx, i18n.German), postResolver, "https://bsky.app/profile/bsky.app/post/3text")
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Contains, `78.901 Likes&nbsp;•&nbsp;3.456 Reposts&nbsp;•&nbsp;12 Antworten`)
+		})
+
+		c.Run("Not found", func(c *qt.C) {
+			for _, link := range []string{
+				"https://bsky.app/profile/bsky.app/post/3missing",
+				"https://bsky.app/profile/bsky.app/post/3deleted",
+				"https://bsky.app/profile/unknown.handle/post/3text",
+			} {
+				data := runResolver(c, ctx, postResolver, link)
+				c.Assert(data.Status, qt.Equals, http.StatusNotFound)
+				c.Assert(data.Message, qt.Equals, "No Bluesky post with this ID found")
+			}
+		})
+
+		c.Run("API error", func(c *qt.C) {
+			data := runResolver(c, ctx, postResolver, "https://bsky.app/profile/bsky.app/post/3internal")
+			c.Assert(data.Status, qt.Equals, http.StatusInternalServerError)
+			c.Assert(data.Message, qt.Contains, "Bluesky API returned error InternalServerError")
+		})
+
+		c.Run("Bad JSON", func(c *qt.C) {
+			data := runResolver(c, ctx, postResolver, "https://bsky.app/profile/bsky.app/post/3bad")
+			c.Assert(data.Status, qt.Equals, http.StatusInternalServerError)
+			c.Assert(data.Message, qt.Contains, "Bluesky API unmarshal error")
+		})
+
+		c.Run("Rate limited", func(c *qt.C) {
+			_, err := postResolver.Run(ctx, utils.MustParseURL("https://bsky.app/profile/bsky.app/post/3ratelimited"), nil)
+			c.Assert(err, qt.ErrorIs, resolver.ErrUpstreamUnavailable)
+		})
+	})
+}
@@ -0,0 +1,12 @@
+package bluesky
+
+import (
+	"net/http"
+
+	"github.com/Chatterino/api/pkg/resolver"
+)
+
+var noBlueskyPostFound = &resolver.Response{
+	Status:  http.StatusNotFound,
+	Message: "No Bluesky post with this ID found",
+}
@@ -0,0 +1,44 @@
+package declarative
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/go-chi/chi/v5"
+)
+
+var (
+	data_raw = map[string][]byte{}
+)
+
+func init() {
+	data_raw["forsen"] = []byte(`{"user":{"username":"forsen","bio":"<3","followers":1234567,"created_at":"2015-09-25T10:00:00Z","avatar":"https://example.com/forsen.png"},"live":true,"tags":["gaming","irl"]}`)
+	data_raw["pajlada"] = []byte(`{"user":{"username":"pajlada","followers":12,"created_at":"2016-01-16T10:00:00Z"},"live":false,"tags":[]}`)
+	data_raw["bad"] = []byte(`xD`)
+}
+
+func testServer() *httptest.Server {
+	r := chi.NewRouter()
+	r.Get("/api/users/{user}", func(w http.ResponseWriter, r *http.Request) {
+		user := chi.URLParam(r, "user")
+
+		if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("X-Client") != "chatterino" {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		if user == "ratelimited" {
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if response, ok := data_raw[user]; ok {
+			w.Write(response)
+		} else {
+			http.Error(w, http.StatusText(404), 404)
+		}
+	})
+	return httptest.NewServer(r)
+}
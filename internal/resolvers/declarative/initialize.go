@@ -0,0 +1,85 @@
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	"golang.org/x/text/language"
+)
+
+// tooltipFuncs are the functions available in the tooltip templates of site resolvers, in addition to t
+func tooltipFuncs(lang language.Tag) map[string]any {
+	return map[string]any{
+		// Formats a number, e.g. {{number .Fields.viewers}} shows 12,345
+		"number": func(value any) string {
+			if number, ok := toInt64(value); ok {
+				return humanize.NumberInt64In(lang, number)
+			}
+			return fmt.Sprint(value)
+		},
+		// Formats an RFC 3339 timestamp as a date, e.g. {{date .Fields.created_at}}
+		"date": func(value any) string {
+			return humanize.CreationDateRFC3339In(lang, fmt.Sprint(value))
+		},
+		// Shortens a value to the maximum length, e.g. {{truncate 100 .Fields.description}}
+		"truncate": func(maxLength int, value any) string {
+			return utils.TruncateString(fmt.Sprint(value), maxLength)
+		},
+	}
+}
+
+// toInt64 converts numbers decoded from the API response to an int64
+func toInt64(value any) (int64, bool) {
+	switch value := value.(type) {
+	case json.Number:
+		if number, err := value.Int64(); err == nil {
+			return number, true
+		}
+		if number, err := value.Float64(); err == nil {
+			return int64(number), true
+		}
+	case string:
+		if number, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return number, true
+		}
+	}
+
+	return 0, false
+}
+
+// Initialize adds the resolver described by an entry of the site-resolvers config
+func Initialize(ctx context.Context, cfg config.APIConfig, pool db.Pool, site config.SiteResolver, resolvers *[]resolver.Resolver) {
+	log := logger.FromContext(ctx)
+
+	var token string
+	if site.Auth.Env != "" {
+		token = os.Getenv(site.Auth.Env)
+		if token == "" {
+			log.Warnw("[Config] Token of site resolver is missing, won't do special responses for it",
+				"name", site.Name,
+				"env", site.Auth.Env,
+			)
+			return
+		}
+	}
+
+	siteResolver, err := NewResolver(ctx, cfg, pool, site, token)
+	if err != nil {
+		log.Warnw("[Config] Invalid site resolver, won't do special responses for it",
+			"name", site.Name,
+			"error", err,
+		)
+		return
+	}
+
+	*resolvers = append(*resolvers, siteResolver)
+}
@@ -0,0 +1,102 @@
+package declarative
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var errInvalidJSONPath = errors.New("invalid JSONPath")
+
+// jsonPathSegment selects either a key of an object or an index of an array
+type jsonPathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// jsonPath is a parsed JSONPath expression. Only the subset selecting a single value is supported:
+// the root ($), children ($.a.b or $['a']['b']) and array indexes ($.a[0], negative indexes count
+// from the end).
+type jsonPath []jsonPathSegment
+
+func parseJSONPath(expression string) (jsonPath, error) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(expression), "$")
+	if !ok {
+		return nil, fmt.Errorf("%w %q: must start with $", errInvalidJSONPath, expression)
+	}
+
+	path := jsonPath{}
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("%w %q: empty key", errInvalidJSONPath, expression)
+			}
+			path = append(path, jsonPathSegment{key: rest[:end]})
+			rest = rest[end:]
+
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("%w %q: missing ]", errInvalidJSONPath, expression)
+			}
+			selector := rest[1:end]
+			rest = rest[end+1:]
+
+			if len(selector) >= 2 && (selector[0] == '\'' || selector[0] == '"') && selector[len(selector)-1] == selector[0] {
+				path = append(path, jsonPathSegment{key: selector[1 : len(selector)-1]})
+				continue
+			}
+
+			index, err := strconv.Atoi(selector)
+			if err != nil {
+				return nil, fmt.Errorf("%w %q: unsupported selector [%s]", errInvalidJSONPath, expression, selector)
+			}
+			path = append(path, jsonPathSegment{index: index, isIndex: true})
+
+		default:
+			return nil, fmt.Errorf("%w %q: unexpected %q", errInvalidJSONPath, expression, rest[0])
+		}
+	}
+
+	return path, nil
+}
+
+// lookup returns the value selected by the path from a value decoded by encoding/json
+func (p jsonPath) lookup(value any) (any, bool) {
+	for _, segment := range p {
+		if segment.isIndex {
+			array, ok := value.([]any)
+			if !ok {
+				return nil, false
+			}
+
+			index := segment.index
+			if index < 0 {
+				index += len(array)
+			}
+			if index < 0 || index >= len(array) {
+				return nil, false
+			}
+			value = array[index]
+			continue
+		}
+
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[segment.key]; !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
@@ -0,0 +1,58 @@
+package declarative
+
+import (
+	"encoding/json"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestJSONPath(t *testing.T) {
+	c := qt.New(t)
+
+	var document any
+	err := json.Unmarshal([]byte(`{
+		"user": {"name": "forsen", "tags": ["a", "b", "c"]},
+		"with.dot": true,
+		"items": [{"id": 1}, {"id": 2}]
+	}`), &document)
+	c.Assert(err, qt.IsNil)
+
+	type testCase struct {
+		expression string
+		expected   any
+		found      bool
+	}
+
+	tests := []testCase{
+		{"$", document, true},
+		{"$.user.name", "forsen", true},
+		{"$['user']['name']", "forsen", true},
+		{`$["with.dot"]`, true, true},
+		{"$.user.tags[1]", "b", true},
+		{"$.user.tags[-1]", "c", true},
+		{"$.items[1].id", float64(2), true},
+		{"$.user.missing", nil, false},
+		{"$.user.tags[3]", nil, false},
+		{"$.user.name.first", nil, false},
+		{"$.items.id", nil, false},
+	}
+
+	for _, test := range tests {
+		c.Run(test.expression, func(c *qt.C) {
+			path, err := parseJSONPath(test.expression)
+			c.Assert(err, qt.IsNil)
+
+			value, found := path.lookup(document)
+			c.Assert(found, qt.Equals, test.found)
+			c.Assert(value, qt.DeepEquals, test.expected)
+		})
+	}
+
+	c.Run("Invalid", func(c *qt.C) {
+		for _, expression := range []string{"", "user.name", "$.", "$..name", "$.tags[", "$.tags[*]", "$name"} {
+			_, err := parseJSONPath(expression)
+			c.Assert(err, qt.ErrorIs, errInvalidJSONPath, qt.Commentf(expression))
+		}
+	})
+}
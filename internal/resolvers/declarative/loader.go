@@ -0,0 +1,158 @@
+package declarative
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+)
+
+// TooltipData is passed to the tooltip templates of site resolvers
+type TooltipData struct {
+	// Named groups of the host and path regular expressions
+	Match map[string]string
+	// Values selected from the API response, missing values are nil
+	Fields map[string]any
+}
+
+type Loader struct {
+	name string
+
+	apiURL  *template.Template
+	headers map[string]string
+
+	fields    map[string]jsonPath
+	tooltip   *i18n.Template
+	thumbnail jsonPath
+	kind      string
+
+	upstream *resolver.Upstream
+}
+
+func (l *Loader) buildURL(match map[string]string) (string, error) {
+	escaped := make(map[string]string, len(match))
+	for name, value := range match {
+		escaped[name] = url.PathEscape(value)
+	}
+
+	var apiURL strings.Builder
+	if err := l.apiURL.Execute(&apiURL, escaped); err != nil {
+		return "", err
+	}
+
+	return apiURL.String(), nil
+}
+
+func (l *Loader) Load(ctx context.Context, key string, r *http.Request) (*resolver.Response, time.Duration, error) {
+	log := logger.FromContext(ctx)
+	lang := i18n.FromContext(ctx)
+	log.Debugw("[SiteResolver] Load",
+		"name", l.name,
+		"key", key,
+	)
+
+	captures, err := url.ParseQuery(key)
+	if err != nil {
+		return resolver.Errorf("%s invalid key: %s", l.name, err)
+	}
+	match := make(map[string]string, len(captures))
+	for name := range captures {
+		match[name] = captures.Get(name)
+	}
+
+	apiURL, err := l.buildURL(match)
+	if err != nil {
+		return resolver.Errorf("%s url template error: %s", l.name, err)
+	}
+
+	var body json.RawMessage
+	if response, cacheDuration, err := resolver.RequestJSON(ctx, resolver.JSONRequest{
+		Upstream: l.upstream,
+		API:      l.name + " API",
+		URL:      apiURL,
+		Headers:  l.headers,
+		NotFound: &resolver.Response{
+			Status:  http.StatusNotFound,
+			Message: "Nothing found for this link",
+		},
+	}, &body); response != nil || err != nil {
+		return response, cacheDuration, err
+	}
+
+	// Numbers are kept as they are, so IDs and big counts aren't shown in scientific notation
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return resolver.Errorf("%s API unmarshal error: %s", l.name, err)
+	}
+
+	data := TooltipData{
+		Match:  match,
+		Fields: make(map[string]any, len(l.fields)),
+	}
+	for name, path := range l.fields {
+		data.Fields[name], _ = path.lookup(document)
+	}
+
+	var tooltip bytes.Buffer
+	if err := l.tooltip.Execute(&tooltip, lang, data); err != nil {
+		return resolver.Errorf("%s template error: %s", l.name, err)
+	}
+
+	response := &resolver.Response{
+		Status:  200,
+		Tooltip: url.PathEscape(tooltip.String()),
+	}
+
+	if l.thumbnail != nil {
+		if thumbnail, ok := l.thumbnail.lookup(document); ok {
+			response.Thumbnail, _ = thumbnail.(string)
+		}
+	}
+
+	if l.kind != "" {
+		response.Data = l.responseData(data.Fields)
+	}
+
+	return response, cache.NoSpecialDur, nil
+}
+
+// responseData builds the structured tooltip data from the fields. Fields named title, description
+// and author fill the fields of the same name.
+func (l *Loader) responseData(fields map[string]any) *resolver.ResponseData {
+	data := &resolver.ResponseData{
+		Kind:   l.kind,
+		Fields: make(map[string]string, len(fields)),
+	}
+
+	for name, value := range fields {
+		if value == nil {
+			continue
+		}
+
+		switch name {
+		case "title":
+			data.Title = fmt.Sprint(value)
+		case "description":
+			data.Description = fmt.Sprint(value)
+		case "author":
+			data.Author = fmt.Sprint(value)
+		default:
+			data.Fields[name] = fmt.Sprint(value)
+		}
+	}
+
+	return data
+}
@@ -0,0 +1,167 @@
+package declarative
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+)
+
+const defaultCacheDuration = time.Hour
+
+type Resolver struct {
+	name string
+
+	hostRegex *regexp.Regexp
+	pathRegex *regexp.Regexp
+
+	siteCache cache.Cache
+}
+
+func (r *Resolver) Check(ctx context.Context, url *url.URL) (context.Context, bool) {
+	if !r.hostRegex.MatchString(strings.ToLower(url.Hostname())) {
+		return ctx, false
+	}
+
+	if r.pathRegex != nil && !r.pathRegex.MatchString(url.Path) {
+		return ctx, false
+	}
+
+	return ctx, true
+}
+
+// captures returns the named groups of the host and path regular expressions matched by the URL
+func (r *Resolver) captures(url *url.URL) url.Values {
+	captures := make(map[string][]string)
+
+	add := func(regex *regexp.Regexp, s string) {
+		if regex == nil {
+			return
+		}
+
+		matches := regex.FindStringSubmatch(s)
+		for i, name := range regex.SubexpNames() {
+			if name != "" && i < len(matches) {
+				captures[name] = []string{matches[i]}
+			}
+		}
+	}
+
+	add(r.hostRegex, strings.ToLower(url.Hostname()))
+	add(r.pathRegex, url.Path)
+
+	return captures
+}
+
+func (r *Resolver) Run(ctx context.Context, url *url.URL, req *http.Request) (*cache.Response, error) {
+	// The captures are all the loader needs to build the API request, so they make up the key
+	return r.siteCache.Get(ctx, r.captures(url).Encode(), req)
+}
+
+func (r *Resolver) Name() string {
+	return r.name
+}
+
+// NewResolver creates the resolver described by an entry of the site-resolvers config.
+// token is sent as described by site.Auth if it's not empty.
+func NewResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, site config.SiteResolver, token string) (*Resolver, error) {
+	if site.Name == "" {
+		return nil, errors.New("missing name")
+	}
+	if site.Host == "" {
+		return nil, errors.New("missing host")
+	}
+	if site.URL == "" {
+		return nil, errors.New("missing url")
+	}
+	if site.Tooltip == "" {
+		return nil, errors.New("missing tooltip")
+	}
+
+	hostRegex, err := regexp.Compile(site.Host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host: %w", err)
+	}
+
+	var pathRegex *regexp.Regexp
+	if site.Path != "" {
+		if pathRegex, err = regexp.Compile(site.Path); err != nil {
+			return nil, fmt.Errorf("invalid path: %w", err)
+		}
+	}
+
+	apiURL, err := template.New(site.Name + ":url").Option("missingkey=error").Parse(site.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+
+	tooltip, err := i18n.ParseTemplate(site.Name+":tooltip", site.Tooltip, tooltipFuncs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tooltip: %w", err)
+	}
+
+	fields := make(map[string]jsonPath, len(site.Fields))
+	for name, expression := range site.Fields {
+		if fields[name], err = parseJSONPath(expression); err != nil {
+			return nil, fmt.Errorf("invalid field %s: %w", name, err)
+		}
+	}
+
+	var thumbnail jsonPath
+	if site.Thumbnail != "" {
+		if thumbnail, err = parseJSONPath(site.Thumbnail); err != nil {
+			return nil, fmt.Errorf("invalid thumbnail: %w", err)
+		}
+	}
+
+	headers := make(map[string]string, len(site.Headers)+1)
+	for key, value := range site.Headers {
+		headers[key] = value
+	}
+	if token != "" {
+		header := site.Auth.Header
+		if header == "" {
+			header = "Authorization"
+		}
+		headers[header] = site.Auth.Prefix + token
+	}
+
+	cacheDuration := site.CacheDuration
+	if cacheDuration <= 0 {
+		cacheDuration = defaultCacheDuration
+	}
+
+	loader := &Loader{
+		name:      site.Name,
+		apiURL:    apiURL,
+		headers:   headers,
+		fields:    fields,
+		tooltip:   tooltip,
+		thumbnail: thumbnail,
+		kind:      site.Kind,
+		upstream:  resolver.NewUpstream(cfg, site.Name),
+	}
+
+	r := &Resolver{
+		name:      site.Name,
+		hostRegex: hostRegex,
+		pathRegex: pathRegex,
+		siteCache: cache.NewDefaultCache(
+			ctx, cfg, pool, cache.NewLocalizedKeyProvider(site.Name),
+			resolver.NewResponseMarshaller(loader), cacheDuration,
+		),
+	}
+
+	return r, nil
+}
@@ -0,0 +1,241 @@
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+)
+
+const testTooltip = `<b>{{.Match.user}}</b>` +
+	`{{ if .Fields.bio }}<br>{{.Fields.bio}}{{ end }}` +
+	`<br><b>{{t "Followers"}}:</b> {{number .Fields.followers}}` +
+	`<br><b>{{t "Created"}}:</b> {{date .Fields.created}}` +
+	`{{ if .Fields.live }}<br><b>{{t "Live"}}</b>{{ end }}`
+
+func testSite(apiURL string) config.SiteResolver {
+	return config.SiteResolver{
+		Name: "example:user",
+		Host: `^(www\.)?example\.com$`,
+		Path: `^/@(?P<user>[a-zA-Z0-9_]+)/?$`,
+		URL:  apiURL + "/api/users/{{.user}}",
+		Headers: map[string]string{
+			"X-Client": "chatterino",
+		},
+		Auth: config.SiteResolverAuth{
+			Env:    "EXAMPLE_TOKEN",
+			Prefix: "Bearer ",
+		},
+		Fields: map[string]string{
+			"bio":       "$.user.bio",
+			"followers": "$.user.followers",
+			"created":   "$.user.created_at",
+			"live":      "$.live",
+			"tag":       "$.tags[0]",
+		},
+		Tooltip:   testTooltip,
+		Thumbnail: "$.user.avatar",
+		Kind:      resolver.DataKindUser,
+	}
+}
+
+func TestResolver(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		CacheBackend: cache.BackendMemory,
+	}
+	ts := testServer()
+	defer ts.Close()
+
+	siteResolver, err := NewResolver(ctx, cfg, nil, testSite(ts.URL), "secret")
+	c.Assert(err, qt.IsNil)
+
+	c.Run("Name", func(c *qt.C) {
+		c.Assert(siteResolver.Name(), qt.Equals, "example:user")
+	})
+
+	c.Run("Check", func(c *qt.C) {
+		type checkTest struct {
+			input    *url.URL
+			expected bool
+		}
+
+		tests := []checkTest{
+			{utils.MustParseURL("https://example.com/@forsen"), true},
+			{utils.MustParseURL("https://WWW.example.com/@forsen/"), true},
+			{utils.MustParseURL("https://example.com/forsen"), false},
+			{utils.MustParseURL("https://example.com/@forsen/videos"), false},
+			{utils.MustParseURL("https://example.org/@forsen"), false},
+			{utils.MustParseURL("https://notexample.com/@forsen"), false},
+		}
+
+		for _, test := range tests {
+			c.Run(test.input.String(), func(c *qt.C) {
+				_, output := siteResolver.Check(ctx, test.input)
+				c.Assert(output, qt.Equals, test.expected)
+			})
+		}
+	})
+
+	c.Run("Run", func(c *qt.C) {
+		run := func(c *qt.C, ctx context.Context, link string) resolver.Response {
+			response, err := siteResolver.Run(ctx, utils.MustParseURL(link), nil)
+			c.Assert(err, qt.IsNil)
+
+			var data resolver.Response
+			c.Assert(json.Unmarshal(response.Payload, &data), qt.IsNil)
+			return data
+		}
+
+		c.Run("Live user", func(c *qt.C) {
+			data := run(c, ctx, "https://example.com/@forsen")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "https://example.com/forsen.png")
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Equals, `<b>forsen</b><br>&lt;3<br><b>Followers:</b> 1.2M<br><b>Created:</b> 25 Sep 2015<br><b>Live</b>`)
+
+			c.Assert(data.Data, qt.DeepEquals, &resolver.ResponseData{
+				Kind: resolver.DataKindUser,
+				Fields: map[string]string{
+					"bio":       "<3",
+					"followers": "1234567",
+					"created":   "2015-09-25T10:00:00Z",
+					"live":      "true",
+					"tag":       "gaming",
+				},
+			})
+		})
+
+		c.Run("Missing fields", func(c *qt.C) {
+			data := run(c, ctx, "https://example.com/@pajlada")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "")
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Equals, `<b>pajlada</b><br><b>Followers:</b> 12<br><b>Created:</b> 16 Jan 2016`)
+		})
+
+		c.Run("German", func(c *qt.C) {
+			data := run(c, i18n.OnContext(ctx, i18n.German), "https://example.com/@forsen")
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Contains, `1,2 Mio.`)
+			c.Assert(tooltip, qt.Contains, `25.09.2015`)
+		})
+
+		c.Run("Not found", func(c *qt.C) {
+			data := run(c, ctx, "https://example.com/@404")
+			c.Assert(data.Status, qt.Equals, http.StatusNotFound)
+		})
+
+		c.Run("Bad response", func(c *qt.C) {
+			data := run(c, ctx, "https://example.com/@bad")
+			c.Assert(data.Status, qt.Equals, http.StatusInternalServerError)
+			c.Assert(data.Message, qt.Contains, "example:user API unmarshal error")
+		})
+
+		c.Run("Rate limited", func(c *qt.C) {
+			_, err := siteResolver.Run(ctx, utils.MustParseURL("https://example.com/@ratelimited"), nil)
+			c.Assert(errors.Is(err, resolver.ErrUpstreamUnavailable), qt.IsTrue)
+		})
+	})
+
+	c.Run("Unauthorized", func(c *qt.C) {
+		site := testSite(ts.URL)
+		site.Name = "example:unauthorized"
+		siteResolver, err := NewResolver(ctx, cfg, nil, site, "wrong")
+		c.Assert(err, qt.IsNil)
+
+		response, err := siteResolver.Run(ctx, utils.MustParseURL("https://example.com/@forsen"), nil)
+		c.Assert(err, qt.IsNil)
+
+		var data resolver.Response
+		c.Assert(json.Unmarshal(response.Payload, &data), qt.IsNil)
+		c.Assert(data.Status, qt.Equals, http.StatusInternalServerError)
+		c.Assert(data.Message, qt.Equals, "example:unauthorized API returned status 401")
+	})
+}
+
+func TestNewResolver(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		CacheBackend: cache.BackendMemory,
+	}
+
+	tests := []struct {
+		label    string
+		modify   func(site *config.SiteResolver)
+		expected string
+	}{
+		{"Missing name", func(site *config.SiteResolver) { site.Name = "" }, "missing name"},
+		{"Missing host", func(site *config.SiteResolver) { site.Host = "" }, "missing host"},
+		{"Missing url", func(site *config.SiteResolver) { site.URL = "" }, "missing url"},
+		{"Missing tooltip", func(site *config.SiteResolver) { site.Tooltip = "" }, "missing tooltip"},
+		{"Invalid host", func(site *config.SiteResolver) { site.Host = "(" }, "invalid host: .*"},
+		{"Invalid path", func(site *config.SiteResolver) { site.Path = "(" }, "invalid path: .*"},
+		{"Invalid url", func(site *config.SiteResolver) { site.URL = "{{" }, "invalid url: .*"},
+		{"Invalid tooltip", func(site *config.SiteResolver) { site.Tooltip = "{{unknown}}" }, "invalid tooltip: .*"},
+		{"Invalid field", func(site *config.SiteResolver) { site.Fields["bio"] = "user.bio" }, "invalid field bio: .*"},
+		{"Invalid thumbnail", func(site *config.SiteResolver) { site.Thumbnail = "$.tags[*]" }, "invalid thumbnail: .*"},
+	}
+
+	for _, test := range tests {
+		c.Run(test.label, func(c *qt.C) {
+			site := testSite("https://api.example.com")
+			test.modify(&site)
+
+			_, err := NewResolver(ctx, cfg, nil, site, "")
+			c.Assert(err, qt.ErrorMatches, test.expected)
+		})
+	}
+}
+
+func TestInitialize(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		CacheBackend: cache.BackendMemory,
+	}
+
+	c.Run("Missing token", func(c *qt.C) {
+		t.Setenv("EXAMPLE_TOKEN", "")
+		customResolvers := []resolver.Resolver{}
+		Initialize(ctx, cfg, nil, testSite("https://api.example.com"), &customResolvers)
+		c.Assert(customResolvers, qt.HasLen, 0)
+	})
+
+	c.Run("Token", func(c *qt.C) {
+		t.Setenv("EXAMPLE_TOKEN", "secret")
+		customResolvers := []resolver.Resolver{}
+		Initialize(ctx, cfg, nil, testSite("https://api.example.com"), &customResolvers)
+		c.Assert(customResolvers, qt.HasLen, 1)
+	})
+
+	c.Run("Invalid", func(c *qt.C) {
+		site := testSite("https://api.example.com")
+		site.Auth = config.SiteResolverAuth{}
+		site.Host = "("
+		customResolvers := []resolver.Resolver{}
+		Initialize(ctx, cfg, nil, site, &customResolvers)
+		c.Assert(customResolvers, qt.HasLen, 0)
+	})
+}
@@ -0,0 +1,116 @@
+package defaultresolver
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/go-chi/chi/v5"
+)
+
+// urlArgument returns the url query parameter of a cache admin request
+func urlArgument(w http.ResponseWriter, req *http.Request) (string, bool) {
+	urlString := req.URL.Query().Get("url")
+	if urlString == "" {
+		if _, err := resolver.WriteInvalidURL(w); err != nil {
+			logger.FromContext(req.Context()).Errorw("Error writing response",
+				"error", err,
+			)
+		}
+		return "", false
+	}
+
+	return urlString, true
+}
+
+// HandlePurgeRequest deletes the values cached for the URL in the url query parameter, from the
+// cache of every resolver that handles it and in every language.
+// Host policies aren't applied, so values cached before a host got its policy can be purged too.
+func (r *LinkResolver) HandlePurgeRequest(w http.ResponseWriter, req *http.Request) {
+	ctx := cache.WithPurge(req.Context())
+	log := logger.FromContext(ctx)
+
+	urlString, ok := urlArgument(w, req)
+	if !ok {
+		return
+	}
+
+	requestUrl, err := url.Parse(urlString)
+	if err != nil {
+		if _, err := resolver.WriteInvalidURL(w); err != nil {
+			log.Errorw("Error writing response",
+				"error", err,
+			)
+		}
+		return
+	}
+
+	_, err = r.resolveURL(ctx, requestUrl, urlString, req)
+	if err == nil || errors.Is(err, cache.ErrPurged) {
+		// Links to images are their own thumbnail
+		_, err = r.thumbnailCache.Get(ctx, urlString, req)
+	}
+	if err != nil && !errors.Is(err, cache.ErrPurged) {
+		log.Errorw("Error purging link",
+			"url", urlString,
+			"error", err,
+		)
+		if _, err := resolver.WriteInternalServerErrorf(w, "Error purging link"); err != nil {
+			log.Errorw("Error writing response",
+				"error", err,
+			)
+		}
+		return
+	}
+
+	log.Infow("Purged link",
+		"url", urlString,
+	)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleRefreshRequest resolves the URL in the url query parameter again, replacing the values
+// cached for it. Responds with the new response of the link resolver.
+func (r *LinkResolver) HandleRefreshRequest(w http.ResponseWriter, req *http.Request) {
+	ctx := cache.WithRefresh(req.Context())
+	log := logger.FromContext(ctx)
+
+	urlString, ok := urlArgument(w, req)
+	if !ok {
+		return
+	}
+
+	response, err := r.resolve(ctx, urlString, req)
+	if err != nil {
+		log.Errorw("Error refreshing link",
+			"url", urlString,
+			"error", err,
+		)
+		if _, err := resolver.WriteInternalServerErrorf(w, "Error refreshing link"); err != nil {
+			log.Errorw("Error writing response",
+				"error", err,
+			)
+		}
+		return
+	}
+
+	log.Infow("Refreshed link",
+		"url", urlString,
+	)
+	w.Header().Add("Content-Type", response.ContentType)
+	w.WriteHeader(response.StatusCode)
+	if _, err := w.Write(response.Payload); err != nil {
+		log.Errorw("Error writing response",
+			"error", err,
+		)
+	}
+}
+
+// mountAdmin adds the link routes of the cache admin API to the admin router
+func mountAdmin(adminRouter chi.Router, linkResolver *LinkResolver) {
+	adminRouter.Delete("/cache/link", linkResolver.HandlePurgeRequest)
+	adminRouter.Post("/cache/link/refresh", linkResolver.HandleRefreshRequest)
+}
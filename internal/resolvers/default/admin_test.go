@@ -0,0 +1,57 @@
+package defaultresolver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+)
+
+func TestHandlePurgeRequest(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		MaxContentLength: 5 * 1024 * 1024, // 5 MB
+		CacheBackend:     cache.BackendMemory,
+		HostPolicies: []config.HostPolicy{
+			{Host: "127.0.0.1", Policy: "block"},
+		},
+	}
+
+	resolver.InitializeStaticResponses(ctx, cfg)
+
+	r := New(ctx, cfg, nil, nil, nil)
+
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fmt.Fprint(w, `<html><head><title>title</title></head></html>`)
+	}))
+	defer ts.Close()
+
+	// Cached before the host was blocked
+	linkURL := ts.URL + "/page"
+	_, err := r.resolveURL(ctx, utils.MustParseURL(linkURL), linkURL, nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(requests.Load(), qt.Equals, int32(1))
+
+	respRec := httptest.NewRecorder()
+	r.HandlePurgeRequest(respRec, newRequest(t, ctx, "DELETE", "/cache/link?url="+url.QueryEscape(linkURL), nil))
+	c.Assert(respRec.Code, qt.Equals, http.StatusNoContent)
+
+	// The link is loaded again instead of coming from the cache
+	_, err = r.resolveURL(ctx, utils.MustParseURL(linkURL), linkURL, nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(requests.Load(), qt.Equals, int32(2))
+}
@@ -0,0 +1,223 @@
+package defaultresolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/resolver"
+)
+
+const (
+	maxBatchRequestSize = 1024 * 1024 // 1 MB
+
+	// The whole batch is answered within this duration, which must stay below the WriteTimeout of
+	// the server (15s, see cmd/api). URLs that couldn't be resolved by then get a timeout response.
+	maxBatchDuration = 10 * time.Second
+
+	// Used if the batch options are missing from the config
+	defaultBatchMaxURLs     = 50
+	defaultBatchConcurrency = 8
+	defaultBatchItemTimeout = 5 * time.Second
+)
+
+type batchOptions struct {
+	maxURLs     int
+	concurrency int
+
+	// Every URL in the batch must be resolved within this duration from the moment it gets one of
+	// the concurrency slots, otherwise a timeout response is returned for it
+	itemTimeout time.Duration
+
+	// The batch is answered within this duration, regardless of the item timeout
+	maxDuration time.Duration
+}
+
+type wrappedBatchResponse struct {
+	response *cache.Response
+	err      error
+}
+
+func writeBatchError(w http.ResponseWriter, statusCode int, format string, a ...any) error {
+	payload, err := json.Marshal(&resolver.Response{
+		Status:  statusCode,
+		Message: resolver.CleanResponse(fmt.Sprintf(format, a...)),
+	})
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, err = w.Write(payload)
+	return err
+}
+
+// batchPayload turns the result of resolving a single URL into the JSON value used in the batch response
+func batchPayload(response *cache.Response, err error) json.RawMessage {
+	if err != nil || !strings.HasPrefix(response.ContentType, "application/json") || !json.Valid(response.Payload) {
+		payload, _ := json.Marshal(&resolver.Response{
+			Status:  http.StatusInternalServerError,
+			Message: "Error resolving link",
+		})
+		return payload
+	}
+
+	return response.Payload
+}
+
+// resolveBefore resolves the URL, giving up once the deadline passes.
+// The URL keeps resolving in the background after the deadline so it ends up in the cache for
+// later requests. release is called once it's done, so the concurrency slot is held until then.
+func (r *LinkResolver) resolveBefore(ctx context.Context, deadline <-chan struct{}, urlString string, req *http.Request, release func()) json.RawMessage {
+	log := logger.FromContext(ctx)
+
+	responseChannel := make(chan wrappedBatchResponse, 1)
+	go func() {
+		defer release()
+
+		response, err := r.resolve(context.WithoutCancel(ctx), urlString, req)
+		responseChannel <- wrappedBatchResponse{response, err}
+	}()
+
+	select {
+	case response := <-responseChannel:
+		if response.err != nil {
+			log.Errorw("Error resolving link in batch",
+				"url", urlString,
+				"error", response.err,
+			)
+		}
+		payload := batchPayload(response.response, response.err)
+		if !resolver.WantsData(req) {
+			payload = resolver.WithoutData(payload)
+		}
+		return payload
+
+	case <-deadline:
+		log.Debugw("Timed out resolving link in batch",
+			"url", urlString,
+		)
+		return resolver.TimedOutBytes
+	}
+}
+
+// HandleBatchRequest resolves a JSON array of URLs, responding with an object mapping each URL to
+// the response the link resolver would have given for it
+func (r *LinkResolver) HandleBatchRequest(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	log := logger.FromContext(ctx)
+
+	var urls []string
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxBatchRequestSize)).Decode(&urls); err != nil {
+		if err := writeBatchError(w, http.StatusBadRequest, "Invalid batch request: expected a JSON array of URLs"); err != nil {
+			log.Errorw("Error writing response",
+				"error", err,
+			)
+		}
+		return
+	}
+
+	if len(urls) > r.batch.maxURLs {
+		if err := writeBatchError(w, http.StatusBadRequest, "Invalid batch request: too many URLs (max %d)", r.batch.maxURLs); err != nil {
+			log.Errorw("Error writing response",
+				"error", err,
+			)
+		}
+		return
+	}
+
+	batchCtx, cancel := context.WithTimeout(ctx, r.batch.maxDuration)
+	defer cancel()
+
+	slots := make(chan struct{}, r.batch.concurrency)
+
+	var responsesMutex sync.Mutex
+	responses := make(map[string]json.RawMessage, len(urls))
+
+	seen := make(map[string]struct{}, len(urls))
+
+	var wg sync.WaitGroup
+	for _, urlString := range urls {
+		if _, ok := seen[urlString]; ok {
+			// Duplicate URL
+			continue
+		}
+		seen[urlString] = struct{}{}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var payload json.RawMessage
+
+			select {
+			case slots <- struct{}{}:
+				// The timeout starts once the URL gets its slot, so URLs waiting behind slow ones
+				// still get the full item timeout unless the batch runs out of time first
+				deadlineCtx, cancel := context.WithTimeout(batchCtx, r.batch.itemTimeout)
+				payload = r.resolveBefore(ctx, deadlineCtx.Done(), urlString, req, func() {
+					<-slots
+				})
+				cancel()
+
+			case <-batchCtx.Done():
+				payload = resolver.TimedOutBytes
+			}
+
+			responsesMutex.Lock()
+			responses[urlString] = payload
+			responsesMutex.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	payload, err := json.Marshal(responses)
+	if err != nil {
+		log.Errorw("Error marshalling batch response",
+			"error", err,
+		)
+		_, err = resolver.WriteInternalServerErrorf(w, "Error resolving links")
+		if err != nil {
+			log.Errorw("Error writing response",
+				"error", err,
+			)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Vary", "Accept, Accept-Language")
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(payload)
+	if err != nil {
+		log.Errorw("Error writing response",
+			"error", err,
+		)
+	}
+}
+
+func newBatchOptions(maxURLs int, concurrency int, itemTimeout time.Duration) batchOptions {
+	if maxURLs <= 0 {
+		maxURLs = defaultBatchMaxURLs
+	}
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+	if itemTimeout <= 0 {
+		itemTimeout = defaultBatchItemTimeout
+	}
+
+	return batchOptions{
+		maxURLs:     maxURLs,
+		concurrency: concurrency,
+		itemTimeout: itemTimeout,
+		maxDuration: maxBatchDuration,
+	}
+}
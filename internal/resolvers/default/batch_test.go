@@ -0,0 +1,171 @@
+package defaultresolver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	qt "github.com/frankban/quicktest"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestBatchRequest(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		MaxContentLength: 5 * 1024 * 1024, // 5 MB
+		// The batch resolves links concurrently, which the pgxmock expectations can't deal with
+		CacheBackend: cache.BackendMemory,
+
+		LinkResolverBatchMaxURLs:     6,
+		LinkResolverBatchItemTimeout: 500 * time.Millisecond,
+	}
+
+	resolver.InitializeStaticResponses(ctx, cfg)
+
+	ignoredHosts := map[string]struct{}{
+		"ignoredhost.com": {},
+	}
+
+	r := New(ctx, cfg, nil, nil, ignoredHosts)
+
+	router := chi.NewRouter()
+	router.Post("/link_resolver/batch", r.HandleBatchRequest)
+
+	unblockSlow := make(chan struct{})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/slow":
+			<-unblockSlow
+			fallthrough
+		case "/delayed":
+			time.Sleep(300 * time.Millisecond)
+			fallthrough
+		case "/a", "/b":
+			fmt.Fprintf(w, "<html><head><title>%s title</title></head><body>xD</body></html>", r.URL.Path)
+		default:
+			http.Error(w, http.StatusText(404), 404)
+		}
+	}))
+	defer ts.Close()
+	// Must happen before closing the server, which waits for the slow request to finish
+	defer close(unblockSlow)
+
+	doRequest := func(c *qt.C, body string) (*http.Response, map[string]resolver.Response) {
+		respRec := httptest.NewRecorder()
+		req := newRequest(t, ctx, "POST", "/link_resolver/batch", strings.NewReader(body))
+		router.ServeHTTP(respRec, req)
+		resp := respRec.Result()
+
+		if resp.StatusCode != http.StatusOK {
+			return resp, nil
+		}
+
+		responses := map[string]resolver.Response{}
+		err := json.NewDecoder(resp.Body).Decode(&responses)
+		c.Assert(err, qt.IsNil)
+		return resp, responses
+	}
+
+	unescapeTooltip := func(c *qt.C, tooltip string) string {
+		unescapedTooltip, err := url.QueryUnescape(tooltip)
+		c.Assert(err, qt.IsNil)
+		return unescapedTooltip
+	}
+
+	marshalURLs := func(urls ...string) string {
+		var b bytes.Buffer
+		json.NewEncoder(&b).Encode(urls)
+		return b.String()
+	}
+
+	c.Run("Resolves every URL", func(c *qt.C) {
+		resp, responses := doRequest(c, marshalURLs(
+			ts.URL+"/a",
+			ts.URL+"/b",
+			ts.URL+"/a",
+			ts.URL+"/404",
+			"https://ignoredhost.com/forsen",
+			" :",
+		))
+		c.Assert(resp.StatusCode, qt.Equals, http.StatusOK)
+		c.Assert(resp.Header.Get("Content-Type"), qt.Equals, "application/json")
+		c.Assert(responses, qt.HasLen, 5)
+
+		c.Assert(responses[ts.URL+"/a"].Status, qt.Equals, http.StatusOK)
+		c.Assert(responses[ts.URL+"/a"].Link, qt.Equals, ts.URL+"/a")
+		c.Assert(unescapeTooltip(c, responses[ts.URL+"/a"].Tooltip), qt.Contains, "/a title")
+		c.Assert(responses[ts.URL+"/b"].Status, qt.Equals, http.StatusOK)
+		c.Assert(unescapeTooltip(c, responses[ts.URL+"/b"].Tooltip), qt.Contains, "/b title")
+		c.Assert(responses[ts.URL+"/404"].Status, qt.Equals, http.StatusNotFound)
+		c.Assert(responses["https://ignoredhost.com/forsen"].Status, qt.Equals, http.StatusForbidden)
+		c.Assert(responses[" :"].Status, qt.Equals, http.StatusBadRequest)
+	})
+
+	c.Run("Slow URLs time out without blocking the rest", func(c *qt.C) {
+		start := time.Now()
+		resp, responses := doRequest(c, marshalURLs(ts.URL+"/slow", ts.URL+"/b"))
+		c.Assert(resp.StatusCode, qt.Equals, http.StatusOK)
+		c.Assert(time.Since(start) < 5*time.Second, qt.IsTrue)
+
+		c.Assert(responses[ts.URL+"/slow"].Status, qt.Equals, http.StatusGatewayTimeout)
+		c.Assert(responses[ts.URL+"/b"].Status, qt.Equals, http.StatusOK)
+	})
+
+	c.Run("Item timeout starts once the URL gets a slot", func(c *qt.C) {
+		concurrency := r.batch.concurrency
+		r.batch.concurrency = 1
+		defer func() {
+			r.batch.concurrency = concurrency
+		}()
+
+		// Resolving both takes longer than the item timeout, but each of them alone doesn't
+		resp, responses := doRequest(c, marshalURLs(ts.URL+"/delayed?1", ts.URL+"/delayed?2"))
+		c.Assert(resp.StatusCode, qt.Equals, http.StatusOK)
+		c.Assert(responses[ts.URL+"/delayed?1"].Status, qt.Equals, http.StatusOK)
+		c.Assert(responses[ts.URL+"/delayed?2"].Status, qt.Equals, http.StatusOK)
+	})
+
+	c.Run("Batch deadline", func(c *qt.C) {
+		maxDuration := r.batch.maxDuration
+		r.batch.maxDuration = 100 * time.Millisecond
+		defer func() {
+			r.batch.maxDuration = maxDuration
+		}()
+
+		start := time.Now()
+		resp, responses := doRequest(c, marshalURLs(ts.URL+"/slow?deadline"))
+		c.Assert(resp.StatusCode, qt.Equals, http.StatusOK)
+		c.Assert(time.Since(start) < cfg.LinkResolverBatchItemTimeout, qt.IsTrue)
+		c.Assert(responses[ts.URL+"/slow?deadline"].Status, qt.Equals, http.StatusGatewayTimeout)
+	})
+
+	c.Run("Empty batch", func(c *qt.C) {
+		resp, responses := doRequest(c, `[]`)
+		c.Assert(resp.StatusCode, qt.Equals, http.StatusOK)
+		c.Assert(responses, qt.HasLen, 0)
+	})
+
+	c.Run("Too many URLs", func(c *qt.C) {
+		resp, _ := doRequest(c, marshalURLs("a", "b", "c", "d", "e", "f", "g"))
+		c.Assert(resp.StatusCode, qt.Equals, http.StatusBadRequest)
+	})
+
+	c.Run("Invalid body", func(c *qt.C) {
+		resp, _ := doRequest(c, `{"url":"https://example.com"}`)
+		c.Assert(resp.StatusCode, qt.Equals, http.StatusBadRequest)
+	})
+}
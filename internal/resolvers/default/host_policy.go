@@ -0,0 +1,306 @@
+package defaultresolver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/spf13/viper"
+)
+
+type hostPolicyAction string
+
+const (
+	// Links to the host are not resolved at all
+	hostPolicyBlock hostPolicyAction = "block"
+	// Links to the host are resolved, but never get a thumbnail
+	hostPolicyTooltipOnly hostPolicyAction = "tooltip-only"
+	// Links to the host are not loaded, and get a tooltip only containing the URL
+	hostPolicyForceMinimal hostPolicyAction = "force-minimal"
+	// Links to the host are cached for a custom duration
+	hostPolicyCacheDuration hostPolicyAction = "cache-duration"
+)
+
+type hostRule struct {
+	// Exact host, or the parent domain when wildcard is set
+	host     string
+	wildcard bool
+	regex    *regexp.Regexp
+
+	action        hostPolicyAction
+	cacheDuration time.Duration
+}
+
+func (r *hostRule) matches(host string) bool {
+	if r.regex != nil {
+		return r.regex.MatchString(host)
+	}
+
+	if r.wildcard {
+		return strings.HasSuffix(host, "."+r.host)
+	}
+
+	return host == r.host
+}
+
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
+
+func newHostRule(policy config.HostPolicy) (hostRule, error) {
+	rule := hostRule{
+		action:        hostPolicyAction(policy.Policy),
+		cacheDuration: policy.CacheDuration,
+	}
+
+	switch rule.action {
+	case hostPolicyBlock, hostPolicyTooltipOnly, hostPolicyForceMinimal:
+	case hostPolicyCacheDuration:
+		if rule.cacheDuration <= 0 {
+			return rule, errors.New("cache-duration host policy requires a positive cache-duration")
+		}
+	default:
+		return rule, fmt.Errorf("unknown host policy %q", policy.Policy)
+	}
+
+	switch {
+	case policy.Host != "" && policy.Regex != "":
+		return rule, fmt.Errorf("host policy can't have both a host (%q) and a regex (%q)", policy.Host, policy.Regex)
+
+	case policy.Regex != "":
+		regex, err := regexp.Compile(policy.Regex)
+		if err != nil {
+			return rule, err
+		}
+		rule.regex = regex
+
+	case policy.Host != "":
+		host := normalizeHost(policy.Host)
+		if after, ok := strings.CutPrefix(host, "*."); ok {
+			rule.wildcard = true
+			host = after
+		}
+		rule.host = host
+
+	default:
+		return rule, fmt.Errorf("host policy %q requires a host or a regex", policy.Policy)
+	}
+
+	return rule, nil
+}
+
+func newHostRules(policies []config.HostPolicy) ([]hostRule, error) {
+	rules := make([]hostRule, 0, len(policies))
+	for _, policy := range policies {
+		rule, err := newHostRule(policy)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// readHostPoliciesFile reads the host-policies list from the given yaml file
+func readHostPoliciesFile(path string) ([]hostRule, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	var policies []config.HostPolicy
+	if err := v.UnmarshalKey("host-policies", &policies); err != nil {
+		return nil, err
+	}
+
+	return newHostRules(policies)
+}
+
+type hostPolicies struct {
+	// Rules from the config, never change
+	configRules []hostRule
+
+	// Rules from host-policies-path, replaced whenever the file changes
+	fileRules atomic.Pointer[[]hostRule]
+}
+
+// match returns the first rule matching the given host. Config rules are checked before file rules.
+func (p *hostPolicies) match(host string) (*hostRule, bool) {
+	host = normalizeHost(host)
+
+	for i := range p.configRules {
+		if p.configRules[i].matches(host) {
+			return &p.configRules[i], true
+		}
+	}
+
+	if fileRules := p.fileRules.Load(); fileRules != nil {
+		for i := range *fileRules {
+			if (*fileRules)[i].matches(host) {
+				return &(*fileRules)[i], true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// watch reloads the rules from path whenever its modification time changes.
+// If the file can't be read or contains invalid rules, the previous rules are kept.
+func (p *hostPolicies) watch(ctx context.Context, path string, interval time.Duration, lastModTime time.Time) {
+	log := logger.FromContext(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			log.Warnw("Error checking host policies file",
+				"path", path,
+				"error", err,
+			)
+			continue
+		}
+
+		if info.ModTime().Equal(lastModTime) {
+			continue
+		}
+		lastModTime = info.ModTime()
+
+		rules, err := readHostPoliciesFile(path)
+		if err != nil {
+			log.Errorw("Error reloading host policies file, keeping previous rules",
+				"path", path,
+				"error", err,
+			)
+			continue
+		}
+
+		p.fileRules.Store(&rules)
+
+		log.Infow("Reloaded host policies",
+			"path", path,
+			"rules", len(rules),
+		)
+	}
+}
+
+func newHostPolicies(ctx context.Context, cfg config.APIConfig) *hostPolicies {
+	log := logger.FromContext(ctx)
+
+	configRules, err := newHostRules(cfg.HostPolicies)
+	if err != nil {
+		log.Fatalw("Error parsing host-policies",
+			"error", err,
+		)
+	}
+
+	p := &hostPolicies{
+		configRules: configRules,
+	}
+
+	if cfg.HostPoliciesPath == "" {
+		return p
+	}
+
+	info, err := os.Stat(cfg.HostPoliciesPath)
+	if err != nil {
+		log.Fatalw("Error reading host policies file",
+			"path", cfg.HostPoliciesPath,
+			"error", err,
+		)
+	}
+
+	fileRules, err := readHostPoliciesFile(cfg.HostPoliciesPath)
+	if err != nil {
+		log.Fatalw("Error parsing host policies file",
+			"path", cfg.HostPoliciesPath,
+			"error", err,
+		)
+	}
+	p.fileRules.Store(&fileRules)
+
+	if cfg.HostPoliciesReloadInterval > 0 {
+		go p.watch(ctx, cfg.HostPoliciesPath, cfg.HostPoliciesReloadInterval, info.ModTime())
+	}
+
+	return p
+}
+
+func forbiddenResponse() *cache.Response {
+	return &cache.Response{
+		Payload:     resolver.ForbiddenURLBytes,
+		StatusCode:  http.StatusForbidden,
+		ContentType: "application/json",
+	}
+}
+
+// minimalResponse builds a tooltip only containing the URL, without loading the link
+func minimalResponse(requestUrl *url.URL) (*cache.Response, error) {
+	var tooltip bytes.Buffer
+	if err := defaultTooltip.Execute(&tooltip, tooltipData{
+		URL: resolver.CleanResponse(requestUrl.String()),
+	}); err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(&resolver.Response{
+		Status:  http.StatusOK,
+		Tooltip: url.PathEscape(tooltip.String()),
+		Link:    requestUrl.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &cache.Response{
+		Payload:     payload,
+		StatusCode:  http.StatusOK,
+		ContentType: "application/json",
+	}, nil
+}
+
+// withoutThumbnail removes the thumbnail from the given resolved link
+func withoutThumbnail(response *cache.Response) *cache.Response {
+	var data resolver.Response
+	if err := json.Unmarshal(response.Payload, &data); err != nil || data.Thumbnail == "" {
+		return response
+	}
+
+	data.Thumbnail = ""
+
+	payload, err := json.Marshal(&data)
+	if err != nil {
+		return response
+	}
+
+	return &cache.Response{
+		Payload:     payload,
+		StatusCode:  response.StatusCode,
+		ContentType: response.ContentType,
+	}
+}
@@ -0,0 +1,217 @@
+package defaultresolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	qt "github.com/frankban/quicktest"
+)
+
+func TestHostRuleMatches(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		policy   config.HostPolicy
+		host     string
+		expected bool
+	}{
+		{config.HostPolicy{Host: "example.com"}, "example.com", true},
+		{config.HostPolicy{Host: "example.com"}, "EXAMPLE.com.", true},
+		{config.HostPolicy{Host: "example.com"}, "www.example.com", false},
+		{config.HostPolicy{Host: "*.example.com"}, "www.example.com", true},
+		{config.HostPolicy{Host: "*.example.com"}, "a.b.example.com", true},
+		{config.HostPolicy{Host: "*.example.com"}, "example.com", false},
+		{config.HostPolicy{Host: "*.example.com"}, "notexample.com", false},
+		{config.HostPolicy{Regex: `^cdn\d+\.example\.net$`}, "cdn12.example.net", true},
+		{config.HostPolicy{Regex: `^cdn\d+\.example\.net$`}, "cdn.example.net", false},
+	}
+
+	for _, test := range tests {
+		c.Run(test.policy.Host+test.policy.Regex+" "+test.host, func(c *qt.C) {
+			test.policy.Policy = string(hostPolicyBlock)
+			p, err := newHostRules([]config.HostPolicy{test.policy})
+			c.Assert(err, qt.IsNil)
+
+			policies := &hostPolicies{configRules: p}
+			_, ok := policies.match(test.host)
+			c.Assert(ok, qt.Equals, test.expected)
+		})
+	}
+}
+
+func TestNewHostRuleErrors(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		label  string
+		policy config.HostPolicy
+	}{
+		{"unknown policy", config.HostPolicy{Host: "example.com", Policy: "xD"}},
+		{"no host or regex", config.HostPolicy{Policy: "block"}},
+		{"host and regex", config.HostPolicy{Host: "example.com", Regex: "example", Policy: "block"}},
+		{"invalid regex", config.HostPolicy{Regex: "(", Policy: "block"}},
+		{"cache duration without duration", config.HostPolicy{Host: "example.com", Policy: "cache-duration"}},
+	}
+
+	for _, test := range tests {
+		c.Run(test.label, func(c *qt.C) {
+			_, err := newHostRule(test.policy)
+			c.Assert(err, qt.IsNotNil)
+		})
+	}
+}
+
+func TestHostPoliciesFileReload(t *testing.T) {
+	ctx, cancel := context.WithCancel(logger.OnContext(context.Background(), logger.NewTest()))
+	defer cancel()
+	c := qt.New(t)
+
+	path := filepath.Join(t.TempDir(), "host-policies.yaml")
+	writeFile := func(content string, modTime time.Time) {
+		c.Assert(os.WriteFile(path, []byte(content), 0o644), qt.IsNil)
+		c.Assert(os.Chtimes(path, modTime, modTime), qt.IsNil)
+	}
+
+	start := time.Now()
+	writeFile(`host-policies:
+  - host: "a.example.com"
+    policy: block
+`, start)
+
+	policies := newHostPolicies(ctx, config.APIConfig{
+		HostPolicies: []config.HostPolicy{
+			{Host: "config.example.com", Policy: "force-minimal"},
+		},
+		HostPoliciesPath:           path,
+		HostPoliciesReloadInterval: 10 * time.Millisecond,
+	})
+
+	rule, ok := policies.match("config.example.com")
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(rule.action, qt.Equals, hostPolicyForceMinimal)
+
+	rule, ok = policies.match("a.example.com")
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(rule.action, qt.Equals, hostPolicyBlock)
+
+	_, ok = policies.match("b.example.com")
+	c.Assert(ok, qt.IsFalse)
+
+	c.Run("Invalid file keeps previous rules", func(c *qt.C) {
+		writeFile(`host-policies:
+  - host: "b.example.com"
+    policy: xD
+`, start.Add(time.Second))
+
+		time.Sleep(100 * time.Millisecond)
+
+		_, ok := policies.match("a.example.com")
+		c.Assert(ok, qt.IsTrue)
+		_, ok = policies.match("b.example.com")
+		c.Assert(ok, qt.IsFalse)
+	})
+
+	c.Run("Changed file is reloaded", func(c *qt.C) {
+		writeFile(`host-policies:
+  - host: "*.example.com"
+    policy: cache-duration
+    cache-duration: 5m
+`, start.Add(2*time.Second))
+
+		c.Assert(waitFor(func() bool {
+			rule, ok := policies.match("b.example.com")
+			return ok && rule.action == hostPolicyCacheDuration
+		}), qt.IsTrue)
+
+		rule, _ := policies.match("b.example.com")
+		c.Assert(rule.cacheDuration, qt.Equals, 5*time.Minute)
+	})
+}
+
+func waitFor(f func() bool) bool {
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if f() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return false
+}
+
+func TestResolveHostPolicies(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		MaxContentLength: 5 * 1024 * 1024, // 5 MB
+		CacheBackend:     cache.BackendMemory,
+		HostPolicies: []config.HostPolicy{
+			{Host: "blocked.example.com", Policy: "block"},
+			{Host: "*.minimal.example.com", Policy: "force-minimal"},
+			{Host: "127.0.0.1", Policy: "tooltip-only"},
+		},
+	}
+
+	resolver.InitializeStaticResponses(ctx, cfg)
+
+	r := New(ctx, cfg, nil, nil, nil)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><head><title>title</title><meta property="og:image" content="https://example.com/thumbnail.png" /></head><body>xD</body></html>`)
+	}))
+	defer ts.Close()
+
+	c.Run("Block", func(c *qt.C) {
+		response, err := r.resolve(ctx, "https://blocked.example.com/page", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.StatusCode, qt.Equals, http.StatusForbidden)
+		c.Assert(response.Payload, qt.DeepEquals, resolver.ForbiddenURLBytes)
+		c.Assert(r.thumbnailForbidden("https://blocked.example.com/image.png"), qt.IsTrue)
+	})
+
+	c.Run("Force minimal", func(c *qt.C) {
+		response, err := r.resolve(ctx, "https://www.minimal.example.com/page", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.StatusCode, qt.Equals, http.StatusOK)
+
+		var data resolver.Response
+		c.Assert(json.Unmarshal(response.Payload, &data), qt.IsNil)
+		c.Assert(data.Thumbnail, qt.Equals, "")
+		tooltip, err := url.PathUnescape(data.Tooltip)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tooltip, qt.Contains, "https://www.minimal.example.com/page")
+	})
+
+	c.Run("Tooltip only", func(c *qt.C) {
+		req := newRequest(t, ctx, "GET", "https://example.com/link_resolver/"+url.QueryEscape(ts.URL), nil)
+		response, err := r.resolve(ctx, ts.URL, req)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.StatusCode, qt.Equals, http.StatusOK)
+
+		var data resolver.Response
+		c.Assert(json.Unmarshal(response.Payload, &data), qt.IsNil)
+		c.Assert(data.Thumbnail, qt.Equals, "")
+		tooltip, err := url.PathUnescape(data.Tooltip)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tooltip, qt.Contains, "title")
+		c.Assert(r.thumbnailForbidden(ts.URL+"/image.png"), qt.IsTrue)
+	})
+
+	c.Run("No policy", func(c *qt.C) {
+		c.Assert(r.thumbnailForbidden("https://example.com/image.png"), qt.IsFalse)
+	})
+}
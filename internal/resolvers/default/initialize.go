@@ -28,11 +28,11 @@ const (
 
 var defaultTooltip = template.Must(template.New("default_tooltip").Parse(defaultTooltipString))
 
-func Initialize(ctx context.Context, cfg config.APIConfig, pool db.Pool, router *chi.Mux, helixClient *helix.Client) {
-	// Ignored hosts can be added here at request of the hoster
-	ignoredHosts := map[string]struct{}{}
-
-	defaultLinkResolver := New(ctx, cfg, pool, helixClient, ignoredHosts)
+// Initialize adds the link resolver routes to the router.
+// adminRouter is the router of the cache admin API, and is nil if the admin API is disabled.
+func Initialize(ctx context.Context, cfg config.APIConfig, pool db.Pool, router *chi.Mux, adminRouter chi.Router, helixClient *helix.Client) {
+	// Hosts can be ignored at request of the hoster using the host-policies config
+	defaultLinkResolver := New(ctx, cfg, pool, helixClient, nil)
 
 	imageCache, err := memcache.NewBackend(256)
 	if err != nil {
@@ -49,6 +49,12 @@ func Initialize(ctx context.Context, cfg config.APIConfig, pool db.Pool, router
 
 	// TODO: Make the max age headers be applied based on the resolved link's configured cache timer
 	router.With(cache.MaxAgeHeaders(time.Minute*10)).Get("/link_resolver/{url}", defaultLinkResolver.HandleRequest)
+	router.Post("/link_resolver/batch", defaultLinkResolver.HandleBatchRequest)
 	router.With(cache.MaxAgeHeaders(time.Minute*10), imageCached).Get("/thumbnail/{url}", defaultLinkResolver.HandleThumbnailRequest)
 	router.With(generatedValuesCached).Get("/generated/{url}", defaultLinkResolver.HandleGeneratedValueRequest)
+	router.Get("/resolvers", defaultLinkResolver.HandleResolversRequest)
+
+	if adminRouter != nil {
+		mountAdmin(adminRouter, defaultLinkResolver)
+	}
 }
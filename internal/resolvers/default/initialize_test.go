@@ -23,6 +23,6 @@ func TestInitialize(t *testing.T) {
 
 	c.Run("No credentials", func(c *qt.C) {
 		cfg := config.APIConfig{}
-		Initialize(ctx, cfg, pool, r, nil)
+		Initialize(ctx, cfg, pool, r, nil, nil)
 	})
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/Chatterino/api/internal/staticresponse"
 	"github.com/Chatterino/api/internal/version"
 	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	"github.com/Chatterino/api/pkg/thumbnail"
 	"github.com/Chatterino/api/pkg/utils"
@@ -29,6 +30,9 @@ type LinkLoader struct {
 	customResolvers      []resolver.Resolver
 	contentTypeResolvers []ContentTypeResolver
 	maxContentLength     uint64
+
+	// Set when robots.txt, X-Robots-Tag and <meta name="robots"> should be honored
+	robots *robotsChecker
 }
 
 func (l *LinkLoader) minimalTooltipData(resp *http.Response) tooltipData {
@@ -57,7 +61,10 @@ func (l *LinkLoader) Load(ctx context.Context, urlString string, r *http.Request
 		return resolver.ReturnInvalidURL()
 	}
 
-	extraHeaders := make(map[string]string)
+	extraHeaders := map[string]string{
+		// Fetch the page in the language the tooltip is rendered in
+		"Accept-Language": i18n.AcceptLanguage(i18n.FromContext(ctx)),
+	}
 	cacheDur := cache.NoSpecialDur
 	ctx, isTwitterRequest := twitter.Check(ctx, requestUrl)
 	if isTwitterRequest {
@@ -66,8 +73,19 @@ func (l *LinkLoader) Load(ctx context.Context, urlString string, r *http.Request
 		cacheDur = time.Hour * 24
 	}
 
+	if l.robots != nil && !l.robots.allowed(ctx, requestUrl, r) {
+		log.Debugw("Not loading url disallowed by robots.txt",
+			"url", requestUrl,
+		)
+		return returnMinimal(requestUrl)
+	}
+
 	resp, err := resolver.RequestGETWithHeaders(requestUrl.String(), extraHeaders)
 	if err != nil {
+		if errors.Is(err, resolver.ErrForbiddenAddress) {
+			return resolver.ReturnForbiddenURL()
+		}
+
 		if strings.HasSuffix(err.Error(), "no such host") {
 			return staticresponse.SNoLinkInfoFound.
 				Return()
@@ -93,6 +111,10 @@ func (l *LinkLoader) Load(ctx context.Context, urlString string, r *http.Request
 					break
 				}
 
+				if err != nil {
+					return nil, nil, nil, cache.NoSpecialDur, err
+				}
+
 				return data.Payload, &data.StatusCode, &data.ContentType, cache.NoSpecialDur, err
 			}
 		}
@@ -113,6 +135,10 @@ func (l *LinkLoader) Load(ctx context.Context, urlString string, r *http.Request
 		return staticresponse.SNoLinkInfoFound.Return()
 	}
 
+	if l.robots != nil && xRobotsTagDisallows(resp.Header) {
+		return returnMinimal(resp.Request.URL)
+	}
+
 	contentType := resp.Header.Get("Content-Type")
 	for _, ctResolver := range l.contentTypeResolvers {
 		if ctResolver.Check(ctx, contentType) {
@@ -150,6 +176,9 @@ func (l *LinkLoader) Load(ctx context.Context, urlString string, r *http.Request
 				Message: "html parser error (or download) " + resolver.CleanResponse(err.Error()),
 			})
 		}
+		if l.robots != nil && metaRobotsDisallows(doc) {
+			return returnMinimal(resp.Request.URL)
+		}
 		data = l.defaultTooltipData(doc, r, resp)
 	}
 
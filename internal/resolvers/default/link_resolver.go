@@ -10,33 +10,26 @@ import (
 
 	"github.com/Chatterino/api/internal/db"
 	"github.com/Chatterino/api/internal/logger"
-	"github.com/Chatterino/api/internal/resolvers/betterttv"
-	"github.com/Chatterino/api/internal/resolvers/discord"
-	"github.com/Chatterino/api/internal/resolvers/frankerfacez"
-	"github.com/Chatterino/api/internal/resolvers/imgur"
-	"github.com/Chatterino/api/internal/resolvers/livestreamfails"
-	"github.com/Chatterino/api/internal/resolvers/oembed"
-	"github.com/Chatterino/api/internal/resolvers/seventv"
-	"github.com/Chatterino/api/internal/resolvers/supinic"
-	"github.com/Chatterino/api/internal/resolvers/twitch"
-	"github.com/Chatterino/api/internal/resolvers/twitter"
-	"github.com/Chatterino/api/internal/resolvers/wikipedia"
-	"github.com/Chatterino/api/internal/resolvers/youtube"
 	"github.com/Chatterino/api/pkg/cache"
 	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	"github.com/Chatterino/api/pkg/utils"
 	"github.com/nicklaw5/helix"
 )
 
 type LinkResolver struct {
-	customResolvers []resolver.Resolver
+	customResolvers  []resolver.Resolver
+	resolverStatuses []ResolverStatus
 
 	ignoredHosts map[string]struct{}
+	hostPolicies *hostPolicies
 
 	linkCache      cache.Cache
 	thumbnailCache cache.Cache
 	generatedCache cache.DependentCache
+
+	batch batchOptions
 }
 
 func (r *LinkResolver) shouldIgnore(u *url.URL) bool {
@@ -50,53 +43,87 @@ func (r *LinkResolver) shouldIgnore(u *url.URL) bool {
 		return true
 	}
 
+	if resolver.IsForbiddenAddress(u.Hostname()) {
+		return true
+	}
+
 	return false
 }
 
-func (r *LinkResolver) HandleRequest(w http.ResponseWriter, req *http.Request) {
-	ctx := req.Context()
-	log := logger.FromContext(ctx)
-
-	log.Debugw("Handle request",
-		"path", req.URL.Path,
-	)
-	// w.Header().Set("Content-Type", "application/json")
-	urlString, err := utils.UnescapeURLArgument(req, "url")
+// thumbnailForbidden returns whether thumbnails for the given URL must not be generated
+func (r *LinkResolver) thumbnailForbidden(urlString string) bool {
+	u, err := url.Parse(urlString)
 	if err != nil {
-		_, err = resolver.WriteInvalidURL(w)
-		if err != nil {
-			log.Errorw("Error writing response",
-				"error", err,
-			)
-		}
-		return
+		// Handled by the thumbnail loader
+		return false
 	}
 
+	if r.shouldIgnore(u) {
+		return true
+	}
+
+	if policy, ok := r.hostPolicies.match(u.Hostname()); ok {
+		return policy.action != hostPolicyCacheDuration
+	}
+
+	return false
+}
+
+// resolve runs the given URL through the custom resolvers, falling back to the default link cache.
+// Invalid and forbidden URLs return their static responses.
+func (r *LinkResolver) resolve(ctx context.Context, urlString string, req *http.Request) (*cache.Response, error) {
+	log := logger.FromContext(ctx)
+
+	// Tooltips are rendered in the language the client asked for
+	ctx = i18n.OnContext(ctx, i18n.FromRequest(req))
+
 	requestUrl, err := url.Parse(urlString)
 	if err != nil {
 		log.Errorw("Error parsing url",
 			"url", urlString,
 			"error", err,
 		)
-		_, err = resolver.WriteInvalidURL(w)
-		if err != nil {
-			log.Errorw("Error writing response",
-				"error", err,
-			)
-		}
-		return
+		return &cache.Response{
+			Payload:     resolver.InvalidURLBytes,
+			StatusCode:  http.StatusBadRequest,
+			ContentType: "application/json",
+		}, nil
 	}
 
 	if r.shouldIgnore(requestUrl) {
-		_, err = resolver.WriteForbiddenURL(w)
+		return forbiddenResponse(), nil
+	}
+
+	policy, hasPolicy := r.hostPolicies.match(requestUrl.Hostname())
+	if !hasPolicy {
+		return r.resolveURL(ctx, requestUrl, urlString, req)
+	}
+
+	switch policy.action {
+	case hostPolicyBlock:
+		return forbiddenResponse(), nil
+
+	case hostPolicyForceMinimal:
+		return minimalResponse(requestUrl)
+
+	case hostPolicyCacheDuration:
+		return r.resolveURL(cache.WithSuccessDuration(ctx, policy.cacheDuration), requestUrl, urlString, req)
+
+	case hostPolicyTooltipOnly:
+		response, err := r.resolveURL(ctx, requestUrl, urlString, req)
 		if err != nil {
-			log.Errorw("Error writing response",
-				"error", err,
-			)
+			return nil, err
 		}
-		return
+		return withoutThumbnail(response), nil
 	}
 
+	return r.resolveURL(ctx, requestUrl, urlString, req)
+}
+
+// resolveURL runs the given URL through the custom resolvers, falling back to the default link cache
+func (r *LinkResolver) resolveURL(ctx context.Context, requestUrl *url.URL, urlString string, req *http.Request) (*cache.Response, error) {
+	log := logger.FromContext(ctx)
+
 	for _, m := range r.customResolvers {
 		if ctx, result := m.Check(ctx, requestUrl); result {
 			log.Debugw("Run url on custom resolver",
@@ -109,49 +136,88 @@ func (r *LinkResolver) HandleRequest(w http.ResponseWriter, req *http.Request) {
 				break
 			}
 
+			if errors.Is(err, cache.ErrPurged) {
+				// The default link cache may have an entry for the URL as well
+				break
+			}
+
 			resolverHits.WithLabelValues(m.Name()).Inc()
 
-			if err != nil {
-				log.Errorw("Error in custom resolver, falling back to default",
+			if errors.Is(err, resolver.ErrUpstreamUnavailable) {
+				// Falling back to the default resolver would only show a worse tooltip until the
+				// upstream is available again
+				log.Debugw("Upstream of custom resolver unavailable",
 					"name", m.Name(),
 					"url", requestUrl,
 					"error", err,
 				)
-				break
+				return &cache.Response{
+					Payload:     resolver.UpstreamUnavailableBytes,
+					StatusCode:  http.StatusServiceUnavailable,
+					ContentType: "application/json",
+				}, nil
 			}
 
-			w.Header().Add("Content-Type", data.ContentType)
-			w.WriteHeader(data.StatusCode)
-			_, err = w.Write(data.Payload)
 			if err != nil {
-				log.Errorw("Error writing response",
+				log.Errorw("Error in custom resolver, falling back to default",
 					"name", m.Name(),
+					"url", requestUrl,
 					"error", err,
 				)
+				break
 			}
-			return
+
+			return data, nil
 		}
 	}
 
 	resolverHits.WithLabelValues("default").Inc()
 
-	response, err := r.linkCache.Get(ctx, urlString, req)
+	return r.linkCache.Get(ctx, urlString, req)
+}
+
+func (r *LinkResolver) HandleRequest(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	log := logger.FromContext(ctx)
+
+	log.Debugw("Handle request",
+		"path", req.URL.Path,
+	)
+	// w.Header().Set("Content-Type", "application/json")
+	urlString, err := utils.UnescapeURLArgument(req, "url")
+	if err != nil {
+		_, err = resolver.WriteInvalidURL(w)
+		if err != nil {
+			log.Errorw("Error writing response",
+				"error", err,
+			)
+		}
+		return
+	}
+
+	response, err := r.resolve(ctx, urlString, req)
 	if err != nil {
 		log.Errorw("Error in default resolver",
-			"url", requestUrl,
+			"url", urlString,
 			"error", err,
 		)
 		_, err = resolver.WriteInternalServerErrorf(w, "Error resolving link")
 		if err != nil {
 			log.Errorw("Error in default resolver",
-				"url", requestUrl,
+				"url", urlString,
 				"error", err,
 			)
 		}
 	} else {
+		payload := response.Payload
+		if !resolver.WantsData(req) {
+			payload = resolver.WithoutData(payload)
+		}
+
 		w.Header().Add("Content-Type", response.ContentType)
+		w.Header().Add("Vary", "Accept, Accept-Language")
 		w.WriteHeader(response.StatusCode)
-		_, err = w.Write(response.Payload)
+		_, err = w.Write(payload)
 		if err != nil {
 			log.Errorw("Error writing response",
 				"error", err,
@@ -175,6 +241,16 @@ func (r *LinkResolver) HandleThumbnailRequest(w http.ResponseWriter, req *http.R
 		return
 	}
 
+	if r.thumbnailForbidden(url) {
+		_, err = resolver.WriteForbiddenURL(w)
+		if err != nil {
+			log.Errorw("Error writing response",
+				"error", err,
+			)
+		}
+		return
+	}
+
 	response, err := r.thumbnailCache.Get(ctx, url, req)
 	if err != nil {
 		log.Errorw("Error in thumbnail request",
@@ -236,23 +312,12 @@ func (r *LinkResolver) HandleGeneratedValueRequest(w http.ResponseWriter, req *h
 }
 
 func New(ctx context.Context, cfg config.APIConfig, pool db.Pool, helixClient *helix.Client, ignoredHosts map[string]struct{}) *LinkResolver {
-	generatedCache := cache.NewPostgreSQLDependentCache(ctx, cfg, pool, cache.NewPrefixKeyProvider("default:dependent"))
-
-	customResolvers := []resolver.Resolver{}
+	generatedCache := cache.NewDefaultDependentCache(ctx, cfg, pool, cache.NewPrefixKeyProvider("default:dependent"))
 
 	// Register Link Resolvers from internal/resolvers/
-	betterttv.Initialize(ctx, cfg, pool, &customResolvers)
-	discord.Initialize(ctx, cfg, pool, &customResolvers)
-	frankerfacez.Initialize(ctx, cfg, pool, &customResolvers)
-	imgur.Initialize(ctx, cfg, pool, &customResolvers)
-	livestreamfails.Initialize(ctx, cfg, pool, &customResolvers)
-	oembed.Initialize(ctx, cfg, pool, &customResolvers)
-	supinic.Initialize(ctx, cfg, pool, &customResolvers)
-	twitch.Initialize(ctx, cfg, pool, helixClient, &customResolvers)
-	twitter.Initialize(ctx, cfg, pool, &customResolvers, generatedCache)
-	wikipedia.Initialize(ctx, cfg, pool, &customResolvers)
-	youtube.Initialize(ctx, cfg, pool, &customResolvers)
-	seventv.Initialize(ctx, cfg, pool, &customResolvers)
+	customResolvers, resolverStatuses := initializeResolvers(
+		ctx, cfg.Resolvers, newResolverPackages(ctx, cfg, pool, helixClient, generatedCache),
+	)
 
 	// The content type resolvers should match from most to least specific
 	contentTypeResolvers := []ContentTypeResolver{
@@ -272,22 +337,34 @@ func New(ctx context.Context, cfg config.APIConfig, pool db.Pool, helixClient *h
 		enableAnimatedThumbnails: cfg.EnableAnimatedThumbnails,
 	}
 
-	thumbnailCache := cache.NewPostgreSQLCache(
+	if cfg.LinkResolverRespectRobots {
+		linkLoader.robots = &robotsChecker{
+			robotsCache: cache.NewDefaultCache(
+				ctx, cfg, pool, cache.NewPrefixKeyProvider("default:robots"), &RobotsLoader{}, cfg.RobotsTxtCacheDuration,
+			),
+		}
+	}
+
+	thumbnailCache := cache.NewDefaultCache(
 		ctx, cfg, pool, cache.NewPrefixKeyProvider("default:thumbnail"), thumbnailLoader,
 		cfg.ThumbnailCacheDuration,
 	)
-	linkCache := cache.NewPostgreSQLCache(
-		ctx, cfg, pool, cache.NewPrefixKeyProvider("default:link"), linkLoader, cfg.DefaultLinkCacheDuration,
+	linkCache := cache.NewDefaultCache(
+		ctx, cfg, pool, cache.NewLocalizedKeyProvider("default:link"), linkLoader, cfg.DefaultLinkCacheDuration,
 	)
 
 	r := &LinkResolver{
-		customResolvers: customResolvers,
+		customResolvers:  customResolvers,
+		resolverStatuses: resolverStatuses,
 
 		ignoredHosts: ignoredHosts,
+		hostPolicies: newHostPolicies(ctx, cfg),
 
 		linkCache:      linkCache,
 		thumbnailCache: thumbnailCache,
 		generatedCache: generatedCache,
+
+		batch: newBatchOptions(cfg.LinkResolverBatchMaxURLs, cfg.LinkResolverBatchConcurrency, cfg.LinkResolverBatchItemTimeout),
 	}
 
 	return r
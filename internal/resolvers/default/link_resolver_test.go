@@ -77,7 +77,12 @@ func TestLinkResolver(t *testing.T) {
 
 	resolverResponses["/"] = "<html><head><title>/ title</title></head><body>xD</body></html>"
 
+	resolverResponses["/video.mp4"] = "xD"
+
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/video.mp4" {
+			w.Header().Set("Content-Type", "video/mp4")
+		}
 		if response, ok := resolverResponses[r.URL.Path]; ok {
 			w.Write([]byte(response))
 			return
@@ -189,6 +194,117 @@ func TestLinkResolver(t *testing.T) {
 		}
 	})
 
+	c.Run("Data", func(c *qt.C) {
+		tests := []struct {
+			label        string
+			accept       string
+			expectedData *resolver.ResponseData
+		}{
+			{
+				label:        "Not requested",
+				expectedData: nil,
+			},
+			{
+				label:  "Requested",
+				accept: resolver.DataMediaType,
+				expectedData: &resolver.ResponseData{
+					Kind: resolver.DataKindMedia,
+					Fields: map[string]string{
+						"content_type": "video/mp4",
+						"size":         "2",
+					},
+				},
+			},
+		}
+
+		for _, test := range tests {
+			c.Run(test.label, func(c *qt.C) {
+				respRec := httptest.NewRecorder()
+
+				pool.ExpectQuery("SELECT").WillReturnError(pgx.ErrNoRows)
+				pool.ExpectExec("INSERT INTO cache").
+					WithArgs("default:link:"+ts.URL+"/video.mp4", pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+				req := newLinkResolverRequest(t, ctx, "GET", ts.URL+"/video.mp4", nil)
+				if test.accept != "" {
+					req.Header.Set("Accept", test.accept)
+				}
+
+				router.ServeHTTP(respRec, req)
+
+				response := resolver.Response{}
+				c.Assert(json.NewDecoder(respRec.Result().Body).Decode(&response), qt.IsNil)
+				c.Assert(response.Status, qt.Equals, http.StatusOK)
+				c.Assert(response.Data, qt.DeepEquals, test.expectedData)
+
+				c.Assert(pool.ExpectationsWereMet(), qt.IsNil)
+			})
+		}
+	})
+
+	c.Run("Language", func(c *qt.C) {
+		tests := []struct {
+			label          string
+			acceptLanguage string
+			expectedKey    string
+			expectedTitle  string
+			expectedSize   string
+		}{
+			{
+				label:         "Default",
+				expectedKey:   "default:link:" + ts.URL + "/video.mp4",
+				expectedTitle: "<b>Media File</b>",
+				expectedSize:  "<b>Size:</b> 2.0 B",
+			},
+			{
+				label:          "German",
+				acceptLanguage: "de-DE, de;q=0.9, en;q=0.8",
+				expectedKey:    "default:link:de:" + ts.URL + "/video.mp4",
+				expectedTitle:  "<b>Mediendatei</b>",
+				expectedSize:   "<b>Größe:</b> 2,0 B",
+			},
+			{
+				label:          "Unsupported",
+				acceptLanguage: "fr",
+				expectedKey:    "default:link:" + ts.URL + "/video.mp4",
+				expectedTitle:  "<b>Media File</b>",
+				expectedSize:   "<b>Size:</b> 2.0 B",
+			},
+		}
+
+		for _, test := range tests {
+			c.Run(test.label, func(c *qt.C) {
+				respRec := httptest.NewRecorder()
+
+				pool.ExpectQuery("SELECT").WillReturnError(pgx.ErrNoRows)
+				pool.ExpectExec("INSERT INTO cache").
+					WithArgs(test.expectedKey, pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+				req := newLinkResolverRequest(t, ctx, "GET", ts.URL+"/video.mp4", nil)
+				if test.acceptLanguage != "" {
+					req.Header.Set("Accept-Language", test.acceptLanguage)
+				}
+
+				router.ServeHTTP(respRec, req)
+
+				c.Assert(respRec.Header().Get("Vary"), qt.Equals, "Accept, Accept-Language")
+
+				response := resolver.Response{}
+				c.Assert(json.NewDecoder(respRec.Result().Body).Decode(&response), qt.IsNil)
+				c.Assert(response.Status, qt.Equals, http.StatusOK)
+
+				tooltip, err := url.PathUnescape(response.Tooltip)
+				c.Assert(err, qt.IsNil)
+				c.Assert(tooltip, qt.Contains, test.expectedTitle)
+				c.Assert(tooltip, qt.Contains, test.expectedSize)
+
+				c.Assert(pool.ExpectationsWereMet(), qt.IsNil)
+			})
+		}
+	})
+
 	c.Run("Early error", func(c *qt.C) {
 		tests := []struct {
 			inputReq     *http.Request
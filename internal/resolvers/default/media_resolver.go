@@ -4,26 +4,27 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"html/template"
 	"mime"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
 
 const mediaTooltipTemplateString = `<div style="text-align: left;">
-<b>Media File</b>
-{{if .MediaType}}<br><b>Type:</b> {{.MediaType}}{{end}}
-{{if .Size}}<br><b>Size:</b> {{.Size}}{{end}}
+<b>{{t "Media File"}}</b>
+{{if .MediaType}}<br><b>{{t "Type"}}:</b> {{.MediaType}}{{end}}
+{{if .Size}}<br><b>{{t "Size"}}:</b> {{.Size}}{{end}}
 </div>
 `
 
-var mediaTooltipTemplate = template.Must(template.New("mediaTooltipTemplate").Parse(mediaTooltipTemplateString))
+var mediaTooltipTemplate = i18n.MustTemplate("mediaTooltipTemplate", mediaTooltipTemplateString)
 
 type mediaTooltipData struct {
 	MediaType string
@@ -44,13 +45,14 @@ func (r *MediaResolver) Check(ctx context.Context, contentType string) bool {
 }
 
 func (r *MediaResolver) Run(ctx context.Context, req *http.Request, resp *http.Response) (*resolver.Response, error) {
+	lang := i18n.FromContext(ctx)
 	mimeType := resp.Header.Get("Content-Type")
 	spl := strings.Split(mimeType, "/")
 
 	size := ""
 	reportedSize := resp.ContentLength
 	if reportedSize > 0 {
-		size = humanize.Bytes(uint64(reportedSize))
+		size = humanize.BytesIn(lang, uint64(reportedSize))
 	}
 
 	ttData := mediaTooltipData{
@@ -62,7 +64,7 @@ func (r *MediaResolver) Run(ctx context.Context, req *http.Request, resp *http.R
 	}
 
 	var tooltip bytes.Buffer
-	if err := mediaTooltipTemplate.Execute(&tooltip, ttData); err != nil {
+	if err := mediaTooltipTemplate.Execute(&tooltip, lang, ttData); err != nil {
 		return nil, err
 	}
 
@@ -71,6 +73,16 @@ func (r *MediaResolver) Run(ctx context.Context, req *http.Request, resp *http.R
 		Status:  http.StatusOK,
 		Link:    targetURL,
 		Tooltip: url.PathEscape(tooltip.String()),
+		Data: &resolver.ResponseData{
+			Kind: resolver.DataKindMedia,
+			Fields: map[string]string{
+				"content_type": mimeType,
+			},
+		},
+	}
+
+	if reportedSize > 0 {
+		response.Data.Fields["size"] = strconv.FormatInt(reportedSize, 10)
 	}
 
 	return response, nil
@@ -4,13 +4,14 @@ import (
 	"bytes"
 	"context"
 	"html"
-	"html/template"
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/Chatterino/api/internal/logger"
 	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	"github.com/Chatterino/api/pkg/utils"
 	"github.com/pdfcpu/pdfcpu/pkg/api"
@@ -20,16 +21,16 @@ import (
 )
 
 const templateString = `<div style="text-align: left;">
-<b>PDF File</b><br>
-{{if .Title}}<b>Title:</b> {{.Title}}<br>{{end}}
-{{if .Author}}<b>Author:</b> {{.Author}}<br>{{end}}
+<b>{{t "PDF File"}}</b><br>
+{{if .Title}}<b>{{t "Title"}}:</b> {{.Title}}<br>{{end}}
+{{if .Author}}<b>{{t "Author"}}:</b> {{.Author}}<br>{{end}}
 <span style="color: #808892;">
-{{.PageCount}} pages{{if .CreationDate}}&nbsp;•&nbsp;{{.CreationDate}}{{end}}
+{{t "%d pages" .PageCount}}{{if .CreationDate}}&nbsp;•&nbsp;{{.CreationDate}}{{end}}
 </span>
 </div>
 `
 
-var pdfTooltipTemplate = template.Must(template.New("pdfTooltipTemplate").Parse(templateString))
+var pdfTooltipTemplate = i18n.MustTemplate("pdfTooltipTemplate", templateString)
 
 type pdfTooltipData struct {
 	Title        string
@@ -49,6 +50,7 @@ func (r *PDFResolver) Check(ctx context.Context, contentType string) bool {
 
 func (r *PDFResolver) Run(ctx context.Context, req *http.Request, resp *http.Response) (*resolver.Response, error) {
 	log := logger.FromContext(ctx)
+	lang := i18n.FromContext(ctx)
 
 	limiter := resolver.WriteLimiter{Limit: r.maxContentLength}
 	limitedReader := io.TeeReader(resp.Body, &limiter)
@@ -72,8 +74,10 @@ func (r *PDFResolver) Run(ctx context.Context, req *http.Request, resp *http.Res
 	}
 
 	dtString := ""
+	published := ""
 	if creationDt, ok := types.DateTime(pdfCtx.XRefTable.CreationDate, true); ok {
-		dtString = humanize.CreationDate(creationDt)
+		dtString = humanize.CreationDateIn(lang, creationDt)
+		published = creationDt.Format(time.RFC3339)
 	}
 
 	ttData := pdfTooltipData{
@@ -84,7 +88,7 @@ func (r *PDFResolver) Run(ctx context.Context, req *http.Request, resp *http.Res
 	}
 
 	var tooltip bytes.Buffer
-	if err := pdfTooltipTemplate.Execute(&tooltip, ttData); err != nil {
+	if err := pdfTooltipTemplate.Execute(&tooltip, lang, ttData); err != nil {
 		return nil, err
 	}
 
@@ -94,6 +98,13 @@ func (r *PDFResolver) Run(ctx context.Context, req *http.Request, resp *http.Res
 		Link:      targetURL,
 		Tooltip:   url.PathEscape(tooltip.String()),
 		Thumbnail: utils.FormatThumbnailURL(r.baseURL, req, targetURL),
+		Data: &resolver.ResponseData{
+			Kind:      resolver.DataKindDocument,
+			Title:     pdfCtx.Title,
+			Author:    pdfCtx.Author,
+			Count:     uint64(pdfCtx.PageCount),
+			Published: published,
+		},
 	}
 
 	return response, nil
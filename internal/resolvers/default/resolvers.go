@@ -0,0 +1,386 @@
+package defaultresolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/internal/resolvers/betterttv"
+	"github.com/Chatterino/api/internal/resolvers/bluesky"
+	"github.com/Chatterino/api/internal/resolvers/declarative"
+	"github.com/Chatterino/api/internal/resolvers/discord"
+	"github.com/Chatterino/api/internal/resolvers/frankerfacez"
+	"github.com/Chatterino/api/internal/resolvers/github"
+	"github.com/Chatterino/api/internal/resolvers/imgur"
+	"github.com/Chatterino/api/internal/resolvers/kick"
+	"github.com/Chatterino/api/internal/resolvers/livestreamfails"
+	"github.com/Chatterino/api/internal/resolvers/mastodon"
+	"github.com/Chatterino/api/internal/resolvers/oembed"
+	"github.com/Chatterino/api/internal/resolvers/reddit"
+	"github.com/Chatterino/api/internal/resolvers/seventv"
+	"github.com/Chatterino/api/internal/resolvers/supinic"
+	"github.com/Chatterino/api/internal/resolvers/twitch"
+	"github.com/Chatterino/api/internal/resolvers/twitter"
+	"github.com/Chatterino/api/internal/resolvers/wikipedia"
+	"github.com/Chatterino/api/internal/resolvers/youtube"
+	"github.com/Chatterino/api/internal/twitchapiclient"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/nicklaw5/helix"
+)
+
+const reasonDisabledInConfig = "Disabled in the resolvers config"
+
+// resolverPackage registers the custom resolvers of one of the packages in internal/resolvers
+type resolverPackage struct {
+	// Names of the resolvers the package can register, in the order they are registered
+	names []string
+	// Config options the package needs to register its resolvers, if any
+	requires string
+
+	initialize func(resolvers *[]resolver.Resolver)
+}
+
+// ResolverStatus describes whether a custom resolver is used, and why not if it isn't
+type ResolverStatus struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Reason  string `json:"reason,omitempty"`
+	Order   int    `json:"order"`
+
+	CacheDuration string `json:"cache_duration,omitempty"`
+	Timeout       string `json:"timeout,omitempty"`
+}
+
+// configuredResolver applies the cache duration and timeout from the resolvers config to a custom resolver
+type configuredResolver struct {
+	resolver.Resolver
+
+	cacheDuration time.Duration
+	timeout       time.Duration
+}
+
+func (r *configuredResolver) Run(ctx context.Context, url *url.URL, req *http.Request) (*cache.Response, error) {
+	if r.cacheDuration > 0 {
+		// Cache durations from host policies are more specific, and take precedence
+		ctx = cache.WithDefaultSuccessDuration(ctx, r.cacheDuration)
+	}
+
+	if r.timeout <= 0 {
+		return r.Resolver.Run(ctx, url, req)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	type result struct {
+		response *cache.Response
+		err      error
+	}
+
+	// Resolvers don't necessarily stop when the context is done (e.g. while waiting for a cache
+	// load started by another request), so we stop waiting for them instead. The channel is
+	// buffered so the resolver can still finish in the background.
+	done := make(chan result, 1)
+	go func() {
+		response, err := r.Resolver.Run(ctx, url, req)
+		done <- result{response, err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.response, res.err
+	case <-ctx.Done():
+		// resolveURL falls back to the default resolver
+		return nil, fmt.Errorf("resolver %s timed out after %s: %w", r.Name(), r.timeout, ctx.Err())
+	}
+}
+
+func resolverEnabled(resolverConfig config.ResolverConfig) bool {
+	return resolverConfig.Enabled == nil || *resolverConfig.Enabled
+}
+
+// newResolverPackages lists the packages registering custom resolvers, in their default order
+func newResolverPackages(ctx context.Context, cfg config.APIConfig, pool db.Pool, helixClient *helix.Client, generatedCache cache.DependentCache) []resolverPackage {
+	packages := []resolverPackage{
+		{
+			names: []string{"betterttv:emote"},
+			initialize: func(resolvers *[]resolver.Resolver) {
+				betterttv.Initialize(ctx, cfg, pool, resolvers)
+			},
+		},
+		{
+			names: []string{"bluesky:post"},
+			initialize: func(resolvers *[]resolver.Resolver) {
+				bluesky.Initialize(ctx, cfg, pool, resolvers, generatedCache)
+			},
+		},
+		{
+			names:    []string{"discord:invite"},
+			requires: "discord-token",
+			initialize: func(resolvers *[]resolver.Resolver) {
+				discord.Initialize(ctx, cfg, pool, resolvers)
+			},
+		},
+		{
+			names: []string{"frankerfacez:emote"},
+			initialize: func(resolvers *[]resolver.Resolver) {
+				frankerfacez.Initialize(ctx, cfg, pool, resolvers)
+			},
+		},
+		{
+			names: []string{"github:issue", "github:commit", "github:release", "github:repo", "github:gist"},
+			initialize: func(resolvers *[]resolver.Resolver) {
+				github.Initialize(ctx, cfg, pool, resolvers)
+			},
+		},
+		{
+			names:    []string{"imgur"},
+			requires: "imgur-client-id",
+			initialize: func(resolvers *[]resolver.Resolver) {
+				imgur.Initialize(ctx, cfg, pool, resolvers)
+			},
+		},
+		{
+			names: []string{"kick:clip", "kick:video", "kick:channel"},
+			initialize: func(resolvers *[]resolver.Resolver) {
+				kick.Initialize(ctx, cfg, pool, resolvers)
+			},
+		},
+		{
+			names: []string{"livestreamfails:clip"},
+			initialize: func(resolvers *[]resolver.Resolver) {
+				livestreamfails.Initialize(ctx, cfg, pool, resolvers)
+			},
+		},
+		{
+			names:    []string{"oembed"},
+			requires: "oembed-providers-path",
+			initialize: func(resolvers *[]resolver.Resolver) {
+				oembed.Initialize(ctx, cfg, pool, resolvers)
+			},
+		},
+		{
+			names: []string{"reddit:comment", "reddit:post", "reddit:subreddit"},
+			initialize: func(resolvers *[]resolver.Resolver) {
+				reddit.Initialize(ctx, cfg, pool, resolvers)
+			},
+		},
+		{
+			names: []string{"supinic:track"},
+			initialize: func(resolvers *[]resolver.Resolver) {
+				supinic.Initialize(ctx, cfg, pool, resolvers)
+			},
+		},
+		{
+			names:    []string{"twitch:user", "twitch:clip", "twitch:video", "twitch:category", "twitch:schedule"},
+			requires: "twitch-client-id and twitch-client-secret",
+			initialize: func(resolvers *[]resolver.Resolver) {
+				twitch.Initialize(ctx, cfg, pool, twitchapiclient.Extend(helixClient, cfg.TwitchClientID), resolvers)
+			},
+		},
+		{
+			names:    []string{"twitter"},
+			requires: "twitter-bearer-token",
+			initialize: func(resolvers *[]resolver.Resolver) {
+				twitter.Initialize(ctx, cfg, pool, resolvers, generatedCache)
+			},
+		},
+		{
+			names: []string{"wikipedia:article"},
+			initialize: func(resolvers *[]resolver.Resolver) {
+				wikipedia.Initialize(ctx, cfg, pool, resolvers)
+			},
+		},
+		{
+			names:    []string{"youtube:playlist", "youtube:channel", "youtube:video", "youtube:video:shorturl", "youtube:clip"},
+			requires: "youtube-api-key",
+			initialize: func(resolvers *[]resolver.Resolver) {
+				youtube.Initialize(ctx, cfg, pool, resolvers)
+			},
+		},
+		{
+			names: []string{"seventv:emote"},
+			initialize: func(resolvers *[]resolver.Resolver) {
+				seventv.Initialize(ctx, cfg, pool, resolvers)
+			},
+		},
+	}
+
+	// Site resolvers are checked after the resolvers written in Go
+	for _, site := range cfg.SiteResolvers {
+		p := resolverPackage{
+			names: []string{site.Name},
+			initialize: func(resolvers *[]resolver.Resolver) {
+				declarative.Initialize(ctx, cfg, pool, site, resolvers)
+			},
+		}
+		if site.Auth.Env != "" && os.Getenv(site.Auth.Env) == "" {
+			p.requires = "the " + site.Auth.Env + " environment variable"
+		}
+
+		packages = append(packages, p)
+	}
+
+	// The Mastodon resolver matches status links on any host, and falls back to the default resolver
+	// if the host isn't an instance. Resolvers checked after it would be skipped for those links.
+	packages = append(packages, resolverPackage{
+		names: []string{"mastodon:status"},
+		initialize: func(resolvers *[]resolver.Resolver) {
+			mastodon.Initialize(ctx, cfg, pool, resolvers, generatedCache)
+		},
+	})
+
+	return packages
+}
+
+// initializeResolvers registers the resolvers of the packages that are enabled in the resolvers config.
+// Returns the enabled resolvers in the order they should be checked in, and the status of every resolver.
+func initializeResolvers(ctx context.Context, resolversConfig map[string]config.ResolverConfig, packages []resolverPackage) ([]resolver.Resolver, []ResolverStatus) {
+	log := logger.FromContext(ctx)
+
+	type orderedResolver struct {
+		resolver resolver.Resolver
+		status   ResolverStatus
+	}
+
+	knownNames := map[string]struct{}{}
+	for _, p := range packages {
+		for _, name := range p.names {
+			knownNames[name] = struct{}{}
+		}
+	}
+	for name := range resolversConfig {
+		if _, ok := knownNames[name]; !ok {
+			log.Warnw("[Config] Unknown resolver in resolvers config",
+				"name", name,
+			)
+		}
+	}
+
+	var resolvers []orderedResolver
+
+	for _, p := range packages {
+		registered := []resolver.Resolver{}
+
+		for _, name := range p.names {
+			if resolverEnabled(resolversConfig[name]) {
+				// Packages whose resolvers are all disabled aren't initialized at all
+				p.initialize(&registered)
+				break
+			}
+		}
+
+		registeredNames := map[string]struct{}{}
+		for _, r := range registered {
+			registeredNames[r.Name()] = struct{}{}
+
+			resolverConfig := resolversConfig[r.Name()]
+			status := ResolverStatus{
+				Name:    r.Name(),
+				Enabled: resolverEnabled(resolverConfig),
+				Order:   resolverConfig.Order,
+			}
+
+			if !status.Enabled {
+				status.Reason = reasonDisabledInConfig
+				resolvers = append(resolvers, orderedResolver{status: status})
+				continue
+			}
+
+			if resolverConfig.CacheDuration > 0 || resolverConfig.Timeout > 0 {
+				r = &configuredResolver{
+					Resolver:      r,
+					cacheDuration: resolverConfig.CacheDuration,
+					timeout:       resolverConfig.Timeout,
+				}
+			}
+			if resolverConfig.CacheDuration > 0 {
+				status.CacheDuration = resolverConfig.CacheDuration.String()
+			}
+			if resolverConfig.Timeout > 0 {
+				status.Timeout = resolverConfig.Timeout.String()
+			}
+
+			resolvers = append(resolvers, orderedResolver{
+				resolver: r,
+				status:   status,
+			})
+		}
+
+		for _, name := range p.names {
+			if _, ok := registeredNames[name]; ok {
+				continue
+			}
+
+			resolverConfig := resolversConfig[name]
+			status := ResolverStatus{
+				Name:  name,
+				Order: resolverConfig.Order,
+			}
+
+			switch {
+			case !resolverEnabled(resolverConfig):
+				status.Reason = reasonDisabledInConfig
+			case p.requires != "":
+				status.Reason = "Missing configuration, requires " + p.requires
+			default:
+				status.Reason = "Failed to initialize"
+			}
+
+			resolvers = append(resolvers, orderedResolver{status: status})
+		}
+	}
+
+	sort.SliceStable(resolvers, func(i, j int) bool {
+		return resolvers[i].status.Order < resolvers[j].status.Order
+	})
+
+	customResolvers := []resolver.Resolver{}
+	statuses := make([]ResolverStatus, 0, len(resolvers))
+	for _, r := range resolvers {
+		if r.resolver != nil {
+			customResolvers = append(customResolvers, r.resolver)
+		}
+		statuses = append(statuses, r.status)
+	}
+
+	return customResolvers, statuses
+}
+
+// HandleResolversRequest lists the custom resolvers in the order they're checked in, and whether
+// they're used by this instance
+func (r *LinkResolver) HandleResolversRequest(w http.ResponseWriter, req *http.Request) {
+	log := logger.FromContext(req.Context())
+
+	payload, err := json.Marshal(r.resolverStatuses)
+	if err != nil {
+		log.Errorw("Error marshalling resolvers",
+			"error", err,
+		)
+		_, err = resolver.WriteInternalServerErrorf(w, "Error listing resolvers")
+		if err != nil {
+			log.Errorw("Error writing response",
+				"error", err,
+			)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(payload)
+	if err != nil {
+		log.Errorw("Error writing response",
+			"error", err,
+		)
+	}
+}
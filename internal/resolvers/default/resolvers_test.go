@@ -0,0 +1,245 @@
+package defaultresolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	qt "github.com/frankban/quicktest"
+)
+
+type testResolver struct {
+	name string
+	run  func(ctx context.Context) (*cache.Response, error)
+}
+
+func (r *testResolver) Check(ctx context.Context, url *url.URL) (context.Context, bool) {
+	return ctx, true
+}
+
+func (r *testResolver) Run(ctx context.Context, url *url.URL, req *http.Request) (*cache.Response, error) {
+	return r.run(ctx)
+}
+
+func (r *testResolver) Name() string {
+	return r.name
+}
+
+func testResolverPackage(initialized *int, requires string, register bool, names ...string) resolverPackage {
+	return resolverPackage{
+		names:    names,
+		requires: requires,
+		initialize: func(resolvers *[]resolver.Resolver) {
+			*initialized++
+			if !register {
+				return
+			}
+			for _, name := range names {
+				*resolvers = append(*resolvers, &testResolver{name: name})
+			}
+		},
+	}
+}
+
+func resolverNames(resolvers []resolver.Resolver) []string {
+	names := []string{}
+	for _, r := range resolvers {
+		names = append(names, r.Name())
+	}
+	return names
+}
+
+func TestInitializeResolvers(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+	disabled := false
+
+	c.Run("Default order", func(c *qt.C) {
+		var initialized int
+		resolvers, statuses := initializeResolvers(ctx, nil, []resolverPackage{
+			testResolverPackage(&initialized, "", true, "a:one", "a:two"),
+			testResolverPackage(&initialized, "b-token", false, "b"),
+			testResolverPackage(&initialized, "", true, "c"),
+		})
+
+		c.Assert(initialized, qt.Equals, 3)
+		c.Assert(resolverNames(resolvers), qt.DeepEquals, []string{"a:one", "a:two", "c"})
+		c.Assert(statuses, qt.DeepEquals, []ResolverStatus{
+			{Name: "a:one", Enabled: true},
+			{Name: "a:two", Enabled: true},
+			{Name: "b", Reason: "Missing configuration, requires b-token"},
+			{Name: "c", Enabled: true},
+		})
+	})
+
+	c.Run("Configured", func(c *qt.C) {
+		var initialized int
+		resolvers, statuses := initializeResolvers(ctx, map[string]config.ResolverConfig{
+			"a:one":   {Enabled: &disabled},
+			"b":       {Enabled: &disabled},
+			"c":       {Order: -1, CacheDuration: time.Hour, Timeout: 5 * time.Second},
+			"unknown": {Enabled: &disabled},
+		}, []resolverPackage{
+			testResolverPackage(&initialized, "", true, "a:one", "a:two"),
+			testResolverPackage(&initialized, "", true, "b"),
+			testResolverPackage(&initialized, "", true, "c"),
+		})
+
+		// Packages whose resolvers are all disabled aren't initialized
+		c.Assert(initialized, qt.Equals, 2)
+		c.Assert(resolverNames(resolvers), qt.DeepEquals, []string{"c", "a:two"})
+		configured, ok := resolvers[0].(*configuredResolver)
+		c.Assert(ok, qt.IsTrue)
+		c.Assert(configured.cacheDuration, qt.Equals, time.Hour)
+		c.Assert(configured.timeout, qt.Equals, 5*time.Second)
+		c.Assert(statuses, qt.DeepEquals, []ResolverStatus{
+			{Name: "c", Enabled: true, Order: -1, CacheDuration: "1h0m0s", Timeout: "5s"},
+			{Name: "a:one", Reason: reasonDisabledInConfig},
+			{Name: "a:two", Enabled: true},
+			{Name: "b", Reason: reasonDisabledInConfig},
+		})
+	})
+}
+
+func TestSiteResolverPackages(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+	t.Setenv("TEST_SITE_TOKEN", "")
+
+	cfg := config.APIConfig{
+		CacheBackend: cache.BackendMemory,
+		SiteResolvers: []config.SiteResolver{
+			{
+				Name:    "example:user",
+				Host:    `^example\.com$`,
+				URL:     "https://api.example.com/user",
+				Tooltip: "{{.Fields.name}}",
+			},
+			{
+				Name:    "example:token",
+				Host:    `^example\.org$`,
+				URL:     "https://api.example.org/user",
+				Tooltip: "{{.Fields.name}}",
+				Auth: config.SiteResolverAuth{
+					Env: "TEST_SITE_TOKEN",
+				},
+			},
+		},
+	}
+
+	packages := newResolverPackages(ctx, cfg, nil, nil, nil)
+
+	// Site resolvers come right before the Mastodon resolver, which is checked last
+	c.Assert(packages[len(packages)-1].names, qt.DeepEquals, []string{"mastodon:status"})
+	resolvers, statuses := initializeResolvers(ctx, nil, packages[len(packages)-3:len(packages)-1])
+
+	c.Assert(resolverNames(resolvers), qt.DeepEquals, []string{"example:user"})
+	c.Assert(statuses, qt.DeepEquals, []ResolverStatus{
+		{Name: "example:user", Enabled: true},
+		{Name: "example:token", Reason: "Missing configuration, requires the TEST_SITE_TOKEN environment variable"},
+	})
+}
+
+func TestConfiguredResolver(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	var runCtx context.Context
+	r := &configuredResolver{
+		Resolver: &testResolver{
+			name: "test",
+			run: func(ctx context.Context) (*cache.Response, error) {
+				runCtx = ctx
+				return &cache.Response{}, nil
+			},
+		},
+		cacheDuration: time.Hour,
+		timeout:       time.Minute,
+	}
+
+	_, err := r.Run(ctx, nil, nil)
+	c.Assert(err, qt.IsNil)
+
+	deadline, ok := runCtx.Deadline()
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(time.Until(deadline) <= time.Minute, qt.IsTrue)
+	// The timeout is released once the resolver is done
+	c.Assert(runCtx.Err(), qt.Equals, context.Canceled)
+
+	c.Run("Timeout falls back to the default resolver", func(c *qt.C) {
+		cfg := config.APIConfig{
+			MaxContentLength: 5 * 1024 * 1024, // 5 MB
+			CacheBackend:     cache.BackendMemory,
+		}
+		resolver.InitializeStaticResponses(ctx, cfg)
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `<html><head><title>default title</title></head></html>`)
+		}))
+		defer ts.Close()
+
+		// The slow resolver ignores its context, like a resolver waiting for a cache load would
+		release := make(chan struct{})
+		defer close(release)
+
+		linkResolver := New(ctx, cfg, nil, nil, nil)
+		linkResolver.customResolvers = []resolver.Resolver{
+			&configuredResolver{
+				Resolver: &testResolver{
+					name: "slow",
+					run: func(ctx context.Context) (*cache.Response, error) {
+						<-release
+						return &cache.Response{Payload: []byte("slow")}, nil
+					},
+				},
+				timeout: 50 * time.Millisecond,
+			},
+		}
+
+		start := time.Now()
+		response, err := linkResolver.resolve(ctx, ts.URL, nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(time.Since(start) < 5*time.Second, qt.IsTrue)
+
+		var data resolver.Response
+		c.Assert(json.Unmarshal(response.Payload, &data), qt.IsNil)
+		tooltip, err := url.PathUnescape(data.Tooltip)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tooltip, qt.Contains, "default title")
+	})
+}
+
+func TestHandleResolversRequest(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	r := &LinkResolver{
+		resolverStatuses: []ResolverStatus{
+			{Name: "twitch:clip", Enabled: true, Order: -1, CacheDuration: "1h0m0s"},
+			{Name: "youtube:video", Reason: "Missing configuration, requires youtube-api-key"},
+		},
+	}
+
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/resolvers", nil)
+	w := httptest.NewRecorder()
+	r.HandleResolversRequest(w, req)
+
+	c.Assert(w.Code, qt.Equals, http.StatusOK)
+	c.Assert(w.Header().Get("Content-Type"), qt.Equals, "application/json")
+
+	var statuses []map[string]any
+	c.Assert(json.Unmarshal(w.Body.Bytes(), &statuses), qt.IsNil)
+	c.Assert(statuses, qt.DeepEquals, []map[string]any{
+		{"name": "twitch:clip", "enabled": true, "order": float64(-1), "cache_duration": "1h0m0s"},
+		{"name": "youtube:video", "enabled": false, "order": float64(0), "reason": "Missing configuration, requires youtube-api-key"},
+	})
+}
@@ -0,0 +1,283 @@
+package defaultresolver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	// Product token of our user agent, matched against the User-agent lines of robots.txt files
+	robotsUserAgent = "chatterino-api-cache"
+
+	// Crawlers must parse at least 500 KiB of a robots.txt file (RFC 9309 section 2.5)
+	maxRobotsTxtSize = 500 * 1024
+)
+
+type robotsRule struct {
+	allow bool
+	// Length of the path pattern, the longest matching rule wins
+	length int
+	regex  *regexp.Regexp
+}
+
+type robotsGroup struct {
+	userAgents []string
+	rules      []robotsRule
+}
+
+type robotsTxt struct {
+	groups []robotsGroup
+}
+
+// robotsPattern converts a robots.txt path pattern to a regex.
+// "*" matches any sequence of characters, and a trailing "$" anchors the pattern to the end of the path.
+func robotsPattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+
+	expr := "^" + strings.Join(parts, ".*")
+	if anchored {
+		expr += "$"
+	}
+
+	return regexp.MustCompile(expr)
+}
+
+func parseRobotsTxt(body []byte) *robotsTxt {
+	robots := &robotsTxt{}
+
+	var group *robotsGroup
+	// Whether the current group has seen a rule line, after which a user-agent line starts a new group
+	groupClosed := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			// Consecutive user-agent lines belong to the same group
+			if group == nil || groupClosed {
+				robots.groups = append(robots.groups, robotsGroup{})
+				group = &robots.groups[len(robots.groups)-1]
+				groupClosed = false
+			}
+			if value != "" {
+				group.userAgents = append(group.userAgents, strings.ToLower(value))
+			}
+
+		case "allow", "disallow":
+			// Rules before the first user-agent line are ignored
+			if group == nil {
+				continue
+			}
+			groupClosed = true
+
+			// An empty rule allows everything, it only ends the group's user-agent lines
+			if value == "" {
+				continue
+			}
+			group.rules = append(group.rules, robotsRule{
+				allow:  key == "allow",
+				length: len(value),
+				regex:  robotsPattern(value),
+			})
+		}
+	}
+
+	return robots
+}
+
+// rulesFor returns the rules of all groups naming the product token of the user agent (compared
+// case-insensitively), or of the "*" groups if none match
+func (r *robotsTxt) rulesFor(userAgent string) []robotsRule {
+	var matching, wildcard []robotsRule
+
+	userAgent = strings.ToLower(userAgent)
+
+	for _, group := range r.groups {
+		for _, groupUserAgent := range group.userAgents {
+			if groupUserAgent == "*" {
+				wildcard = append(wildcard, group.rules...)
+				break
+			}
+
+			if groupUserAgent == userAgent {
+				matching = append(matching, group.rules...)
+				break
+			}
+		}
+	}
+
+	if len(matching) > 0 {
+		return matching
+	}
+
+	return wildcard
+}
+
+// allowed returns whether the user agent may fetch the path (including the query).
+// The longest matching rule decides, and allow rules win ties.
+func (r *robotsTxt) allowed(userAgent string, path string) bool {
+	var best *robotsRule
+
+	for _, rule := range r.rulesFor(userAgent) {
+		if !rule.regex.MatchString(path) {
+			continue
+		}
+
+		if best == nil || rule.length > best.length || (rule.length == best.length && rule.allow) {
+			best = &rule
+		}
+	}
+
+	return best == nil || best.allow
+}
+
+// robotsDirectivesDisallow returns whether a comma separated list of robots directives (as used by
+// X-Robots-Tag and <meta name="robots">) forbids us from previewing the page
+func robotsDirectivesDisallow(directives string) bool {
+	for directive := range strings.SplitSeq(directives, ",") {
+		switch strings.ToLower(strings.TrimSpace(directive)) {
+		case "noindex", "none", "nopreview":
+			return true
+		}
+	}
+
+	return false
+}
+
+// xRobotsTagDisallows returns whether the X-Robots-Tag headers forbid us from previewing the page.
+// Headers can be targeted to a specific user agent, e.g. "googlebot: noindex".
+func xRobotsTagDisallows(header http.Header) bool {
+	for _, value := range header.Values("X-Robots-Tag") {
+		if userAgent, directives, ok := strings.Cut(value, ":"); ok {
+			userAgent = strings.ToLower(strings.TrimSpace(userAgent))
+			// "unavailable_after: <date>" is a directive rather than a user agent
+			if !strings.ContainsAny(userAgent, ", ") && userAgent != "unavailable_after" {
+				if !strings.Contains(robotsUserAgent, userAgent) {
+					continue
+				}
+				value = directives
+			}
+		}
+
+		if robotsDirectivesDisallow(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// metaRobotsDisallows returns whether the page's <meta name="robots"> tags forbid us from previewing it
+func metaRobotsDisallows(doc *goquery.Document) bool {
+	disallowed := false
+
+	doc.Find("meta[name]").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		name := strings.ToLower(s.AttrOr("name", ""))
+		if name != "robots" && name != robotsUserAgent {
+			return true
+		}
+
+		disallowed = robotsDirectivesDisallow(s.AttrOr("content", ""))
+		return !disallowed
+	})
+
+	return disallowed
+}
+
+// RobotsLoader loads the robots.txt file of an origin (e.g. https://example.com)
+type RobotsLoader struct{}
+
+func (l *RobotsLoader) Load(ctx context.Context, origin string, r *http.Request) ([]byte, *int, *string, time.Duration, error) {
+	resp, err := resolver.RequestGET(ctx, origin+"/robots.txt")
+	if err != nil {
+		return nil, nil, nil, cache.NoSpecialDur, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRobotsTxtSize))
+	if err != nil {
+		return nil, nil, nil, cache.NoSpecialDur, err
+	}
+
+	statusCode := resp.StatusCode
+	contentType := "text/plain"
+
+	return body, &statusCode, &contentType, cache.NoSpecialDur, nil
+}
+
+type robotsChecker struct {
+	robotsCache cache.Cache
+}
+
+// allowed returns whether the robots.txt of the URL's origin allows us to fetch it.
+// If the robots.txt file can't be loaded, the URL is allowed.
+func (c *robotsChecker) allowed(ctx context.Context, u *url.URL, r *http.Request) bool {
+	log := logger.FromContext(ctx)
+
+	origin := u.Scheme + "://" + u.Host
+
+	response, err := c.robotsCache.Get(ctx, origin, r)
+	if err != nil {
+		log.Debugw("Error loading robots.txt, allowing url",
+			"origin", origin,
+			"error", err,
+		)
+		return true
+	}
+
+	switch {
+	case response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= http.StatusInternalServerError:
+		// The robots.txt file is unreachable, assume everything is disallowed (RFC 9309 section 2.3.1.4)
+		return false
+	case response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices:
+		// The robots.txt file is unavailable, assume everything is allowed (RFC 9309 section 2.3.1.3)
+		return true
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+
+	return parseRobotsTxt(response.Payload).allowed(robotsUserAgent, path)
+}
+
+// returnMinimal returns a tooltip only containing the URL, used for pages we're not allowed to preview
+func returnMinimal(u *url.URL) ([]byte, *int, *string, time.Duration, error) {
+	response, err := minimalResponse(u)
+	if err != nil {
+		return nil, nil, nil, cache.NoSpecialDur, err
+	}
+
+	return response.Payload, &response.StatusCode, &response.ContentType, cache.NoSpecialDur, nil
+}
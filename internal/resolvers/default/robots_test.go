@@ -0,0 +1,231 @@
+package defaultresolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/PuerkitoBio/goquery"
+	qt "github.com/frankban/quicktest"
+)
+
+func TestRobotsTxtAllowed(t *testing.T) {
+	c := qt.New(t)
+
+	robots := parseRobotsTxt([]byte(`# comment
+Disallow: /ignored-before-user-agent
+
+User-agent: *
+Disallow: /private
+Allow: /private/public
+Disallow: /*.pdf$
+
+User-agent: otherbot
+User-agent: Chatterino-API-Cache
+Disallow: /no-chatterino # trailing comment
+Disallow:
+
+User-agent: googlebot
+Disallow: /
+`))
+
+	tests := []struct {
+		userAgent string
+		path      string
+		expected  bool
+	}{
+		{robotsUserAgent, "/", true},
+		{robotsUserAgent, "/no-chatterino", false},
+		{robotsUserAgent, "/no-chatterino/page?a=b", false},
+		// Our group matched, so the * group doesn't apply
+		{robotsUserAgent, "/private", true},
+		{robotsUserAgent, "/ignored-before-user-agent", true},
+		{"somebot", "/private", false},
+		{"somebot", "/private/public/page", true},
+		{"somebot", "/document.pdf", false},
+		{"somebot", "/document.pdf?download=1", true},
+		{"somebot", "/no-chatterino", true},
+		{"otherbot", "/no-chatterino", false},
+		{"googlebot", "/anything", false},
+	}
+
+	for _, test := range tests {
+		c.Run(test.userAgent+" "+test.path, func(c *qt.C) {
+			c.Assert(robots.allowed(test.userAgent, test.path), qt.Equals, test.expected)
+		})
+	}
+
+	c.Run("Empty file", func(c *qt.C) {
+		c.Assert(parseRobotsTxt(nil).allowed(robotsUserAgent, "/"), qt.IsTrue)
+	})
+
+	c.Run("Equal length rules prefer allow", func(c *qt.C) {
+		robots := parseRobotsTxt([]byte("User-agent: *\nDisallow: /page\nAllow: /page\n"))
+		c.Assert(robots.allowed(robotsUserAgent, "/page"), qt.IsTrue)
+	})
+
+	c.Run("Empty disallow ends the group", func(c *qt.C) {
+		robots := parseRobotsTxt([]byte("User-agent: *\nDisallow:\n\nUser-agent: GPTBot\nDisallow: /\n"))
+		c.Assert(robots.allowed(robotsUserAgent, "/"), qt.IsTrue)
+		c.Assert(robots.allowed("gptbot", "/"), qt.IsFalse)
+	})
+
+	c.Run("Parts of the product token don't match", func(c *qt.C) {
+		robots := parseRobotsTxt([]byte("User-agent: api\nDisallow: /\n\nUser-agent: cache\nDisallow: /\n"))
+		c.Assert(robots.allowed(robotsUserAgent, "/"), qt.IsTrue)
+		c.Assert(robots.allowed("Chatterino-API-Cache", "/"), qt.IsTrue)
+
+		robots = parseRobotsTxt([]byte("User-agent: api\nDisallow: /\n\nUser-agent: CHATTERINO-API-CACHE\nDisallow: /\n"))
+		c.Assert(robots.allowed(robotsUserAgent, "/"), qt.IsFalse)
+	})
+
+	c.Run("Empty user agent matches nothing", func(c *qt.C) {
+		robots := parseRobotsTxt([]byte("User-agent:\nDisallow: /\n"))
+		c.Assert(robots.allowed(robotsUserAgent, "/"), qt.IsTrue)
+	})
+}
+
+func TestXRobotsTagDisallows(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		values   []string
+		expected bool
+	}{
+		{nil, false},
+		{[]string{"noindex"}, true},
+		{[]string{"nofollow, NoIndex"}, true},
+		{[]string{"none"}, true},
+		{[]string{"nopreview"}, true},
+		{[]string{"nofollow"}, false},
+		{[]string{"googlebot: noindex"}, false},
+		{[]string{"chatterino-api-cache: noindex"}, true},
+		{[]string{"unavailable_after: 25 Jun 2010 15:00:00 PST"}, false},
+		{[]string{"nofollow", "noindex"}, true},
+	}
+
+	for _, test := range tests {
+		c.Run(strings.Join(test.values, "|"), func(c *qt.C) {
+			header := http.Header{}
+			for _, value := range test.values {
+				header.Add("X-Robots-Tag", value)
+			}
+			c.Assert(xRobotsTagDisallows(header), qt.Equals, test.expected)
+		})
+	}
+}
+
+func TestMetaRobotsDisallows(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		head     string
+		expected bool
+	}{
+		{``, false},
+		{`<meta name="robots" content="noindex">`, true},
+		{`<meta name="ROBOTS" content="nofollow, none">`, true},
+		{`<meta name="robots" content="nofollow">`, false},
+		{`<meta name="googlebot" content="noindex">`, false},
+		{`<meta name="chatterino-api-cache" content="nopreview">`, true},
+		{`<meta name="description" content="noindex">`, false},
+	}
+
+	for _, test := range tests {
+		c.Run(test.head, func(c *qt.C) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader("<html><head>" + test.head + "</head><body>xD</body></html>"))
+			c.Assert(err, qt.IsNil)
+			c.Assert(metaRobotsDisallows(doc), qt.Equals, test.expected)
+		})
+	}
+}
+
+func TestLinkLoaderRobots(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		MaxContentLength: 5 * 1024 * 1024, // 5 MB
+		CacheBackend:     cache.BackendMemory,
+	}
+
+	var robotsRequests atomic.Int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			robotsRequests.Add(1)
+			fmt.Fprint(w, "User-agent: chatterino-api-cache\nDisallow: /disallowed\n")
+			return
+		case "/header":
+			w.Header().Set("X-Robots-Tag", "noindex")
+		case "/meta":
+			fmt.Fprint(w, `<html><head><title>meta title</title><meta name="robots" content="noindex"></head><body>xD</body></html>`)
+			return
+		}
+		fmt.Fprintf(w, `<html><head><title>%s title</title></head><body>xD</body></html>`, r.URL.Path)
+	}))
+	defer ts.Close()
+
+	loader := &LinkLoader{
+		baseURL:          cfg.BaseURL,
+		maxContentLength: cfg.MaxContentLength,
+		robots: &robotsChecker{
+			robotsCache: cache.NewDefaultCache(ctx, cfg, nil, cache.NewPrefixKeyProvider("test:robots"), &RobotsLoader{}, time.Hour),
+		},
+	}
+
+	load := func(c *qt.C, path string) string {
+		req := newLinkResolverRequest(t, ctx, "GET", ts.URL+path, nil)
+		payload, statusCode, _, _, err := loader.Load(ctx, ts.URL+path, req)
+		c.Assert(err, qt.IsNil)
+		if statusCode != nil {
+			c.Assert(*statusCode, qt.Equals, http.StatusOK)
+		}
+
+		var response resolver.Response
+		c.Assert(json.Unmarshal(payload, &response), qt.IsNil)
+		c.Assert(response.Status, qt.Equals, http.StatusOK)
+
+		tooltip, err := url.PathUnescape(response.Tooltip)
+		c.Assert(err, qt.IsNil)
+		return tooltip
+	}
+
+	c.Run("Allowed", func(c *qt.C) {
+		c.Assert(load(c, "/allowed"), qt.Contains, "/allowed title")
+	})
+
+	c.Run("Disallowed by robots.txt", func(c *qt.C) {
+		tooltip := load(c, "/disallowed")
+		c.Assert(tooltip, qt.Not(qt.Contains), "title")
+		c.Assert(tooltip, qt.Contains, ts.URL+"/disallowed")
+	})
+
+	c.Run("Disallowed by X-Robots-Tag", func(c *qt.C) {
+		tooltip := load(c, "/header")
+		c.Assert(tooltip, qt.Not(qt.Contains), "title")
+		c.Assert(tooltip, qt.Contains, ts.URL+"/header")
+	})
+
+	c.Run("Disallowed by meta robots", func(c *qt.C) {
+		tooltip := load(c, "/meta")
+		c.Assert(tooltip, qt.Not(qt.Contains), "title")
+		c.Assert(tooltip, qt.Contains, ts.URL+"/meta")
+	})
+
+	c.Run("robots.txt is cached", func(c *qt.C) {
+		c.Assert(robotsRequests.Load(), qt.Equals, int32(1))
+	})
+}
@@ -3,6 +3,7 @@ package defaultresolver
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -34,6 +35,10 @@ func (l *ThumbnailLoader) Load(ctx context.Context, urlString string, r *http.Re
 
 	resp, err := resolver.RequestGET(ctx, url.String())
 	if err != nil {
+		if errors.Is(err, resolver.ErrForbiddenAddress) {
+			return resolver.ReturnForbiddenURL()
+		}
+
 		if strings.HasSuffix(err.Error(), "no such host") {
 			return resolver.InternalServerErrorf("Error loading thumbnail, could not resolve host %s", err.Error())
 		}
@@ -3,13 +3,13 @@ package discord
 import (
 	"context"
 	"errors"
-	"html/template"
 	"net/http"
 	"regexp"
 
 	"github.com/Chatterino/api/internal/db"
 	"github.com/Chatterino/api/internal/logger"
 	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	"github.com/Chatterino/api/pkg/utils"
 )
@@ -18,11 +18,11 @@ const (
 	discordInviteTooltip = `<div style="text-align: left;">
 <b>{{.ServerName}}</b>
 <br>
-<br><b>Server Created:</b> {{.ServerCreated}}
-<br><b>Channel:</b> {{.InviteChannel}}
-{{ if .InviterTag}}<br><b>Inviter:</b> {{.InviterTag}}{{end}}
-{{ if .ServerPerks}}<br><b>Server Perks:</b> {{.ServerPerks}}{{end}}
-<br><b>Members:</b> <span style="color: #43b581;">{{.OnlineCount}} online</span>&nbsp;•&nbsp;<span style="color: #808892;">{{.TotalCount}} total</span>
+<br><b>{{t "Server Created"}}:</b> {{.ServerCreated}}
+<br><b>{{t "Channel"}}:</b> {{.InviteChannel}}
+{{ if .InviterTag}}<br><b>{{t "Inviter"}}:</b> {{.InviterTag}}{{end}}
+{{ if .ServerPerks}}<br><b>{{t "Server Perks"}}:</b> {{.ServerPerks}}{{end}}
+<br><b>{{t "Members"}}:</b> <span style="color: #43b581;">{{t "%s online" .OnlineCount}}</span>&nbsp;•&nbsp;<span style="color: #808892;">{{t "%s total" .TotalCount}}</span>
 </div>
 `
 )
@@ -37,7 +37,7 @@ var (
 
 	errInvalidDiscordInvite = errors.New("invalid Discord invite Path")
 
-	discordInviteTemplate = template.Must(template.New("discordInviteTooltip").Parse(discordInviteTooltip))
+	discordInviteTemplate = i18n.MustTemplate("discordInviteTooltip", discordInviteTooltip)
 )
 
 func Initialize(ctx context.Context, cfg config.APIConfig, pool db.Pool, resolvers *[]resolver.Resolver) {
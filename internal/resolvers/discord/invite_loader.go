@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -16,6 +17,7 @@ import (
 	"github.com/Chatterino/api/internal/logger"
 	"github.com/Chatterino/api/pkg/cache"
 	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 )
 
@@ -52,13 +54,17 @@ type InviteLoader struct {
 	baseURL *url.URL
 
 	token string
+
+	upstream *resolver.Upstream
 }
 
-func NewInviteLoader(baseURL *url.URL, token string) *InviteLoader {
+func NewInviteLoader(baseURL *url.URL, token string, upstream *resolver.Upstream) *InviteLoader {
 	l := &InviteLoader{
 		baseURL: baseURL,
 
 		token: token,
+
+		upstream: upstream,
 	}
 
 	return l
@@ -75,6 +81,7 @@ func (l *InviteLoader) buildURL(inviteCode string) *url.URL {
 
 func (l *InviteLoader) Load(ctx context.Context, inviteCode string, r *http.Request) (*resolver.Response, time.Duration, error) {
 	log := logger.FromContext(ctx)
+	lang := i18n.FromContext(ctx)
 	log.Debugw("[DiscordInvite] Get invite",
 		"inviteCode", inviteCode,
 	)
@@ -89,8 +96,15 @@ func (l *InviteLoader) Load(ctx context.Context, inviteCode string, r *http.Requ
 	}
 
 	// Execute Discord API request
-	resp, err := resolver.RequestGETWithHeaders(apiURL.String(), extraHeaders)
+	resp, err := l.upstream.Do(func() (*http.Response, error) {
+		return resolver.RequestGETWithHeaders(apiURL.String(), extraHeaders)
+	})
 	if err != nil {
+		if errors.Is(err, resolver.ErrUpstreamUnavailable) {
+			// Don't cache anything, so stale entries can still be served
+			return nil, cache.NoSpecialDur, err
+		}
+
 		return &resolver.Response{
 			Status:  http.StatusInternalServerError,
 			Message: "Discord API request error " + resolver.CleanResponse(err.Error()),
@@ -98,6 +112,14 @@ func (l *InviteLoader) Load(ctx context.Context, inviteCode string, r *http.Requ
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		log.Warnw("[DiscordInvite] Rate limited by the Discord API",
+			"inviteCode", inviteCode,
+			"retryAfter", resp.Header.Get("Retry-After"),
+		)
+		return nil, cache.NoSpecialDur, fmt.Errorf("%w: discord rate limited", resolver.ErrUpstreamUnavailable)
+	}
+
 	// Error out if the invite isn't found or something else went wrong with the request
 	if resp.StatusCode < http.StatusOK || resp.StatusCode > http.StatusMultipleChoices {
 		return inviteNotFoundResponse, cache.NoSpecialDur, nil
@@ -130,7 +152,7 @@ func (l *InviteLoader) Load(ctx context.Context, inviteCode string, r *http.Requ
 	// Comverting Discord Snowflake to date string
 	// Reference https://discord.com/developers/docs/reference#snowflakes
 	snowflake, _ := strconv.ParseInt(jsonResponse.Guild.ID, 10, 64)
-	dateFromSnowflake := humanize.CreationDateUnix(snowflake>>22/1000 + 1420066800)
+	dateFromSnowflake := humanize.CreationDateIn(lang, time.Unix(snowflake>>22/1000+1420066800, 0))
 
 	// Adding row with inviter's user tag if present
 	userTag := ""
@@ -161,13 +183,13 @@ func (l *InviteLoader) Load(ctx context.Context, inviteCode string, r *http.Requ
 		InviteChannel: fmt.Sprintf("#%s", jsonResponse.Channel.Name),
 		InviterTag:    userTag,
 		ServerPerks:   parsedPerks,
-		OnlineCount:   humanize.Number(jsonResponse.OnlineCount),
-		TotalCount:    humanize.Number(jsonResponse.TotalCount),
+		OnlineCount:   humanize.NumberIn(lang, jsonResponse.OnlineCount),
+		TotalCount:    humanize.NumberIn(lang, jsonResponse.TotalCount),
 	}
 
 	// Build a tooltip using the tooltip template (see tooltipTemplate) with the data we massaged above
 	var tooltip bytes.Buffer
-	if err := discordInviteTemplate.Execute(&tooltip, data); err != nil {
+	if err := discordInviteTemplate.Execute(&tooltip, lang, data); err != nil {
 		return &resolver.Response{
 			Status:  http.StatusInternalServerError,
 			Message: "Discord Invite template error " + resolver.CleanResponse(err.Error()),
@@ -1,9 +1,17 @@
 package discord
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"sync/atomic"
 	"testing"
 
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
 	"github.com/Chatterino/api/pkg/utils"
 	qt "github.com/frankban/quicktest"
 )
@@ -45,9 +53,34 @@ func TestBuildURL(t *testing.T) {
 
 	for _, t := range tests {
 		c.Run(t.label, func(c *qt.C) {
-			loader := NewInviteLoader(t.baseURL, "fakecode")
+			loader := NewInviteLoader(t.baseURL, "fakecode", nil)
 			actual := loader.buildURL(t.inviteCode)
 			c.Assert(actual.String(), qt.Equals, t.expected)
 		})
 	}
 }
+
+func TestInviteLoaderRateLimited(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	loader := NewInviteLoader(utils.MustParseURL(ts.URL+"/api/v9/invites/"), "fakecode", resolver.NewUpstream(config.APIConfig{}, "discord"))
+
+	response, _, err := loader.Load(ctx, "forsen", nil)
+	c.Assert(response, qt.IsNil)
+	c.Assert(errors.Is(err, resolver.ErrUpstreamUnavailable), qt.IsTrue)
+
+	// Retry-After is honored, so the API isn't requested again
+	response, _, err = loader.Load(ctx, "forsen", nil)
+	c.Assert(response, qt.IsNil)
+	c.Assert(errors.Is(err, resolver.ErrUpstreamUnavailable), qt.IsTrue)
+	c.Assert(requests.Load(), qt.Equals, int32(1))
+}
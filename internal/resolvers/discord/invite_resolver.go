@@ -37,13 +37,12 @@ func (r *InviteResolver) Name() string {
 }
 
 func NewInviteResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, baseURL *url.URL) *InviteResolver {
-	inviteLoader := NewInviteLoader(baseURL, cfg.DiscordToken)
+	inviteLoader := NewInviteLoader(baseURL, cfg.DiscordToken, resolver.NewUpstream(cfg, "discord"))
 
 	// We cache invites longer on purpose as the API is pretty strict with its rate limiting, and the information changes very seldomly anyway
-	// TODO: Log 429 errors from the loader
 	r := &InviteResolver{
-		inviteCache: cache.NewPostgreSQLCache(
-			ctx, cfg, pool, cache.NewPrefixKeyProvider("discord:invite"),
+		inviteCache: cache.NewDefaultCache(
+			ctx, cfg, pool, cache.NewLocalizedKeyProvider("discord:invite"),
 			resolver.NewResponseMarshaller(inviteLoader), cfg.DiscordInviteCacheDuration),
 	}
 
@@ -11,6 +11,7 @@ import (
 
 	"github.com/Chatterino/api/internal/logger"
 	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 )
 
@@ -153,7 +154,7 @@ func (l *EmoteLoader) Load(ctx context.Context, emoteID string, r *http.Request)
 
 	// Build a tooltip using the tooltip template (see tooltipTemplate) with the data we massaged above
 	var tooltip bytes.Buffer
-	if err := tmpl.Execute(&tooltip, data); err != nil {
+	if err := tmpl.Execute(&tooltip, i18n.FromContext(ctx), data); err != nil {
 		return resolver.Errorf("FrankerFaceZ template error: %s", err)
 	}
 
@@ -161,6 +162,14 @@ func (l *EmoteLoader) Load(ctx context.Context, emoteID string, r *http.Request)
 		Status:    200,
 		Tooltip:   url.PathEscape(tooltip.String()),
 		Thumbnail: thumbnailURL,
+		Data: &resolver.ResponseData{
+			Kind:   resolver.DataKindEmote,
+			Title:  data.Code,
+			Author: data.Uploader,
+			Fields: map[string]string{
+				"provider": "FrankerFaceZ",
+			},
+		},
 	}, cache.NoSpecialDur, nil
 }
 
@@ -46,8 +46,8 @@ func NewEmoteResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, e
 	emoteLoader := NewEmoteLoader(emoteAPIURL)
 
 	r := &EmoteResolver{
-		emoteCache: cache.NewPostgreSQLCache(
-			ctx, cfg, pool, cache.NewPrefixKeyProvider("frankerfacez:emote"),
+		emoteCache: cache.NewDefaultCache(
+			ctx, cfg, pool, cache.NewLocalizedKeyProvider("frankerfacez:emote"),
 			resolver.NewResponseMarshaller(emoteLoader), cfg.FfzEmoteCacheDuration),
 	}
 
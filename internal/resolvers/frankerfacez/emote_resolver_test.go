@@ -117,7 +117,7 @@ func TestEmoteResolver(t *testing.T) {
 					inputEmoteHash: "297734",
 					inputReq:       nil,
 					expectedResponse: &cache.Response{
-						Payload:     []byte(`{"status":200,"thumbnail":"https://cdn.frankerfacez.com/emoticon/297734/4","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%0A%3Cb%3EpajaSx%3C%2Fb%3E%3Cbr%3E%0A%3Cb%3EFrankerFaceZ%20Emote%3C%2Fb%3E%3Cbr%3E%0A%3Cb%3EBy:%3C%2Fb%3E%20pajlada%3C%2Fdiv%3E"}`),
+						Payload:     []byte(`{"status":200,"thumbnail":"https://cdn.frankerfacez.com/emoticon/297734/4","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%0A%3Cb%3EpajaSx%3C%2Fb%3E%3Cbr%3E%0A%3Cb%3EFrankerFaceZ%20Emote%3C%2Fb%3E%3Cbr%3E%0A%3Cb%3EBy:%3C%2Fb%3E%20pajlada%3C%2Fdiv%3E","data":{"kind":"emote","title":"pajaSx","author":"pajlada","fields":{"provider":"FrankerFaceZ"}}}`),
 						StatusCode:  http.StatusOK,
 						ContentType: "application/json",
 					},
@@ -182,7 +182,7 @@ func TestEmoteResolver(t *testing.T) {
 					inputEmoteHash: "297734",
 					inputReq:       nil,
 					expectedResponse: &cache.Response{
-						Payload:     []byte(`{"status":200,"thumbnail":"https://cdn.frankerfacez.com/emoticon/297734/4","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%0A%3Cb%3EpajaSx%3C%2Fb%3E%3Cbr%3E%0A%3Cb%3EFrankerFaceZ%20Emote%3C%2Fb%3E%3Cbr%3E%0A%3Cb%3EBy:%3C%2Fb%3E%20pajlada%3C%2Fdiv%3E"}`),
+						Payload:     []byte(`{"status":200,"thumbnail":"https://cdn.frankerfacez.com/emoticon/297734/4","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%0A%3Cb%3EpajaSx%3C%2Fb%3E%3Cbr%3E%0A%3Cb%3EFrankerFaceZ%20Emote%3C%2Fb%3E%3Cbr%3E%0A%3Cb%3EBy:%3C%2Fb%3E%20pajlada%3C%2Fdiv%3E","data":{"kind":"emote","title":"pajaSx","author":"pajlada","fields":{"provider":"FrankerFaceZ"}}}`),
 						StatusCode:  http.StatusOK,
 						ContentType: "application/json",
 					},
@@ -3,11 +3,11 @@ package frankerfacez
 import (
 	"context"
 	"errors"
-	"html/template"
 	"regexp"
 
 	"github.com/Chatterino/api/internal/db"
 	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	"github.com/Chatterino/api/pkg/utils"
 )
@@ -18,8 +18,8 @@ const (
 
 	tooltipTemplate = `<div style="text-align: left;">
 <b>{{.Code}}</b><br>
-<b>FrankerFaceZ Emote</b><br>
-<b>By:</b> {{.Uploader}}</div>`
+<b>{{t "FrankerFaceZ Emote"}}</b><br>
+<b>{{t "By"}}:</b> {{.Uploader}}</div>`
 )
 
 var (
@@ -31,7 +31,7 @@ var (
 
 	emotePathRegex = regexp.MustCompile(`/emoticon/([0-9]+)(-(.+)?)?$`)
 
-	tmpl = template.Must(template.New("frankerfacezEmoteTooltip").Parse(tooltipTemplate))
+	tmpl = i18n.MustTemplate("frankerfacezEmoteTooltip", tooltipTemplate)
 
 	errInvalidFrankerFaceZEmotePath = errors.New("invalid FrankerFaceZ emote path")
 )
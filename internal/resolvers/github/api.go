@@ -0,0 +1,191 @@
+package github
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/resolver"
+)
+
+const (
+	// Maximum number of responses kept for conditional requests
+	maxETagEntries = 1000
+
+	// Maximum total size of the responses kept for conditional requests
+	maxETagStoreSize = 8 * 1024 * 1024
+
+	// Larger responses, e.g. commits with big diffs, aren't kept for conditional requests
+	maxETagBodySize = 64 * 1024
+)
+
+// parseTime parses the timestamps of the GitHub API, which are missing for e.g. unpublished releases
+func parseTime(value string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+type etagEntry struct {
+	apiURL string
+	etag   string
+	body   []byte
+}
+
+// etagStore keeps the last response of API URLs with their ETag. When their links are loaded
+// again, the response is only sent again if it changed.
+// The least recently used responses are evicted first, losing them only costs a full request.
+type etagStore struct {
+	mutex sync.Mutex
+	// Elements hold an etagEntry, the most recently used ones are at the front
+	recent  *list.List
+	entries map[string]*list.Element
+	size    int
+}
+
+func newETagStore() *etagStore {
+	return &etagStore{
+		recent:  list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+func (s *etagStore) get(apiURL string) (etagEntry, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	element, ok := s.entries[apiURL]
+	if !ok {
+		return etagEntry{}, false
+	}
+	s.recent.MoveToFront(element)
+
+	return element.Value.(etagEntry), true
+}
+
+func (s *etagStore) set(apiURL string, entry etagEntry) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.remove(apiURL)
+
+	for s.recent.Len() > 0 && (s.recent.Len() >= maxETagEntries || s.size+len(entry.body) > maxETagStoreSize) {
+		s.remove(s.recent.Back().Value.(etagEntry).apiURL)
+	}
+
+	entry.apiURL = apiURL
+	s.entries[apiURL] = s.recent.PushFront(entry)
+	s.size += len(entry.body)
+}
+
+func (s *etagStore) remove(apiURL string) {
+	element, ok := s.entries[apiURL]
+	if !ok {
+		return
+	}
+
+	s.recent.Remove(element)
+	s.size -= len(element.Value.(etagEntry).body)
+	delete(s.entries, apiURL)
+}
+
+// apiClient makes the requests to the GitHub API shared by all GitHub resolvers
+type apiClient struct {
+	baseURL  *url.URL
+	token    string
+	upstream *resolver.Upstream
+	etags    *etagStore
+}
+
+func newAPIClient(baseURL *url.URL, token string, upstream *resolver.Upstream) *apiClient {
+	return &apiClient{
+		baseURL:  baseURL,
+		token:    token,
+		upstream: upstream,
+		etags:    newETagStore(),
+	}
+}
+
+// buildURL resolves the path of an endpoint against the API base URL, e.g. "repos/Chatterino/api".
+// The path must already be escaped.
+func (c *apiClient) buildURL(path string) string {
+	return c.baseURL.String() + path
+}
+
+// rateLimited checks whether the response says we ran out of requests. GitHub uses 403 for this too.
+func rateLimited(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	return resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0"
+}
+
+// request requests an endpoint of the GitHub API and decodes its response into v.
+// If the request didn't succeed, the loader should return what request returned instead.
+func (c *apiClient) request(ctx context.Context, path string, notFound *resolver.Response, v any) (*resolver.Response, time.Duration, error) {
+	apiURL := c.buildURL(path)
+
+	headers := map[string]string{
+		"Accept":               "application/vnd.github+json",
+		"X-GitHub-Api-Version": "2022-11-28",
+	}
+	if c.token != "" {
+		headers["Authorization"] = "Bearer " + c.token
+	}
+
+	previous, hasPrevious := c.etags.get(apiURL)
+	if hasPrevious {
+		headers["If-None-Match"] = previous.etag
+	}
+
+	var body json.RawMessage
+	etag := ""
+
+	response, cacheDuration, err := resolver.RequestJSON(ctx, resolver.JSONRequest{
+		Upstream: c.upstream,
+		API:      "GitHub API",
+		URL:      apiURL,
+		Headers:  headers,
+		// Private repositories return 404, deleted issues 410
+		NotFound: notFound,
+		CheckResponse: func(resp *http.Response) (bool, *resolver.Response, time.Duration, error) {
+			switch {
+			case resp.StatusCode == http.StatusNotModified && hasPrevious:
+				body = previous.body
+				return true, nil, cache.NoSpecialDur, nil
+
+			case rateLimited(resp):
+				return true, nil, cache.NoSpecialDur, fmt.Errorf("%w: github rate limited", resolver.ErrUpstreamUnavailable)
+			}
+
+			etag = resp.Header.Get("ETag")
+			return false, nil, cache.NoSpecialDur, nil
+		},
+	}, &body)
+	if response != nil || err != nil {
+		return response, cacheDuration, err
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return resolver.Errorf("GitHub API unmarshal error: %s", err)
+	}
+
+	if etag != "" && len(body) <= maxETagBodySize {
+		c.etags.set(apiURL, etagEntry{
+			etag: etag,
+			body: body,
+		})
+	}
+
+	return nil, cache.NoSpecialDur, nil
+}
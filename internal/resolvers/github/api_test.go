@@ -0,0 +1,106 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/resolver"
+	qt "github.com/frankban/quicktest"
+)
+
+func TestAPIClient(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	api := newTestAPI()
+	defer api.Close()
+
+	c.Run("Conditional requests", func(c *qt.C) {
+		client := api.client("")
+
+		for range 3 {
+			var repo githubRepo
+			response, _, err := client.request(ctx, "repos/chatterino/api", noGitHubRepoFound, &repo)
+			c.Assert(err, qt.IsNil)
+			c.Assert(response, qt.IsNil)
+			c.Assert(repo.FullName, qt.Equals, "Chatterino/api")
+		}
+
+		// Only the first request got a full response, the other ones were answered with 304
+		c.Assert(api.fullResponses.Load(), qt.Equals, int32(1))
+	})
+
+	c.Run("Token", func(c *qt.C) {
+		var repo githubRepo
+		_, _, err := api.client("secret").request(ctx, "repos/chatterino/api", noGitHubRepoFound, &repo)
+		c.Assert(err, qt.IsNil)
+		c.Assert(api.authorization.Load(), qt.Equals, "Bearer secret")
+
+		_, _, err = api.client("").request(ctx, "repos/chatterino/api", noGitHubRepoFound, &repo)
+		c.Assert(err, qt.IsNil)
+		c.Assert(api.authorization.Load(), qt.Equals, "")
+	})
+
+	c.Run("Not found", func(c *qt.C) {
+		var repo githubRepo
+		response, _, err := api.client("").request(ctx, "repos/chatterino/404", noGitHubRepoFound, &repo)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response, qt.Equals, noGitHubRepoFound)
+	})
+
+	c.Run("Rate limited", func(c *qt.C) {
+		var repo githubRepo
+		_, _, err := api.client("").request(ctx, "repos/chatterino/ratelimited", noGitHubRepoFound, &repo)
+		c.Assert(err, qt.ErrorIs, resolver.ErrUpstreamUnavailable)
+	})
+
+	c.Run("Forbidden", func(c *qt.C) {
+		var repo githubRepo
+		response, _, err := api.client("").request(ctx, "repos/chatterino/forbidden", noGitHubRepoFound, &repo)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Status, qt.Equals, http.StatusInternalServerError)
+		c.Assert(response.Message, qt.Equals, "GitHub API returned status 403")
+	})
+}
+
+func TestETagStore(t *testing.T) {
+	c := qt.New(t)
+
+	store := newETagStore()
+	for i := range maxETagEntries + 10 {
+		store.set(fmt.Sprintf("url%d", i), etagEntry{etag: "etag"})
+	}
+	c.Assert(store.entries, qt.HasLen, maxETagEntries)
+
+	// Updating an entry doesn't evict another one
+	store.set("url0", etagEntry{etag: "new"})
+	c.Assert(store.entries, qt.HasLen, maxETagEntries)
+
+	entry, ok := store.get("url0")
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(entry.etag, qt.Equals, "new")
+
+	// The least recently used entries are evicted first
+	_, ok = store.get("url10")
+	c.Assert(ok, qt.IsFalse)
+	_, ok = store.get("url11")
+	c.Assert(ok, qt.IsTrue)
+}
+
+func TestETagStoreSize(t *testing.T) {
+	c := qt.New(t)
+
+	store := newETagStore()
+	body := make([]byte, maxETagBodySize)
+	for i := range maxETagStoreSize/maxETagBodySize + 1 {
+		store.set(fmt.Sprintf("url%d", i), etagEntry{etag: "etag", body: body})
+	}
+	c.Assert(store.size, qt.Equals, maxETagStoreSize)
+	c.Assert(store.entries, qt.HasLen, maxETagStoreSize/maxETagBodySize)
+
+	_, ok := store.get("url0")
+	c.Assert(ok, qt.IsFalse)
+}
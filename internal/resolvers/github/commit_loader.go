@@ -0,0 +1,108 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+// Maximum length of the commit messages shown in tooltips
+const maxCommitMessageLength = 200
+
+var errInvalidCommitKey = errors.New("invalid GitHub commit key")
+
+type githubCommitTooltipData struct {
+	Message   string
+	Repo      string
+	ShortSHA  string
+	Author    string
+	Age       string
+	Additions string
+	Deletions string
+	Files     string
+}
+
+type CommitLoader struct {
+	client *apiClient
+}
+
+// Load loads a commit, keyed by the API path of its repository and its hash, e.g. "chatterino/api/1a2b3c4"
+func (l *CommitLoader) Load(ctx context.Context, key string, r *http.Request) (*resolver.Response, time.Duration, error) {
+	log := logger.FromContext(ctx)
+
+	slash := strings.LastIndex(key, "/")
+	if slash == -1 {
+		return nil, cache.NoSpecialDur, errInvalidCommitKey
+	}
+	repo, sha := key[:slash], key[slash+1:]
+
+	log.Debugw("[GitHub] Get commit",
+		"repo", repo,
+		"sha", sha,
+	)
+
+	var commit githubCommit
+	if response, dur, err := l.client.request(ctx, "repos/"+repo+"/commits/"+sha, noGitHubCommitFound, &commit); response != nil || err != nil {
+		return response, dur, err
+	}
+
+	lang := i18n.FromContext(ctx)
+
+	// The first line of the message is its title
+	title, _, _ := strings.Cut(commit.Commit.Message, "\n")
+
+	author := commit.Author.login()
+	if author == "" {
+		author = commit.Commit.Author.Name
+	}
+
+	data := githubCommitTooltipData{
+		Message:   utils.TruncateString(strings.TrimSpace(title), maxCommitMessageLength),
+		Repo:      repoFromHTMLURL(commit.HTMLURL, repo),
+		ShortSHA:  commit.SHA[:min(len(commit.SHA), 7)],
+		Author:    author,
+		Additions: humanize.NumberInt64In(lang, commit.Stats.Additions),
+		Deletions: humanize.NumberInt64In(lang, commit.Stats.Deletions),
+		Files:     humanize.NumberIn(lang, uint64(len(commit.Files))),
+	}
+	if date, ok := parseTime(commit.Commit.Author.Date); ok {
+		data.Age = humanize.AgoIn(lang, time.Since(date))
+	}
+
+	var tooltip bytes.Buffer
+	if err := githubCommitTooltip.Execute(&tooltip, lang, data); err != nil {
+		return resolver.Errorf("GitHub commit template error: %s", err)
+	}
+
+	return &resolver.Response{
+		Status:    200,
+		Tooltip:   url.PathEscape(tooltip.String()),
+		Thumbnail: commit.Author.avatarURL(),
+		Data: &resolver.ResponseData{
+			Kind:        resolver.DataKindCommit,
+			Title:       data.Message,
+			Description: commit.Commit.Message,
+			Author:      author,
+			Published:   commit.Commit.Author.Date,
+			Fields: map[string]string{
+				"repository": data.Repo,
+				"sha":        commit.SHA,
+				"additions":  strconv.FormatInt(commit.Stats.Additions, 10),
+				"deletions":  strconv.FormatInt(commit.Stats.Deletions, 10),
+				"files":      strconv.Itoa(len(commit.Files)),
+			},
+		},
+	}, cache.NoSpecialDur, nil
+}
@@ -0,0 +1,67 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+// Matches commit links, e.g. /Chatterino/api/commit/1a2b3c4
+var commitRegex = regexp.MustCompile(repoPathPattern + `\/commit\/([0-9a-fA-F]{7,40})\/?$`)
+
+// commitKey returns the cache key of the commit the URL links to, e.g. "chatterino/api/1a2b3c4",
+// or an empty string if it's not a commit link
+func commitKey(url *url.URL) string {
+	if !utils.IsDomains(url, domains) {
+		return ""
+	}
+
+	match := commitRegex.FindStringSubmatch(url.Path)
+	if len(match) != 4 {
+		return ""
+	}
+
+	return repoPath(match[1], match[2]) + "/" + strings.ToLower(match[3])
+}
+
+type CommitResolver struct {
+	commitCache cache.Cache
+}
+
+func (r *CommitResolver) Check(ctx context.Context, url *url.URL) (context.Context, bool) {
+	return ctx, commitKey(url) != ""
+}
+
+func (r *CommitResolver) Run(ctx context.Context, url *url.URL, req *http.Request) (*cache.Response, error) {
+	key := commitKey(url)
+	if key == "" {
+		return nil, errInvalidGitHubCommit
+	}
+
+	return r.commitCache.Get(ctx, key, req)
+}
+
+func (r *CommitResolver) Name() string {
+	return "github:commit"
+}
+
+func NewCommitResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, client *apiClient) *CommitResolver {
+	commitLoader := &CommitLoader{
+		client: client,
+	}
+
+	r := &CommitResolver{
+		commitCache: cache.NewDefaultCache(ctx, cfg, pool, cache.NewLocalizedKeyProvider("github:commit"),
+			resolver.NewResponseMarshaller(commitLoader), cfg.GithubCommitCacheDuration),
+	}
+
+	return r
+}
@@ -0,0 +1,106 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+)
+
+func TestCommitResolver(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		CacheBackend: cache.BackendMemory,
+	}
+	api := newTestAPI()
+	defer api.Close()
+
+	commitResolver := NewCommitResolver(ctx, cfg, nil, api.client(""))
+
+	c.Assert(commitResolver, qt.IsNotNil)
+
+	c.Run("Name", func(c *qt.C) {
+		c.Assert(commitResolver.Name(), qt.Equals, "github:commit")
+	})
+
+	c.Run("Check", func(c *qt.C) {
+		type checkTest struct {
+			input    *url.URL
+			expected bool
+		}
+
+		tests := []checkTest{
+			{utils.MustParseURL("https://github.com/Chatterino/api/commit/1a2b3c4"), true},
+			{utils.MustParseURL("https://github.com/Chatterino/api/commit/1A2B3C4D5E6F/"), true},
+			{utils.MustParseURL("https://github.com/Chatterino/api/commit/1a2b3c"), false},
+			{utils.MustParseURL("https://github.com/Chatterino/api/commit/main"), false},
+			{utils.MustParseURL("https://github.com/Chatterino/api/commits/1a2b3c4"), false},
+		}
+
+		for _, test := range tests {
+			c.Run(test.input.String(), func(c *qt.C) {
+				_, output := commitResolver.Check(ctx, test.input)
+				c.Assert(output, qt.Equals, test.expected)
+			})
+		}
+	})
+
+	c.Run("Run", func(c *qt.C) {
+		c.Run("Commit", func(c *qt.C) {
+			data := runResolver(c, ctx, commitResolver, "https://github.com/Chatterino/api/commit/1A2B3C4")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "https://avatars.githubusercontent.com/u/2")
+			c.Assert(data.Tooltip, qt.Equals, `<div style="text-align: left;">`+
+				`<b>Fix the link resolver</b><hr>`+
+				`<b>Chatterino/api</b> • 1a2b3c4<br>`+
+				`<b>Author:</b> pajlada • 2 days ago<br>`+
+				`<span style="color: #3fb950;">+1,234</span> <span style="color: #f85149;">-56</span> • 2 files changed`+
+				`</div>`)
+
+			c.Assert(data.Data, qt.DeepEquals, &resolver.ResponseData{
+				Kind:        resolver.DataKindCommit,
+				Title:       "Fix the link resolver",
+				Description: "Fix the link resolver\n\nIt was broken.",
+				Author:      "pajlada",
+				Published:   createdAt.Format(time.RFC3339),
+				Fields: map[string]string{
+					"repository": "Chatterino/api",
+					"sha":        "1a2b3c4d5e6f",
+					"additions":  "1234",
+					"deletions":  "56",
+					"files":      "2",
+				},
+			})
+		})
+
+		c.Run("Author without account", func(c *qt.C) {
+			data := runResolver(c, ctx, commitResolver, "https://github.com/Chatterino/api/commit/abcdef0")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "")
+			c.Assert(data.Tooltip, qt.Contains, `<b>Author:</b> Someone • 2 days ago<br>`)
+		})
+
+		c.Run("German", func(c *qt.C) {
+			data := runResolver(c, i18n.OnContext(ctx, i18n.German), commitResolver, "https://github.com/Chatterino/api/commit/1a2b3c4")
+			c.Assert(data.Tooltip, qt.Contains, `+1.234</span>`)
+			c.Assert(data.Tooltip, qt.Contains, `2 Dateien geändert`)
+		})
+
+		c.Run("Not found", func(c *qt.C) {
+			data := runResolver(c, ctx, commitResolver, "https://github.com/Chatterino/api/commit/0000000")
+			c.Assert(data.Status, qt.Equals, http.StatusNotFound)
+			c.Assert(data.Message, qt.Equals, "No GitHub commit with this hash found")
+		})
+	})
+}
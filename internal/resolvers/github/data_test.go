@@ -0,0 +1,142 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+	"github.com/go-chi/chi/v5"
+)
+
+var (
+	repoData    = map[string][]byte{}
+	issueData   = map[string][]byte{}
+	commitData  = map[string][]byte{}
+	releaseData = map[string][]byte{}
+	gistData    = map[string][]byte{}
+
+	// Issues and commits were created two days ago
+	createdAt = time.Now().UTC().Add(-48 * time.Hour).Truncate(time.Second)
+)
+
+func init() {
+	created := createdAt.Format(time.RFC3339)
+
+	repoData["chatterino/api"] = []byte(`{"full_name":"Chatterino/api","description":"Go web service used as a proxy <3","language":"Go","stargazers_count":1234,"forks_count":56,"archived":false,"created_at":"2019-03-01T12:00:00Z","owner":{"login":"Chatterino","avatar_url":"https://avatars.githubusercontent.com/u/1"}}`)
+	repoData["chatterino/old"] = []byte(`{"full_name":"Chatterino/old","description":"","language":null,"stargazers_count":1,"forks_count":0,"archived":true,"created_at":"2016-01-01T00:00:00Z","owner":{"login":"Chatterino","avatar_url":"https://avatars.githubusercontent.com/u/1"}}`)
+	repoData["chatterino/bad"] = []byte(`xD`)
+
+	issueData["chatterino/api/1"] = []byte(fmt.Sprintf(`{"number":1,"html_url":"https://github.com/Chatterino/api/issues/1","title":"Links <b>break</b>","state":"open","comments":3,"created_at":"%s","user":{"login":"pajlada","avatar_url":"https://avatars.githubusercontent.com/u/2"},"labels":[{"name":"bug"},{"name":"help wanted"}]}`, created))
+	issueData["chatterino/api/2"] = []byte(fmt.Sprintf(`{"number":2,"html_url":"https://github.com/Chatterino/api/pull/2","title":"Fix links","state":"closed","comments":0,"created_at":"%s","user":{"login":"zneix","avatar_url":"https://avatars.githubusercontent.com/u/3"},"labels":[],"pull_request":{"merged_at":"2024-01-02T00:00:00Z"}}`, created))
+	issueData["chatterino/api/3"] = []byte(fmt.Sprintf(`{"number":3,"html_url":"https://github.com/Chatterino/api/pull/3","title":"WIP","state":"open","draft":true,"comments":1,"created_at":"%s","user":null,"labels":[],"pull_request":{"merged_at":null}}`, created))
+	issueData["chatterino/api/4"] = []byte(fmt.Sprintf(`{"number":4,"html_url":"https://github.com/Chatterino/api/issues/4","title":"Won't fix","state":"closed","state_reason":"not_planned","comments":1,"created_at":"%s","user":{"login":"pajlada"},"labels":[]}`, created))
+
+	commitData["chatterino/api/1a2b3c4"] = []byte(fmt.Sprintf(`{"sha":"1a2b3c4d5e6f","html_url":"https://github.com/Chatterino/api/commit/1a2b3c4d5e6f","commit":{"message":"Fix the link resolver\n\nIt was broken.","author":{"name":"Rasmus","date":"%s"}},"author":{"login":"pajlada","avatar_url":"https://avatars.githubusercontent.com/u/2"},"stats":{"additions":1234,"deletions":56},"files":[{"filename":"a.go"},{"filename":"b.go"}]}`, created))
+	commitData["chatterino/api/abcdef0"] = []byte(fmt.Sprintf(`{"sha":"abcdef0123","html_url":"https://github.com/Chatterino/api/commit/abcdef0123","commit":{"message":"Unlinked author","author":{"name":"Someone","date":"%s"}},"author":null,"stats":{"additions":1,"deletions":0},"files":[{"filename":"a.go"}]}`, created))
+
+	releaseData["chatterino/api/latest"] = []byte(`{"name":"","html_url":"https://github.com/Chatterino/api/releases/tag/v2.0.0","tag_name":"v2.0.0","prerelease":false,"published_at":"2024-03-01T12:00:00Z","author":{"login":"pajlada","avatar_url":"https://avatars.githubusercontent.com/u/2"}}`)
+	releaseData["chatterino/api/tags/v2.1.0-beta"] = []byte(`{"name":"Beta release","html_url":"https://github.com/Chatterino/api/releases/tag/v2.1.0-beta","tag_name":"v2.1.0-beta","prerelease":true,"published_at":"2024-04-01T12:00:00Z","author":{"login":"pajlada","avatar_url":"https://avatars.githubusercontent.com/u/2"}}`)
+
+	gistData["0123456789abcdef"] = []byte(`{"description":"My config","public":true,"comments":2,"created_at":"2024-03-01T12:00:00Z","owner":{"login":"pajlada","avatar_url":"https://avatars.githubusercontent.com/u/2"},"files":{"b.yaml":{"filename":"b.yaml"},"a.json":{"filename":"a.json"}}}`)
+	gistData["fedcba9876543210"] = []byte(`{"description":"","public":false,"comments":0,"created_at":"2024-03-01T12:00:00Z","owner":{"login":"pajlada","avatar_url":"https://avatars.githubusercontent.com/u/2"},"files":{"1.txt":{},"2.txt":{},"3.txt":{},"4.txt":{},"5.txt":{},"6.txt":{}}}`)
+}
+
+// testAPI is a stub of the GitHub API, which answers conditional requests like GitHub
+type testAPI struct {
+	*httptest.Server
+
+	// Number of requests that got a full response
+	fullResponses atomic.Int32
+	// Authorization header of the last request
+	authorization atomic.Value
+}
+
+func newTestAPI() *testAPI {
+	api := &testAPI{}
+
+	r := chi.NewRouter()
+	serve := func(data map[string][]byte, id func(r *http.Request) string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			id := id(r)
+
+			api.authorization.Store(r.Header.Get("Authorization"))
+
+			switch id {
+			case "chatterino/ratelimited":
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				http.Error(w, `{"message":"API rate limit exceeded"}`, http.StatusForbidden)
+			case "chatterino/forbidden":
+				http.Error(w, `{"message":"Repository access blocked"}`, http.StatusForbidden)
+			default:
+				response, ok := data[id]
+				if !ok {
+					http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+					return
+				}
+
+				etag := fmt.Sprintf(`W/"%x"`, len(response))
+				if r.Header.Get("If-None-Match") == etag {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+
+				api.fullResponses.Add(1)
+				w.Header().Set("Content-Type", "application/json; charset=utf-8")
+				w.Header().Set("ETag", etag)
+				w.Write(response)
+			}
+		}
+	}
+
+	repo := func(r *http.Request) string {
+		return chi.URLParam(r, "owner") + "/" + chi.URLParam(r, "repo")
+	}
+
+	r.Get("/repos/{owner}/{repo}", serve(repoData, repo))
+	r.Get("/repos/{owner}/{repo}/issues/{number}", serve(issueData, func(r *http.Request) string {
+		return repo(r) + "/" + chi.URLParam(r, "number")
+	}))
+	r.Get("/repos/{owner}/{repo}/commits/{sha}", serve(commitData, func(r *http.Request) string {
+		return repo(r) + "/" + chi.URLParam(r, "sha")
+	}))
+	r.Get("/repos/{owner}/{repo}/releases/latest", serve(releaseData, func(r *http.Request) string {
+		return repo(r) + "/latest"
+	}))
+	r.Get("/repos/{owner}/{repo}/releases/tags/{tag}", serve(releaseData, func(r *http.Request) string {
+		return repo(r) + "/tags/" + chi.URLParam(r, "tag")
+	}))
+	r.Get("/gists/{id}", serve(gistData, func(r *http.Request) string {
+		return chi.URLParam(r, "id")
+	}))
+
+	api.Server = httptest.NewServer(r)
+	return api
+}
+
+// client returns an API client for the stub
+func (api *testAPI) client(token string) *apiClient {
+	return newAPIClient(utils.MustParseURL(api.URL+"/"), token, nil)
+}
+
+// runResolver runs the resolver and decodes the response it returned, with its tooltip unescaped
+func runResolver(c *qt.C, ctx context.Context, r resolver.Resolver, link string) resolver.Response {
+	response, err := r.Run(ctx, utils.MustParseURL(link), nil)
+	c.Assert(err, qt.IsNil)
+
+	var data resolver.Response
+	c.Assert(json.Unmarshal(response.Payload, &data), qt.IsNil)
+
+	tooltip, err := url.PathUnescape(data.Tooltip)
+	c.Assert(err, qt.IsNil)
+	data.Tooltip = tooltip
+
+	return data
+}
@@ -0,0 +1,106 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+// Maximum number of file names shown in gist tooltips
+const maxGistFiles = 5
+
+type githubGistTooltipData struct {
+	Title    string
+	Author   string
+	Files    string
+	Created  string
+	Comments string
+	Secret   bool
+}
+
+type GistLoader struct {
+	client *apiClient
+}
+
+func (l *GistLoader) Load(ctx context.Context, id string, r *http.Request) (*resolver.Response, time.Duration, error) {
+	log := logger.FromContext(ctx)
+
+	log.Debugw("[GitHub] Get gist",
+		"id", id,
+	)
+
+	var gist githubGist
+	if response, dur, err := l.client.request(ctx, "gists/"+url.PathEscape(id), noGitHubGistFound, &gist); response != nil || err != nil {
+		return response, dur, err
+	}
+
+	lang := i18n.FromContext(ctx)
+
+	// GitHub sorts the files of gists by name
+	files := make([]string, 0, len(gist.Files))
+	for name := range gist.Files {
+		files = append(files, name)
+	}
+	slices.Sort(files)
+
+	shownFiles := strings.Join(files[:min(len(files), maxGistFiles)], ", ")
+	if len(files) > maxGistFiles {
+		shownFiles += ", …"
+	}
+
+	// Gists without a description are shown with their first file on GitHub
+	title := gist.Description
+	if title == "" && len(files) > 0 {
+		title = files[0]
+	}
+
+	data := githubGistTooltipData{
+		Title:    utils.TruncateString(title, maxCommitMessageLength),
+		Author:   gist.Owner.login(),
+		Files:    shownFiles,
+		Comments: humanize.NumberInt64In(lang, gist.Comments),
+		Secret:   !gist.Public,
+	}
+	if createdAt, ok := parseTime(gist.CreatedAt); ok {
+		data.Created = humanize.CreationDateIn(lang, createdAt)
+	}
+
+	var tooltip bytes.Buffer
+	if err := githubGistTooltip.Execute(&tooltip, lang, data); err != nil {
+		return resolver.Errorf("GitHub gist template error: %s", err)
+	}
+
+	fields := map[string]string{
+		"files": strconv.Itoa(len(files)),
+	}
+	if !gist.Public {
+		fields["secret"] = "true"
+	}
+
+	return &resolver.Response{
+		Status:    200,
+		Tooltip:   url.PathEscape(tooltip.String()),
+		Thumbnail: gist.Owner.avatarURL(),
+		Data: &resolver.ResponseData{
+			Kind:        resolver.DataKindDocument,
+			Title:       data.Title,
+			Description: gist.Description,
+			Author:      data.Author,
+			Comments:    uint64(max(gist.Comments, 0)),
+			Published:   gist.CreatedAt,
+			Fields:      fields,
+		},
+	}, cache.NoSpecialDur, nil
+}
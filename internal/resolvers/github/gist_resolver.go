@@ -0,0 +1,66 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+// Matches gist links, e.g. /pajlada/0123456789abcdef or /0123456789abcdef
+var gistRegex = regexp.MustCompile(`^\/(?:[a-zA-Z0-9-]+\/)?([0-9a-fA-F]{7,})\/?$`)
+
+// gistID returns the ID of the gist the URL links to, or an empty string if it's not a gist link
+func gistID(url *url.URL) string {
+	if !utils.IsDomains(url, gistDomains) {
+		return ""
+	}
+
+	match := gistRegex.FindStringSubmatch(url.Path)
+	if len(match) != 2 {
+		return ""
+	}
+
+	return strings.ToLower(match[1])
+}
+
+type GistResolver struct {
+	gistCache cache.Cache
+}
+
+func (r *GistResolver) Check(ctx context.Context, url *url.URL) (context.Context, bool) {
+	return ctx, gistID(url) != ""
+}
+
+func (r *GistResolver) Run(ctx context.Context, url *url.URL, req *http.Request) (*cache.Response, error) {
+	id := gistID(url)
+	if id == "" {
+		return nil, errInvalidGitHubGist
+	}
+
+	return r.gistCache.Get(ctx, id, req)
+}
+
+func (r *GistResolver) Name() string {
+	return "github:gist"
+}
+
+func NewGistResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, client *apiClient) *GistResolver {
+	gistLoader := &GistLoader{
+		client: client,
+	}
+
+	r := &GistResolver{
+		gistCache: cache.NewDefaultCache(ctx, cfg, pool, cache.NewLocalizedKeyProvider("github:gist"),
+			resolver.NewResponseMarshaller(gistLoader), cfg.GithubGistCacheDuration),
+	}
+
+	return r
+}
@@ -0,0 +1,113 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+)
+
+func TestGistResolver(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		CacheBackend: cache.BackendMemory,
+	}
+	api := newTestAPI()
+	defer api.Close()
+
+	gistResolver := NewGistResolver(ctx, cfg, nil, api.client(""))
+
+	c.Assert(gistResolver, qt.IsNotNil)
+
+	c.Run("Name", func(c *qt.C) {
+		c.Assert(gistResolver.Name(), qt.Equals, "github:gist")
+	})
+
+	c.Run("Check", func(c *qt.C) {
+		type checkTest struct {
+			input    *url.URL
+			expected bool
+		}
+
+		tests := []checkTest{
+			{utils.MustParseURL("https://gist.github.com/pajlada/0123456789abcdef"), true},
+			{utils.MustParseURL("https://gist.github.com/0123456789ABCDEF/"), true},
+			{utils.MustParseURL("https://gist.github.com/pajlada"), false},
+			{utils.MustParseURL("https://gist.github.com/pajlada/0123456789abcdef/raw"), false},
+			{utils.MustParseURL("https://github.com/pajlada/0123456789abcdef"), false},
+		}
+
+		for _, test := range tests {
+			c.Run(test.input.String(), func(c *qt.C) {
+				_, output := gistResolver.Check(ctx, test.input)
+				c.Assert(output, qt.Equals, test.expected)
+			})
+		}
+	})
+
+	c.Run("Run", func(c *qt.C) {
+		c.Run("Gist", func(c *qt.C) {
+			data := runResolver(c, ctx, gistResolver, "https://gist.github.com/pajlada/0123456789abcdef")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "https://avatars.githubusercontent.com/u/2")
+			c.Assert(data.Tooltip, qt.Equals, `<div style="text-align: left;">`+
+				`<b>My config</b><hr>`+
+				`<b>Author:</b> pajlada<br>`+
+				`<b>Files:</b> a.json, b.yaml<br>`+
+				`<b>Created:</b> 01 Mar 2024<br>`+
+				`2 comments`+
+				`</div>`)
+
+			c.Assert(data.Data, qt.DeepEquals, &resolver.ResponseData{
+				Kind:        resolver.DataKindDocument,
+				Title:       "My config",
+				Description: "My config",
+				Author:      "pajlada",
+				Comments:    2,
+				Published:   "2024-03-01T12:00:00Z",
+				Fields: map[string]string{
+					"files": "2",
+				},
+			})
+		})
+
+		c.Run("Secret gist", func(c *qt.C) {
+			data := runResolver(c, ctx, gistResolver, "https://gist.github.com/fedcba9876543210")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Tooltip, qt.Equals, `<div style="text-align: left;">`+
+				`<b>1.txt</b><hr>`+
+				`<b>Author:</b> pajlada<br>`+
+				`<b>Files:</b> 1.txt, 2.txt, 3.txt, 4.txt, 5.txt, …<br>`+
+				`<b>Created:</b> 01 Mar 2024<br>`+
+				`0 comments`+
+				`<li><b><span style="color: red;">SECRET</span></b></li>`+
+				`</div>`)
+			c.Assert(data.Data.Fields, qt.DeepEquals, map[string]string{
+				"files":  "6",
+				"secret": "true",
+			})
+		})
+
+		c.Run("German", func(c *qt.C) {
+			data := runResolver(c, i18n.OnContext(ctx, i18n.German), gistResolver, "https://gist.github.com/fedcba9876543210")
+			c.Assert(data.Tooltip, qt.Contains, `<b>Dateien:</b> 1.txt`)
+			c.Assert(data.Tooltip, qt.Contains, `GEHEIM`)
+		})
+
+		c.Run("Not found", func(c *qt.C) {
+			data := runResolver(c, ctx, gistResolver, "https://gist.github.com/404404404")
+			c.Assert(data.Status, qt.Equals, http.StatusNotFound)
+			c.Assert(data.Message, qt.Equals, "No GitHub gist with this ID found")
+		})
+	})
+}
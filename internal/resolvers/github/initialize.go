@@ -0,0 +1,88 @@
+package github
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+const (
+	githubRepoTooltipString = `<div style="text-align: left;">` +
+		`<b>{{.Name}}</b><br>` +
+		`{{ if .Description }}{{.Description}}<br>{{ end }}` +
+		`{{ if .Language }}<b>{{t "Language"}}:</b> {{.Language}}<br>{{ end }}` +
+		`<b>{{t "Stars"}}:</b> {{.Stars}} • <b>{{t "Forks"}}:</b> {{.Forks}}` +
+		`{{ if .Archived }}<li><b><span style="color: red;">{{t "ARCHIVED"}}</span></b></li>{{ end }}` +
+		`</div>`
+
+	githubIssueTooltipString = `<div style="text-align: left;">` +
+		`<b>{{.Title}}</b> #{{.Number}}<hr>` +
+		`<b>{{.Repo}}</b> • <b><span style="color: {{.StateColor}};">{{t .State}}</span></b><br>` +
+		`<b>{{t "Author"}}:</b> {{.Author}}{{ if .Age }} • {{.Age}}{{ end }}<br>` +
+		`{{ if .Labels }}<b>{{t "Labels"}}:</b> {{.Labels}}<br>{{ end }}` +
+		`{{t "%s comments" .Comments}}` +
+		`</div>`
+
+	githubCommitTooltipString = `<div style="text-align: left;">` +
+		`<b>{{.Message}}</b><hr>` +
+		`<b>{{.Repo}}</b> • {{.ShortSHA}}<br>` +
+		`<b>{{t "Author"}}:</b> {{.Author}}{{ if .Age }} • {{.Age}}{{ end }}<br>` +
+		`<span style="color: #3fb950;">+{{.Additions}}</span> <span style="color: #f85149;">-{{.Deletions}}</span> • {{t "%s files changed" .Files}}` +
+		`</div>`
+
+	githubReleaseTooltipString = `<div style="text-align: left;">` +
+		`<b>{{.Name}}</b><hr>` +
+		`<b>{{.Repo}}</b> • {{.Tag}}<br>` +
+		`<b>{{t "Author"}}:</b> {{.Author}}` +
+		`{{ if .Published }}<br><b>{{t "Published"}}:</b> {{.Published}}{{ end }}` +
+		`{{ if .Prerelease }}<li><b><span style="color: red;">{{t "PRE-RELEASE"}}</span></b></li>{{ end }}` +
+		`</div>`
+
+	githubGistTooltipString = `<div style="text-align: left;">` +
+		`<b>{{.Title}}</b><hr>` +
+		`<b>{{t "Author"}}:</b> {{.Author}}<br>` +
+		`<b>{{t "Files"}}:</b> {{.Files}}<br>` +
+		`<b>{{t "Created"}}:</b> {{.Created}}<br>` +
+		`{{t "%s comments" .Comments}}` +
+		`{{ if .Secret }}<li><b><span style="color: red;">{{t "SECRET"}}</span></b></li>{{ end }}` +
+		`</div>`
+)
+
+var (
+	errInvalidGitHubRepo    = errors.New("invalid GitHub repository link")
+	errInvalidGitHubIssue   = errors.New("invalid GitHub issue link")
+	errInvalidGitHubCommit  = errors.New("invalid GitHub commit link")
+	errInvalidGitHubRelease = errors.New("invalid GitHub release link")
+	errInvalidGitHubGist    = errors.New("invalid GitHub gist link")
+
+	githubRepoTooltip    = i18n.MustTemplate("githubRepoTooltip", githubRepoTooltipString)
+	githubIssueTooltip   = i18n.MustTemplate("githubIssueTooltip", githubIssueTooltipString)
+	githubCommitTooltip  = i18n.MustTemplate("githubCommitTooltip", githubCommitTooltipString)
+	githubReleaseTooltip = i18n.MustTemplate("githubReleaseTooltip", githubReleaseTooltipString)
+	githubGistTooltip    = i18n.MustTemplate("githubGistTooltip", githubGistTooltipString)
+
+	domains = map[string]struct{}{
+		"github.com":     {},
+		"www.github.com": {},
+	}
+
+	gistDomains = map[string]struct{}{
+		"gist.github.com": {},
+	}
+)
+
+func Initialize(ctx context.Context, cfg config.APIConfig, pool db.Pool, resolvers *[]resolver.Resolver) {
+	apiURL := utils.MustParseURL("https://api.github.com/")
+	client := newAPIClient(apiURL, cfg.GithubToken, resolver.NewUpstream(cfg, "github"))
+
+	*resolvers = append(*resolvers, NewIssueResolver(ctx, cfg, pool, client))
+	*resolvers = append(*resolvers, NewCommitResolver(ctx, cfg, pool, client))
+	*resolvers = append(*resolvers, NewReleaseResolver(ctx, cfg, pool, client))
+	*resolvers = append(*resolvers, NewRepoResolver(ctx, cfg, pool, client))
+	*resolvers = append(*resolvers, NewGistResolver(ctx, cfg, pool, client))
+}
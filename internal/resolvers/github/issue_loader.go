@@ -0,0 +1,146 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+// Maximum length of the label list shown in tooltips
+const maxLabelsLength = 100
+
+var errInvalidIssueKey = errors.New("invalid GitHub issue key")
+
+// Colors GitHub uses for the states of issues and pull requests
+const (
+	stateColorOpen      = "#3fb950"
+	stateColorClosed    = "#f85149"
+	stateColorCompleted = "#a371f7"
+	stateColorNeutral   = "#8b949e"
+)
+
+type githubIssueTooltipData struct {
+	Title      string
+	Number     int64
+	Repo       string
+	State      string
+	StateColor string
+	Author     string
+	Age        string
+	Labels     string
+	Comments   string
+}
+
+type IssueLoader struct {
+	client *apiClient
+}
+
+// issueState returns the state shown for an issue or pull request, with the color GitHub shows it in
+func issueState(issue githubIssue) (string, string) {
+	isPullRequest := issue.PullRequest != nil
+
+	switch {
+	case isPullRequest && issue.PullRequest.MergedAt != nil:
+		return "Merged", stateColorCompleted
+	case issue.State == "closed" && isPullRequest:
+		return "Closed", stateColorClosed
+	case issue.State == "closed" && issue.StateReason == "not_planned":
+		return "Closed", stateColorNeutral
+	case issue.State == "closed":
+		return "Closed", stateColorCompleted
+	case issue.Draft:
+		return "Draft", stateColorNeutral
+	default:
+		return "Open", stateColorOpen
+	}
+}
+
+// Load loads an issue or pull request, keyed by the API path of its repository and its number,
+// e.g. "chatterino/api/123"
+func (l *IssueLoader) Load(ctx context.Context, key string, r *http.Request) (*resolver.Response, time.Duration, error) {
+	log := logger.FromContext(ctx)
+
+	slash := strings.LastIndex(key, "/")
+	if slash == -1 {
+		return nil, cache.NoSpecialDur, errInvalidIssueKey
+	}
+	repo, number := key[:slash], key[slash+1:]
+
+	log.Debugw("[GitHub] Get issue",
+		"repo", repo,
+		"number", number,
+	)
+
+	// The issues endpoint returns pull requests too, including whether they were merged
+	var issue githubIssue
+	if response, dur, err := l.client.request(ctx, "repos/"+repo+"/issues/"+number, noGitHubIssueFound, &issue); response != nil || err != nil {
+		return response, dur, err
+	}
+
+	lang := i18n.FromContext(ctx)
+	state, stateColor := issueState(issue)
+
+	labels := make([]string, 0, len(issue.Labels))
+	for _, label := range issue.Labels {
+		labels = append(labels, label.Name)
+	}
+
+	data := githubIssueTooltipData{
+		Title:      issue.Title,
+		Number:     issue.Number,
+		Repo:       repoFromHTMLURL(issue.HTMLURL, repo),
+		State:      state,
+		StateColor: stateColor,
+		Author:     issue.User.login(),
+		Labels:     utils.TruncateString(strings.Join(labels, ", "), maxLabelsLength),
+		Comments:   humanize.NumberInt64In(lang, issue.Comments),
+	}
+	if createdAt, ok := parseTime(issue.CreatedAt); ok {
+		data.Age = humanize.AgoIn(lang, time.Since(createdAt))
+	}
+
+	var tooltip bytes.Buffer
+	if err := githubIssueTooltip.Execute(&tooltip, lang, data); err != nil {
+		return resolver.Errorf("GitHub issue template error: %s", err)
+	}
+
+	kind := resolver.DataKindIssue
+	if issue.PullRequest != nil {
+		kind = resolver.DataKindPullRequest
+	}
+
+	fields := map[string]string{
+		"repository": data.Repo,
+		"number":     strconv.FormatInt(issue.Number, 10),
+		"state":      strings.ToLower(state),
+	}
+	if len(labels) > 0 {
+		fields["labels"] = strings.Join(labels, ",")
+	}
+
+	return &resolver.Response{
+		Status:    200,
+		Tooltip:   url.PathEscape(tooltip.String()),
+		Thumbnail: issue.User.avatarURL(),
+		Data: &resolver.ResponseData{
+			Kind:      kind,
+			Title:     issue.Title,
+			Author:    data.Author,
+			Comments:  uint64(max(issue.Comments, 0)),
+			Published: issue.CreatedAt,
+			Fields:    fields,
+		},
+	}, cache.NoSpecialDur, nil
+}
@@ -0,0 +1,66 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+// Matches issue and pull request links, e.g. /Chatterino/api/issues/123 or /Chatterino/api/pull/456/files
+var issueRegex = regexp.MustCompile(repoPathPattern + `\/(?:issues|pull)\/(\d+)(?:\/[a-z-]+)?\/?$`)
+
+// issueKey returns the cache key of the issue the URL links to, e.g. "chatterino/api/123",
+// or an empty string if it's not an issue or pull request link
+func issueKey(url *url.URL) string {
+	if !utils.IsDomains(url, domains) {
+		return ""
+	}
+
+	match := issueRegex.FindStringSubmatch(url.Path)
+	if len(match) != 4 {
+		return ""
+	}
+
+	return repoPath(match[1], match[2]) + "/" + match[3]
+}
+
+type IssueResolver struct {
+	issueCache cache.Cache
+}
+
+func (r *IssueResolver) Check(ctx context.Context, url *url.URL) (context.Context, bool) {
+	return ctx, issueKey(url) != ""
+}
+
+func (r *IssueResolver) Run(ctx context.Context, url *url.URL, req *http.Request) (*cache.Response, error) {
+	key := issueKey(url)
+	if key == "" {
+		return nil, errInvalidGitHubIssue
+	}
+
+	return r.issueCache.Get(ctx, key, req)
+}
+
+func (r *IssueResolver) Name() string {
+	return "github:issue"
+}
+
+func NewIssueResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, client *apiClient) *IssueResolver {
+	issueLoader := &IssueLoader{
+		client: client,
+	}
+
+	r := &IssueResolver{
+		issueCache: cache.NewDefaultCache(ctx, cfg, pool, cache.NewLocalizedKeyProvider("github:issue"),
+			resolver.NewResponseMarshaller(issueLoader), cfg.GithubIssueCacheDuration),
+	}
+
+	return r
+}
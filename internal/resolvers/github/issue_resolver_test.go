@@ -0,0 +1,124 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+)
+
+func TestIssueResolver(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		CacheBackend: cache.BackendMemory,
+	}
+	api := newTestAPI()
+	defer api.Close()
+
+	issueResolver := NewIssueResolver(ctx, cfg, nil, api.client(""))
+
+	c.Assert(issueResolver, qt.IsNotNil)
+
+	c.Run("Name", func(c *qt.C) {
+		c.Assert(issueResolver.Name(), qt.Equals, "github:issue")
+	})
+
+	c.Run("Check", func(c *qt.C) {
+		type checkTest struct {
+			input    *url.URL
+			expected bool
+		}
+
+		tests := []checkTest{
+			{utils.MustParseURL("https://github.com/Chatterino/api/issues/1"), true},
+			{utils.MustParseURL("https://github.com/Chatterino/api/pull/2/"), true},
+			{utils.MustParseURL("https://github.com/Chatterino/api/pull/2/files"), true},
+			{utils.MustParseURL("https://github.com/Chatterino/api/pull/2#issuecomment-123"), true},
+			{utils.MustParseURL("https://github.com/Chatterino/api/issues"), false},
+			{utils.MustParseURL("https://github.com/Chatterino/api/issues/new"), false},
+			{utils.MustParseURL("https://github.com/Chatterino/api/pull/2/files/abc"), false},
+		}
+
+		for _, test := range tests {
+			c.Run(test.input.String(), func(c *qt.C) {
+				_, output := issueResolver.Check(ctx, test.input)
+				c.Assert(output, qt.Equals, test.expected)
+			})
+		}
+	})
+
+	c.Run("Run", func(c *qt.C) {
+		c.Run("Issue", func(c *qt.C) {
+			data := runResolver(c, ctx, issueResolver, "https://github.com/chatterino/API/issues/1")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "https://avatars.githubusercontent.com/u/2")
+			c.Assert(data.Tooltip, qt.Equals, `<div style="text-align: left;">`+
+				`<b>Links &lt;b&gt;break&lt;/b&gt;</b> #1<hr>`+
+				`<b>Chatterino/api</b> • <b><span style="color: #3fb950;">Open</span></b><br>`+
+				`<b>Author:</b> pajlada • 2 days ago<br>`+
+				`<b>Labels:</b> bug, help wanted<br>`+
+				`3 comments`+
+				`</div>`)
+
+			c.Assert(data.Data, qt.DeepEquals, &resolver.ResponseData{
+				Kind:      resolver.DataKindIssue,
+				Title:     "Links <b>break</b>",
+				Author:    "pajlada",
+				Comments:  3,
+				Published: createdAt.Format(time.RFC3339),
+				Fields: map[string]string{
+					"repository": "Chatterino/api",
+					"number":     "1",
+					"state":      "open",
+					"labels":     "bug,help wanted",
+				},
+			})
+		})
+
+		c.Run("Merged pull request", func(c *qt.C) {
+			data := runResolver(c, ctx, issueResolver, "https://github.com/Chatterino/api/pull/2")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Tooltip, qt.Contains, `<b>Chatterino/api</b> • <b><span style="color: #a371f7;">Merged</span></b><br>`)
+			c.Assert(data.Tooltip, qt.Not(qt.Contains), "Labels")
+			c.Assert(data.Data.Kind, qt.Equals, resolver.DataKindPullRequest)
+			c.Assert(data.Data.Fields["state"], qt.Equals, "merged")
+		})
+
+		c.Run("Draft pull request of a deleted user", func(c *qt.C) {
+			data := runResolver(c, ctx, issueResolver, "https://github.com/Chatterino/api/pull/3")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "")
+			c.Assert(data.Tooltip, qt.Contains, `<span style="color: #8b949e;">Draft</span>`)
+		})
+
+		c.Run("Issue closed as not planned", func(c *qt.C) {
+			data := runResolver(c, ctx, issueResolver, "https://github.com/Chatterino/api/issues/4")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Tooltip, qt.Contains, `<span style="color: #8b949e;">Closed</span>`)
+		})
+
+		c.Run("German", func(c *qt.C) {
+			data := runResolver(c, i18n.OnContext(ctx, i18n.German), issueResolver, "https://github.com/Chatterino/api/pull/2")
+			c.Assert(data.Tooltip, qt.Contains, `>Gemergt</span>`)
+			c.Assert(data.Tooltip, qt.Contains, `<b>Autor:</b> zneix • vor 2 Tagen<br>`)
+			c.Assert(data.Tooltip, qt.Contains, `0 Kommentare`)
+		})
+
+		c.Run("Not found", func(c *qt.C) {
+			data := runResolver(c, ctx, issueResolver, "https://github.com/Chatterino/api/issues/404")
+			c.Assert(data.Status, qt.Equals, http.StatusNotFound)
+			c.Assert(data.Message, qt.Equals, "No GitHub issue or pull request with this number found")
+		})
+	})
+}
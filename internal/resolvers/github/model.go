@@ -0,0 +1,121 @@
+package github
+
+import (
+	"net/url"
+	"strings"
+)
+
+type githubUser struct {
+	Login     string `json:"login"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type githubRepo struct {
+	FullName        string      `json:"full_name"`
+	Description     string      `json:"description"`
+	Language        string      `json:"language"`
+	StargazersCount int64       `json:"stargazers_count"`
+	ForksCount      int64       `json:"forks_count"`
+	Archived        bool        `json:"archived"`
+	Fork            bool        `json:"fork"`
+	CreatedAt       string      `json:"created_at"`
+	Owner           *githubUser `json:"owner"`
+}
+
+type githubLabel struct {
+	Name string `json:"name"`
+}
+
+// githubIssue is returned by the issues endpoint, which returns pull requests too
+type githubIssue struct {
+	Number      int64         `json:"number"`
+	HTMLURL     string        `json:"html_url"`
+	Title       string        `json:"title"`
+	State       string        `json:"state"`
+	StateReason string        `json:"state_reason"`
+	Draft       bool          `json:"draft"`
+	Comments    int64         `json:"comments"`
+	CreatedAt   string        `json:"created_at"`
+	User        *githubUser   `json:"user"`
+	Labels      []githubLabel `json:"labels"`
+
+	// Only set for pull requests
+	PullRequest *struct {
+		MergedAt *string `json:"merged_at"`
+	} `json:"pull_request"`
+}
+
+type githubCommit struct {
+	SHA     string `json:"sha"`
+	HTMLURL string `json:"html_url"`
+	Commit  struct {
+		Message string `json:"message"`
+		Author  struct {
+			Name string `json:"name"`
+			Date string `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+	// The GitHub account of the author, if their email is linked to one
+	Author *githubUser `json:"author"`
+	Stats  struct {
+		Additions int64 `json:"additions"`
+		Deletions int64 `json:"deletions"`
+	} `json:"stats"`
+	Files []struct {
+		Filename string `json:"filename"`
+	} `json:"files"`
+}
+
+type githubRelease struct {
+	Name        string      `json:"name"`
+	HTMLURL     string      `json:"html_url"`
+	TagName     string      `json:"tag_name"`
+	Prerelease  bool        `json:"prerelease"`
+	PublishedAt string      `json:"published_at"`
+	Author      *githubUser `json:"author"`
+}
+
+type githubGist struct {
+	Description string      `json:"description"`
+	Public      bool        `json:"public"`
+	Comments    int64       `json:"comments"`
+	CreatedAt   string      `json:"created_at"`
+	Owner       *githubUser `json:"owner"`
+	Files       map[string]struct {
+		Filename string `json:"filename"`
+		Language string `json:"language"`
+	} `json:"files"`
+}
+
+// repoFromHTMLURL returns the owner and name of the repository of a GitHub link in their original case,
+// e.g. "Chatterino/api" for https://github.com/Chatterino/api/issues/123
+func repoFromHTMLURL(htmlURL string, fallback string) string {
+	u, err := url.Parse(htmlURL)
+	if err != nil {
+		return fallback
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(u.Path, "/"), "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return fallback
+	}
+
+	return parts[0] + "/" + parts[1]
+}
+
+// login returns the login of the user, which is missing for deleted accounts
+func (u *githubUser) login() string {
+	if u == nil {
+		return ""
+	}
+
+	return u.Login
+}
+
+func (u *githubUser) avatarURL() string {
+	if u == nil {
+		return ""
+	}
+
+	return u.AvatarURL
+}
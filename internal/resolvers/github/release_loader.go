@@ -0,0 +1,102 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+)
+
+var errInvalidReleaseKey = errors.New("invalid GitHub release key")
+
+type githubReleaseTooltipData struct {
+	Name       string
+	Repo       string
+	Tag        string
+	Author     string
+	Published  string
+	Prerelease bool
+}
+
+type ReleaseLoader struct {
+	client *apiClient
+}
+
+// Load loads a release, keyed by the API path of its repository and the path of the release,
+// e.g. "chatterino/api/tags/v2.0.0" or "chatterino/api/latest"
+func (l *ReleaseLoader) Load(ctx context.Context, key string, r *http.Request) (*resolver.Response, time.Duration, error) {
+	log := logger.FromContext(ctx)
+
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) != 3 {
+		return nil, cache.NoSpecialDur, errInvalidReleaseKey
+	}
+	repo, release := parts[0]+"/"+parts[1], parts[2]
+	if tag, ok := strings.CutPrefix(release, "tags/"); ok {
+		release = "tags/" + url.PathEscape(tag)
+	}
+
+	log.Debugw("[GitHub] Get release",
+		"repo", repo,
+		"release", release,
+	)
+
+	var githubRelease githubRelease
+	if response, dur, err := l.client.request(ctx, "repos/"+repo+"/releases/"+release, noGitHubReleaseFound, &githubRelease); response != nil || err != nil {
+		return response, dur, err
+	}
+
+	lang := i18n.FromContext(ctx)
+
+	// Releases without a name are shown with their tag on GitHub
+	name := githubRelease.Name
+	if name == "" {
+		name = githubRelease.TagName
+	}
+
+	data := githubReleaseTooltipData{
+		Name:       name,
+		Repo:       repoFromHTMLURL(githubRelease.HTMLURL, repo),
+		Tag:        githubRelease.TagName,
+		Author:     githubRelease.Author.login(),
+		Prerelease: githubRelease.Prerelease,
+	}
+	if publishedAt, ok := parseTime(githubRelease.PublishedAt); ok {
+		data.Published = humanize.CreationDateIn(lang, publishedAt)
+	}
+
+	var tooltip bytes.Buffer
+	if err := githubReleaseTooltip.Execute(&tooltip, lang, data); err != nil {
+		return resolver.Errorf("GitHub release template error: %s", err)
+	}
+
+	fields := map[string]string{
+		"repository": data.Repo,
+		"tag":        githubRelease.TagName,
+	}
+	if githubRelease.Prerelease {
+		fields["prerelease"] = "true"
+	}
+
+	return &resolver.Response{
+		Status:    200,
+		Tooltip:   url.PathEscape(tooltip.String()),
+		Thumbnail: githubRelease.Author.avatarURL(),
+		Data: &resolver.ResponseData{
+			Kind:      resolver.DataKindRelease,
+			Title:     name,
+			Author:    data.Author,
+			Published: githubRelease.PublishedAt,
+			Fields:    fields,
+		},
+	}, cache.NoSpecialDur, nil
+}
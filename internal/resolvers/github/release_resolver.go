@@ -0,0 +1,72 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+// Matches release links, e.g. /Chatterino/api/releases/tag/v2.0.0 or /Chatterino/api/releases/latest
+var releaseRegex = regexp.MustCompile(repoPathPattern + `\/releases\/(?:tag\/([^\/]+)|(latest))\/?$`)
+
+// releaseKey returns the cache key of the release the URL links to, which is the API path of the
+// release relative to the releases of the repository, e.g. "chatterino/api/tags/v2.0.0".
+// Returns an empty string if it's not a release link.
+func releaseKey(url *url.URL) string {
+	if !utils.IsDomains(url, domains) {
+		return ""
+	}
+
+	match := releaseRegex.FindStringSubmatch(url.Path)
+	if len(match) != 5 {
+		return ""
+	}
+
+	if match[4] != "" {
+		return repoPath(match[1], match[2]) + "/latest"
+	}
+
+	// Tags are case sensitive
+	return repoPath(match[1], match[2]) + "/tags/" + match[3]
+}
+
+type ReleaseResolver struct {
+	releaseCache cache.Cache
+}
+
+func (r *ReleaseResolver) Check(ctx context.Context, url *url.URL) (context.Context, bool) {
+	return ctx, releaseKey(url) != ""
+}
+
+func (r *ReleaseResolver) Run(ctx context.Context, url *url.URL, req *http.Request) (*cache.Response, error) {
+	key := releaseKey(url)
+	if key == "" {
+		return nil, errInvalidGitHubRelease
+	}
+
+	return r.releaseCache.Get(ctx, key, req)
+}
+
+func (r *ReleaseResolver) Name() string {
+	return "github:release"
+}
+
+func NewReleaseResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, client *apiClient) *ReleaseResolver {
+	releaseLoader := &ReleaseLoader{
+		client: client,
+	}
+
+	r := &ReleaseResolver{
+		releaseCache: cache.NewDefaultCache(ctx, cfg, pool, cache.NewLocalizedKeyProvider("github:release"),
+			resolver.NewResponseMarshaller(releaseLoader), cfg.GithubReleaseCacheDuration),
+	}
+
+	return r
+}
@@ -0,0 +1,100 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+)
+
+func TestReleaseResolver(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		CacheBackend: cache.BackendMemory,
+	}
+	api := newTestAPI()
+	defer api.Close()
+
+	releaseResolver := NewReleaseResolver(ctx, cfg, nil, api.client(""))
+
+	c.Assert(releaseResolver, qt.IsNotNil)
+
+	c.Run("Name", func(c *qt.C) {
+		c.Assert(releaseResolver.Name(), qt.Equals, "github:release")
+	})
+
+	c.Run("Check", func(c *qt.C) {
+		type checkTest struct {
+			input    *url.URL
+			expected bool
+		}
+
+		tests := []checkTest{
+			{utils.MustParseURL("https://github.com/Chatterino/api/releases/tag/v2.0.0"), true},
+			{utils.MustParseURL("https://github.com/Chatterino/api/releases/latest/"), true},
+			{utils.MustParseURL("https://github.com/Chatterino/api/releases"), false},
+			{utils.MustParseURL("https://github.com/Chatterino/api/releases/tag/"), false},
+			{utils.MustParseURL("https://github.com/Chatterino/api/releases/download/v2.0.0/api.zip"), false},
+		}
+
+		for _, test := range tests {
+			c.Run(test.input.String(), func(c *qt.C) {
+				_, output := releaseResolver.Check(ctx, test.input)
+				c.Assert(output, qt.Equals, test.expected)
+			})
+		}
+	})
+
+	c.Run("Run", func(c *qt.C) {
+		c.Run("Latest release", func(c *qt.C) {
+			data := runResolver(c, ctx, releaseResolver, "https://github.com/Chatterino/api/releases/latest")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "https://avatars.githubusercontent.com/u/2")
+			c.Assert(data.Tooltip, qt.Equals, `<div style="text-align: left;">`+
+				`<b>v2.0.0</b><hr>`+
+				`<b>Chatterino/api</b> • v2.0.0<br>`+
+				`<b>Author:</b> pajlada<br>`+
+				`<b>Published:</b> 01 Mar 2024`+
+				`</div>`)
+
+			c.Assert(data.Data, qt.DeepEquals, &resolver.ResponseData{
+				Kind:      resolver.DataKindRelease,
+				Title:     "v2.0.0",
+				Author:    "pajlada",
+				Published: "2024-03-01T12:00:00Z",
+				Fields: map[string]string{
+					"repository": "Chatterino/api",
+					"tag":        "v2.0.0",
+				},
+			})
+		})
+
+		c.Run("Pre-release", func(c *qt.C) {
+			data := runResolver(c, ctx, releaseResolver, "https://github.com/chatterino/api/releases/tag/v2.1.0-beta")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Tooltip, qt.Equals, `<div style="text-align: left;">`+
+				`<b>Beta release</b><hr>`+
+				`<b>Chatterino/api</b> • v2.1.0-beta<br>`+
+				`<b>Author:</b> pajlada<br>`+
+				`<b>Published:</b> 01 Apr 2024`+
+				`<li><b><span style="color: red;">PRE-RELEASE</span></b></li>`+
+				`</div>`)
+			c.Assert(data.Data.Fields["prerelease"], qt.Equals, "true")
+		})
+
+		c.Run("Tags are case sensitive", func(c *qt.C) {
+			data := runResolver(c, ctx, releaseResolver, "https://github.com/Chatterino/api/releases/tag/V2.1.0-BETA")
+			c.Assert(data.Status, qt.Equals, http.StatusNotFound)
+			c.Assert(data.Message, qt.Equals, "No GitHub release with this tag found")
+		})
+	})
+}
@@ -0,0 +1,84 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+)
+
+type githubRepoTooltipData struct {
+	Name        string
+	Description string
+	Language    string
+	Stars       string
+	Forks       string
+	Archived    bool
+}
+
+type RepoLoader struct {
+	client *apiClient
+}
+
+// Load loads a repository, keyed by its API path, e.g. "chatterino/api"
+func (l *RepoLoader) Load(ctx context.Context, name string, r *http.Request) (*resolver.Response, time.Duration, error) {
+	log := logger.FromContext(ctx)
+
+	log.Debugw("[GitHub] Get repository",
+		"name", name,
+	)
+
+	var repo githubRepo
+	if response, dur, err := l.client.request(ctx, "repos/"+name, noGitHubRepoFound, &repo); response != nil || err != nil {
+		return response, dur, err
+	}
+
+	lang := i18n.FromContext(ctx)
+
+	data := githubRepoTooltipData{
+		Name:        repo.FullName,
+		Description: repo.Description,
+		Language:    repo.Language,
+		Stars:       humanize.NumberInt64In(lang, repo.StargazersCount),
+		Forks:       humanize.NumberInt64In(lang, repo.ForksCount),
+		Archived:    repo.Archived,
+	}
+
+	var tooltip bytes.Buffer
+	if err := githubRepoTooltip.Execute(&tooltip, lang, data); err != nil {
+		return resolver.Errorf("GitHub repository template error: %s", err)
+	}
+
+	fields := map[string]string{
+		"stars": strconv.FormatInt(repo.StargazersCount, 10),
+		"forks": strconv.FormatInt(repo.ForksCount, 10),
+	}
+	if repo.Language != "" {
+		fields["language"] = repo.Language
+	}
+	if repo.Archived {
+		fields["archived"] = "true"
+	}
+
+	return &resolver.Response{
+		Status:    200,
+		Tooltip:   url.PathEscape(tooltip.String()),
+		Thumbnail: repo.Owner.avatarURL(),
+		Data: &resolver.ResponseData{
+			Kind:        resolver.DataKindRepository,
+			Title:       repo.FullName,
+			Description: repo.Description,
+			Author:      repo.Owner.login(),
+			Published:   repo.CreatedAt,
+			Fields:      fields,
+		},
+	}, cache.NoSpecialDur, nil
+}
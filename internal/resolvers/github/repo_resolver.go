@@ -0,0 +1,99 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+// Matches the owner and name of a repository at the start of a path
+const repoPathPattern = `^\/([a-zA-Z0-9-]+)\/([a-zA-Z0-9._-]+)`
+
+// Matches repository links, e.g. /Chatterino/api
+var repoRegex = regexp.MustCompile(repoPathPattern + `\/?$`)
+
+// Pages of GitHub looking like repository links
+var ignoredOwners = []string{
+	"about",
+	"apps",
+	"collections",
+	"enterprise",
+	"events",
+	"explore",
+	"features",
+	"issues",
+	"marketplace",
+	"notifications",
+	"orgs",
+	"organizations",
+	"pricing",
+	"pulls",
+	"search",
+	"settings",
+	"sponsors",
+	"topics",
+	"trending",
+	"users",
+}
+
+// repoPath returns the escaped API path of the repository, lowercased as GitHub names aren't case sensitive
+func repoPath(owner, repo string) string {
+	return url.PathEscape(strings.ToLower(owner)) + "/" + url.PathEscape(strings.ToLower(strings.TrimSuffix(repo, ".git")))
+}
+
+// repoName returns the API path of the repository the URL links to, or an empty string if it's not a repository link
+func repoName(url *url.URL) string {
+	if !utils.IsDomains(url, domains) {
+		return ""
+	}
+
+	match := repoRegex.FindStringSubmatch(url.Path)
+	if len(match) != 3 || slices.Contains(ignoredOwners, strings.ToLower(match[1])) {
+		return ""
+	}
+
+	return repoPath(match[1], match[2])
+}
+
+type RepoResolver struct {
+	repoCache cache.Cache
+}
+
+func (r *RepoResolver) Check(ctx context.Context, url *url.URL) (context.Context, bool) {
+	return ctx, repoName(url) != ""
+}
+
+func (r *RepoResolver) Run(ctx context.Context, url *url.URL, req *http.Request) (*cache.Response, error) {
+	name := repoName(url)
+	if name == "" {
+		return nil, errInvalidGitHubRepo
+	}
+
+	return r.repoCache.Get(ctx, name, req)
+}
+
+func (r *RepoResolver) Name() string {
+	return "github:repo"
+}
+
+func NewRepoResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, client *apiClient) *RepoResolver {
+	repoLoader := &RepoLoader{
+		client: client,
+	}
+
+	r := &RepoResolver{
+		repoCache: cache.NewDefaultCache(ctx, cfg, pool, cache.NewLocalizedKeyProvider("github:repo"),
+			resolver.NewResponseMarshaller(repoLoader), cfg.GithubRepoCacheDuration),
+	}
+
+	return r
+}
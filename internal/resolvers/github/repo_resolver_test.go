@@ -0,0 +1,119 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+)
+
+func TestRepoResolver(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		CacheBackend: cache.BackendMemory,
+	}
+	api := newTestAPI()
+	defer api.Close()
+
+	repoResolver := NewRepoResolver(ctx, cfg, nil, api.client(""))
+
+	c.Assert(repoResolver, qt.IsNotNil)
+
+	c.Run("Name", func(c *qt.C) {
+		c.Assert(repoResolver.Name(), qt.Equals, "github:repo")
+	})
+
+	c.Run("Check", func(c *qt.C) {
+		type checkTest struct {
+			input    *url.URL
+			expected bool
+		}
+
+		tests := []checkTest{
+			{utils.MustParseURL("https://github.com/Chatterino/api"), true},
+			{utils.MustParseURL("https://www.github.com/Chatterino/api/"), true},
+			{utils.MustParseURL("https://github.com/Chatterino/chatterino2.git"), true},
+			{utils.MustParseURL("https://github.com/some-user/some_repo.js"), true},
+			{utils.MustParseURL("https://github.com/Chatterino"), false},
+			{utils.MustParseURL("https://github.com/Chatterino/api/issues"), false},
+			{utils.MustParseURL("https://github.com/orgs/Chatterino"), false},
+			{utils.MustParseURL("https://github.com/settings/profile"), false},
+			{utils.MustParseURL("https://github.com/Topics/go"), false},
+			{utils.MustParseURL("https://gist.github.com/Chatterino/api"), false},
+			{utils.MustParseURL("https://notgithub.com/Chatterino/api"), false},
+		}
+
+		for _, test := range tests {
+			c.Run(test.input.String(), func(c *qt.C) {
+				_, output := repoResolver.Check(ctx, test.input)
+				c.Assert(output, qt.Equals, test.expected)
+			})
+		}
+	})
+
+	c.Run("Run", func(c *qt.C) {
+		c.Run("Repository", func(c *qt.C) {
+			data := runResolver(c, ctx, repoResolver, "https://github.com/Chatterino/API")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "https://avatars.githubusercontent.com/u/1")
+			c.Assert(data.Tooltip, qt.Equals, `<div style="text-align: left;">`+
+				`<b>Chatterino/api</b><br>`+
+				`Go web service used as a proxy &lt;3<br>`+
+				`<b>Language:</b> Go<br>`+
+				`<b>Stars:</b> 1,234 • <b>Forks:</b> 56`+
+				`</div>`)
+
+			c.Assert(data.Data, qt.DeepEquals, &resolver.ResponseData{
+				Kind:        resolver.DataKindRepository,
+				Title:       "Chatterino/api",
+				Description: "Go web service used as a proxy <3",
+				Author:      "Chatterino",
+				Published:   "2019-03-01T12:00:00Z",
+				Fields: map[string]string{
+					"language": "Go",
+					"stars":    "1234",
+					"forks":    "56",
+				},
+			})
+		})
+
+		c.Run("Archived repository", func(c *qt.C) {
+			data := runResolver(c, ctx, repoResolver, "https://github.com/Chatterino/old.git")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Tooltip, qt.Equals, `<div style="text-align: left;">`+
+				`<b>Chatterino/old</b><br>`+
+				`<b>Stars:</b> 1 • <b>Forks:</b> 0`+
+				`<li><b><span style="color: red;">ARCHIVED</span></b></li>`+
+				`</div>`)
+			c.Assert(data.Data.Fields["archived"], qt.Equals, "true")
+		})
+
+		c.Run("German", func(c *qt.C) {
+			data := runResolver(c, i18n.OnContext(ctx, i18n.German), repoResolver, "https://github.com/Chatterino/api")
+			c.Assert(data.Tooltip, qt.Contains, `<b>Sprache:</b> Go<br>`)
+			c.Assert(data.Tooltip, qt.Contains, `<b>Sterne:</b> 1.234`)
+		})
+
+		c.Run("Not found", func(c *qt.C) {
+			data := runResolver(c, ctx, repoResolver, "https://github.com/Chatterino/404")
+			c.Assert(data.Status, qt.Equals, http.StatusNotFound)
+			c.Assert(data.Message, qt.Equals, "No GitHub repository with this name found")
+		})
+
+		c.Run("Bad JSON", func(c *qt.C) {
+			data := runResolver(c, ctx, repoResolver, "https://github.com/Chatterino/bad")
+			c.Assert(data.Status, qt.Equals, http.StatusInternalServerError)
+			c.Assert(data.Message, qt.Contains, "GitHub API unmarshal error")
+		})
+	})
+}
@@ -0,0 +1,34 @@
+package github
+
+import (
+	"net/http"
+
+	"github.com/Chatterino/api/pkg/resolver"
+)
+
+var (
+	noGitHubRepoFound = &resolver.Response{
+		Status:  http.StatusNotFound,
+		Message: "No GitHub repository with this name found",
+	}
+
+	noGitHubIssueFound = &resolver.Response{
+		Status:  http.StatusNotFound,
+		Message: "No GitHub issue or pull request with this number found",
+	}
+
+	noGitHubCommitFound = &resolver.Response{
+		Status:  http.StatusNotFound,
+		Message: "No GitHub commit with this hash found",
+	}
+
+	noGitHubReleaseFound = &resolver.Response{
+		Status:  http.StatusNotFound,
+		Message: "No GitHub release with this tag found",
+	}
+
+	noGitHubGistFound = &resolver.Response{
+		Status:  http.StatusNotFound,
+		Message: "No GitHub gist with this ID found",
+	}
+)
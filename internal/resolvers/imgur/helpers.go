@@ -13,14 +13,15 @@ import (
 	"github.com/Chatterino/api/pkg/humanize"
 	"github.com/Chatterino/api/pkg/resolver"
 	"github.com/koffeinsource/go-imgur"
+	"golang.org/x/text/language"
 )
 
 // Make a miniImage struct from a Gallery Image Info go-imgur struct
-func makeMiniImageFromGImage(imageInfo imgur.GalleryImageInfo) miniImage {
+func makeMiniImageFromGImage(lang language.Tag, imageInfo imgur.GalleryImageInfo) miniImage {
 	mini := miniImage{
 		Title:       imageInfo.Title,
 		Description: imageInfo.Description,
-		UploadDate:  humanize.CreationDateTimeUnix(int64(imageInfo.Datetime)),
+		UploadDate:  humanize.CreationDateTimeIn(lang, time.Unix(int64(imageInfo.Datetime), 0).UTC()),
 		Nsfw:        imageInfo.Nsfw,
 		Animated:    imageInfo.Animated,
 		Album:       true,
@@ -37,11 +38,11 @@ func makeMiniImageFromGImage(imageInfo imgur.GalleryImageInfo) miniImage {
 }
 
 // Make a miniImage struct from an Image Info go-imgur struct
-func makeMiniImage(imageInfo imgur.ImageInfo) miniImage {
+func makeMiniImage(lang language.Tag, imageInfo imgur.ImageInfo) miniImage {
 	mini := miniImage{
 		Title:       imageInfo.Title,
 		Description: imageInfo.Description,
-		UploadDate:  humanize.CreationDateTimeUnix(int64(imageInfo.Datetime)),
+		UploadDate:  humanize.CreationDateTimeIn(lang, time.Unix(int64(imageInfo.Datetime), 0).UTC()),
 		Nsfw:        imageInfo.Nsfw,
 		Animated:    imageInfo.Animated,
 		Album:       true,
@@ -95,10 +96,10 @@ func finalizeMiniImage(mini *miniImage) {
 	}
 }
 
-func buildTooltip(miniData miniImage) (*resolver.Response, time.Duration, error) {
+func buildTooltip(lang language.Tag, miniData miniImage) (*resolver.Response, time.Duration, error) {
 	var tooltip bytes.Buffer
 
-	if err := imageTooltipTemplate.Execute(&tooltip, &miniData); err != nil {
+	if err := imageTooltipTemplate.Execute(&tooltip, lang, &miniData); err != nil {
 		return resolver.Errorf("Imgur template error: %s", err)
 	}
 
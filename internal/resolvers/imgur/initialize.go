@@ -2,11 +2,12 @@ package imgur
 
 import (
 	"context"
-	"html/template"
+	"net/http"
 
 	"github.com/Chatterino/api/internal/db"
 	"github.com/Chatterino/api/internal/logger"
 	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	"github.com/koffeinsource/go-imgur"
 )
@@ -15,7 +16,7 @@ var (
 	// max size of an image before we use a small thumbnail of it
 	maxRawImageSize = 50 * 1024
 
-	imageTooltipTemplate = template.Must(template.New("imageTooltipTemplate").Parse(imageTooltip))
+	imageTooltipTemplate = i18n.MustTemplate("imageTooltipTemplate", imageTooltip)
 )
 
 func Initialize(ctx context.Context, cfg config.APIConfig, pool db.Pool, resolvers *[]resolver.Resolver) {
@@ -25,7 +26,13 @@ func Initialize(ctx context.Context, cfg config.APIConfig, pool db.Pool, resolve
 		return
 	}
 
-	imgurClient, err := imgur.NewClient(resolver.HTTPClient(), cfg.ImgurClientID, "")
+	upstream := resolver.NewUpstream(cfg, "imgur")
+	httpClient := &http.Client{
+		Timeout:   resolver.HTTPClient().Timeout,
+		Transport: upstream.RoundTripper(resolver.HTTPClient().Transport),
+	}
+
+	imgurClient, err := imgur.NewClient(httpClient, cfg.ImgurClientID, "")
 	if err != nil {
 		log.Warnw("Error initializing imgur client:", err)
 		return
@@ -33,5 +40,5 @@ func Initialize(ctx context.Context, cfg config.APIConfig, pool db.Pool, resolve
 
 	imgurClient.Log = &NullLogger{}
 
-	*resolvers = append(*resolvers, NewResolver(ctx, cfg, pool, imgurClient))
+	*resolvers = append(*resolvers, NewResolver(ctx, cfg, pool, imgurClient, upstream))
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/Chatterino/api/internal/logger"
 	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	"github.com/Chatterino/api/pkg/utils"
 )
@@ -14,10 +15,17 @@ import (
 type Loader struct {
 	baseURL   string
 	apiClient ImgurClient
+	// Outcomes of the requests are recorded by the HTTP client of apiClient
+	upstream *resolver.Upstream
 }
 
 func (l *Loader) Load(ctx context.Context, urlString string, r *http.Request) (*resolver.Response, time.Duration, error) {
 	log := logger.FromContext(ctx)
+	lang := i18n.FromContext(ctx)
+
+	if err := l.upstream.Allow(); err != nil {
+		return nil, cache.NoSpecialDur, err
+	}
 
 	genericInfo, _, err := l.apiClient.GetInfoFromURL(urlString)
 	if err != nil {
@@ -39,19 +47,19 @@ func (l *Loader) Load(ctx context.Context, urlString string, r *http.Request) (*
 	var miniData miniImage
 
 	if genericInfo.Image != nil {
-		miniData = makeMiniImage(*genericInfo.Image)
+		miniData = makeMiniImage(lang, *genericInfo.Image)
 	} else if genericInfo.GImage != nil {
-		miniData = makeMiniImageFromGImage(*genericInfo.GImage)
+		miniData = makeMiniImageFromGImage(lang, *genericInfo.GImage)
 	} else if genericInfo.Album != nil {
 		ptr := genericInfo.Album
 		if len(ptr.Images) == 0 {
 			return &resolver.Response{
 				Status:  http.StatusOK,
-				Tooltip: "Empty album",
+				Tooltip: i18n.T(lang, "Empty album"),
 			}, cache.NoSpecialDur, nil
 		}
 
-		miniData = makeMiniImage(ptr.Images[0])
+		miniData = makeMiniImage(lang, ptr.Images[0])
 
 		miniData.Album = true
 		miniData.Title = ptr.Title
@@ -61,11 +69,11 @@ func (l *Loader) Load(ctx context.Context, urlString string, r *http.Request) (*
 		if len(ptr.Images) == 0 {
 			return &resolver.Response{
 				Status:  http.StatusOK,
-				Tooltip: "Empty album",
+				Tooltip: i18n.T(lang, "Empty album"),
 			}, cache.NoSpecialDur, nil
 		}
 
-		miniData = makeMiniImage(ptr.Images[0])
+		miniData = makeMiniImage(lang, ptr.Images[0])
 
 		miniData.Album = true
 		miniData.Title = ptr.Title
@@ -83,5 +91,5 @@ func (l *Loader) Load(ctx context.Context, urlString string, r *http.Request) (*
 		miniData.Link = utils.FormatThumbnailURL(l.baseURL, r, miniData.Link)
 	}
 
-	return buildTooltip(miniData)
+	return buildTooltip(lang, miniData)
 }
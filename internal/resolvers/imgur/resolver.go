@@ -51,15 +51,16 @@ func (r *Resolver) Name() string {
 	return "imgur"
 }
 
-func NewResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, imgurClient ImgurClient) *Resolver {
+func NewResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, imgurClient ImgurClient, upstream *resolver.Upstream) *Resolver {
 	loader := &Loader{
 		baseURL:   cfg.BaseURL,
 		apiClient: imgurClient,
+		upstream:  upstream,
 	}
 
 	r := &Resolver{
-		imgurCache: cache.NewPostgreSQLCache(
-			ctx, cfg, pool, cache.NewPrefixKeyProvider("imgur"),
+		imgurCache: cache.NewDefaultCache(
+			ctx, cfg, pool, cache.NewLocalizedKeyProvider("imgur"),
 			resolver.NewResponseMarshaller(loader), cfg.ImgurCacheDuration),
 	}
 
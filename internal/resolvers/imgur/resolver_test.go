@@ -76,7 +76,7 @@ func TestResolver(t *testing.T) {
 		BaseURL: "https://example.com/",
 	}
 
-	r := NewResolver(ctx, cfg, pool, imgurClient)
+	r := NewResolver(ctx, cfg, pool, imgurClient, nil)
 
 	c.Assert(r, qt.IsNotNil)
 
@@ -0,0 +1,23 @@
+package kick
+
+import (
+	"net/url"
+	"time"
+)
+
+// Layout of the livestream timestamps, which are in UTC
+const livestreamTimeLayout = "2006-01-02 15:04:05"
+
+// parseTime parses the timestamps of the Kick API, which are either RFC 3339 or livestream timestamps
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(livestreamTimeLayout, value)
+}
+
+// buildURL resolves the path of an endpoint against the API base URL, e.g. "v2/channels/xqc"
+func buildURL(baseURL *url.URL, path string) string {
+	return baseURL.ResolveReference(&url.URL{Path: path}).String()
+}
@@ -0,0 +1,142 @@
+package kick
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+)
+
+type kickChannelTooltipData struct {
+	Name      string
+	Bio       string
+	Followers string
+	URL       string
+}
+
+type kickChannelLiveTooltipData struct {
+	Name      string
+	Bio       string
+	Followers string
+	URL       string
+	Title     string
+	Category  string
+	Viewers   string
+	Uptime    string
+}
+
+type ChannelLoader struct {
+	apiURL   *url.URL
+	upstream *resolver.Upstream
+}
+
+func (l *ChannelLoader) Load(ctx context.Context, slug string, r *http.Request) (*resolver.Response, time.Duration, error) {
+	log := logger.FromContext(ctx)
+
+	log.Debugw("[Kick] Get channel",
+		"slug", slug,
+	)
+
+	var channel kickChannel
+	apiURL := buildURL(l.apiURL, "v2/channels/"+url.PathEscape(slug))
+	if response, cacheDuration, err := resolver.RequestJSON(ctx, resolver.JSONRequest{
+		Upstream: l.upstream,
+		API:      "Kick API",
+		URL:      apiURL,
+		NotFound: noKickChannelWithThisNameFound,
+	}, &channel); response != nil || err != nil {
+		return response, cacheDuration, err
+	}
+
+	if channel.Livestream == nil || !channel.Livestream.IsLive {
+		return channelResponse(ctx, channel)
+	}
+
+	return channelLiveResponse(ctx, channel, *channel.Livestream)
+}
+
+func channelResponse(ctx context.Context, channel kickChannel) (*resolver.Response, time.Duration, error) {
+	lang := i18n.FromContext(ctx)
+
+	data := kickChannelTooltipData{
+		Name:      channel.User.Username,
+		Bio:       channel.User.Bio,
+		Followers: humanize.NumberInt64In(lang, channel.FollowersCount),
+		URL:       fmt.Sprintf("https://kick.com/%s", channel.Slug),
+	}
+
+	var tooltip bytes.Buffer
+	if err := kickChannelTooltip.Execute(&tooltip, lang, data); err != nil {
+		return resolver.Errorf("Kick channel template error: %s", err)
+	}
+
+	return &resolver.Response{
+		Status:    200,
+		Tooltip:   url.PathEscape(tooltip.String()),
+		Thumbnail: channel.User.ProfilePic,
+		Data: &resolver.ResponseData{
+			Kind:        resolver.DataKindUser,
+			Title:       data.Name,
+			Description: channel.User.Bio,
+			Followers:   uint64(max(channel.FollowersCount, 0)),
+		},
+	}, cache.NoSpecialDur, nil
+}
+
+func channelLiveResponse(ctx context.Context, channel kickChannel, livestream kickLivestream) (*resolver.Response, time.Duration, error) {
+	lang := i18n.FromContext(ctx)
+
+	category := firstCategory(livestream.Categories)
+	data := kickChannelLiveTooltipData{
+		Name:      channel.User.Username,
+		Bio:       channel.User.Bio,
+		Followers: humanize.NumberInt64In(lang, channel.FollowersCount),
+		URL:       fmt.Sprintf("https://kick.com/%s", channel.Slug),
+		Title:     livestream.SessionTitle,
+		Category:  category,
+		Viewers:   humanize.NumberInt64In(lang, livestream.ViewerCount),
+	}
+
+	var published string
+	if startTime, err := parseTime(livestream.StartTime); err == nil {
+		data.Uptime = humanize.Duration(time.Since(startTime))
+		published = startTime.Format(time.RFC3339)
+	}
+
+	var tooltip bytes.Buffer
+	if err := kickChannelLiveTooltip.Execute(&tooltip, lang, data); err != nil {
+		return resolver.Errorf("Kick channel template error: %s", err)
+	}
+
+	thumbnail := channel.User.ProfilePic
+	if livestream.Thumbnail != nil && livestream.Thumbnail.URL != "" {
+		thumbnail = livestream.Thumbnail.URL
+	}
+
+	return &resolver.Response{
+		Status:    200,
+		Tooltip:   url.PathEscape(tooltip.String()),
+		Thumbnail: thumbnail,
+		Data: &resolver.ResponseData{
+			Kind:        resolver.DataKindLivestream,
+			Title:       livestream.SessionTitle,
+			Description: channel.User.Bio,
+			Author:      data.Name,
+			Views:       uint64(max(livestream.ViewerCount, 0)),
+			Followers:   uint64(max(channel.FollowersCount, 0)),
+			Published:   published,
+			Live:        true,
+			Fields: map[string]string{
+				"category": category,
+			},
+		},
+	}, cache.NoSpecialDur, nil
+}
@@ -0,0 +1,78 @@
+package kick
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+var channelRegex = regexp.MustCompile(`^\/([a-zA-Z0-9_-]+)\/?$`)
+var ignoredChannels = []string{
+	"browse",
+	"categories",
+	"category",
+	"following",
+	"search",
+	"video",
+	"videos",
+	"clips",
+	"dashboard",
+	"settings",
+	"subscriptions",
+	"community-guidelines",
+	"dmca-policy",
+	"privacy-policy",
+	"terms-of-service",
+}
+
+type ChannelResolver struct {
+	channelCache cache.Cache
+}
+
+func (r *ChannelResolver) Check(ctx context.Context, url *url.URL) (context.Context, bool) {
+	if !utils.IsDomains(url, domains) {
+		return ctx, false
+	}
+
+	channelMatch := channelRegex.FindStringSubmatch(url.Path)
+	if len(channelMatch) != 2 {
+		return ctx, false
+	}
+
+	if slices.Contains(ignoredChannels, strings.ToLower(channelMatch[1])) {
+		return ctx, false
+	}
+
+	return ctx, true
+}
+
+func (r *ChannelResolver) Run(ctx context.Context, url *url.URL, req *http.Request) (*cache.Response, error) {
+	return r.channelCache.Get(ctx, strings.ToLower(strings.Trim(url.Path, "/")), req)
+}
+
+func (r *ChannelResolver) Name() string {
+	return "kick:channel"
+}
+
+func NewChannelResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, apiURL *url.URL, upstream *resolver.Upstream) *ChannelResolver {
+	channelLoader := &ChannelLoader{
+		apiURL:   apiURL,
+		upstream: upstream,
+	}
+
+	r := &ChannelResolver{
+		channelCache: cache.NewDefaultCache(ctx, cfg, pool, cache.NewLocalizedKeyProvider("kick:channel"),
+			resolver.NewResponseMarshaller(channelLoader), cfg.KickChannelCacheDuration),
+	}
+
+	return r
+}
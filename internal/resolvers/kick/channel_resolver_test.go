@@ -0,0 +1,153 @@
+package kick
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+)
+
+// runResolver runs the resolver and decodes the response it returned
+func TestChannelResolver(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		CacheBackend: cache.BackendMemory,
+	}
+	ts := testServer()
+	defer ts.Close()
+	apiURL := utils.MustParseURL(ts.URL + "/api/")
+
+	channelResolver := NewChannelResolver(ctx, cfg, nil, apiURL, resolver.NewUpstream(cfg, "kick"))
+
+	c.Assert(channelResolver, qt.IsNotNil)
+
+	c.Run("Name", func(c *qt.C) {
+		c.Assert(channelResolver.Name(), qt.Equals, "kick:channel")
+	})
+
+	c.Run("Check", func(c *qt.C) {
+		type checkTest struct {
+			input    *url.URL
+			expected bool
+		}
+
+		tests := []checkTest{
+			{utils.MustParseURL("https://kick.com/xqc"), true},
+			{utils.MustParseURL("https://www.kick.com/xqc/"), true},
+			{utils.MustParseURL("https://KICK.com/some-streamer_123"), true},
+			{utils.MustParseURL("https://kick.com/browse"), false},
+			{utils.MustParseURL("https://kick.com/Categories"), false},
+			{utils.MustParseURL("https://kick.com/xqc/videos"), false},
+			{utils.MustParseURL("https://kick.com/"), false},
+			{utils.MustParseURL("https://notkick.com/xqc"), false},
+		}
+
+		for _, test := range tests {
+			c.Run(test.input.String(), func(c *qt.C) {
+				_, output := channelResolver.Check(ctx, test.input)
+				c.Assert(output, qt.Equals, test.expected)
+			})
+		}
+	})
+
+	c.Run("Run", func(c *qt.C) {
+		c.Run("Live channel", func(c *qt.C) {
+			data := runResolver(c, ctx, channelResolver, "https://kick.com/xQc")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "https://images.kick.com/video_thumbnails/xqc/thumbnail.webp")
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Matches, `<div style="text-align: left;">`+
+				`<b>xQc - Kick</b><br>`+
+				`&lt;3 juicers<br>`+
+				`1.2M followers<br>`+
+				`<b>URL:</b> https://kick.com/xqc<br>`+
+				`<b><span style="color: #53fc18;">Live</span></b><br>`+
+				`<b>Title</b>: JUICED<br>`+
+				`<b>Category</b>: Just Chatting<br>`+
+				`<b>Viewers</b>: 45,678<br>`+
+				`<b>Uptime</b>: 01:00:0\d`+
+				`</div>`)
+
+			c.Assert(data.Data, qt.DeepEquals, &resolver.ResponseData{
+				Kind:        resolver.DataKindLivestream,
+				Title:       "JUICED",
+				Description: "<3 juicers",
+				Author:      "xQc",
+				Views:       45678,
+				Followers:   1234567,
+				Published:   liveStartTime.Format(time.RFC3339),
+				Live:        true,
+				Fields: map[string]string{
+					"category": "Just Chatting",
+				},
+			})
+		})
+
+		c.Run("Offline channel", func(c *qt.C) {
+			data := runResolver(c, ctx, channelResolver, "https://kick.com/forsen")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "https://files.kick.com/images/user/1/profile_image/forsen.webp")
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Equals, `<div style="text-align: left;">`+
+				`<b>Forsen - Kick</b><br>`+
+				`12 followers<br>`+
+				`<b>URL:</b> https://kick.com/forsen`+
+				`</div>`)
+
+			c.Assert(data.Data, qt.DeepEquals, &resolver.ResponseData{
+				Kind:      resolver.DataKindUser,
+				Title:     "Forsen",
+				Followers: 12,
+			})
+		})
+
+		c.Run("German", func(c *qt.C) {
+			data := runResolver(c, i18n.OnContext(ctx, i18n.German), channelResolver, "https://kick.com/xqc")
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Contains, `1,2 Mio. Follower`)
+			c.Assert(tooltip, qt.Contains, `<b>Kategorie</b>: Just Chatting`)
+			c.Assert(tooltip, qt.Contains, `<b>Zuschauer</b>: 45.678`)
+		})
+
+		c.Run("Not found", func(c *qt.C) {
+			data := runResolver(c, ctx, channelResolver, "https://kick.com/404")
+			c.Assert(data.Status, qt.Equals, http.StatusNotFound)
+			c.Assert(data.Message, qt.Equals, "No Kick channel with this name found")
+		})
+
+		c.Run("Bad JSON", func(c *qt.C) {
+			data := runResolver(c, ctx, channelResolver, "https://kick.com/bad")
+			c.Assert(data.Status, qt.Equals, http.StatusInternalServerError)
+			c.Assert(data.Message, qt.Contains, "Kick API unmarshal error")
+		})
+
+		c.Run("Bad status", func(c *qt.C) {
+			data := runResolver(c, ctx, channelResolver, "https://kick.com/forbidden")
+			c.Assert(data.Status, qt.Equals, http.StatusInternalServerError)
+			c.Assert(data.Message, qt.Equals, "Kick API returned status 403")
+		})
+
+		c.Run("Rate limited", func(c *qt.C) {
+			_, err := channelResolver.Run(ctx, utils.MustParseURL("https://kick.com/ratelimited"), nil)
+			c.Assert(errors.Is(err, resolver.ErrUpstreamUnavailable), qt.IsTrue)
+		})
+	})
+}
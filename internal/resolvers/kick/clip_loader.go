@@ -0,0 +1,95 @@
+package kick
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+)
+
+type kickClipTooltipData struct {
+	Title        string
+	AuthorName   string
+	ChannelName  string
+	Category     string
+	Duration     string
+	CreationDate string
+	Views        string
+}
+
+type ClipLoader struct {
+	apiURL   *url.URL
+	upstream *resolver.Upstream
+}
+
+func (l *ClipLoader) Load(ctx context.Context, clipID string, r *http.Request) (*resolver.Response, time.Duration, error) {
+	log := logger.FromContext(ctx)
+	lang := i18n.FromContext(ctx)
+
+	log.Debugw("[Kick] Get clip",
+		"clipID", clipID,
+	)
+
+	var clipResponse kickClipResponse
+	apiURL := buildURL(l.apiURL, "v2/clips/"+url.PathEscape(clipID))
+	if response, cacheDuration, err := resolver.RequestJSON(ctx, resolver.JSONRequest{
+		Upstream: l.upstream,
+		API:      "Kick API",
+		URL:      apiURL,
+		NotFound: noKickClipWithThisIDFound,
+	}, &clipResponse); response != nil || err != nil {
+		return response, cacheDuration, err
+	}
+
+	if clipResponse.Clip == nil {
+		return noKickClipWithThisIDFound, cache.NoSpecialDur, nil
+	}
+
+	clip := clipResponse.Clip
+
+	var creationDate, published string
+	if createdAt, err := parseTime(clip.CreatedAt); err == nil {
+		creationDate = humanize.CreationDateIn(lang, createdAt)
+		published = createdAt.Format(time.RFC3339)
+	}
+
+	data := kickClipTooltipData{
+		Title:        clip.Title,
+		AuthorName:   clip.Creator.Username,
+		ChannelName:  clip.Channel.Username,
+		Category:     clip.Category.Name,
+		Duration:     humanize.DurationSeconds(time.Duration(clip.Duration) * time.Second),
+		CreationDate: creationDate,
+		Views:        humanize.NumberInt64In(lang, clip.Views),
+	}
+
+	var tooltip bytes.Buffer
+	if err := kickClipTooltip.Execute(&tooltip, lang, data); err != nil {
+		return resolver.Errorf("Kick clip template error: %s", err)
+	}
+
+	return &resolver.Response{
+		Status:    200,
+		Tooltip:   url.PathEscape(tooltip.String()),
+		Thumbnail: clip.ThumbnailURL,
+		Data: &resolver.ResponseData{
+			Kind:      resolver.DataKindClip,
+			Title:     clip.Title,
+			Author:    clip.Channel.Username,
+			Duration:  clip.Duration,
+			Views:     uint64(max(clip.Views, 0)),
+			Published: published,
+			Fields: map[string]string{
+				"clipped_by": clip.Creator.Username,
+				"category":   clip.Category.Name,
+			},
+		},
+	}, cache.NoSpecialDur, nil
+}
@@ -0,0 +1,77 @@
+package kick
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+var (
+	clipIDRegex = regexp.MustCompile(`^clip_[a-zA-Z0-9]+$`)
+	// Matches https://kick.com/ChannelName/clips/clip_ID
+	clipPathRegex = regexp.MustCompile(`^\/[a-zA-Z0-9_-]+\/clips\/(clip_[a-zA-Z0-9]+)\/?$`)
+)
+
+// parseClipID returns the ID of the clip the URL links to, or an empty string if it doesn't link to a clip
+func parseClipID(url *url.URL) string {
+	if !utils.IsDomains(url, domains) {
+		return ""
+	}
+
+	if matches := clipPathRegex.FindStringSubmatch(url.Path); len(matches) == 2 {
+		return matches[1]
+	}
+
+	// Clips opened on a channel page look like https://kick.com/ChannelName?clip=clip_ID
+	if channelRegex.MatchString(url.Path) {
+		if clipID := url.Query().Get("clip"); clipIDRegex.MatchString(clipID) {
+			return clipID
+		}
+	}
+
+	return ""
+}
+
+type ClipResolver struct {
+	clipCache cache.Cache
+}
+
+func (r *ClipResolver) Check(ctx context.Context, url *url.URL) (context.Context, bool) {
+	return ctx, parseClipID(url) != ""
+}
+
+func (r *ClipResolver) Run(ctx context.Context, url *url.URL, req *http.Request) (*cache.Response, error) {
+	clipID := parseClipID(url)
+	if clipID == "" {
+		return nil, errInvalidKickClip
+	}
+
+	return r.clipCache.Get(ctx, clipID, req)
+}
+
+func (r *ClipResolver) Name() string {
+	return "kick:clip"
+}
+
+func NewClipResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, apiURL *url.URL, upstream *resolver.Upstream) *ClipResolver {
+	clipLoader := &ClipLoader{
+		apiURL:   apiURL,
+		upstream: upstream,
+	}
+
+	r := &ClipResolver{
+		clipCache: cache.NewDefaultCache(
+			ctx, cfg, pool, cache.NewLocalizedKeyProvider("kick:clip"),
+			resolver.NewResponseMarshaller(clipLoader), cfg.KickClipCacheDuration,
+		),
+	}
+
+	return r
+}
@@ -0,0 +1,110 @@
+package kick
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+)
+
+func TestClipResolver(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		CacheBackend: cache.BackendMemory,
+	}
+	ts := testServer()
+	defer ts.Close()
+	apiURL := utils.MustParseURL(ts.URL + "/api/")
+
+	clipResolver := NewClipResolver(ctx, cfg, nil, apiURL, resolver.NewUpstream(cfg, "kick"))
+
+	c.Assert(clipResolver, qt.IsNotNil)
+
+	c.Run("Name", func(c *qt.C) {
+		c.Assert(clipResolver.Name(), qt.Equals, "kick:clip")
+	})
+
+	c.Run("Check", func(c *qt.C) {
+		type checkTest struct {
+			input    *url.URL
+			expected bool
+		}
+
+		tests := []checkTest{
+			{utils.MustParseURL("https://kick.com/xqc/clips/clip_01HTEST"), true},
+			{utils.MustParseURL("https://www.kick.com/xqc/clips/clip_01HTEST/"), true},
+			{utils.MustParseURL("https://kick.com/xqc?clip=clip_01HTEST"), true},
+			{utils.MustParseURL("https://kick.com/xqc?clip=01HTEST"), false},
+			{utils.MustParseURL("https://kick.com/xqc"), false},
+			{utils.MustParseURL("https://kick.com/xqc/clips"), false},
+			{utils.MustParseURL("https://kick.com/xqc/clips/01HTEST"), false},
+			{utils.MustParseURL("https://notkick.com/xqc/clips/clip_01HTEST"), false},
+		}
+
+		for _, test := range tests {
+			c.Run(test.input.String(), func(c *qt.C) {
+				_, output := clipResolver.Check(ctx, test.input)
+				c.Assert(output, qt.Equals, test.expected)
+			})
+		}
+	})
+
+	c.Run("Run", func(c *qt.C) {
+		c.Run("Clip", func(c *qt.C) {
+			data := runResolver(c, ctx, clipResolver, "https://kick.com/xqc?clip=clip_01HTEST")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "https://clips.kick.com/clips/clip_01HTEST/thumbnail.png")
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Equals, `<div style="text-align: left;">`+
+				`<b>he did it</b><hr>`+
+				`<b>Clipped by:</b> pajlada<br>`+
+				`<b>Channel:</b> xQc<br>`+
+				`<b>Category:</b> Minecraft<br>`+
+				`<b>Duration:</b> 30s<br>`+
+				`<b>Created:</b> 01 Mar 2024<br>`+
+				`<b>Views:</b> 1,337`+
+				`</div>`)
+
+			c.Assert(data.Data, qt.DeepEquals, &resolver.ResponseData{
+				Kind:      resolver.DataKindClip,
+				Title:     "he did it",
+				Author:    "xQc",
+				Duration:  30,
+				Views:     1337,
+				Published: "2024-03-01T12:00:00Z",
+				Fields: map[string]string{
+					"clipped_by": "pajlada",
+					"category":   "Minecraft",
+				},
+			})
+		})
+
+		c.Run("Not found", func(c *qt.C) {
+			data := runResolver(c, ctx, clipResolver, "https://kick.com/xqc/clips/clip_404")
+			c.Assert(data.Status, qt.Equals, http.StatusNotFound)
+			c.Assert(data.Message, qt.Equals, "No Kick clip with this ID found")
+		})
+
+		c.Run("Missing clip", func(c *qt.C) {
+			data := runResolver(c, ctx, clipResolver, "https://kick.com/xqc/clips/clip_01HNULL")
+			c.Assert(data.Status, qt.Equals, http.StatusNotFound)
+		})
+
+		c.Run("Invalid link", func(c *qt.C) {
+			response, err := clipResolver.Run(ctx, utils.MustParseURL("https://kick.com/xqc"), nil)
+			c.Assert(err, qt.Equals, errInvalidKickClip)
+			c.Assert(response, qt.IsNil)
+		})
+	})
+}
@@ -0,0 +1,75 @@
+package kick
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+	"github.com/go-chi/chi/v5"
+)
+
+var (
+	channelData = map[string][]byte{}
+	clipData    = map[string][]byte{}
+	videoData   = map[string][]byte{}
+
+	// The livestream of the live channel started an hour ago
+	liveStartTime = time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+)
+
+func init() {
+	channelData["bad"] = []byte(`xD`)
+	channelData["xqc"] = []byte(fmt.Sprintf(`{"id":668,"slug":"xqc","followers_count":1234567,"user":{"username":"xQc","bio":"<3 juicers","profile_pic":"https://files.kick.com/images/user/676/profile_image/xqc.webp"},"livestream":{"id":1,"session_title":"JUICED","is_live":true,"start_time":"%s","viewer_count":45678,"thumbnail":{"url":"https://images.kick.com/video_thumbnails/xqc/thumbnail.webp"},"categories":[{"id":15,"name":"Just Chatting"}]}}`, liveStartTime.Format(livestreamTimeLayout)))
+	channelData["forsen"] = []byte(`{"id":1,"slug":"forsen","followers_count":12,"user":{"username":"Forsen","bio":"","profile_pic":"https://files.kick.com/images/user/1/profile_image/forsen.webp"},"livestream":null}`)
+
+	clipData["clip_01HTEST"] = []byte(`{"clip":{"id":"clip_01HTEST","title":"he did it","thumbnail_url":"https://clips.kick.com/clips/clip_01HTEST/thumbnail.png","duration":30,"views":1337,"created_at":"2024-03-01T12:00:00.000000Z","category":{"name":"Minecraft"},"creator":{"username":"pajlada"},"channel":{"username":"xQc","slug":"xqc"}}}`)
+	clipData["clip_01HNULL"] = []byte(`{"clip":null}`)
+
+	videoData["8e8d8d4a-5b5e-4a40-9f3a-0a6c3b1e0b2f"] = []byte(`{"id":1,"uuid":"8e8d8d4a-5b5e-4a40-9f3a-0a6c3b1e0b2f","views":4200,"created_at":"2024-03-02T00:00:00.000000Z","livestream":{"session_title":"VOD title","duration":5415000,"thumbnail":"https://images.kick.com/video_thumbnails/xqc/vod.webp","start_time":"2024-03-01 22:30:00","categories":[{"name":"Just Chatting"}],"channel":{"slug":"xqc","user":{"username":"xQc"}}}}`)
+}
+
+func testServer() *httptest.Server {
+	r := chi.NewRouter()
+	serve := func(data map[string][]byte, param string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			id := chi.URLParam(r, param)
+
+			w.Header().Set("Content-Type", "application/json")
+
+			switch id {
+			case "ratelimited":
+				http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			case "forbidden":
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			default:
+				if response, ok := data[id]; ok {
+					w.Write(response)
+				} else {
+					http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+				}
+			}
+		}
+	}
+
+	r.Get("/api/v2/channels/{slug}", serve(channelData, "slug"))
+	r.Get("/api/v2/clips/{clipID}", serve(clipData, "clipID"))
+	r.Get("/api/v1/video/{videoID}", serve(videoData, "videoID"))
+
+	return httptest.NewServer(r)
+}
+
+// runResolver runs the resolver and decodes the response it returned
+func runResolver(c *qt.C, ctx context.Context, r resolver.Resolver, link string) resolver.Response {
+	response, err := r.Run(ctx, utils.MustParseURL(link), nil)
+	c.Assert(err, qt.IsNil)
+
+	var data resolver.Response
+	c.Assert(json.Unmarshal(response.Payload, &data), qt.IsNil)
+	return data
+}
@@ -0,0 +1,77 @@
+package kick
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+const (
+	kickChannelTooltipString = `<div style="text-align: left;">` +
+		`<b>{{.Name}} - Kick</b><br>` +
+		`{{ if .Bio }}{{.Bio}}<br>{{ end }}` +
+		`{{t "%s followers" .Followers}}<br>` +
+		`<b>{{t "URL"}}:</b> {{.URL}}` +
+		`</div>`
+
+	kickChannelLiveTooltipString = `<div style="text-align: left;">` +
+		`<b>{{.Name}} - Kick</b><br>` +
+		`{{ if .Bio }}{{.Bio}}<br>{{ end }}` +
+		`{{t "%s followers" .Followers}}<br>` +
+		`<b>{{t "URL"}}:</b> {{.URL}}<br>` +
+		`<b><span style="color: #53fc18;">{{t "Live"}}</span></b><br>` +
+		`<b>{{t "Title"}}</b>: {{.Title}}<br>` +
+		`{{ if .Category }}<b>{{t "Category"}}</b>: {{.Category}}<br>{{ end }}` +
+		`<b>{{t "Viewers"}}</b>: {{.Viewers}}<br>` +
+		`<b>{{t "Uptime"}}</b>: {{.Uptime}}` +
+		`</div>`
+
+	kickClipTooltipString = `<div style="text-align: left;">` +
+		`<b>{{.Title}}</b><hr>` +
+		`<b>{{t "Clipped by"}}:</b> {{.AuthorName}}<br>` +
+		`<b>{{t "Channel"}}:</b> {{.ChannelName}}<br>` +
+		`{{ if .Category }}<b>{{t "Category"}}:</b> {{.Category}}<br>{{ end }}` +
+		`<b>{{t "Duration"}}:</b> {{.Duration}}<br>` +
+		`<b>{{t "Created"}}:</b> {{.CreationDate}}<br>` +
+		`<b>{{t "Views"}}:</b> {{.Views}}` +
+		`</div>`
+
+	kickVideoTooltipString = `<div style="text-align: left;">` +
+		`<b>{{.Title}}</b><hr>` +
+		`<b>{{t "Channel"}}:</b> {{.ChannelName}}<br>` +
+		`{{ if .Category }}<b>{{t "Category"}}:</b> {{.Category}}<br>{{ end }}` +
+		`<b>{{t "Duration"}}:</b> {{.Duration}}<br>` +
+		`<b>{{t "Created"}}:</b> {{.CreationDate}}<br>` +
+		`<b>{{t "Views"}}:</b> {{.Views}}` +
+		`</div>`
+)
+
+var (
+	errInvalidKickClip  = errors.New("invalid Kick clip link")
+	errInvalidKickVideo = errors.New("invalid Kick video link")
+
+	kickChannelTooltip     = i18n.MustTemplate("kickChannelTooltip", kickChannelTooltipString)
+	kickChannelLiveTooltip = i18n.MustTemplate("kickChannelLiveTooltip", kickChannelLiveTooltipString)
+	kickClipTooltip        = i18n.MustTemplate("kickClipTooltip", kickClipTooltipString)
+	kickVideoTooltip       = i18n.MustTemplate("kickVideoTooltip", kickVideoTooltipString)
+
+	domains = map[string]struct{}{
+		"kick.com":     {},
+		"www.kick.com": {},
+	}
+)
+
+func Initialize(ctx context.Context, cfg config.APIConfig, pool db.Pool, resolvers *[]resolver.Resolver) {
+	apiURL := utils.MustParseURL("https://kick.com/api/")
+	upstream := resolver.NewUpstream(cfg, "kick")
+
+	// Clip links can also look like channel links, so clips need to be checked first
+	*resolvers = append(*resolvers, NewClipResolver(ctx, cfg, pool, apiURL, upstream))
+	*resolvers = append(*resolvers, NewVideoResolver(ctx, cfg, pool, apiURL, upstream))
+	*resolvers = append(*resolvers, NewChannelResolver(ctx, cfg, pool, apiURL, upstream))
+}
@@ -0,0 +1,78 @@
+package kick
+
+type kickCategory struct {
+	Name string `json:"name"`
+}
+
+type kickUser struct {
+	Username   string `json:"username"`
+	Bio        string `json:"bio"`
+	ProfilePic string `json:"profile_pic"`
+}
+
+type kickLivestream struct {
+	SessionTitle string `json:"session_title"`
+	IsLive       bool   `json:"is_live"`
+	// Formatted as "2006-01-02 15:04:05" in UTC
+	StartTime   string `json:"start_time"`
+	ViewerCount int64  `json:"viewer_count"`
+	Thumbnail   *struct {
+		URL string `json:"url"`
+	} `json:"thumbnail"`
+	Categories []kickCategory `json:"categories"`
+}
+
+// kickChannel is returned by the /v2/channels/{slug} endpoint
+type kickChannel struct {
+	Slug           string          `json:"slug"`
+	FollowersCount int64           `json:"followers_count"`
+	User           kickUser        `json:"user"`
+	Livestream     *kickLivestream `json:"livestream"`
+}
+
+// kickClipResponse is returned by the /v2/clips/{id} endpoint
+type kickClipResponse struct {
+	Clip *struct {
+		ID           string `json:"id"`
+		Title        string `json:"title"`
+		ThumbnailURL string `json:"thumbnail_url"`
+		// In seconds
+		Duration  int64        `json:"duration"`
+		Views     int64        `json:"views"`
+		CreatedAt string       `json:"created_at"`
+		Category  kickCategory `json:"category"`
+		Creator   kickUser     `json:"creator"`
+		Channel   struct {
+			Username string `json:"username"`
+			Slug     string `json:"slug"`
+		} `json:"channel"`
+	} `json:"clip"`
+}
+
+// kickVideo is returned by the /v1/video/{uuid} endpoint
+type kickVideo struct {
+	UUID      string `json:"uuid"`
+	Views     int64  `json:"views"`
+	CreatedAt string `json:"created_at"`
+
+	Livestream struct {
+		SessionTitle string `json:"session_title"`
+		// In milliseconds
+		Duration   int64          `json:"duration"`
+		Thumbnail  string         `json:"thumbnail"`
+		StartTime  string         `json:"start_time"`
+		Categories []kickCategory `json:"categories"`
+		Channel    struct {
+			Slug string   `json:"slug"`
+			User kickUser `json:"user"`
+		} `json:"channel"`
+	} `json:"livestream"`
+}
+
+func firstCategory(categories []kickCategory) string {
+	if len(categories) == 0 {
+		return ""
+	}
+
+	return categories[0].Name
+}
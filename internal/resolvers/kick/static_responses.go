@@ -0,0 +1,24 @@
+package kick
+
+import (
+	"net/http"
+
+	"github.com/Chatterino/api/pkg/resolver"
+)
+
+var (
+	noKickChannelWithThisNameFound = &resolver.Response{
+		Status:  http.StatusNotFound,
+		Message: "No Kick channel with this name found",
+	}
+
+	noKickClipWithThisIDFound = &resolver.Response{
+		Status:  http.StatusNotFound,
+		Message: "No Kick clip with this ID found",
+	}
+
+	noKickVideoWithThisIDFound = &resolver.Response{
+		Status:  http.StatusNotFound,
+		Message: "No Kick video with this ID found",
+	}
+)
@@ -0,0 +1,95 @@
+package kick
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+)
+
+type kickVideoTooltipData struct {
+	Title        string
+	ChannelName  string
+	Category     string
+	Duration     string
+	CreationDate string
+	Views        string
+}
+
+type VideoLoader struct {
+	apiURL   *url.URL
+	upstream *resolver.Upstream
+}
+
+func (l *VideoLoader) Load(ctx context.Context, videoID string, r *http.Request) (*resolver.Response, time.Duration, error) {
+	log := logger.FromContext(ctx)
+	lang := i18n.FromContext(ctx)
+
+	log.Debugw("[Kick] Get video",
+		"videoID", videoID,
+	)
+
+	var video kickVideo
+	apiURL := buildURL(l.apiURL, "v1/video/"+url.PathEscape(videoID))
+	if response, cacheDuration, err := resolver.RequestJSON(ctx, resolver.JSONRequest{
+		Upstream: l.upstream,
+		API:      "Kick API",
+		URL:      apiURL,
+		NotFound: noKickVideoWithThisIDFound,
+	}, &video); response != nil || err != nil {
+		return response, cacheDuration, err
+	}
+
+	livestream := video.Livestream
+	duration := time.Duration(livestream.Duration) * time.Millisecond
+	category := firstCategory(livestream.Categories)
+
+	// The video is created when the livestream ends, so the start of the livestream is used if it's known
+	var creationDate, published string
+	createdAt, err := parseTime(livestream.StartTime)
+	if err != nil {
+		createdAt, err = parseTime(video.CreatedAt)
+	}
+	if err == nil {
+		creationDate = humanize.CreationDateIn(lang, createdAt)
+		published = createdAt.Format(time.RFC3339)
+	}
+
+	data := kickVideoTooltipData{
+		Title:        livestream.SessionTitle,
+		ChannelName:  livestream.Channel.User.Username,
+		Category:     category,
+		Duration:     humanize.Duration(duration),
+		CreationDate: creationDate,
+		Views:        humanize.NumberInt64In(lang, video.Views),
+	}
+
+	var tooltip bytes.Buffer
+	if err := kickVideoTooltip.Execute(&tooltip, lang, data); err != nil {
+		return resolver.Errorf("Kick video template error: %s", err)
+	}
+
+	return &resolver.Response{
+		Status:    200,
+		Tooltip:   url.PathEscape(tooltip.String()),
+		Thumbnail: livestream.Thumbnail,
+		Data: &resolver.ResponseData{
+			Kind:      resolver.DataKindVideo,
+			Title:     livestream.SessionTitle,
+			Author:    livestream.Channel.User.Username,
+			Duration:  int64(duration.Seconds()),
+			Views:     uint64(max(video.Views, 0)),
+			Published: published,
+			Fields: map[string]string{
+				"category": category,
+			},
+		},
+	}, cache.NoSpecialDur, nil
+}
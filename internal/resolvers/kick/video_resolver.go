@@ -0,0 +1,59 @@
+package kick
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+// Matches https://kick.com/video/UUID and https://kick.com/ChannelName/videos/UUID
+var videoPathRegex = regexp.MustCompile(`^\/(?:video|[a-zA-Z0-9_-]+\/videos)\/([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})\/?$`)
+
+type VideoResolver struct {
+	videoCache cache.Cache
+}
+
+func (r *VideoResolver) Check(ctx context.Context, url *url.URL) (context.Context, bool) {
+	if !utils.IsDomains(url, domains) {
+		return ctx, false
+	}
+
+	return ctx, videoPathRegex.MatchString(url.Path)
+}
+
+func (r *VideoResolver) Run(ctx context.Context, url *url.URL, req *http.Request) (*cache.Response, error) {
+	matches := videoPathRegex.FindStringSubmatch(url.Path)
+	if len(matches) != 2 {
+		return nil, errInvalidKickVideo
+	}
+
+	return r.videoCache.Get(ctx, strings.ToLower(matches[1]), req)
+}
+
+func (r *VideoResolver) Name() string {
+	return "kick:video"
+}
+
+func NewVideoResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, apiURL *url.URL, upstream *resolver.Upstream) *VideoResolver {
+	videoLoader := &VideoLoader{
+		apiURL:   apiURL,
+		upstream: upstream,
+	}
+
+	r := &VideoResolver{
+		videoCache: cache.NewDefaultCache(
+			ctx, cfg, pool, cache.NewLocalizedKeyProvider("kick:video"),
+			resolver.NewResponseMarshaller(videoLoader), cfg.KickVideoCacheDuration,
+		),
+	}
+
+	return r
+}
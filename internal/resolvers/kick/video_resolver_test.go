@@ -0,0 +1,96 @@
+package kick
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+)
+
+func TestVideoResolver(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		CacheBackend: cache.BackendMemory,
+	}
+	ts := testServer()
+	defer ts.Close()
+	apiURL := utils.MustParseURL(ts.URL + "/api/")
+
+	videoResolver := NewVideoResolver(ctx, cfg, nil, apiURL, resolver.NewUpstream(cfg, "kick"))
+
+	c.Assert(videoResolver, qt.IsNotNil)
+
+	c.Run("Name", func(c *qt.C) {
+		c.Assert(videoResolver.Name(), qt.Equals, "kick:video")
+	})
+
+	c.Run("Check", func(c *qt.C) {
+		type checkTest struct {
+			input    *url.URL
+			expected bool
+		}
+
+		tests := []checkTest{
+			{utils.MustParseURL("https://kick.com/video/8e8d8d4a-5b5e-4a40-9f3a-0a6c3b1e0b2f"), true},
+			{utils.MustParseURL("https://kick.com/xqc/videos/8e8d8d4a-5b5e-4a40-9f3a-0a6c3b1e0b2f"), true},
+			{utils.MustParseURL("https://www.kick.com/video/8E8D8D4A-5B5E-4A40-9F3A-0A6C3B1E0B2F/"), true},
+			{utils.MustParseURL("https://kick.com/video/123"), false},
+			{utils.MustParseURL("https://kick.com/xqc/videos"), false},
+			{utils.MustParseURL("https://kick.com/xqc/video/8e8d8d4a-5b5e-4a40-9f3a-0a6c3b1e0b2f"), false},
+			{utils.MustParseURL("https://notkick.com/video/8e8d8d4a-5b5e-4a40-9f3a-0a6c3b1e0b2f"), false},
+		}
+
+		for _, test := range tests {
+			c.Run(test.input.String(), func(c *qt.C) {
+				_, output := videoResolver.Check(ctx, test.input)
+				c.Assert(output, qt.Equals, test.expected)
+			})
+		}
+	})
+
+	c.Run("Run", func(c *qt.C) {
+		c.Run("Video", func(c *qt.C) {
+			data := runResolver(c, ctx, videoResolver, "https://kick.com/xqc/videos/8E8D8D4A-5B5E-4A40-9F3A-0A6C3B1E0B2F")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "https://images.kick.com/video_thumbnails/xqc/vod.webp")
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Equals, `<div style="text-align: left;">`+
+				`<b>VOD title</b><hr>`+
+				`<b>Channel:</b> xQc<br>`+
+				`<b>Category:</b> Just Chatting<br>`+
+				`<b>Duration:</b> 01:30:15<br>`+
+				`<b>Created:</b> 01 Mar 2024<br>`+
+				`<b>Views:</b> 4,200`+
+				`</div>`)
+
+			c.Assert(data.Data, qt.DeepEquals, &resolver.ResponseData{
+				Kind:      resolver.DataKindVideo,
+				Title:     "VOD title",
+				Author:    "xQc",
+				Duration:  5415,
+				Views:     4200,
+				Published: "2024-03-01T22:30:00Z",
+				Fields: map[string]string{
+					"category": "Just Chatting",
+				},
+			})
+		})
+
+		c.Run("Not found", func(c *qt.C) {
+			data := runResolver(c, ctx, videoResolver, "https://kick.com/video/00000000-0000-0000-0000-000000000000")
+			c.Assert(data.Status, qt.Equals, http.StatusNotFound)
+			c.Assert(data.Message, qt.Equals, "No Kick video with this ID found")
+		})
+	})
+}
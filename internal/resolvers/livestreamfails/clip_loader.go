@@ -12,6 +12,7 @@ import (
 
 	"github.com/Chatterino/api/pkg/cache"
 	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -66,6 +67,7 @@ type ClipLoader struct {
 }
 
 func (l *ClipLoader) Load(ctx context.Context, clipID string, r *http.Request) (*resolver.Response, time.Duration, error) {
+	lang := i18n.FromContext(ctx)
 	apiURL := fmt.Sprintf(l.apiURLFormat, clipID)
 
 	// Execute Livestreamfails API request
@@ -97,15 +99,15 @@ func (l *ClipLoader) Load(ctx context.Context, clipID string, r *http.Request) (
 		NSFW:         clipData.IsNSFW,
 		Title:        clipData.Label,
 		Category:     clipData.Category.Label,
-		RedditScore:  humanize.Number(uint64(clipData.RedditScore)),
+		RedditScore:  humanize.NumberIn(lang, uint64(clipData.RedditScore)),
 		Platform:     caser.String(strings.ToLower(clipData.SourcePlatform)),
 		StreamerName: clipData.Streamer.Label,
-		CreationDate: humanize.CreationDate(clipData.CreatedAt),
+		CreationDate: humanize.CreationDateIn(lang, clipData.CreatedAt),
 	}
 
 	// Build a tooltip using the tooltip template (see tooltipTemplate) with the data we massaged above
 	var tooltip bytes.Buffer
-	if err := livestreamfailsClipsTemplate.Execute(&tooltip, data); err != nil {
+	if err := livestreamfailsClipsTemplate.Execute(&tooltip, lang, data); err != nil {
 		return resolver.Errorf("Livestreamfails template error: %s", err)
 	}
 
@@ -50,8 +50,8 @@ func NewClipResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, ap
 	}
 
 	r := &ClipResolver{
-		clipCache: cache.NewPostgreSQLCache(
-			ctx, cfg, pool, cache.NewPrefixKeyProvider("livestreamfails:clip"),
+		clipCache: cache.NewDefaultCache(
+			ctx, cfg, pool, cache.NewLocalizedKeyProvider("livestreamfails:clip"),
 			resolver.NewResponseMarshaller(clipLoader), cfg.LivestreamfailsClipCacheDuration),
 	}
 
@@ -2,11 +2,11 @@ package livestreamfails
 
 import (
 	"context"
-	"html/template"
 	"regexp"
 
 	"github.com/Chatterino/api/internal/db"
 	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 )
 
@@ -16,16 +16,16 @@ const (
 	livestreamfailsTooltipString = `<div style="text-align: left;">
 {{ if .NSFW }}<li><b><span style="color: red">NSFW</span></b></li>{{ end }}
 <b>{{.Title}}</b><hr>
-<b>Streamer:</b> {{.StreamerName}}<br>
-<b>Category:</b> {{.Category}}<br>
-<b>Platform:</b> {{.Platform}}<br>
-<b>Reddit score:</b> {{.RedditScore}}<br>
-<b>Created:</b> {{.CreationDate}}
+<b>{{t "Streamer"}}:</b> {{.StreamerName}}<br>
+<b>{{t "Category"}}:</b> {{.Category}}<br>
+<b>{{t "Platform"}}:</b> {{.Platform}}<br>
+<b>{{t "Reddit score"}}:</b> {{.RedditScore}}<br>
+<b>{{t "Created"}}:</b> {{.CreationDate}}
 </div>`
 )
 
 var (
-	livestreamfailsClipsTemplate = template.Must(template.New("livestreamfailsclipsTooltip").Parse(livestreamfailsTooltipString))
+	livestreamfailsClipsTemplate = i18n.MustTemplate("livestreamfailsclipsTooltip", livestreamfailsTooltipString)
 
 	pathRegex = regexp.MustCompile(`^/(?:clip|post)/([0-9]+)`)
 )
@@ -0,0 +1,51 @@
+package mastodon
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/PuerkitoBio/goquery"
+)
+
+// getJSON requests a JSON document of an instance and decodes it into v.
+// Returns false if the document doesn't exist or isn't valid JSON, and an error if the request failed.
+func getJSON(ctx context.Context, url string, v any) (bool, error) {
+	resp, err := resolver.RequestGET(ctx, url)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, nil
+	}
+
+	if err := resolver.DecodeJSON(resp.Body, v); err != nil {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// statusText converts the HTML content of a status to plain text, keeping its line breaks
+func statusText(content string) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return ""
+	}
+
+	// Mastodon shortens links by hiding their scheme and the end of long URLs
+	doc.Find(".invisible").Remove()
+	doc.Find(".ellipsis").AppendHtml("…")
+
+	doc.Find("br").ReplaceWithHtml("\n")
+	doc.Find("p").Each(func(i int, s *goquery.Selection) {
+		if i > 0 {
+			s.PrependHtml("\n\n")
+		}
+	})
+
+	return strings.TrimSpace(doc.Text())
+}
@@ -0,0 +1,30 @@
+package mastodon
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestStatusText(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`<p>Hello</p>`, "Hello"},
+		{`<p>First<br>line</p><p>Second paragraph</p>`, "First\nline\n\nSecond paragraph"},
+		{`<p>Escaped &lt;b&gt; &amp; <b>bold</b></p>`, "Escaped <b> & bold"},
+		{`<p><a href="https://example.com/a/very/long/path"><span class="invisible">https://</span><span class="ellipsis">example.com/a/very</span><span class="invisible">/long/path</span></a></p>`, "example.com/a/very…"},
+		{`<p><a href="https://mastodon.social/tags/forsen" class="mention hashtag">#<span>forsen</span></a></p>`, "#forsen"},
+		{`Plain text`, "Plain text"},
+		{``, ""},
+	}
+
+	for _, test := range tests {
+		c.Run(test.input, func(c *qt.C) {
+			c.Assert(statusText(test.input), qt.Equals, test.expected)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package mastodon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+	"github.com/go-chi/chi/v5"
+)
+
+var statuses = map[string][]byte{}
+
+const createdAt = "2024-03-01T12:30:00.000Z"
+
+func init() {
+	statuses["109318521938466543"] = []byte(`{"id":"109318521938466543","created_at":"` + createdAt + `","content":"<p>Hello &lt;world&gt;<br />Second line</p><p>See <a href=\"https://example.com/a/very/long/path\" rel=\"nofollow noopener\" target=\"_blank\"><span class=\"invisible\">https://</span><span class=\"ellipsis\">example.com/a/very</span><span class=\"invisible\">/long/path</span></a></p>","spoiler_text":"","sensitive":false,"replies_count":12,"reblogs_count":3456,"favourites_count":78901,"account":{"username":"Gargron","acct":"Gargron","display_name":"Eugen Rochko","avatar_static":"https://files.mastodon.social/avatar.png"},"media_attachments":[],"card":null}`)
+	statuses["2"] = []byte(`{"id":"2","created_at":"` + createdAt + `","content":"<p>Image</p>","spoiler_text":"","sensitive":false,"replies_count":0,"reblogs_count":0,"favourites_count":1,"account":{"username":"forsen","acct":"forsen@example.social","display_name":""},"media_attachments":[{"type":"image","url":"https://files.example/original/1.png","preview_url":"https://files.example/small/1.png"},{"type":"audio","url":"https://files.example/original/2.mp3","preview_url":null}]}`)
+	statuses["3"] = []byte(`{"id":"3","created_at":"` + createdAt + `","content":"<p>Gallery</p>","account":{"username":"forsen","acct":"forsen"},"media_attachments":[{"type":"image","preview_url":"TESTSERVER/media/1.png"},{"type":"video","preview_url":"TESTSERVER/media/2.png"}]}`)
+	statuses["4"] = []byte(`{"id":"4","created_at":"` + createdAt + `","content":"<p>Spoilers</p>","spoiler_text":"Movie <spoilers>","sensitive":true,"account":{"username":"forsen","acct":"forsen"},"media_attachments":[{"type":"image","preview_url":"https://files.example/small/spoiler.png"}]}`)
+	statuses["5"] = []byte(`{"id":"5","created_at":"` + createdAt + `","content":"<p>Link</p>","account":{"username":"forsen","acct":"forsen"},"media_attachments":[],"card":{"url":"https://example.com","image":"https://files.example/cards/example.png"}}`)
+	statuses["AbCdEf123"] = []byte(`{"id":"AbCdEf123","created_at":"` + createdAt + `","content":"Plain <b>text</b>","account":{"username":"lain","acct":"lain","display_name":"Lain"},"favourites_count":1}`)
+	statuses["bad"] = []byte(`xD`)
+}
+
+// testServer serves an instance of the given software. Mastodon instances are detected with
+// /api/v1/instance, other instances with NodeInfo. Servers of unknown software aren't instances.
+func testServer(software string) *httptest.Server {
+	r := chi.NewRouter()
+
+	r.Get("/api/v1/instance", func(w http.ResponseWriter, r *http.Request) {
+		if software != "mastodon" {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"uri":"` + r.Host + `","title":"Mastodon","version":"4.2.0"}`))
+	})
+	r.Get("/.well-known/nodeinfo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"links":[{"rel":"http://nodeinfo.diaspora.software/ns/schema/2.0","href":"http://` + r.Host + `/nodeinfo/2.0"}]}`))
+	})
+	r.Get("/nodeinfo/2.0", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"version":"2.0","software":{"name":"` + software + `","version":"1.0.0"}}`))
+	})
+	r.Get("/api/v1/statuses/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+
+		switch id {
+		case "401":
+			http.Error(w, `{"error":"This API requires an authenticated user"}`, http.StatusUnauthorized)
+		case "429":
+			http.Error(w, `{"error":"Too many requests"}`, http.StatusTooManyRequests)
+		case "500":
+			http.Error(w, `{"error":"Internal server error"}`, http.StatusInternalServerError)
+		default:
+			if response, ok := statuses[id]; ok {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(strings.ReplaceAll(string(response), "TESTSERVER", "http://"+r.Host)))
+			} else {
+				http.Error(w, `{"error":"Record not found"}`, http.StatusNotFound)
+			}
+		}
+	})
+	r.Get("/media/*", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte(`not an image`))
+	})
+
+	return httptest.NewServer(r)
+}
+
+// runResolver runs the resolver and decodes the response it returned
+func runResolver(c *qt.C, ctx context.Context, r resolver.Resolver, link string) resolver.Response {
+	response, err := r.Run(ctx, utils.MustParseURL(link), nil)
+	c.Assert(err, qt.IsNil)
+
+	var data resolver.Response
+	c.Assert(json.Unmarshal(response.Payload, &data), qt.IsNil)
+	return data
+}
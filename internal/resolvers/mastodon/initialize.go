@@ -0,0 +1,33 @@
+package mastodon
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+)
+
+const mastodonStatusTooltipString = `<div style="text-align: left;">` +
+	`<b>{{.Name}} (@{{.Acct}})</b>` +
+	`{{ if .ContentWarning }}<br><b>{{t "Content warning"}}:</b> {{.ContentWarning}}` +
+	`{{ else if .Text }}<br><span style="white-space: pre-wrap; word-wrap: break-word;">{{.Text}}</span>{{ end }}` +
+	`<br><span style="color: #808892;">{{t "%s likes" .Likes}}&nbsp;•&nbsp;{{t "%s boosts" .Boosts}}&nbsp;•&nbsp;{{t "%s replies" .Replies}}&nbsp;•&nbsp;{{.Timestamp}}</span>` +
+	`{{ if .NSFW }}<li><b><span style="color: red;">NSFW</span></b></li>{{ end }}` +
+	`</div>`
+
+var (
+	errInvalidMastodonStatus = errors.New("invalid Mastodon status link")
+
+	mastodonStatusTooltip = i18n.MustTemplate("mastodonStatusTooltip", mastodonStatusTooltipString)
+)
+
+// Initialize registers the Mastodon status resolver. It resolves status links of any server implementing
+// the Mastodon API, so it should be checked after all other resolvers. Collages of statuses with several
+// images are stored in collageCache.
+func Initialize(ctx context.Context, cfg config.APIConfig, pool db.Pool, resolvers *[]resolver.Resolver, collageCache cache.DependentCache) {
+	*resolvers = append(*resolvers, NewStatusResolver(ctx, cfg, pool, "https", collageCache))
+}
@@ -0,0 +1,30 @@
+package mastodon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/pashagolub/pgxmock"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestInitialize(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	pool, err := pgxmock.NewPool()
+	c.Assert(err, qt.IsNil)
+
+	cfg := config.APIConfig{}
+	collageCache := cache.NewPostgreSQLDependentCache(ctx, cfg, pool, cache.NewPrefixKeyProvider("test"))
+	customResolvers := []resolver.Resolver{}
+	c.Assert(customResolvers, qt.HasLen, 0)
+	Initialize(ctx, cfg, pool, &customResolvers, collageCache)
+	c.Assert(customResolvers, qt.HasLen, 1)
+	c.Assert(customResolvers[0].Name(), qt.Equals, "mastodon:status")
+}
@@ -0,0 +1,104 @@
+package mastodon
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+)
+
+// Software of servers which implement the Mastodon API, as reported by NodeInfo
+var supportedSoftware = map[string]struct{}{
+	"akkoma":     {},
+	"fedibird":   {},
+	"gotosocial": {},
+	"hometown":   {},
+	"kmyblue":    {},
+	"mastodon":   {},
+	"pleroma":    {},
+}
+
+// Prefix of the rel of the links to NodeInfo documents, e.g. http://nodeinfo.diaspora.software/ns/schema/2.0
+const nodeInfoRelPrefix = "http://nodeinfo.diaspora.software/ns/schema/"
+
+// How long a host is treated as not being an instance after it couldn't be reached. Every status
+// link to an unreachable host would wait for the detection otherwise.
+const unreachableInstanceCacheDuration = 5 * time.Minute
+
+// InstanceLoader checks whether a host (e.g. mastodon.social) is a server implementing the Mastodon API.
+// The loaded payload is the name of the server's software, or empty if it's not an instance.
+type InstanceLoader struct {
+	scheme string
+}
+
+func (l *InstanceLoader) Load(ctx context.Context, host string, r *http.Request) ([]byte, *int, *string, time.Duration, error) {
+	log := logger.FromContext(ctx)
+
+	log.Debugw("[Mastodon] Detect instance",
+		"host", host,
+	)
+
+	statusCode := http.StatusOK
+	contentType := "text/plain"
+
+	software, err := l.detectSoftware(ctx, host)
+	if err != nil {
+		log.Debugw("[Mastodon] Couldn't reach host, treating it as not an instance",
+			"host", host,
+			"error", err,
+		)
+		return []byte{}, &statusCode, &contentType, unreachableInstanceCacheDuration, nil
+	}
+
+	return []byte(software), &statusCode, &contentType, cache.NoSpecialDur, nil
+}
+
+// detectSoftware asks the host for its instance information, then for its NodeInfo
+func (l *InstanceLoader) detectSoftware(ctx context.Context, host string) (string, error) {
+	origin := l.scheme + "://" + host
+
+	var instance instanceResponse
+	ok, err := getJSON(ctx, origin+"/api/v1/instance", &instance)
+	if err != nil {
+		return "", err
+	}
+	if ok && instance.URI != "" {
+		// Every server implementing the Mastodon API is treated the same way
+		return "mastodon", nil
+	}
+
+	var links nodeInfoLinks
+	if ok, err := getJSON(ctx, origin+"/.well-known/nodeinfo", &links); err != nil || !ok {
+		return "", err
+	}
+
+	for _, link := range links.Links {
+		if !strings.HasPrefix(link.Rel, nodeInfoRelPrefix) {
+			continue
+		}
+
+		// The NodeInfo document must be served by the host itself
+		href, err := url.Parse(link.Href)
+		if err != nil || !strings.EqualFold(href.Host, host) {
+			continue
+		}
+
+		var info nodeInfo
+		if ok, err := getJSON(ctx, href.String(), &info); err != nil || !ok {
+			return "", err
+		}
+
+		software := strings.ToLower(info.Software.Name)
+		if _, ok := supportedSoftware[software]; ok {
+			return software, nil
+		}
+
+		return "", nil
+	}
+
+	return "", nil
+}
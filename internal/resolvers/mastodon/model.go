@@ -0,0 +1,73 @@
+package mastodon
+
+import "time"
+
+// instanceResponse is the response of /api/v1/instance
+type instanceResponse struct {
+	URI     string `json:"uri"`
+	Version string `json:"version"`
+}
+
+// nodeInfoLinks is the response of /.well-known/nodeinfo, which links to the NodeInfo documents of a server
+type nodeInfoLinks struct {
+	Links []struct {
+		Rel  string `json:"rel"`
+		Href string `json:"href"`
+	} `json:"links"`
+}
+
+type nodeInfo struct {
+	Software struct {
+		Name string `json:"name"`
+	} `json:"software"`
+}
+
+type account struct {
+	Username     string `json:"username"`
+	Acct         string `json:"acct"`
+	DisplayName  string `json:"display_name"`
+	AvatarStatic string `json:"avatar_static"`
+}
+
+type mediaAttachment struct {
+	// image, gifv, video, audio or unknown
+	Type       string `json:"type"`
+	URL        string `json:"url"`
+	PreviewURL string `json:"preview_url"`
+}
+
+type status struct {
+	ID               string            `json:"id"`
+	CreatedAt        time.Time         `json:"created_at"`
+	Content          string            `json:"content"`
+	SpoilerText      string            `json:"spoiler_text"`
+	Sensitive        bool              `json:"sensitive"`
+	RepliesCount     uint64            `json:"replies_count"`
+	ReblogsCount     uint64            `json:"reblogs_count"`
+	FavouritesCount  uint64            `json:"favourites_count"`
+	Account          account           `json:"account"`
+	MediaAttachments []mediaAttachment `json:"media_attachments"`
+	Card             *struct {
+		Image string `json:"image"`
+	} `json:"card"`
+}
+
+// imageURLs returns the URLs of the images shown for the media attached to the status
+func (s *status) imageURLs() []string {
+	var urls []string
+	for _, media := range s.MediaAttachments {
+		switch media.Type {
+		case "image", "gifv", "video":
+			if media.PreviewURL != "" {
+				urls = append(urls, media.PreviewURL)
+			}
+		}
+	}
+
+	// Link previews are only shown for statuses without media
+	if len(urls) == 0 && s.Card != nil && s.Card.Image != "" {
+		urls = append(urls, s.Card.Image)
+	}
+
+	return urls
+}
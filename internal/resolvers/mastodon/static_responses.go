@@ -0,0 +1,12 @@
+package mastodon
+
+import (
+	"net/http"
+
+	"github.com/Chatterino/api/pkg/resolver"
+)
+
+var noMastodonStatusFound = &resolver.Response{
+	Status:  http.StatusNotFound,
+	Message: "No Mastodon status with this ID found",
+}
@@ -0,0 +1,175 @@
+package mastodon
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/thumbnail"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+// Maximum length of the status texts shown in tooltips
+const maxStatusLength = 500
+
+type mastodonStatusTooltipData struct {
+	Name           string
+	Acct           string
+	Text           string
+	ContentWarning string
+	Likes          string
+	Boosts         string
+	Replies        string
+	Timestamp      string
+	NSFW           bool
+}
+
+type StatusLoader struct {
+	scheme string
+
+	baseURL                string
+	statusCacheKeyProvider cache.KeyProvider
+	collageCache           cache.DependentCache
+	maxThumbnailSize       uint
+}
+
+// One collage per language, as statuses are cached per language and remove their collages with them
+var collageKeyProvider = cache.NewLocalizedKeyProvider("mastodon:collage")
+
+func buildCollageKey(ctx context.Context, key string) string {
+	return collageKeyProvider.CacheKey(ctx, key)
+}
+
+// Load loads a status, keyed by the host of its instance and its ID, e.g. "mastodon.social/109318521938466543"
+func (l *StatusLoader) Load(ctx context.Context, key string, r *http.Request) (*resolver.Response, time.Duration, error) {
+	log := logger.FromContext(ctx)
+
+	log.Debugw("[Mastodon] Get status",
+		"key", key,
+	)
+
+	host, id, ok := strings.Cut(key, "/")
+	if !ok {
+		return noMastodonStatusFound, cache.NoSpecialDur, nil
+	}
+
+	apiURL := l.scheme + "://" + host + "/api/v1/statuses/" + url.PathEscape(id)
+
+	var status status
+	response, cacheDuration, err := resolver.RequestJSON(ctx, resolver.JSONRequest{
+		API: "Mastodon API",
+		URL: apiURL,
+		// Private statuses are reported as not found too
+		NotFound: noMastodonStatusFound,
+		CheckResponse: func(resp *http.Response) (bool, *resolver.Response, time.Duration, error) {
+			switch resp.StatusCode {
+			case http.StatusTooManyRequests:
+				return true, nil, cache.NoSpecialDur, fmt.Errorf("%w: mastodon instance %s rate limited", resolver.ErrUpstreamUnavailable, host)
+
+			// Instances can require authentication for their API, their pages may still be previewed
+			case http.StatusUnauthorized, http.StatusForbidden:
+				return true, nil, cache.NoSpecialDur, resolver.ErrDontHandle
+			}
+
+			return false, nil, cache.NoSpecialDur, nil
+		},
+	}, &status)
+	if response != nil || err != nil {
+		return response, cacheDuration, err
+	}
+
+	lang := i18n.FromContext(ctx)
+	text := statusText(status.Content)
+
+	name := status.Account.DisplayName
+	if name == "" {
+		name = status.Account.Username
+	}
+
+	// Accounts of the instance itself don't have its domain in their acct
+	acct := status.Account.Acct
+	if !strings.Contains(acct, "@") {
+		acct += "@" + host
+	}
+
+	// Like Mastodon, statuses with a content warning only show the warning
+	data := mastodonStatusTooltipData{
+		Name:           name,
+		Acct:           acct,
+		ContentWarning: status.SpoilerText,
+		Likes:          humanize.NumberIn(lang, status.FavouritesCount),
+		Boosts:         humanize.NumberIn(lang, status.ReblogsCount),
+		Replies:        humanize.NumberIn(lang, status.RepliesCount),
+		Timestamp:      humanize.CreationDateTimeIn(lang, status.CreatedAt),
+		NSFW:           status.Sensitive,
+	}
+	if status.SpoilerText == "" {
+		data.Text = utils.TruncateString(text, maxStatusLength)
+	}
+
+	var tooltip bytes.Buffer
+	if err := mastodonStatusTooltip.Execute(&tooltip, lang, data); err != nil {
+		return resolver.Errorf("Mastodon status template error: %s", err)
+	}
+
+	thumbnail := ""
+	if !status.Sensitive && status.SpoilerText == "" {
+		thumbnail = l.buildThumbnailURL(ctx, key, status.imageURLs(), r)
+	}
+
+	fields := map[string]string{
+		"acct":   acct,
+		"boosts": strconv.FormatUint(status.ReblogsCount, 10),
+	}
+	if status.SpoilerText != "" {
+		fields["content_warning"] = status.SpoilerText
+	}
+
+	return &resolver.Response{
+		Status:    http.StatusOK,
+		Tooltip:   url.PathEscape(tooltip.String()),
+		Thumbnail: thumbnail,
+		Data: &resolver.ResponseData{
+			Kind:        resolver.DataKindPost,
+			Description: text,
+			Author:      name,
+			Likes:       status.FavouritesCount,
+			Comments:    status.RepliesCount,
+			Published:   status.CreatedAt.Format(time.RFC3339),
+			NSFW:        status.Sensitive,
+			Fields:      fields,
+		},
+	}, cache.NoSpecialDur, nil
+}
+
+// buildThumbnailURL uses the only image of a status as its thumbnail, and composes a collage if it has several
+func (l *StatusLoader) buildThumbnailURL(ctx context.Context, key string, imageURLs []string, r *http.Request) string {
+	switch len(imageURLs) {
+	case 0:
+		return ""
+	case 1:
+		return imageURLs[0]
+	}
+
+	collage := &thumbnail.Collage{
+		BaseURL:      l.baseURL,
+		CollageCache: l.collageCache,
+		ParentKey:    l.statusCacheKeyProvider.CacheKey(ctx, key),
+		CollageKey:   buildCollageKey(ctx, key),
+		Options: thumbnail.CollageOptions{
+			MaxSize: l.maxThumbnailSize,
+		},
+	}
+
+	return collage.BuildThumbnailURL(ctx, imageURLs, r)
+}
@@ -0,0 +1,114 @@
+package mastodon
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+)
+
+// Match the links of statuses on the different servers implementing the Mastodon API.
+// The last group of each pattern captures the ID of the status.
+var statusRegexes = []*regexp.Regexp{
+	// Mastodon, e.g. /@Gargron/109318521938466543 or /@Gargron@mastodon.social/109318521938466543
+	regexp.MustCompile(`^\/@[a-zA-Z0-9_.-]+(?:@[a-zA-Z0-9.-]+)?\/([0-9]+)\/?$`),
+	// Mastodon's ActivityPub IDs, e.g. /users/Gargron/statuses/109318521938466543
+	regexp.MustCompile(`^\/users\/[a-zA-Z0-9_.-]+\/statuses\/([0-9]+)\/?$`),
+	// GoToSocial, e.g. /@user/statuses/01HEWV37MHV8BAC8ANFGVRRM5D
+	regexp.MustCompile(`^\/@[a-zA-Z0-9_.-]+\/statuses\/([0-9A-Z]{26})\/?$`),
+	// Pleroma and Akkoma, e.g. /notice/AbCdEf123
+	regexp.MustCompile(`^\/notice\/([a-zA-Z0-9]+)\/?$`),
+}
+
+type StatusResolver struct {
+	instanceCache cache.Cache
+	statusCache   cache.Cache
+}
+
+// statusID returns the ID of the status the URL links to, or an empty string if it doesn't look like a status link
+func statusID(url *url.URL) string {
+	for _, regex := range statusRegexes {
+		if match := regex.FindStringSubmatch(url.Path); len(match) == 2 {
+			return match[1]
+		}
+	}
+
+	return ""
+}
+
+// isInstance returns whether the host is a server implementing the Mastodon API
+func (r *StatusResolver) isInstance(ctx context.Context, host string, req *http.Request) bool {
+	log := logger.FromContext(ctx)
+
+	response, err := r.instanceCache.Get(ctx, host, req)
+	if err != nil {
+		log.Debugw("Error detecting Mastodon instance",
+			"host", host,
+			"error", err,
+		)
+		return false
+	}
+
+	return len(response.Payload) > 0
+}
+
+// Check matches status links on any host. Whether the host is an instance is only checked in Run.
+func (r *StatusResolver) Check(ctx context.Context, url *url.URL) (context.Context, bool) {
+	return ctx, url.Host != "" && statusID(url) != ""
+}
+
+func (r *StatusResolver) Run(ctx context.Context, url *url.URL, req *http.Request) (*cache.Response, error) {
+	id := statusID(url)
+	if id == "" {
+		return nil, errInvalidMastodonStatus
+	}
+
+	host := strings.ToLower(url.Host)
+	if !r.isInstance(ctx, host, req) {
+		return nil, resolver.ErrDontHandle
+	}
+
+	return r.statusCache.Get(ctx, host+"/"+id, req)
+}
+
+func (r *StatusResolver) Name() string {
+	return "mastodon:status"
+}
+
+// NewStatusResolver creates the status resolver. Instances are requested with the given scheme, which is
+// only changed in tests.
+func NewStatusResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, scheme string, collageCache cache.DependentCache) *StatusResolver {
+	statusCacheKeyProvider := cache.NewLocalizedKeyProvider("mastodon:status")
+
+	instanceLoader := &InstanceLoader{
+		scheme: scheme,
+	}
+
+	statusLoader := &StatusLoader{
+		scheme: scheme,
+
+		baseURL:                cfg.BaseURL,
+		statusCacheKeyProvider: statusCacheKeyProvider,
+		collageCache:           collageCache,
+		maxThumbnailSize:       cfg.MaxThumbnailSize,
+	}
+
+	statusCache := cache.NewDefaultCache(ctx, cfg, pool, statusCacheKeyProvider,
+		resolver.NewResponseMarshaller(statusLoader), cfg.MastodonStatusCacheDuration)
+	statusCache.RegisterDependent(ctx, collageCache)
+
+	r := &StatusResolver{
+		instanceCache: cache.NewDefaultCache(ctx, cfg, pool, cache.NewPrefixKeyProvider("mastodon:instance"),
+			instanceLoader, cfg.MastodonInstanceCacheDuration),
+		statusCache: statusCache,
+	}
+
+	return r
+}
@@ -0,0 +1,200 @@
+package mastodon
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+)
+
+// runResolver runs the resolver and decodes the response it returned
+func TestStatusResolver(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		BaseURL:          "https://api.example.com",
+		CacheBackend:     cache.BackendMemory,
+		MaxThumbnailSize: 300,
+	}
+	collageCache := cache.NewMemoryDependentCache(ctx, cfg, cache.NewPrefixKeyProvider("mastodon:collage"))
+
+	mastodon := testServer("mastodon")
+	defer mastodon.Close()
+	pleroma := testServer("pleroma")
+	defer pleroma.Close()
+	blog := testServer("wordpress")
+	defer blog.Close()
+
+	statusResolver := NewStatusResolver(ctx, cfg, nil, "http", collageCache)
+
+	c.Assert(statusResolver, qt.IsNotNil)
+
+	c.Run("Name", func(c *qt.C) {
+		c.Assert(statusResolver.Name(), qt.Equals, "mastodon:status")
+	})
+
+	c.Run("Check", func(c *qt.C) {
+		type checkTest struct {
+			input    *url.URL
+			expected bool
+		}
+
+		tests := []checkTest{
+			{utils.MustParseURL("https://mastodon.social/@Gargron/109318521938466543"), true},
+			{utils.MustParseURL("https://mastodon.social/@Gargron@mastodon.social/109318521938466543/"), true},
+			{utils.MustParseURL("https://mastodon.social/users/Gargron/statuses/109318521938466543"), true},
+			{utils.MustParseURL("https://gts.example/@user/statuses/01HEWV37MHV8BAC8ANFGVRRM5D"), true},
+			{utils.MustParseURL("https://pleroma.example/notice/AbCdEf123"), true},
+			{utils.MustParseURL("https://mastodon.social/@Gargron"), false},
+			{utils.MustParseURL("https://mastodon.social/@Gargron/media"), false},
+			{utils.MustParseURL("https://mastodon.social/@Gargron/109318521938466543/reblogs"), false},
+			{utils.MustParseURL("https://medium.com/@user/some-article-0123456789ab"), false},
+		}
+
+		for _, test := range tests {
+			c.Run(test.input.String(), func(c *qt.C) {
+				_, output := statusResolver.Check(ctx, test.input)
+				c.Assert(output, qt.Equals, test.expected)
+			})
+		}
+	})
+
+	c.Run("Run", func(c *qt.C) {
+		c.Run("Status", func(c *qt.C) {
+			data := runResolver(c, ctx, statusResolver, mastodon.URL+"/@Gargron/109318521938466543")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "")
+
+			host := utils.MustParseURL(mastodon.URL).Host
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Equals, `<div style="text-align: left;">`+
+				`<b>Eugen Rochko (@Gargron@`+host+`)</b>`+
+				`<br><span style="white-space: pre-wrap; word-wrap: break-word;">Hello &lt;world&gt;`+"\n"+`Second line`+"\n\n"+`See example.com/a/very…</span>`+
+				`<br><span style="color: #808892;">78,901 likes&nbsp;•&nbsp;3,456 boosts&nbsp;•&nbsp;12 replies&nbsp;•&nbsp;01 Mar 2024 • 12:30 UTC</span>`+
+				`</div>`)
+
+			c.Assert(data.Data, qt.DeepEquals, &resolver.ResponseData{
+				Kind:        resolver.DataKindPost,
+				Description: "Hello <world>\nSecond line\n\nSee example.com/a/very…",
+				Author:      "Eugen Rochko",
+				Likes:       78901,
+				Comments:    12,
+				Published:   "2024-03-01T12:30:00Z",
+				Fields: map[string]string{
+					"acct":   "Gargron@" + host,
+					"boosts": "3456",
+				},
+			})
+		})
+
+		c.Run("Image", func(c *qt.C) {
+			data := runResolver(c, ctx, statusResolver, mastodon.URL+"/users/forsen/statuses/2")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "https://files.example/small/1.png")
+
+			// Remote accounts keep the domain of their instance, accounts without a display name show their username
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Contains, `<b>forsen (@forsen@example.social)</b>`)
+		})
+
+		c.Run("Gallery", func(c *qt.C) {
+			// None of the images can be decoded, so no collage is built
+			data := runResolver(c, ctx, statusResolver, mastodon.URL+"/@forsen/3")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "")
+		})
+
+		c.Run("Content warning", func(c *qt.C) {
+			data := runResolver(c, ctx, statusResolver, mastodon.URL+"/@forsen/4")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "")
+			c.Assert(data.Data.NSFW, qt.IsTrue)
+			c.Assert(data.Data.Fields["content_warning"], qt.Equals, "Movie <spoilers>")
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Contains, `<br><b>Content warning:</b> Movie &lt;spoilers&gt;<br>`)
+			c.Assert(tooltip, qt.Not(qt.Contains), `Spoilers</span>`)
+			c.Assert(tooltip, qt.Contains, `<li><b><span style="color: red;">NSFW</span></b></li>`)
+		})
+
+		c.Run("Link preview", func(c *qt.C) {
+			data := runResolver(c, ctx, statusResolver, mastodon.URL+"/@forsen/5")
+			c.Assert(data.Thumbnail, qt.Equals, "https://files.example/cards/example.png")
+		})
+
+		c.Run("NodeInfo", func(c *qt.C) {
+			data := runResolver(c, ctx, statusResolver, pleroma.URL+"/notice/AbCdEf123")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Data.Author, qt.Equals, "Lain")
+			c.Assert(data.Data.Description, qt.Equals, "Plain text")
+		})
+
+		c.Run("German", func(c *qt.C) {
+			data := runResolver(c, i18n.OnContext(ctx, i18n.German), statusResolver, mastodon.URL+"/@Gargron/109318521938466543")
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Contains, `78.901 Likes&nbsp;•&nbsp;3.456 Boosts&nbsp;•&nbsp;12 Antworten`)
+
+			data = runResolver(c, i18n.OnContext(ctx, i18n.German), statusResolver, mastodon.URL+"/@forsen/4")
+			tooltip, err = url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Contains, `<b>Inhaltswarnung:</b>`)
+		})
+
+		c.Run("Not an instance", func(c *qt.C) {
+			_, err := statusResolver.Run(ctx, utils.MustParseURL(blog.URL+"/@forsen/109318521938466543"), nil)
+			c.Assert(err, qt.ErrorIs, resolver.ErrDontHandle)
+		})
+
+		c.Run("Unreachable host", func(c *qt.C) {
+			_, err := statusResolver.Run(ctx, utils.MustParseURL("http://127.0.0.1:1/@forsen/109318521938466543"), nil)
+			c.Assert(err, qt.ErrorIs, resolver.ErrDontHandle)
+
+			// The host is remembered as not being an instance for a while
+			response := statusResolver.instanceCache.GetOnly(ctx, "127.0.0.1:1")
+			c.Assert(response, qt.IsNotNil)
+			c.Assert(response.Payload, qt.HasLen, 0)
+		})
+
+		c.Run("Authentication required", func(c *qt.C) {
+			_, err := statusResolver.Run(ctx, utils.MustParseURL(mastodon.URL+"/@forsen/401"), nil)
+			c.Assert(err, qt.ErrorIs, resolver.ErrDontHandle)
+		})
+
+		c.Run("Not found", func(c *qt.C) {
+			data := runResolver(c, ctx, statusResolver, mastodon.URL+"/@forsen/404")
+			c.Assert(data.Status, qt.Equals, http.StatusNotFound)
+			c.Assert(data.Message, qt.Equals, "No Mastodon status with this ID found")
+		})
+
+		c.Run("API error", func(c *qt.C) {
+			data := runResolver(c, ctx, statusResolver, mastodon.URL+"/@forsen/500")
+			c.Assert(data.Status, qt.Equals, http.StatusInternalServerError)
+			c.Assert(data.Message, qt.Contains, "Mastodon API returned status 500")
+		})
+
+		c.Run("Bad JSON", func(c *qt.C) {
+			data := runResolver(c, ctx, statusResolver, mastodon.URL+"/notice/bad")
+			c.Assert(data.Status, qt.Equals, http.StatusInternalServerError)
+			c.Assert(data.Message, qt.Contains, "Mastodon API unmarshal error")
+		})
+
+		c.Run("Rate limited", func(c *qt.C) {
+			_, err := statusResolver.Run(ctx, utils.MustParseURL(mastodon.URL+"/@forsen/429"), nil)
+			c.Assert(err, qt.ErrorIs, resolver.ErrUpstreamUnavailable)
+		})
+	})
+}
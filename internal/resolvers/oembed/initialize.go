@@ -2,12 +2,12 @@ package oembed
 
 import (
 	"context"
-	"html/template"
 	"os"
 
 	"github.com/Chatterino/api/internal/db"
 	"github.com/Chatterino/api/internal/logger"
 	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 )
 
@@ -15,13 +15,13 @@ const (
 	oEmbedTooltipString = `<div style="text-align: left;">
 <b>{{.ProviderName}}{{ if .Title }} - {{.Title}}{{ end }}</b><hr>
 {{ if .Description }}{{.Description}}{{ end }}
-{{ if .AuthorName }}<br><b>Author:</b> {{.AuthorName}}{{ end }}
-<br><b>URL:</b> {{.RequestedURL}}
+{{ if .AuthorName }}<br><b>{{t "Author"}}:</b> {{.AuthorName}}{{ end }}
+<br><b>{{t "URL"}}:</b> {{.RequestedURL}}
 </div>`
 )
 
 var (
-	oEmbedTemplate = template.Must(template.New("oEmbedTemplateTooltip").Parse(oEmbedTooltipString))
+	oEmbedTemplate = i18n.MustTemplate("oEmbedTemplateTooltip", oEmbedTooltipString)
 )
 
 func Initialize(ctx context.Context, cfg config.APIConfig, pool db.Pool, resolvers *[]resolver.Resolver) {
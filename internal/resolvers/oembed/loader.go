@@ -12,6 +12,7 @@ import (
 
 	"github.com/Chatterino/api/pkg/cache"
 	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	"github.com/dyatlov/go-oembed/oembed"
 )
@@ -62,7 +63,7 @@ func (l *Loader) Load(ctx context.Context, requestedURL string, r *http.Request)
 
 	// Build a tooltip using the tooltip template (see tooltipTemplate) with the data we massaged above
 	var tooltip bytes.Buffer
-	if err := oEmbedTemplate.Execute(&tooltip, infoTooltipData); err != nil {
+	if err := oEmbedTemplate.Execute(&tooltip, i18n.FromContext(ctx), infoTooltipData); err != nil {
 		return &resolver.Response{
 			Status:  http.StatusInternalServerError,
 			Message: "oEmbed template error: " + resolver.CleanResponse(err.Error()),
@@ -54,8 +54,8 @@ func NewResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, data [
 	}
 
 	r := &Resolver{
-		oEmbedCache: cache.NewPostgreSQLCache(
-			ctx, cfg, pool, cache.NewPrefixKeyProvider("oembed"),
+		oEmbedCache: cache.NewDefaultCache(
+			ctx, cfg, pool, cache.NewLocalizedKeyProvider("oembed"),
 			resolver.NewResponseMarshaller(loader), cfg.OembedCacheDuration,
 		),
 		oEmbed: oEmbed,
@@ -0,0 +1,59 @@
+package reddit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Chatterino/api/pkg/resolver"
+)
+
+// buildURL resolves the path of a .json endpoint against the base URL, e.g. "r/forsen/about.json".
+// raw_json stops Reddit from HTML-escaping the strings of its responses.
+func buildURL(baseURL *url.URL, path string, query url.Values) string {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("raw_json", "1")
+
+	return baseURL.ResolveReference(&url.URL{Path: path, RawQuery: query.Encode()}).String()
+}
+
+// createdAt converts the created_utc timestamps of Reddit, which are in seconds
+func createdAt(createdUTC float64) time.Time {
+	return time.Unix(int64(createdUTC), 0).UTC()
+}
+
+// checkForbidden reports private, quarantined and banned subreddits, and the posts in them, as
+// notFound. Reddit returns 403 with the reason as JSON for them, other 403s are Reddit blocking our
+// requests, e.g. with an HTML page for datacenter IPs.
+func checkForbidden(notFound *resolver.Response) func(resp *http.Response) (bool, *resolver.Response, time.Duration, error) {
+	return func(resp *http.Response) (bool, *resolver.Response, time.Duration, error) {
+		if resp.StatusCode != http.StatusForbidden {
+			return false, nil, resolver.NoSpecialDur, nil
+		}
+
+		var forbidden struct {
+			Reason string `json:"reason"`
+		}
+		if err := resolver.DecodeJSON(resp.Body, &forbidden); err != nil || forbidden.Reason == "" {
+			return false, nil, resolver.NoSpecialDur, nil
+		}
+
+		return true, notFound, resolver.NoSpecialDur, nil
+	}
+}
+
+// firstThing decodes the first child of the listing into v, if it's of the given kind
+func firstThing(listing redditListing, kind string, v any) (bool, error) {
+	if len(listing.Data.Children) == 0 || listing.Data.Children[0].Kind != kind {
+		return false, nil
+	}
+
+	if err := json.Unmarshal(listing.Data.Children[0].Data, v); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
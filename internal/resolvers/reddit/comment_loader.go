@@ -0,0 +1,125 @@
+package reddit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+// Maximum length of the comment bodies shown in tooltips
+const maxCommentLength = 300
+
+var errInvalidCommentKey = errors.New("invalid Reddit comment key")
+
+type redditCommentTooltipData struct {
+	PostTitle string
+	Subreddit string
+	Author    string
+	Body      string
+	Score     string
+	Age       string
+	NSFW      bool
+}
+
+type CommentLoader struct {
+	apiURL   *url.URL
+	upstream *resolver.Upstream
+}
+
+// Load loads a comment, keyed by the ID of its post and its own ID, e.g. "1abcde/kx9z8y7"
+func (l *CommentLoader) Load(ctx context.Context, key string, r *http.Request) (*resolver.Response, time.Duration, error) {
+	log := logger.FromContext(ctx)
+
+	postID, commentID, ok := strings.Cut(key, "/")
+	if !ok {
+		return nil, cache.NoSpecialDur, errInvalidCommentKey
+	}
+
+	log.Debugw("[Reddit] Get comment",
+		"postID", postID,
+		"commentID", commentID,
+	)
+
+	// The first listing holds the post, the second one the comment
+	var listings []redditListing
+	path := "comments/" + url.PathEscape(postID) + "/_/" + url.PathEscape(commentID) + ".json"
+	apiURL := buildURL(l.apiURL, path, url.Values{"limit": {"1"}, "depth": {"1"}})
+	if response, cacheDuration, err := resolver.RequestJSON(ctx, resolver.JSONRequest{
+		Upstream:      l.upstream,
+		API:           "Reddit API",
+		URL:           apiURL,
+		NotFound:      noRedditCommentWithThisIDFound,
+		CheckResponse: checkForbidden(noRedditCommentWithThisIDFound),
+	}, &listings); response != nil || err != nil {
+		return response, cacheDuration, err
+	}
+
+	if len(listings) < 2 {
+		return noRedditCommentWithThisIDFound, cache.NoSpecialDur, nil
+	}
+
+	var post redditPost
+	var comment redditComment
+	okPost, err := firstThing(listings[0], kindPost, &post)
+	if err != nil {
+		return resolver.Errorf("Reddit API unmarshal error: %s", err)
+	}
+	okComment, err := firstThing(listings[1], kindComment, &comment)
+	if err != nil {
+		return resolver.Errorf("Reddit API unmarshal error: %s", err)
+	}
+	if !okPost || !okComment || comment.ID != commentID {
+		return noRedditCommentWithThisIDFound, cache.NoSpecialDur, nil
+	}
+
+	return commentResponse(ctx, post, comment)
+}
+
+func commentResponse(ctx context.Context, post redditPost, comment redditComment) (*resolver.Response, time.Duration, error) {
+	lang := i18n.FromContext(ctx)
+	created := createdAt(comment.CreatedUTC)
+
+	data := redditCommentTooltipData{
+		PostTitle: post.Title,
+		Subreddit: comment.SubredditNamePrefixed,
+		Author:    comment.Author,
+		Body:      utils.TruncateString(comment.Body, maxCommentLength),
+		Score:     humanize.NumberInt64In(lang, comment.Score),
+		Age:       humanize.AgoIn(lang, time.Since(created)),
+		NSFW:      post.Over18,
+	}
+
+	var tooltip bytes.Buffer
+	if err := redditCommentTooltip.Execute(&tooltip, lang, data); err != nil {
+		return resolver.Errorf("Reddit comment template error: %s", err)
+	}
+
+	return &resolver.Response{
+		Status:  200,
+		Tooltip: url.PathEscape(tooltip.String()),
+		Data: &resolver.ResponseData{
+			Kind:        resolver.DataKindPost,
+			Title:       post.Title,
+			Description: comment.Body,
+			Author:      comment.Author,
+			Published:   created.Format(time.RFC3339),
+			NSFW:        post.Over18,
+			Fields: map[string]string{
+				"subreddit": comment.SubredditNamePrefixed,
+				"score":     strconv.FormatInt(comment.Score, 10),
+			},
+		},
+	}, cache.NoSpecialDur, nil
+}
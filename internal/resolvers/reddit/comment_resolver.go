@@ -0,0 +1,68 @@
+package reddit
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+// Matches comment links, e.g. /r/forsen/comments/1abcde/some_title/kx9z8y7/ or
+// /r/forsen/comments/1abcde/comment/kx9z8y7/
+var commentRegex = regexp.MustCompile(`^\/(?:r|u|user)\/[a-zA-Z0-9_-]+\/comments\/([a-zA-Z0-9]+)\/[^\/]+\/([a-zA-Z0-9]+)\/?$`)
+
+type CommentResolver struct {
+	commentCache cache.Cache
+}
+
+// commentKey returns the cache key of the comment the URL links to, or an empty string if it's not a comment link
+func commentKey(url *url.URL) string {
+	if !utils.IsDomains(url, domains) {
+		return ""
+	}
+
+	match := commentRegex.FindStringSubmatch(url.Path)
+	if len(match) != 3 {
+		return ""
+	}
+
+	return strings.ToLower(match[1] + "/" + match[2])
+}
+
+func (r *CommentResolver) Check(ctx context.Context, url *url.URL) (context.Context, bool) {
+	return ctx, commentKey(url) != ""
+}
+
+func (r *CommentResolver) Run(ctx context.Context, url *url.URL, req *http.Request) (*cache.Response, error) {
+	key := commentKey(url)
+	if key == "" {
+		return nil, errInvalidRedditComment
+	}
+
+	return r.commentCache.Get(ctx, key, req)
+}
+
+func (r *CommentResolver) Name() string {
+	return "reddit:comment"
+}
+
+func NewCommentResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, apiURL *url.URL, upstream *resolver.Upstream) *CommentResolver {
+	commentLoader := &CommentLoader{
+		apiURL:   apiURL,
+		upstream: upstream,
+	}
+
+	r := &CommentResolver{
+		commentCache: cache.NewDefaultCache(ctx, cfg, pool, cache.NewLocalizedKeyProvider("reddit:comment"),
+			resolver.NewResponseMarshaller(commentLoader), cfg.RedditCommentCacheDuration),
+	}
+
+	return r
+}
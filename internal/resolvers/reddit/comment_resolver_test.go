@@ -0,0 +1,98 @@
+package reddit
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+)
+
+func TestCommentResolver(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		CacheBackend: cache.BackendMemory,
+	}
+	ts := testServer()
+	defer ts.Close()
+	apiURL := utils.MustParseURL(ts.URL + "/")
+
+	commentResolver := NewCommentResolver(ctx, cfg, nil, apiURL, resolver.NewUpstream(cfg, "reddit"))
+
+	c.Assert(commentResolver, qt.IsNotNil)
+
+	c.Run("Name", func(c *qt.C) {
+		c.Assert(commentResolver.Name(), qt.Equals, "reddit:comment")
+	})
+
+	c.Run("Check", func(c *qt.C) {
+		type checkTest struct {
+			input    *url.URL
+			expected bool
+		}
+
+		tests := []checkTest{
+			{utils.MustParseURL("https://www.reddit.com/r/forsen/comments/1abcde/forsen_3/kx9z8y7/"), true},
+			{utils.MustParseURL("https://www.reddit.com/r/forsen/comments/1abcde/comment/kx9z8y7/?context=3"), true},
+			{utils.MustParseURL("https://old.reddit.com/user/pajlada/comments/1abcde/forsen_3/kx9z8y7"), true},
+			{utils.MustParseURL("https://www.reddit.com/r/forsen/comments/1abcde/forsen_3/"), false},
+			{utils.MustParseURL("https://www.reddit.com/r/forsen/comments/1abcde/forsen_3/kx9z8y7/more"), false},
+			{utils.MustParseURL("https://redd.it/1abcde/kx9z8y7"), false},
+		}
+
+		for _, test := range tests {
+			c.Run(test.input.String(), func(c *qt.C) {
+				_, output := commentResolver.Check(ctx, test.input)
+				c.Assert(output, qt.Equals, test.expected)
+			})
+		}
+	})
+
+	c.Run("Run", func(c *qt.C) {
+		c.Run("Comment", func(c *qt.C) {
+			data := runResolver(c, ctx, commentResolver, "https://www.reddit.com/r/forsen/comments/1abcde/comment/kx9z8y7/")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Equals, `<div style="text-align: left;">`+
+				`<b>Forsen &lt;3</b><hr>`+
+				`<b>r/forsen</b> • u/forsen • 2 hours ago<br>`+
+				`I agree<br>`+
+				`<b>Score:</b> 420`+
+				`</div>`)
+
+			c.Assert(data.Data, qt.DeepEquals, &resolver.ResponseData{
+				Kind:        resolver.DataKindPost,
+				Title:       "Forsen <3",
+				Description: "I agree",
+				Author:      "forsen",
+				Published:   createdUTC.Format(time.RFC3339),
+				Fields: map[string]string{
+					"subreddit": "r/forsen",
+					"score":     "420",
+				},
+			})
+		})
+
+		c.Run("Not found", func(c *qt.C) {
+			for _, link := range []string{
+				"https://www.reddit.com/r/forsen/comments/1abcde/comment/missing/",
+				"https://www.reddit.com/r/forsen/comments/404/comment/kx9z8y7/",
+			} {
+				data := runResolver(c, ctx, commentResolver, link)
+				c.Assert(data.Status, qt.Equals, http.StatusNotFound)
+				c.Assert(data.Message, qt.Equals, "No Reddit comment with this ID found")
+			}
+		})
+	})
+}
@@ -0,0 +1,102 @@
+package reddit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+	"github.com/go-chi/chi/v5"
+)
+
+var (
+	postData      = map[string][]byte{}
+	commentData   = map[string][]byte{}
+	subredditData = map[string][]byte{}
+
+	// The posts and comments were created two hours ago
+	createdUTC = time.Now().UTC().Add(-2 * time.Hour).Truncate(time.Second)
+)
+
+func init() {
+	created := createdUTC.Unix()
+	comments := `{"kind":"Listing","data":{"children":[]}}`
+
+	postData["bad"] = []byte(`xD`)
+	postData["1abcde"] = []byte(fmt.Sprintf(`[{"kind":"Listing","data":{"children":[{"kind":"t3","data":{"id":"1abcde","title":"Forsen <3","author":"pajlada","subreddit_name_prefixed":"r/forsen","score":12345,"num_comments":678,"over_18":false,"spoiler":false,"created_utc":%d.0,"thumbnail":"https://b.thumbs.redditmedia.com/small.jpg","preview":{"images":[{"source":{"url":"https://preview.redd.it/image.jpg?width=1080&s=abc"},"variants":{}}]}}}]}},%s]`, created, comments))
+	postData["1nsfw"] = []byte(fmt.Sprintf(`[{"kind":"Listing","data":{"children":[{"kind":"t3","data":{"id":"1nsfw","title":"NSFW post","author":"pajlada","subreddit_name_prefixed":"r/forsen","score":-5,"num_comments":0,"over_18":true,"spoiler":false,"created_utc":%d.0,"thumbnail":"nsfw","preview":{"images":[{"source":{"url":"https://preview.redd.it/nsfw.jpg"},"variants":{"nsfw":{"source":{"url":"https://preview.redd.it/nsfw.jpg?blur=40"}},"obfuscated":{"source":{"url":"https://preview.redd.it/nsfw.jpg?blur=10"}}}}]}}}]}},%s]`, created, comments))
+	postData["1spoil"] = []byte(fmt.Sprintf(`[{"kind":"Listing","data":{"children":[{"kind":"t3","data":{"id":"1spoil","title":"Spoiler post","author":"pajlada","subreddit_name_prefixed":"r/forsen","score":1,"num_comments":1,"over_18":false,"spoiler":true,"created_utc":%d.0,"thumbnail":"spoiler","preview":{"images":[{"source":{"url":"https://preview.redd.it/spoiler.jpg"},"variants":{"obfuscated":{"source":{"url":"https://preview.redd.it/spoiler.jpg?blur=40"}}}}]}}}]}},%s]`, created, comments))
+	postData["1nsfwnopreview"] = []byte(fmt.Sprintf(`[{"kind":"Listing","data":{"children":[{"kind":"t3","data":{"id":"1nsfwnopreview","title":"NSFW link","author":"pajlada","subreddit_name_prefixed":"r/forsen","score":1,"num_comments":1,"over_18":true,"spoiler":false,"created_utc":%d.0,"thumbnail":"https://b.thumbs.redditmedia.com/nsfw.jpg"}}]}},%s]`, created, comments))
+	postData["1self"] = []byte(fmt.Sprintf(`[{"kind":"Listing","data":{"children":[{"kind":"t3","data":{"id":"1self","title":"Self post","author":"pajlada","subreddit_name_prefixed":"r/forsen","score":1,"num_comments":1,"over_18":false,"spoiler":false,"created_utc":%d.0,"thumbnail":"self"}}]}},%s]`, created, comments))
+	postData["1empty"] = []byte(`[]`)
+
+	commentData["1abcde/kx9z8y7"] = []byte(fmt.Sprintf(`[{"kind":"Listing","data":{"children":[{"kind":"t3","data":{"id":"1abcde","title":"Forsen <3","author":"pajlada","subreddit_name_prefixed":"r/forsen","over_18":false}}]}},{"kind":"Listing","data":{"children":[{"kind":"t1","data":{"id":"kx9z8y7","author":"forsen","body":"I agree","subreddit_name_prefixed":"r/forsen","score":420,"created_utc":%d.0}}]}}]`, created))
+	commentData["1abcde/missing"] = []byte(`[{"kind":"Listing","data":{"children":[{"kind":"t3","data":{"id":"1abcde","title":"Forsen <3"}}]}},{"kind":"Listing","data":{"children":[]}}]`)
+
+	subredditData["forsen"] = []byte(`{"kind":"t5","data":{"display_name_prefixed":"r/forsen","title":"Forsen","public_description":"The forsen subreddit","subscribers":123456,"over18":false,"created_utc":1420070400.0,"icon_img":"https://b.thumbs.redditmedia.com/icon.png","community_icon":"https://styles.redditmedia.com/community_icon.png?width=256"}}`)
+	subredditData["nsfwsub"] = []byte(`{"kind":"t5","data":{"display_name_prefixed":"r/nsfwsub","title":"","public_description":"","subscribers":10,"over18":true,"created_utc":1420070400.0,"icon_img":"https://b.thumbs.redditmedia.com/nsfw.png","community_icon":""}}`)
+	subredditData["search"] = []byte(`{"kind":"Listing","data":{"children":[]}}`)
+}
+
+func testServer() *httptest.Server {
+	r := chi.NewRouter()
+	serve := func(data map[string][]byte, id func(r *http.Request) string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			id := id(r)
+
+			if r.URL.Query().Get("raw_json") != "1" {
+				http.Error(w, "raw_json must be set", http.StatusBadRequest)
+				return
+			}
+
+			switch id {
+			case "ratelimited":
+				http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			case "private":
+				w.Header().Set("Content-Type", "application/json")
+				http.Error(w, `{"reason":"private","message":"Forbidden","error":403}`, http.StatusForbidden)
+			case "forbidden":
+				w.Header().Set("Content-Type", "text/html")
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`<html>You've been blocked by network security.</html>`))
+			case "blocked":
+				w.Header().Set("Content-Type", "text/html")
+				w.Write([]byte(`<html>whoa there, pardner!</html>`))
+			default:
+				if response, ok := data[id]; ok {
+					w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+					w.Write(response)
+				} else {
+					http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+				}
+			}
+		}
+	}
+
+	r.Get("/comments/{postID}.json", serve(postData, func(r *http.Request) string {
+		return chi.URLParam(r, "postID")
+	}))
+	r.Get("/comments/{postID}/_/{commentID}.json", serve(commentData, func(r *http.Request) string {
+		return chi.URLParam(r, "postID") + "/" + chi.URLParam(r, "commentID")
+	}))
+	r.Get("/r/{subreddit}/about.json", serve(subredditData, func(r *http.Request) string {
+		return chi.URLParam(r, "subreddit")
+	}))
+
+	return httptest.NewServer(r)
+}
+
+// runResolver runs the resolver and decodes the response it returned
+func runResolver(c *qt.C, ctx context.Context, r resolver.Resolver, link string) resolver.Response {
+	response, err := r.Run(ctx, utils.MustParseURL(link), nil)
+	c.Assert(err, qt.IsNil)
+
+	var data resolver.Response
+	c.Assert(json.Unmarshal(response.Payload, &data), qt.IsNil)
+	return data
+}
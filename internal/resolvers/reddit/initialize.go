@@ -0,0 +1,66 @@
+package reddit
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+const (
+	redditPostTooltipString = `<div style="text-align: left;">` +
+		`<b>{{.Title}}</b><hr>` +
+		`<b>{{.Subreddit}}</b> • u/{{.Author}} • {{.Age}}<br>` +
+		`<b>{{t "Score"}}:</b> {{.Score}} • {{t "%s comments" .Comments}}` +
+		`{{ if .NSFW }}<li><b><span style="color: red;">NSFW</span></b></li>{{ end }}` +
+		`{{ if .Spoiler }}<li><b><span style="color: red;">{{t "SPOILER"}}</span></b></li>{{ end }}` +
+		`</div>`
+
+	redditCommentTooltipString = `<div style="text-align: left;">` +
+		`<b>{{.PostTitle}}</b><hr>` +
+		`<b>{{.Subreddit}}</b> • u/{{.Author}} • {{.Age}}<br>` +
+		`{{.Body}}<br>` +
+		`<b>{{t "Score"}}:</b> {{.Score}}` +
+		`{{ if .NSFW }}<li><b><span style="color: red;">NSFW</span></b></li>{{ end }}` +
+		`</div>`
+
+	redditSubredditTooltipString = `<div style="text-align: left;">` +
+		`<b>{{.Name}}</b>{{ if .Title }} - {{.Title}}{{ end }}<br>` +
+		`{{ if .Description }}{{.Description}}<br>{{ end }}` +
+		`<b>{{t "Members"}}:</b> {{.Members}}<br>` +
+		`<b>{{t "Created"}}:</b> {{.Created}}` +
+		`{{ if .NSFW }}<li><b><span style="color: red;">NSFW</span></b></li>{{ end }}` +
+		`</div>`
+)
+
+var (
+	errInvalidRedditComment = errors.New("invalid Reddit comment link")
+	errInvalidRedditPost    = errors.New("invalid Reddit post link")
+	errInvalidSubreddit     = errors.New("invalid subreddit link")
+
+	redditPostTooltip      = i18n.MustTemplate("redditPostTooltip", redditPostTooltipString)
+	redditCommentTooltip   = i18n.MustTemplate("redditCommentTooltip", redditCommentTooltipString)
+	redditSubredditTooltip = i18n.MustTemplate("redditSubredditTooltip", redditSubredditTooltipString)
+
+	domains = map[string]struct{}{
+		"reddit.com":     {},
+		"www.reddit.com": {},
+		"old.reddit.com": {},
+		"new.reddit.com": {},
+		"np.reddit.com":  {},
+		"m.reddit.com":   {},
+	}
+)
+
+func Initialize(ctx context.Context, cfg config.APIConfig, pool db.Pool, resolvers *[]resolver.Resolver) {
+	apiURL := utils.MustParseURL("https://www.reddit.com/")
+	upstream := resolver.NewUpstream(cfg, "reddit")
+
+	*resolvers = append(*resolvers, NewCommentResolver(ctx, cfg, pool, apiURL, upstream))
+	*resolvers = append(*resolvers, NewPostResolver(ctx, cfg, pool, apiURL, upstream))
+	*resolvers = append(*resolvers, NewSubredditResolver(ctx, cfg, pool, apiURL, upstream))
+}
@@ -0,0 +1,77 @@
+package reddit
+
+import "encoding/json"
+
+// Kinds of redditThing
+const (
+	kindComment   = "t1"
+	kindPost      = "t3"
+	kindSubreddit = "t5"
+)
+
+// redditThing wraps every object returned by the Reddit API
+type redditThing struct {
+	Kind string          `json:"kind"`
+	Data json.RawMessage `json:"data"`
+}
+
+type redditListing struct {
+	Kind string `json:"kind"`
+	Data struct {
+		Children []redditThing `json:"children"`
+	} `json:"data"`
+}
+
+type redditImage struct {
+	URL string `json:"url"`
+}
+
+type redditPreviewImage struct {
+	Source   redditImage `json:"source"`
+	Variants struct {
+		// Blurred versions of the image, set for NSFW and spoiler posts
+		NSFW *struct {
+			Source redditImage `json:"source"`
+		} `json:"nsfw"`
+		Obfuscated *struct {
+			Source redditImage `json:"source"`
+		} `json:"obfuscated"`
+	} `json:"variants"`
+}
+
+type redditPost struct {
+	ID                    string  `json:"id"`
+	Title                 string  `json:"title"`
+	Author                string  `json:"author"`
+	SubredditNamePrefixed string  `json:"subreddit_name_prefixed"`
+	Score                 int64   `json:"score"`
+	NumComments           int64   `json:"num_comments"`
+	Over18                bool    `json:"over_18"`
+	Spoiler               bool    `json:"spoiler"`
+	CreatedUTC            float64 `json:"created_utc"`
+	Thumbnail             string  `json:"thumbnail"`
+	Preview               *struct {
+		Images []redditPreviewImage `json:"images"`
+	} `json:"preview"`
+}
+
+type redditComment struct {
+	ID                    string  `json:"id"`
+	Author                string  `json:"author"`
+	Body                  string  `json:"body"`
+	SubredditNamePrefixed string  `json:"subreddit_name_prefixed"`
+	Score                 int64   `json:"score"`
+	CreatedUTC            float64 `json:"created_utc"`
+}
+
+// redditSubreddit is returned by the /r/{subreddit}/about.json endpoint
+type redditSubreddit struct {
+	DisplayNamePrefixed string  `json:"display_name_prefixed"`
+	Title               string  `json:"title"`
+	PublicDescription   string  `json:"public_description"`
+	Subscribers         int64   `json:"subscribers"`
+	Over18              bool    `json:"over18"`
+	CreatedUTC          float64 `json:"created_utc"`
+	IconImg             string  `json:"icon_img"`
+	CommunityIcon       string  `json:"community_icon"`
+}
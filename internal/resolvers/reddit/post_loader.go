@@ -0,0 +1,139 @@
+package reddit
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+)
+
+type redditPostTooltipData struct {
+	Title     string
+	Subreddit string
+	Author    string
+	Score     string
+	Comments  string
+	Age       string
+	NSFW      bool
+	Spoiler   bool
+}
+
+type PostLoader struct {
+	apiURL   *url.URL
+	upstream *resolver.Upstream
+}
+
+func (l *PostLoader) Load(ctx context.Context, postID string, r *http.Request) (*resolver.Response, time.Duration, error) {
+	log := logger.FromContext(ctx)
+
+	log.Debugw("[Reddit] Get post",
+		"postID", postID,
+	)
+
+	// The first listing holds the post, the second one its comments
+	var listings []redditListing
+	apiURL := buildURL(l.apiURL, "comments/"+url.PathEscape(postID)+".json", url.Values{"limit": {"1"}})
+	if response, cacheDuration, err := resolver.RequestJSON(ctx, resolver.JSONRequest{
+		Upstream:      l.upstream,
+		API:           "Reddit API",
+		URL:           apiURL,
+		NotFound:      noRedditPostWithThisIDFound,
+		CheckResponse: checkForbidden(noRedditPostWithThisIDFound),
+	}, &listings); response != nil || err != nil {
+		return response, cacheDuration, err
+	}
+
+	var post redditPost
+	if len(listings) == 0 {
+		return noRedditPostWithThisIDFound, cache.NoSpecialDur, nil
+	}
+	if ok, err := firstThing(listings[0], kindPost, &post); err != nil {
+		return resolver.Errorf("Reddit API unmarshal error: %s", err)
+	} else if !ok {
+		return noRedditPostWithThisIDFound, cache.NoSpecialDur, nil
+	}
+
+	return postResponse(ctx, post)
+}
+
+func postResponse(ctx context.Context, post redditPost) (*resolver.Response, time.Duration, error) {
+	lang := i18n.FromContext(ctx)
+	created := createdAt(post.CreatedUTC)
+
+	data := redditPostTooltipData{
+		Title:     post.Title,
+		Subreddit: post.SubredditNamePrefixed,
+		Author:    post.Author,
+		Score:     humanize.NumberInt64In(lang, post.Score),
+		Comments:  humanize.NumberInt64In(lang, post.NumComments),
+		Age:       humanize.AgoIn(lang, time.Since(created)),
+		NSFW:      post.Over18,
+		Spoiler:   post.Spoiler,
+	}
+
+	var tooltip bytes.Buffer
+	if err := redditPostTooltip.Execute(&tooltip, lang, data); err != nil {
+		return resolver.Errorf("Reddit post template error: %s", err)
+	}
+
+	fields := map[string]string{
+		"subreddit": post.SubredditNamePrefixed,
+		"score":     strconv.FormatInt(post.Score, 10),
+	}
+	if post.Spoiler {
+		fields["spoiler"] = "true"
+	}
+
+	return &resolver.Response{
+		Status:    200,
+		Tooltip:   url.PathEscape(tooltip.String()),
+		Thumbnail: postThumbnail(post),
+		Data: &resolver.ResponseData{
+			Kind:      resolver.DataKindPost,
+			Title:     post.Title,
+			Author:    post.Author,
+			Comments:  uint64(max(post.NumComments, 0)),
+			Published: created.Format(time.RFC3339),
+			NSFW:      post.Over18,
+			Fields:    fields,
+		},
+	}, cache.NoSpecialDur, nil
+}
+
+// postThumbnail picks the thumbnail of a post. NSFW and spoiler posts only get the blurred
+// versions of their preview image, or no thumbnail if Reddit didn't provide one.
+func postThumbnail(post redditPost) string {
+	hidden := post.Over18 || post.Spoiler
+
+	if post.Preview != nil && len(post.Preview.Images) > 0 {
+		image := post.Preview.Images[0]
+		if !hidden {
+			return image.Source.URL
+		}
+
+		if post.Over18 && image.Variants.NSFW != nil {
+			return image.Variants.NSFW.Source.URL
+		}
+		if image.Variants.Obfuscated != nil {
+			return image.Variants.Obfuscated.Source.URL
+		}
+
+		return ""
+	}
+
+	// Posts without a preview have placeholders like "self", "nsfw" or "spoiler" as their thumbnail
+	if hidden || !strings.HasPrefix(post.Thumbnail, "https://") {
+		return ""
+	}
+
+	return post.Thumbnail
+}
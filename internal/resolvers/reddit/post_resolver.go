@@ -0,0 +1,74 @@
+package reddit
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+var (
+	// Matches post links, e.g. /r/forsen/comments/1abcde/some_title/ or /user/forsen/comments/1abcde
+	postRegex = regexp.MustCompile(`^\/(?:(?:r|u|user)\/[a-zA-Z0-9_-]+\/)?comments\/([a-zA-Z0-9]+)(?:\/[^\/]*)?\/?$`)
+
+	// Matches the short links of posts, e.g. https://redd.it/1abcde
+	shortPostRegex = regexp.MustCompile(`^\/([a-zA-Z0-9]+)\/?$`)
+)
+
+type PostResolver struct {
+	postCache cache.Cache
+}
+
+// postID returns the ID of the post the URL links to, or an empty string if it's not a post link
+func postID(url *url.URL) string {
+	var match []string
+	if utils.IsDomain(url, "redd.it") {
+		match = shortPostRegex.FindStringSubmatch(url.Path)
+	} else if utils.IsDomains(url, domains) {
+		match = postRegex.FindStringSubmatch(url.Path)
+	}
+
+	if len(match) != 2 {
+		return ""
+	}
+
+	return strings.ToLower(match[1])
+}
+
+func (r *PostResolver) Check(ctx context.Context, url *url.URL) (context.Context, bool) {
+	return ctx, postID(url) != ""
+}
+
+func (r *PostResolver) Run(ctx context.Context, url *url.URL, req *http.Request) (*cache.Response, error) {
+	postID := postID(url)
+	if postID == "" {
+		return nil, errInvalidRedditPost
+	}
+
+	return r.postCache.Get(ctx, postID, req)
+}
+
+func (r *PostResolver) Name() string {
+	return "reddit:post"
+}
+
+func NewPostResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, apiURL *url.URL, upstream *resolver.Upstream) *PostResolver {
+	postLoader := &PostLoader{
+		apiURL:   apiURL,
+		upstream: upstream,
+	}
+
+	r := &PostResolver{
+		postCache: cache.NewDefaultCache(ctx, cfg, pool, cache.NewLocalizedKeyProvider("reddit:post"),
+			resolver.NewResponseMarshaller(postLoader), cfg.RedditPostCacheDuration),
+	}
+
+	return r
+}
@@ -0,0 +1,172 @@
+package reddit
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+)
+
+// runResolver runs the resolver and decodes the response it returned
+func TestPostResolver(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		CacheBackend: cache.BackendMemory,
+	}
+	ts := testServer()
+	defer ts.Close()
+	apiURL := utils.MustParseURL(ts.URL + "/")
+
+	postResolver := NewPostResolver(ctx, cfg, nil, apiURL, resolver.NewUpstream(cfg, "reddit"))
+
+	c.Assert(postResolver, qt.IsNotNil)
+
+	c.Run("Name", func(c *qt.C) {
+		c.Assert(postResolver.Name(), qt.Equals, "reddit:post")
+	})
+
+	c.Run("Check", func(c *qt.C) {
+		type checkTest struct {
+			input    *url.URL
+			expected bool
+		}
+
+		tests := []checkTest{
+			{utils.MustParseURL("https://www.reddit.com/r/forsen/comments/1abcde/forsen_3/"), true},
+			{utils.MustParseURL("https://reddit.com/r/forsen/comments/1abcde"), true},
+			{utils.MustParseURL("https://old.reddit.com/r/forsen/comments/1abcde/forsen_3/?utm_source=share"), true},
+			{utils.MustParseURL("https://np.reddit.com/user/pajlada/comments/1abcde/"), true},
+			{utils.MustParseURL("https://www.reddit.com/comments/1abcde"), true},
+			{utils.MustParseURL("https://redd.it/1abcde"), true},
+			{utils.MustParseURL("https://www.reddit.com/r/forsen/comments/1abcde/forsen_3/kx9z8y7/"), false},
+			{utils.MustParseURL("https://www.reddit.com/r/forsen/"), false},
+			{utils.MustParseURL("https://redd.it/"), false},
+			{utils.MustParseURL("https://notreddit.com/r/forsen/comments/1abcde"), false},
+		}
+
+		for _, test := range tests {
+			c.Run(test.input.String(), func(c *qt.C) {
+				_, output := postResolver.Check(ctx, test.input)
+				c.Assert(output, qt.Equals, test.expected)
+			})
+		}
+	})
+
+	c.Run("Run", func(c *qt.C) {
+		c.Run("Post", func(c *qt.C) {
+			data := runResolver(c, ctx, postResolver, "https://www.reddit.com/r/forsen/comments/1ABCDE/forsen_3/")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "https://preview.redd.it/image.jpg?width=1080&s=abc")
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Equals, `<div style="text-align: left;">`+
+				`<b>Forsen &lt;3</b><hr>`+
+				`<b>r/forsen</b> • u/pajlada • 2 hours ago<br>`+
+				`<b>Score:</b> 12,345 • 678 comments`+
+				`</div>`)
+
+			c.Assert(data.Data, qt.DeepEquals, &resolver.ResponseData{
+				Kind:      resolver.DataKindPost,
+				Title:     "Forsen <3",
+				Author:    "pajlada",
+				Comments:  678,
+				Published: createdUTC.Format(time.RFC3339),
+				Fields: map[string]string{
+					"subreddit": "r/forsen",
+					"score":     "12345",
+				},
+			})
+		})
+
+		c.Run("Short link", func(c *qt.C) {
+			data := runResolver(c, ctx, postResolver, "https://redd.it/1abcde")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Data.Title, qt.Equals, "Forsen <3")
+		})
+
+		c.Run("NSFW post", func(c *qt.C) {
+			data := runResolver(c, ctx, postResolver, "https://www.reddit.com/r/forsen/comments/1nsfw/")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "https://preview.redd.it/nsfw.jpg?blur=40")
+			c.Assert(data.Data.NSFW, qt.IsTrue)
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Contains, `<b>Score:</b> -5 • 0 comments`+
+				`<li><b><span style="color: red;">NSFW</span></b></li>`)
+		})
+
+		c.Run("Spoiler post", func(c *qt.C) {
+			data := runResolver(c, ctx, postResolver, "https://www.reddit.com/r/forsen/comments/1spoil/")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "https://preview.redd.it/spoiler.jpg?blur=40")
+			c.Assert(data.Data.Fields["spoiler"], qt.Equals, "true")
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Contains, `<li><b><span style="color: red;">SPOILER</span></b></li>`)
+		})
+
+		c.Run("NSFW post without preview", func(c *qt.C) {
+			data := runResolver(c, ctx, postResolver, "https://www.reddit.com/r/forsen/comments/1nsfwnopreview/")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "")
+		})
+
+		c.Run("Self post", func(c *qt.C) {
+			data := runResolver(c, ctx, postResolver, "https://www.reddit.com/r/forsen/comments/1self/")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "")
+		})
+
+		c.Run("German", func(c *qt.C) {
+			data := runResolver(c, i18n.OnContext(ctx, i18n.German), postResolver, "https://www.reddit.com/r/forsen/comments/1abcde/")
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Contains, `u/pajlada • vor 2 Stunden`)
+			c.Assert(tooltip, qt.Contains, `<b>Punkte:</b> 12.345 • 678 Kommentare`)
+		})
+
+		c.Run("Not found", func(c *qt.C) {
+			for _, postID := range []string{"404", "private", "1empty"} {
+				data := runResolver(c, ctx, postResolver, "https://www.reddit.com/comments/"+postID)
+				c.Assert(data.Status, qt.Equals, http.StatusNotFound)
+				c.Assert(data.Message, qt.Equals, "No Reddit post with this ID found")
+			}
+		})
+
+		c.Run("Bad JSON", func(c *qt.C) {
+			data := runResolver(c, ctx, postResolver, "https://www.reddit.com/comments/bad")
+			c.Assert(data.Status, qt.Equals, http.StatusInternalServerError)
+			c.Assert(data.Message, qt.Contains, "Reddit API unmarshal error")
+		})
+
+		c.Run("Blocked", func(c *qt.C) {
+			data := runResolver(c, ctx, postResolver, "https://www.reddit.com/comments/blocked")
+			c.Assert(data.Status, qt.Equals, http.StatusInternalServerError)
+			c.Assert(data.Message, qt.Contains, "Reddit API returned content type text/html")
+
+			data = runResolver(c, ctx, postResolver, "https://www.reddit.com/comments/forbidden")
+			c.Assert(data.Status, qt.Equals, http.StatusInternalServerError)
+			c.Assert(data.Message, qt.Contains, "Reddit API returned status 403")
+		})
+
+		c.Run("Rate limited", func(c *qt.C) {
+			_, err := postResolver.Run(ctx, utils.MustParseURL("https://www.reddit.com/comments/ratelimited"), nil)
+			c.Assert(err, qt.ErrorIs, resolver.ErrUpstreamUnavailable)
+		})
+	})
+}
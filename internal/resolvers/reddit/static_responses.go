@@ -0,0 +1,24 @@
+package reddit
+
+import (
+	"net/http"
+
+	"github.com/Chatterino/api/pkg/resolver"
+)
+
+var (
+	noRedditPostWithThisIDFound = &resolver.Response{
+		Status:  http.StatusNotFound,
+		Message: "No Reddit post with this ID found",
+	}
+
+	noRedditCommentWithThisIDFound = &resolver.Response{
+		Status:  http.StatusNotFound,
+		Message: "No Reddit comment with this ID found",
+	}
+
+	noSubredditWithThisNameFound = &resolver.Response{
+		Status:  http.StatusNotFound,
+		Message: "No subreddit with this name found",
+	}
+)
@@ -0,0 +1,104 @@
+package reddit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+)
+
+type redditSubredditTooltipData struct {
+	Name        string
+	Title       string
+	Description string
+	Members     string
+	Created     string
+	NSFW        bool
+}
+
+type SubredditLoader struct {
+	apiURL   *url.URL
+	upstream *resolver.Upstream
+}
+
+func (l *SubredditLoader) Load(ctx context.Context, name string, r *http.Request) (*resolver.Response, time.Duration, error) {
+	log := logger.FromContext(ctx)
+
+	log.Debugw("[Reddit] Get subreddit",
+		"name", name,
+	)
+
+	var thing redditThing
+	apiURL := buildURL(l.apiURL, "r/"+url.PathEscape(name)+"/about.json", nil)
+	if response, cacheDuration, err := resolver.RequestJSON(ctx, resolver.JSONRequest{
+		Upstream:      l.upstream,
+		API:           "Reddit API",
+		URL:           apiURL,
+		NotFound:      noSubredditWithThisNameFound,
+		CheckResponse: checkForbidden(noSubredditWithThisNameFound),
+	}, &thing); response != nil || err != nil {
+		return response, cacheDuration, err
+	}
+
+	// Reddit answers with search results for subreddits that don't exist
+	if thing.Kind != kindSubreddit {
+		return noSubredditWithThisNameFound, cache.NoSpecialDur, nil
+	}
+
+	var subreddit redditSubreddit
+	if err := json.Unmarshal(thing.Data, &subreddit); err != nil {
+		return resolver.Errorf("Reddit API unmarshal error: %s", err)
+	}
+
+	return subredditResponse(ctx, subreddit)
+}
+
+func subredditResponse(ctx context.Context, subreddit redditSubreddit) (*resolver.Response, time.Duration, error) {
+	lang := i18n.FromContext(ctx)
+	created := createdAt(subreddit.CreatedUTC)
+
+	data := redditSubredditTooltipData{
+		Name:        subreddit.DisplayNamePrefixed,
+		Title:       subreddit.Title,
+		Description: subreddit.PublicDescription,
+		Members:     humanize.NumberInt64In(lang, subreddit.Subscribers),
+		Created:     humanize.CreationDateIn(lang, created),
+		NSFW:        subreddit.Over18,
+	}
+
+	var tooltip bytes.Buffer
+	if err := redditSubredditTooltip.Execute(&tooltip, lang, data); err != nil {
+		return resolver.Errorf("Reddit subreddit template error: %s", err)
+	}
+
+	// Icons of NSFW subreddits are hidden like the thumbnails of NSFW posts
+	thumbnail := subreddit.CommunityIcon
+	if thumbnail == "" {
+		thumbnail = subreddit.IconImg
+	}
+	if subreddit.Over18 {
+		thumbnail = ""
+	}
+
+	return &resolver.Response{
+		Status:    200,
+		Tooltip:   url.PathEscape(tooltip.String()),
+		Thumbnail: thumbnail,
+		Data: &resolver.ResponseData{
+			Kind:        resolver.DataKindChannel,
+			Title:       subreddit.DisplayNamePrefixed,
+			Description: subreddit.PublicDescription,
+			Followers:   uint64(max(subreddit.Subscribers, 0)),
+			Published:   created.Format(time.RFC3339),
+			NSFW:        subreddit.Over18,
+		},
+	}, cache.NoSpecialDur, nil
+}
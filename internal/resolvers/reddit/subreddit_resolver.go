@@ -0,0 +1,57 @@
+package reddit
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+// Matches subreddit links, e.g. /r/forsen/
+var subredditRegex = regexp.MustCompile(`^\/r\/([a-zA-Z0-9_]+)\/?$`)
+
+type SubredditResolver struct {
+	subredditCache cache.Cache
+}
+
+func (r *SubredditResolver) Check(ctx context.Context, url *url.URL) (context.Context, bool) {
+	if !utils.IsDomains(url, domains) {
+		return ctx, false
+	}
+
+	return ctx, subredditRegex.MatchString(url.Path)
+}
+
+func (r *SubredditResolver) Run(ctx context.Context, url *url.URL, req *http.Request) (*cache.Response, error) {
+	match := subredditRegex.FindStringSubmatch(url.Path)
+	if len(match) != 2 {
+		return nil, errInvalidSubreddit
+	}
+
+	return r.subredditCache.Get(ctx, strings.ToLower(match[1]), req)
+}
+
+func (r *SubredditResolver) Name() string {
+	return "reddit:subreddit"
+}
+
+func NewSubredditResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, apiURL *url.URL, upstream *resolver.Upstream) *SubredditResolver {
+	subredditLoader := &SubredditLoader{
+		apiURL:   apiURL,
+		upstream: upstream,
+	}
+
+	r := &SubredditResolver{
+		subredditCache: cache.NewDefaultCache(ctx, cfg, pool, cache.NewLocalizedKeyProvider("reddit:subreddit"),
+			resolver.NewResponseMarshaller(subredditLoader), cfg.RedditSubredditCacheDuration),
+	}
+
+	return r
+}
@@ -0,0 +1,117 @@
+package reddit
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+)
+
+func TestSubredditResolver(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		CacheBackend: cache.BackendMemory,
+	}
+	ts := testServer()
+	defer ts.Close()
+	apiURL := utils.MustParseURL(ts.URL + "/")
+
+	subredditResolver := NewSubredditResolver(ctx, cfg, nil, apiURL, resolver.NewUpstream(cfg, "reddit"))
+
+	c.Assert(subredditResolver, qt.IsNotNil)
+
+	c.Run("Name", func(c *qt.C) {
+		c.Assert(subredditResolver.Name(), qt.Equals, "reddit:subreddit")
+	})
+
+	c.Run("Check", func(c *qt.C) {
+		type checkTest struct {
+			input    *url.URL
+			expected bool
+		}
+
+		tests := []checkTest{
+			{utils.MustParseURL("https://www.reddit.com/r/forsen"), true},
+			{utils.MustParseURL("https://reddit.com/r/Forsen/"), true},
+			{utils.MustParseURL("https://m.reddit.com/r/forsen_2/"), true},
+			{utils.MustParseURL("https://www.reddit.com/r/forsen/top"), false},
+			{utils.MustParseURL("https://www.reddit.com/user/forsen"), false},
+			{utils.MustParseURL("https://www.reddit.com/"), false},
+			{utils.MustParseURL("https://redd.it/r/forsen"), false},
+		}
+
+		for _, test := range tests {
+			c.Run(test.input.String(), func(c *qt.C) {
+				_, output := subredditResolver.Check(ctx, test.input)
+				c.Assert(output, qt.Equals, test.expected)
+			})
+		}
+	})
+
+	c.Run("Run", func(c *qt.C) {
+		c.Run("Subreddit", func(c *qt.C) {
+			data := runResolver(c, ctx, subredditResolver, "https://www.reddit.com/r/Forsen/")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "https://styles.redditmedia.com/community_icon.png?width=256")
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Equals, `<div style="text-align: left;">`+
+				`<b>r/forsen</b> - Forsen<br>`+
+				`The forsen subreddit<br>`+
+				`<b>Members:</b> 123,456<br>`+
+				`<b>Created:</b> 01 Jan 2015`+
+				`</div>`)
+
+			c.Assert(data.Data, qt.DeepEquals, &resolver.ResponseData{
+				Kind:        resolver.DataKindChannel,
+				Title:       "r/forsen",
+				Description: "The forsen subreddit",
+				Followers:   123456,
+				Published:   "2015-01-01T00:00:00Z",
+			})
+		})
+
+		c.Run("NSFW subreddit", func(c *qt.C) {
+			data := runResolver(c, ctx, subredditResolver, "https://www.reddit.com/r/nsfwsub")
+			c.Assert(data.Status, qt.Equals, http.StatusOK)
+			c.Assert(data.Thumbnail, qt.Equals, "")
+			c.Assert(data.Data.NSFW, qt.IsTrue)
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Equals, `<div style="text-align: left;">`+
+				`<b>r/nsfwsub</b><br>`+
+				`<b>Members:</b> 10<br>`+
+				`<b>Created:</b> 01 Jan 2015`+
+				`<li><b><span style="color: red;">NSFW</span></b></li>`+
+				`</div>`)
+		})
+
+		c.Run("German", func(c *qt.C) {
+			data := runResolver(c, i18n.OnContext(ctx, i18n.German), subredditResolver, "https://www.reddit.com/r/forsen")
+
+			tooltip, err := url.PathUnescape(data.Tooltip)
+			c.Assert(err, qt.IsNil)
+			c.Assert(tooltip, qt.Contains, `<b>Mitglieder:</b> 123.456`)
+		})
+
+		c.Run("Not found", func(c *qt.C) {
+			for _, name := range []string{"404", "private", "search"} {
+				data := runResolver(c, ctx, subredditResolver, "https://www.reddit.com/r/"+name)
+				c.Assert(data.Status, qt.Equals, http.StatusNotFound)
+				c.Assert(data.Message, qt.Equals, "No subreddit with this name found")
+			}
+		})
+	})
+}
@@ -4,22 +4,26 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Chatterino/api/internal/logger"
 	"github.com/Chatterino/api/pkg/cache"
 	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	"github.com/Chatterino/api/pkg/utils"
 )
 
 type EmoteLoader struct {
-	apiURL  string
-	baseURL string
+	apiURL   string
+	baseURL  string
+	upstream *resolver.Upstream
 }
 
 func (l *EmoteLoader) Load(ctx context.Context, emoteHash string, r *http.Request) (*resolver.Response, time.Duration, error) {
@@ -30,8 +34,14 @@ func (l *EmoteLoader) Load(ctx context.Context, emoteHash string, r *http.Reques
 	)
 
 	// Execute SevenTV API request
-	resp, err := resolver.RequestGET(ctx, fmt.Sprintf("%s/%s", l.apiURL, emoteHash))
+	resp, err := l.upstream.Do(func() (*http.Response, error) {
+		return resolver.RequestGET(ctx, fmt.Sprintf("%s/%s", l.apiURL, emoteHash))
+	})
 	if err != nil {
+		if errors.Is(err, resolver.ErrUpstreamUnavailable) {
+			return nil, cache.NoSpecialDur, err
+		}
+
 		return resolver.Errorf("7TV API request error: %s", err)
 	}
 	defer resp.Body.Close()
@@ -63,7 +73,7 @@ func (l *EmoteLoader) Load(ctx context.Context, emoteHash string, r *http.Reques
 
 	// Build a tooltip using the tooltip template (see tooltipTemplate) with the data we massaged above
 	var tooltip bytes.Buffer
-	if err := seventvEmoteTemplate.Execute(&tooltip, data); err != nil {
+	if err := seventvEmoteTemplate.Execute(&tooltip, i18n.FromContext(ctx), data); err != nil {
 		return resolver.Errorf("7TV emote template error: %s", err)
 	}
 
@@ -91,6 +101,16 @@ func (l *EmoteLoader) Load(ctx context.Context, emoteHash string, r *http.Reques
 		Status:    http.StatusOK,
 		Tooltip:   url.PathEscape(tooltip.String()),
 		Thumbnail: thumbnail,
+		Data: &resolver.ResponseData{
+			Kind:   resolver.DataKindEmote,
+			Title:  data.Code,
+			Author: data.Uploader,
+			Fields: map[string]string{
+				"provider": "7TV",
+				"type":     data.Type,
+				"unlisted": strconv.FormatBool(data.Unlisted),
+			},
+		},
 		// We don't include a Link here to prevent the resolved URL from changing
 		// It should really only be set when the URL is noticeably different (e.g. for short URLs)
 	}
@@ -100,7 +120,8 @@ func (l *EmoteLoader) Load(ctx context.Context, emoteHash string, r *http.Reques
 
 func NewEmoteLoader(cfg config.APIConfig, apiURL *url.URL) *EmoteLoader {
 	return &EmoteLoader{
-		apiURL:  apiURL.String(),
-		baseURL: cfg.BaseURL,
+		apiURL:   apiURL.String(),
+		baseURL:  cfg.BaseURL,
+		upstream: resolver.NewUpstream(cfg, "seventv"),
 	}
 }
@@ -42,8 +42,8 @@ func NewEmoteResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, a
 	emoteLoader := NewEmoteLoader(cfg, apiURL)
 
 	r := &EmoteResolver{
-		emoteCache: cache.NewPostgreSQLCache(
-			ctx, cfg, pool, cache.NewPrefixKeyProvider("seventv:emote"),
+		emoteCache: cache.NewDefaultCache(
+			ctx, cfg, pool, cache.NewLocalizedKeyProvider("seventv:emote"),
 			resolver.NewResponseMarshaller(emoteLoader), cfg.SeventvEmoteCacheDuration),
 	}
 
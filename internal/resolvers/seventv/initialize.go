@@ -3,11 +3,11 @@ package seventv
 import (
 	"context"
 	"errors"
-	"html/template"
 	"regexp"
 
 	"github.com/Chatterino/api/internal/db"
 	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	"github.com/Chatterino/api/pkg/utils"
 )
@@ -15,10 +15,10 @@ import (
 const (
 	tooltipTemplate = `<div style="text-align: left;">
 <b>{{.Code}}</b><br>
-<b>{{.Type}} 7TV Emote</b><br>
-<b>By:</b> {{.Uploader}}` +
+<b>{{t "%s 7TV Emote" (t .Type)}}</b><br>
+<b>{{t "By"}}:</b> {{.Uploader}}` +
 		`{{ if .Unlisted }}` + `
-<li><b><span style="color: red;">UNLISTED</span></b></li>{{ end }}
+<li><b><span style="color: red;">{{t "UNLISTED"}}</span></b></li>{{ end }}
 </div>`
 )
 
@@ -32,7 +32,7 @@ var (
 
 	emotePathRegex = regexp.MustCompile(`/emotes/([a-f\d]{24}|[0-7][\dA-HJKMNP-TV-Z]{25})`)
 
-	seventvEmoteTemplate = template.Must(template.New("seventvEmoteTooltip").Parse(tooltipTemplate))
+	seventvEmoteTemplate = i18n.MustTemplate("seventvEmoteTooltip", tooltipTemplate)
 )
 
 func Initialize(ctx context.Context, cfg config.APIConfig, pool db.Pool, resolvers *[]resolver.Resolver) {
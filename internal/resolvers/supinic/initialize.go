@@ -3,11 +3,11 @@ package supinic
 import (
 	"context"
 	"errors"
-	"html/template"
 	"regexp"
 
 	"github.com/Chatterino/api/internal/db"
 	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 )
 
@@ -17,14 +17,14 @@ const (
 	tooltipTemplate = `<div style="text-align: left;">
 <b>{{.Name}}</b><br>
 <br>
-<b>By:</b> {{.AuthorName}}<br>
-<b>Track ID:</b> {{.ID}}<br>
-<b>Duration:</b> {{.Duration}}<br>
-<b>Tags:</b> {{.Tags}}</div>`
+<b>{{t "By"}}:</b> {{.AuthorName}}<br>
+<b>{{t "Track ID"}}:</b> {{.ID}}<br>
+<b>{{t "Duration"}}:</b> {{.Duration}}<br>
+<b>{{t "Tags"}}:</b> {{.Tags}}</div>`
 )
 
 var (
-	trackListTemplate = template.Must(template.New("trackListEntryTooltip").Parse(tooltipTemplate))
+	trackListTemplate = i18n.MustTemplate("trackListEntryTooltip", tooltipTemplate)
 
 	errInvalidTrackPath = errors.New("invalid track list track path")
 
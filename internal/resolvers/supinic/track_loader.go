@@ -14,6 +14,7 @@ import (
 
 	"github.com/Chatterino/api/pkg/cache"
 	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 )
 
@@ -119,7 +120,7 @@ func (l *TrackLoader) Load(ctx context.Context, rawTrackID string, r *http.Reque
 
 	// Build a tooltip using the tooltip template (see tooltipTemplate) with the data we massaged above
 	var tooltip bytes.Buffer
-	if err := trackListTemplate.Execute(&tooltip, data); err != nil {
+	if err := trackListTemplate.Execute(&tooltip, i18n.FromContext(ctx), data); err != nil {
 		return &resolver.Response{
 			Status:  http.StatusInternalServerError,
 			Message: "Track list template error " + resolver.CleanResponse(err.Error()),
@@ -47,8 +47,8 @@ func NewTrackResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool) *
 	trackLoader := &TrackLoader{}
 
 	r := &TrackResolver{
-		trackCache: cache.NewPostgreSQLCache(
-			ctx, cfg, pool, cache.NewPrefixKeyProvider("supinic:track"),
+		trackCache: cache.NewDefaultCache(
+			ctx, cfg, pool, cache.NewLocalizedKeyProvider("supinic:track"),
 			resolver.NewResponseMarshaller(trackLoader), cfg.SupinicTrackCacheDuration),
 	}
 
@@ -0,0 +1,131 @@
+package twitch
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/internal/twitchapiclient"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/nicklaw5/helix"
+)
+
+// Number of streams whose viewers are counted, Helix returns at most 100 streams per request
+const categoryStreamCount = 100
+
+// Box art URLs from the search endpoint are sized already, e.g. https://static-cdn.jtvnw.net/ttv-boxart/509658-52x72.jpg
+var boxArtSizeRegex = regexp.MustCompile(`-\d+x\d+(\.\w+)$`)
+
+type twitchCategoryTooltipData struct {
+	Name         string
+	Viewers      string
+	LiveChannels string
+	// Whether there are more live channels than the ones counted
+	More bool
+}
+
+// boxArtURL returns the box art URL of a category in the size used for thumbnails
+func boxArtURL(boxArtURL string) string {
+	boxArtURL = strings.ReplaceAll(boxArtURL, "{width}", "285")
+	boxArtURL = strings.ReplaceAll(boxArtURL, "{height}", "380")
+
+	return boxArtSizeRegex.ReplaceAllString(boxArtURL, "-285x380$1")
+}
+
+type CategoryLoader struct {
+	helixAPI TwitchAPIClient
+}
+
+func (l *CategoryLoader) Load(ctx context.Context, slug string, r *http.Request) (*resolver.Response, time.Duration, error) {
+	log := logger.FromContext(ctx)
+	lang := i18n.FromContext(ctx)
+
+	log.Debugw("[Twitch] Get category",
+		"slug", slug,
+	)
+
+	// Helix can't look up categories by their slug, so the category is searched for by name instead
+	searchResponse, err := l.helixAPI.SearchCategories(&twitchapiclient.SearchCategoriesParams{
+		Query: strings.ReplaceAll(slug, "-", " "),
+		First: 20,
+	})
+	if err != nil {
+		log.Errorw("[Twitch] Error searching category",
+			"slug", slug,
+			"error", err,
+		)
+
+		return resolver.Errorf("Twitch category load error: %s", err)
+	}
+	if searchResponse.StatusCode != http.StatusOK {
+		return resolver.Errorf("Twitch category load error: %d %s", searchResponse.StatusCode, searchResponse.ErrorMessage)
+	}
+
+	var category *helix.Game
+	for i, c := range searchResponse.Data.Categories {
+		if categorySlug(c.Name) == slug {
+			category = &searchResponse.Data.Categories[i]
+			break
+		}
+	}
+
+	if category == nil {
+		return noTwitchCategoryWithThisNameFound, cache.NoSpecialDur, nil
+	}
+
+	streamsResponse, err := l.helixAPI.GetStreams(&helix.StreamsParams{
+		GameIDs: []string{category.ID},
+		First:   categoryStreamCount,
+	})
+	if err != nil {
+		log.Errorw("[Twitch] Error getting category streams",
+			"categoryID", category.ID,
+			"error", err,
+		)
+
+		return resolver.Errorf("Twitch category load error: %s", err)
+	}
+	if streamsResponse.StatusCode != http.StatusOK {
+		return resolver.Errorf("Twitch category load error: %d %s", streamsResponse.StatusCode, streamsResponse.ErrorMessage)
+	}
+
+	// Streams are sorted by viewers, so only the top streams are counted if there are more
+	var viewers uint64
+	for _, stream := range streamsResponse.Data.Streams {
+		viewers += uint64(stream.ViewerCount)
+	}
+	liveChannels := uint64(len(streamsResponse.Data.Streams))
+	moreStreams := streamsResponse.Data.Pagination.Cursor != ""
+
+	data := twitchCategoryTooltipData{
+		Name:         category.Name,
+		Viewers:      humanize.NumberIn(lang, viewers),
+		LiveChannels: humanize.NumberIn(lang, liveChannels),
+		More:         moreStreams,
+	}
+
+	var tooltip bytes.Buffer
+	if err := twitchCategoryTooltip.Execute(&tooltip, lang, data); err != nil {
+		return resolver.Errorf("Twitch category template error: %s", err)
+	}
+
+	return &resolver.Response{
+		Status:    200,
+		Tooltip:   url.PathEscape(tooltip.String()),
+		Thumbnail: boxArtURL(category.BoxArtURL),
+		Data: &resolver.ResponseData{
+			Kind:  resolver.DataKindCategory,
+			Title: category.Name,
+			Views: viewers,
+			Count: liveChannels,
+		},
+	}, cache.NoSpecialDur, nil
+}
@@ -0,0 +1,158 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/internal/mocks"
+	"github.com/Chatterino/api/internal/twitchapiclient"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	qt "github.com/frankban/quicktest"
+	"github.com/nicklaw5/helix"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBoxArtURL(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(boxArtURL("https://static-cdn.jtvnw.net/ttv-boxart/509658-52x72.jpg"), qt.Equals, "https://static-cdn.jtvnw.net/ttv-boxart/509658-285x380.jpg")
+	c.Assert(boxArtURL("https://static-cdn.jtvnw.net/ttv-boxart/509658-{width}x{height}.jpg"), qt.Equals, "https://static-cdn.jtvnw.net/ttv-boxart/509658-285x380.jpg")
+	c.Assert(boxArtURL(""), qt.Equals, "")
+}
+
+func TestCategoryLoader(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+	mockCtrl := gomock.NewController(c)
+	m := mocks.NewMockTwitchAPIClient(mockCtrl)
+
+	loader := &CategoryLoader{
+		helixAPI: m,
+	}
+
+	expectSearch := func(query string, categories ...helix.Game) {
+		response := &twitchapiclient.SearchCategoriesResponse{}
+		response.StatusCode = http.StatusOK
+		response.Data.Categories = categories
+
+		m.
+			EXPECT().
+			SearchCategories(gomock.Eq(&twitchapiclient.SearchCategoriesParams{Query: query, First: 20})).
+			Return(response, nil)
+	}
+
+	expectStreams := func(cursor string, viewers ...int) {
+		response := &helix.StreamsResponse{}
+		response.StatusCode = http.StatusOK
+		for _, v := range viewers {
+			response.Data.Streams = append(response.Data.Streams, helix.Stream{ViewerCount: v})
+		}
+		response.Data.Pagination.Cursor = cursor
+
+		m.
+			EXPECT().
+			GetStreams(gomock.Eq(&helix.StreamsParams{GameIDs: []string{"460630"}, First: 100})).
+			Return(response, nil)
+	}
+
+	siege := helix.Game{
+		ID:        "460630",
+		Name:      "Tom Clancy's Rainbow Six Siege",
+		BoxArtURL: "https://static-cdn.jtvnw.net/ttv-boxart/460630-52x72.jpg",
+	}
+	siegeX := helix.Game{
+		ID:   "1",
+		Name: "Tom Clancy's Rainbow Six Siege X",
+	}
+
+	c.Run("Category", func(c *qt.C) {
+		expectSearch("tom clancys rainbow six siege", siegeX, siege)
+		expectStreams("", 1000, 234)
+
+		response, _, err := loader.Load(ctx, "tom-clancys-rainbow-six-siege", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Status, qt.Equals, 200)
+		c.Assert(response.Thumbnail, qt.Equals, "https://static-cdn.jtvnw.net/ttv-boxart/460630-285x380.jpg")
+
+		tooltip, err := url.PathUnescape(response.Tooltip)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tooltip, qt.Equals, `<div style="text-align: left;"><b>Tom Clancy&#39;s Rainbow Six Siege</b><hr><b>Viewers:</b> 1,234<br><b>Live channels:</b> 2</div>`)
+
+		c.Assert(response.Data, qt.DeepEquals, &resolver.ResponseData{
+			Kind:  resolver.DataKindCategory,
+			Title: "Tom Clancy's Rainbow Six Siege",
+			Views: 1234,
+			Count: 2,
+		})
+	})
+
+	c.Run("More streams", func(c *qt.C) {
+		expectSearch("tom clancys rainbow six siege", siege)
+		expectStreams("cursor", 1000, 234)
+
+		response, _, err := loader.Load(i18n.OnContext(ctx, i18n.German), "tom-clancys-rainbow-six-siege", nil)
+		c.Assert(err, qt.IsNil)
+
+		tooltip, err := url.PathUnescape(response.Tooltip)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tooltip, qt.Contains, `<b>Zuschauer:</b> 1.234+<br><b>Live-Kanäle:</b> 2+`)
+	})
+
+	c.Run("No category", func(c *qt.C) {
+		expectSearch("tom clancys rainbow six siege", siegeX)
+
+		response, _, err := loader.Load(ctx, "tom-clancys-rainbow-six-siege", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Status, qt.Equals, 404)
+		c.Assert(response.Message, qt.Equals, "No Twitch category with this name found")
+	})
+
+	c.Run("Search error", func(c *qt.C) {
+		m.
+			EXPECT().
+			SearchCategories(gomock.Any()).
+			Return(nil, errors.New("error"))
+
+		response, _, err := loader.Load(ctx, "just-chatting", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Status, qt.Equals, 500)
+		c.Assert(response.Message, qt.Equals, "Twitch category load error: error")
+	})
+
+	c.Run("Search error response", func(c *qt.C) {
+		searchResponse := &twitchapiclient.SearchCategoriesResponse{}
+		searchResponse.StatusCode = http.StatusUnauthorized
+		searchResponse.ErrorMessage = "Invalid OAuth token"
+		m.
+			EXPECT().
+			SearchCategories(gomock.Any()).
+			Return(searchResponse, nil)
+
+		response, _, err := loader.Load(ctx, "just-chatting", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Status, qt.Equals, 500)
+		c.Assert(response.Message, qt.Equals, "Twitch category load error: 401 Invalid OAuth token")
+	})
+
+	c.Run("Streams error response", func(c *qt.C) {
+		expectSearch("tom clancys rainbow six siege", siege)
+
+		streamsResponse := &helix.StreamsResponse{}
+		streamsResponse.StatusCode = http.StatusTooManyRequests
+		streamsResponse.ErrorMessage = "Too Many Requests"
+		m.
+			EXPECT().
+			GetStreams(gomock.Any()).
+			Return(streamsResponse, nil)
+
+		response, _, err := loader.Load(ctx, "tom-clancys-rainbow-six-siege", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Status, qt.Equals, 500)
+		c.Assert(response.Message, qt.Equals, "Twitch category load error: 429 Too Many Requests")
+	})
+}
@@ -0,0 +1,81 @@
+package twitch
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+// Matches category pages, e.g. https://twitch.tv/directory/category/just-chatting, and the game pages
+// they replaced, e.g. https://twitch.tv/directory/game/Just%20Chatting
+var categoryRegex = regexp.MustCompile(`^\/directory\/(?:category|game)\/([^\/]+)\/?$`)
+
+// categorySlug returns the slug Twitch uses in the links of the category with the given name,
+// e.g. "Tom Clancy's Rainbow Six Siege" becomes "tom-clancys-rainbow-six-siege"
+func categorySlug(name string) string {
+	var slug strings.Builder
+
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			slug.WriteRune(r)
+		case r == ' ' || r == '-':
+			if slug.Len() > 0 && !strings.HasSuffix(slug.String(), "-") {
+				slug.WriteRune('-')
+			}
+		}
+	}
+
+	return strings.TrimSuffix(slug.String(), "-")
+}
+
+type CategoryResolver struct {
+	categoryCache cache.Cache
+}
+
+func (r *CategoryResolver) Check(ctx context.Context, url *url.URL) (context.Context, bool) {
+	if !utils.IsDomains(url, userDomains) {
+		return ctx, false
+	}
+
+	matches := categoryRegex.FindStringSubmatch(url.Path)
+
+	return ctx, len(matches) == 2 && categorySlug(matches[1]) != ""
+}
+
+func (r *CategoryResolver) Run(ctx context.Context, url *url.URL, req *http.Request) (*cache.Response, error) {
+	matches := categoryRegex.FindStringSubmatch(url.Path)
+	if len(matches) != 2 {
+		return nil, errInvalidTwitchCategory
+	}
+
+	return r.categoryCache.Get(ctx, categorySlug(matches[1]), req)
+}
+
+func (r *CategoryResolver) Name() string {
+	return "twitch:category"
+}
+
+func NewCategoryResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, helixAPI TwitchAPIClient) *CategoryResolver {
+	categoryLoader := &CategoryLoader{
+		helixAPI: helixAPI,
+	}
+
+	r := &CategoryResolver{
+		categoryCache: cache.NewDefaultCache(
+			ctx, cfg, pool, cache.NewLocalizedKeyProvider("twitch:category"),
+			resolver.NewResponseMarshaller(categoryLoader), cfg.TwitchCategoryCacheDuration,
+		),
+	}
+
+	return r
+}
@@ -0,0 +1,116 @@
+package twitch
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/internal/mocks"
+	"github.com/Chatterino/api/internal/twitchapiclient"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCategorySlug(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"just-chatting", "just-chatting"},
+		{"Just Chatting", "just-chatting"},
+		{"Tom Clancy's Rainbow Six Siege", "tom-clancys-rainbow-six-siege"},
+		{"Counter-Strike", "counter-strike"},
+		{"Art - Painting ", "art-painting"},
+		{"Pokémon", "pokémon"},
+		{"!!!", ""},
+	}
+
+	for _, test := range tests {
+		c.Run(test.input, func(c *qt.C) {
+			c.Assert(categorySlug(test.input), qt.Equals, test.expected)
+		})
+	}
+}
+
+func TestCategoryResolver(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	c := qt.New(t)
+
+	pool, _ := pgxmock.NewPool()
+	cfg := config.APIConfig{}
+	helixClient := mocks.NewMockTwitchAPIClient(ctrl)
+
+	resolver := NewCategoryResolver(ctx, cfg, pool, helixClient)
+
+	c.Assert(resolver, qt.IsNotNil)
+
+	c.Run("Name", func(c *qt.C) {
+		c.Assert(resolver.Name(), qt.Equals, "twitch:category")
+	})
+
+	c.Run("Check", func(c *qt.C) {
+		type checkTest struct {
+			input    *url.URL
+			expected bool
+		}
+
+		tests := []checkTest{
+			{utils.MustParseURL("https://twitch.tv/directory/category/just-chatting"), true},
+			{utils.MustParseURL("https://www.twitch.tv/directory/category/just-chatting/"), true},
+			{utils.MustParseURL("https://m.twitch.tv/directory/game/Just%20Chatting"), true},
+			{utils.MustParseURL("https://twitch.tv/directory/category/just-chatting/clips"), false},
+			{utils.MustParseURL("https://twitch.tv/directory/category/"), false},
+			{utils.MustParseURL("https://twitch.tv/directory/category/!!!"), false},
+			{utils.MustParseURL("https://twitch.tv/directory"), false},
+			{utils.MustParseURL("https://clips.twitch.tv/directory/category/just-chatting"), false},
+		}
+
+		for _, test := range tests {
+			c.Run(test.input.String(), func(c *qt.C) {
+				_, output := resolver.Check(ctx, test.input)
+				c.Assert(output, qt.Equals, test.expected)
+			})
+		}
+	})
+
+	c.Run("Run", func(c *qt.C) {
+		c.Run("Non-matching link", func(c *qt.C) {
+			outputBytes, outputError := resolver.Run(ctx, utils.MustParseURL("https://twitch.tv/directory"), nil)
+			c.Assert(outputError, qt.Equals, errInvalidTwitchCategory)
+			c.Assert(outputBytes, qt.IsNil)
+		})
+
+		tests := []struct {
+			label    string
+			inputURL *url.URL
+		}{
+			{"Category", utils.MustParseURL("https://twitch.tv/directory/category/just-chatting")},
+			{"Game", utils.MustParseURL("https://twitch.tv/directory/game/Just%20Chatting")},
+		}
+
+		for _, test := range tests {
+			c.Run(test.label, func(c *qt.C) {
+				response := &twitchapiclient.SearchCategoriesResponse{}
+				response.StatusCode = http.StatusOK
+				helixClient.EXPECT().SearchCategories(&twitchapiclient.SearchCategoriesParams{Query: "just chatting", First: 20}).Times(1).Return(response, nil)
+				pool.ExpectQuery("SELECT").WillReturnError(pgx.ErrNoRows)
+				pool.ExpectExec("INSERT INTO cache").
+					WithArgs("twitch:category:just-chatting", []byte(`{"status":404,"message":"No Twitch category with this name found"}`), http.StatusOK, "application/json", pgxmock.AnyArg()).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				_, outputError := resolver.Run(ctx, test.inputURL, nil)
+				c.Assert(outputError, qt.IsNil)
+				c.Assert(pool.ExpectationsWereMet(), qt.IsNil)
+			})
+		}
+	})
+}
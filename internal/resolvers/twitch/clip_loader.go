@@ -10,6 +10,7 @@ import (
 	"github.com/Chatterino/api/internal/logger"
 	"github.com/Chatterino/api/pkg/cache"
 	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	"github.com/nicklaw5/helix"
 )
@@ -29,6 +30,7 @@ type ClipLoader struct {
 
 func (l *ClipLoader) Load(ctx context.Context, clipSlug string, r *http.Request) (*resolver.Response, time.Duration, error) {
 	log := logger.FromContext(ctx)
+	lang := i18n.FromContext(ctx)
 
 	log.Debugw("[Twitch] Get clip",
 		"clipSlug", clipSlug,
@@ -55,12 +57,12 @@ func (l *ClipLoader) Load(ctx context.Context, clipSlug string, r *http.Request)
 		AuthorName:   clip.CreatorName,
 		ChannelName:  clip.BroadcasterName,
 		Duration:     humanize.DurationSeconds(time.Duration(clip.Duration) * time.Second),
-		CreationDate: humanize.CreationDateRFC3339(clip.CreatedAt),
-		Views:        humanize.Number(uint64(clip.ViewCount)),
+		CreationDate: humanize.CreationDateRFC3339In(lang, clip.CreatedAt),
+		Views:        humanize.NumberIn(lang, uint64(clip.ViewCount)),
 	}
 
 	var tooltip bytes.Buffer
-	if err := twitchClipsTooltip.Execute(&tooltip, data); err != nil {
+	if err := twitchClipsTooltip.Execute(&tooltip, lang, data); err != nil {
 		return resolver.Errorf("Twitch clip template error: %s", err)
 	}
 
@@ -68,5 +70,16 @@ func (l *ClipLoader) Load(ctx context.Context, clipSlug string, r *http.Request)
 		Status:    200,
 		Tooltip:   url.PathEscape(tooltip.String()),
 		Thumbnail: clip.ThumbnailURL,
+		Data: &resolver.ResponseData{
+			Kind:      resolver.DataKindClip,
+			Title:     clip.Title,
+			Author:    clip.BroadcasterName,
+			Duration:  int64(clip.Duration),
+			Views:     uint64(clip.ViewCount),
+			Published: clip.CreatedAt,
+			Fields: map[string]string{
+				"clipped_by": clip.CreatorName,
+			},
+		},
 	}, cache.NoSpecialDur, nil
 }
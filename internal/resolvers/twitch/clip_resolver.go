@@ -73,8 +73,8 @@ func NewClipResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, he
 	}
 
 	r := &ClipResolver{
-		clipCache: cache.NewPostgreSQLCache(
-			ctx, cfg, pool, cache.NewPrefixKeyProvider("twitch:clip"),
+		clipCache: cache.NewDefaultCache(
+			ctx, cfg, pool, cache.NewLocalizedKeyProvider("twitch:clip"),
 			resolver.NewResponseMarshaller(clipLoader), cfg.TwitchClipCacheDuration,
 		),
 	}
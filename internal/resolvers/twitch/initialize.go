@@ -5,11 +5,12 @@ package twitch
 import (
 	"context"
 	"errors"
-	"html/template"
 
 	"github.com/Chatterino/api/internal/db"
 	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/internal/twitchapiclient"
 	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	"github.com/Chatterino/api/pkg/utils"
 	"github.com/nicklaw5/helix"
@@ -19,44 +20,87 @@ type TwitchAPIClient interface {
 	GetClips(params *helix.ClipsParams) (clip *helix.ClipsResponse, err error)
 	GetUsers(params *helix.UsersParams) (user *helix.UsersResponse, err error)
 	GetStreams(params *helix.StreamsParams) (stream *helix.StreamsResponse, err error)
+	GetVideos(params *helix.VideosParams) (video *helix.VideosResponse, err error)
+	GetChannelFollowers(params *twitchapiclient.ChannelFollowersParams) (followers *twitchapiclient.ChannelFollowersResponse, err error)
+	GetSchedule(params *twitchapiclient.ScheduleParams) (schedule *twitchapiclient.ScheduleResponse, err error)
+	SearchCategories(params *twitchapiclient.SearchCategoriesParams) (categories *twitchapiclient.SearchCategoriesResponse, err error)
 }
 
 const (
 	twitchClipsTooltipString = `<div style="text-align: left;">` +
 		`<b>{{.Title}}</b><hr>` +
-		`<b>Clipped by:</b> {{.AuthorName}}<br>` +
-		`<b>Channel:</b> {{.ChannelName}}<br>` +
-		`<b>Duration:</b> {{.Duration}}<br>` +
-		`<b>Created:</b> {{.CreationDate}}<br>` +
-		`<b>Views:</b> {{.Views}}` +
+		`<b>{{t "Clipped by"}}:</b> {{.AuthorName}}<br>` +
+		`<b>{{t "Channel"}}:</b> {{.ChannelName}}<br>` +
+		`<b>{{t "Duration"}}:</b> {{.Duration}}<br>` +
+		`<b>{{t "Created"}}:</b> {{.CreationDate}}<br>` +
+		`<b>{{t "Views"}}:</b> {{.Views}}` +
+		`</div>`
+
+	twitchVideoTooltipString = `<div style="text-align: left;">` +
+		`<b>{{.Title}}</b><hr>` +
+		`<b>{{t "Channel"}}:</b> {{.ChannelName}}<br>` +
+		`<b>{{t "Type"}}:</b> {{t .Type}}<br>` +
+		`<b>{{t "Duration"}}:</b> {{.Duration}}<br>` +
+		`{{ if .StartOffset }}<b>{{t "Starts at"}}:</b> {{.StartOffset}}<br>{{ end }}` +
+		`<b>{{t "Published"}}:</b> {{.PublishedDate}}<br>` +
+		`<b>{{t "Views"}}:</b> {{.Views}}` +
+		`</div>`
+
+	twitchCategoryTooltipString = `<div style="text-align: left;">` +
+		`<b>{{.Name}}</b><hr>` +
+		`<b>{{t "Viewers"}}:</b> {{.Viewers}}{{if .More}}+{{end}}<br>` +
+		`<b>{{t "Live channels"}}:</b> {{.LiveChannels}}{{if .More}}+{{end}}` +
+		`</div>`
+
+	twitchScheduleTooltipString = `<div style="text-align: left;">` +
+		`<b>{{.Name}} - {{t "Schedule"}}</b><hr>` +
+		`{{ if .VacationUntil }}<b>{{t "On vacation until"}}:</b> {{.VacationUntil}}<br>{{ end }}` +
+		`{{ range $i, $segment := .Segments }}{{ if $i }}<br>{{ end }}` +
+		`<b>{{$segment.StartTime}}</b>{{ if $segment.Title }}: {{$segment.Title}}{{ end }}{{ if $segment.Category }} ({{$segment.Category}}){{ end }}` +
+		`{{ else }}{{t "No upcoming streams"}}{{ end }}` +
 		`</div>`
 
 	twitchUserTooltipString = `<div style="text-align: left;">` +
-		`<b>{{.Name}} - Twitch</b><br>` +
+		`<b>{{.Name}} - Twitch</b>{{ if .BroadcasterType }} <span style="color: #9146ff;">{{t .BroadcasterType}}</span>{{ end }}<br>` +
 		`{{.Description}}<br>` +
-		`<b>Created:</b> {{.CreatedAt}}<br>` +
-		`<b>URL:</b> {{.URL}}` +
+		`<b>{{t "Created"}}:</b> {{.CreatedAt}}<br>` +
+		`{{ if .Followers }}<b>{{t "Followers"}}:</b> {{.Followers}}<br>{{ end }}` +
+		`{{ if .LastLive }}<b>{{t "Last live"}}:</b> {{.LastLive}}<br>{{ end }}` +
+		`{{ if .LastVODTitle }}<b>{{t "Last VOD"}}:</b> {{.LastVODTitle}}<br>{{ end }}` +
+		`<b>{{t "URL"}}:</b> {{.URL}}` +
 		`</div>`
 
 	twitchUserLiveTooltipString = `<div style="text-align: left;">` +
 		`<b>{{.Name}} - Twitch</b><br>` +
 		`{{.Description}}<br>` +
-		`<b>Created:</b> {{.CreatedAt}}<br>` +
-		`<b>URL:</b> {{.URL}}<br>` +
-		`<b><span style="color: #ff0000;">Live</span></b><br>` +
-		`<b>Title</b>: {{.Title}}<br>` +
-		`<b>Game</b>: {{.Game}}<br>` +
-		`<b>Viewers</b>: {{.Viewers}}<br>` +
-		`<b>Uptime</b>: {{.Uptime}}` +
+		`<b>{{t "Created"}}:</b> {{.CreatedAt}}<br>` +
+		`<b>{{t "URL"}}:</b> {{.URL}}<br>` +
+		`<b><span style="color: #ff0000;">{{t "Live"}}</span></b><br>` +
+		`<b>{{t "Title"}}</b>: {{.Title}}<br>` +
+		`<b>{{t "Game"}}</b>: {{.Game}}<br>` +
+		`<b>{{t "Viewers"}}</b>: {{.Viewers}}<br>` +
+		`<b>{{t "Uptime"}}</b>: {{.Uptime}}` +
 		`</div>`
 )
 
+// Values of the twitch-user-thumbnail option
+const (
+	userThumbnailProfileImage  = "profile-image"
+	userThumbnailOfflineBanner = "offline-banner"
+)
+
 var (
-	errInvalidTwitchClip = errors.New("invalid Twitch clip link")
+	errInvalidTwitchClip     = errors.New("invalid Twitch clip link")
+	errInvalidTwitchVideo    = errors.New("invalid Twitch video link")
+	errInvalidTwitchCategory = errors.New("invalid Twitch category link")
+	errInvalidTwitchSchedule = errors.New("invalid Twitch schedule link")
 
-	twitchClipsTooltip    = template.Must(template.New("twitchclipsTooltip").Parse(twitchClipsTooltipString))
-	twitchUserTooltip     = template.Must(template.New("twitchUserTooltip").Parse(twitchUserTooltipString))
-	twitchUserLiveTooltip = template.Must(template.New("twitchUserLiveTooltip").Parse(twitchUserLiveTooltipString))
+	twitchClipsTooltip    = i18n.MustTemplate("twitchclipsTooltip", twitchClipsTooltipString)
+	twitchUserTooltip     = i18n.MustTemplate("twitchUserTooltip", twitchUserTooltipString)
+	twitchUserLiveTooltip = i18n.MustTemplate("twitchUserLiveTooltip", twitchUserLiveTooltipString)
+	twitchVideoTooltip    = i18n.MustTemplate("twitchVideoTooltip", twitchVideoTooltipString)
+	twitchCategoryTooltip = i18n.MustTemplate("twitchCategoryTooltip", twitchCategoryTooltipString)
+	twitchScheduleTooltip = i18n.MustTemplate("twitchScheduleTooltip", twitchScheduleTooltipString)
 
 	// Domains that can contain valid clips
 	domains = map[string]struct{}{
@@ -82,6 +126,17 @@ func Initialize(ctx context.Context, cfg config.APIConfig, pool db.Pool, helixCl
 		return
 	}
 
+	switch cfg.TwitchUserThumbnail {
+	case "", userThumbnailProfileImage, userThumbnailOfflineBanner:
+	default:
+		log.Warnw("[Config] Unknown twitch-user-thumbnail, offline channels will show their profile image",
+			"thumbnail", cfg.TwitchUserThumbnail,
+		)
+	}
+
 	*resolvers = append(*resolvers, NewUserResolver(ctx, cfg, pool, helixClient))
 	*resolvers = append(*resolvers, NewClipResolver(ctx, cfg, pool, helixClient))
+	*resolvers = append(*resolvers, NewVideoResolver(ctx, cfg, pool, helixClient))
+	*resolvers = append(*resolvers, NewCategoryResolver(ctx, cfg, pool, helixClient))
+	*resolvers = append(*resolvers, NewScheduleResolver(ctx, cfg, pool, helixClient))
 }
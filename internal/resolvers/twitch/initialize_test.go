@@ -6,10 +6,10 @@ import (
 
 	"github.com/Chatterino/api/internal/logger"
 	"github.com/Chatterino/api/internal/mocks"
+	"github.com/Chatterino/api/internal/twitchapiclient"
 	"github.com/Chatterino/api/pkg/config"
 	"github.com/Chatterino/api/pkg/resolver"
 	qt "github.com/frankban/quicktest"
-	"github.com/nicklaw5/helix"
 	"github.com/pashagolub/pgxmock"
 	"go.uber.org/mock/gomock"
 )
@@ -40,7 +40,7 @@ func TestInitialize(t *testing.T) {
 	})
 	c.Run("No helix client", func(c *qt.C) {
 		customResolvers := []resolver.Resolver{}
-		var helixClient *helix.Client = nil
+		var helixClient *twitchapiclient.Client = nil
 		c.Assert(customResolvers, qt.HasLen, 0)
 		Initialize(ctx, cfg, pool, helixClient, &customResolvers)
 		c.Assert(customResolvers, qt.HasLen, 0)
@@ -49,6 +49,6 @@ func TestInitialize(t *testing.T) {
 		customResolvers := []resolver.Resolver{}
 		c.Assert(customResolvers, qt.HasLen, 0)
 		Initialize(ctx, cfg, pool, helixClient, &customResolvers)
-		c.Assert(customResolvers, qt.HasLen, 2)
+		c.Assert(customResolvers, qt.HasLen, 5)
 	})
 }
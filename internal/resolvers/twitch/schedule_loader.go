@@ -0,0 +1,140 @@
+package twitch
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/internal/twitchapiclient"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/nicklaw5/helix"
+)
+
+// Maximum number of upcoming segments shown in the tooltip
+const maxScheduleSegments = 3
+
+type twitchScheduleSegmentData struct {
+	StartTime string
+	Title     string
+	Category  string
+}
+
+type twitchScheduleTooltipData struct {
+	Name          string
+	VacationUntil string
+	Segments      []twitchScheduleSegmentData
+}
+
+type ScheduleLoader struct {
+	helixAPI TwitchAPIClient
+}
+
+func (l *ScheduleLoader) Load(ctx context.Context, login string, r *http.Request) (*resolver.Response, time.Duration, error) {
+	log := logger.FromContext(ctx)
+	lang := i18n.FromContext(ctx)
+
+	log.Debugw("[Twitch] Get schedule",
+		"login", login,
+	)
+
+	usersResponse, err := l.helixAPI.GetUsers(&helix.UsersParams{Logins: []string{login}})
+	if err != nil {
+		log.Errorw("[Twitch] Error getting user",
+			"login", login,
+			"error", err,
+		)
+
+		return resolver.Errorf("Twitch user load error: %s", err)
+	}
+
+	if len(usersResponse.Data.Users) != 1 {
+		return noTwitchUserWithThisNameFound, cache.NoSpecialDur, nil
+	}
+
+	user := usersResponse.Data.Users[0]
+
+	scheduleResponse, err := l.helixAPI.GetSchedule(&twitchapiclient.ScheduleParams{
+		BroadcasterID: user.ID,
+		// Canceled segments are skipped, so a few more are requested than are shown
+		First: maxScheduleSegments * 2,
+	})
+	if err != nil {
+		log.Errorw("[Twitch] Error getting schedule",
+			"login", login,
+			"error", err,
+		)
+
+		return resolver.Errorf("Twitch schedule load error: %s", err)
+	}
+
+	// Broadcasters without any upcoming segments get a 404
+	if scheduleResponse.StatusCode != http.StatusOK && scheduleResponse.StatusCode != http.StatusNotFound {
+		return resolver.Errorf("Twitch schedule load error: %d %s", scheduleResponse.StatusCode, scheduleResponse.ErrorMessage)
+	}
+
+	schedule := scheduleResponse.Data
+
+	data := twitchScheduleTooltipData{
+		Name: buildName(login, user),
+	}
+
+	if schedule.Vacation != nil && schedule.Vacation.EndTime.After(time.Now()) {
+		data.VacationUntil = humanize.CreationDateTimeIn(lang, schedule.Vacation.EndTime.UTC())
+	}
+
+	var next *twitchapiclient.ScheduleSegment
+	for i, segment := range schedule.Segments {
+		if segment.CanceledUntil != nil {
+			continue
+		}
+		if len(data.Segments) == maxScheduleSegments {
+			break
+		}
+
+		segmentData := twitchScheduleSegmentData{
+			StartTime: humanize.CreationDateTimeIn(lang, segment.StartTime.UTC()),
+			Title:     segment.Title,
+		}
+		if segment.Category != nil {
+			segmentData.Category = segment.Category.Name
+		}
+
+		data.Segments = append(data.Segments, segmentData)
+		if next == nil {
+			next = &schedule.Segments[i]
+		}
+	}
+
+	var tooltip bytes.Buffer
+	if err := twitchScheduleTooltip.Execute(&tooltip, lang, data); err != nil {
+		return resolver.Errorf("Twitch schedule template error: %s", err)
+	}
+
+	responseData := &resolver.ResponseData{
+		Kind:   resolver.DataKindSchedule,
+		Author: data.Name,
+		Count:  uint64(len(data.Segments)),
+	}
+	if next != nil {
+		responseData.Title = next.Title
+		responseData.Published = next.StartTime.UTC().Format(time.RFC3339)
+		if next.Category != nil {
+			responseData.Fields = map[string]string{
+				"category": next.Category.Name,
+			}
+		}
+	}
+
+	return &resolver.Response{
+		Status:    200,
+		Tooltip:   url.PathEscape(tooltip.String()),
+		Thumbnail: user.ProfileImageURL,
+		Data:      responseData,
+	}, cache.NoSpecialDur, nil
+}
@@ -0,0 +1,195 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/internal/mocks"
+	"github.com/Chatterino/api/internal/twitchapiclient"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	qt "github.com/frankban/quicktest"
+	"github.com/nicklaw5/helix"
+	"go.uber.org/mock/gomock"
+)
+
+func TestScheduleLoader(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+	mockCtrl := gomock.NewController(c)
+	m := mocks.NewMockTwitchAPIClient(mockCtrl)
+
+	loader := &ScheduleLoader{
+		helixAPI: m,
+	}
+
+	user := helix.User{
+		ID:              "11148817",
+		Login:           "pajlada",
+		DisplayName:     "pajlada",
+		ProfileImageURL: "https://static-cdn.jtvnw.net/jtv_user_pictures/pajlada-profile_image.png",
+	}
+
+	expectUser := func() {
+		response := &helix.UsersResponse{}
+		response.Data.Users = []helix.User{user}
+
+		m.
+			EXPECT().
+			GetUsers(gomock.Eq(&helix.UsersParams{Logins: []string{"pajlada"}})).
+			Return(response, nil)
+	}
+
+	expectSchedule := func(statusCode int, schedule twitchapiclient.Schedule) {
+		response := &twitchapiclient.ScheduleResponse{Data: schedule}
+		response.StatusCode = statusCode
+
+		m.
+			EXPECT().
+			GetSchedule(gomock.Eq(&twitchapiclient.ScheduleParams{BroadcasterID: "11148817", First: 6})).
+			Return(response, nil)
+	}
+
+	start := time.Date(2030, time.March, 4, 18, 0, 0, 0, time.UTC)
+	canceledUntil := start.Add(2 * time.Hour)
+	segment := func(offset time.Duration, title, category string) twitchapiclient.ScheduleSegment {
+		s := twitchapiclient.ScheduleSegment{
+			StartTime: start.Add(offset),
+			EndTime:   start.Add(offset + 2*time.Hour),
+			Title:     title,
+		}
+		if category != "" {
+			s.Category = &twitchapiclient.ScheduleCategory{Name: category}
+		}
+		return s
+	}
+
+	c.Run("Schedule", func(c *qt.C) {
+		canceled := segment(0, "Canceled", "")
+		canceled.CanceledUntil = &canceledUntil
+
+		expectUser()
+		expectSchedule(http.StatusOK, twitchapiclient.Schedule{
+			Segments: []twitchapiclient.ScheduleSegment{
+				canceled,
+				segment(24*time.Hour, "Coding <3", "Software and Game Development"),
+				segment(48*time.Hour, "", "Just Chatting"),
+				segment(72*time.Hour, "No category", ""),
+				segment(96*time.Hour, "Too late", ""),
+			},
+		})
+
+		response, _, err := loader.Load(ctx, "pajlada", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Status, qt.Equals, 200)
+		c.Assert(response.Thumbnail, qt.Equals, user.ProfileImageURL)
+
+		tooltip, err := url.PathUnescape(response.Tooltip)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tooltip, qt.Equals, `<div style="text-align: left;"><b>pajlada - Schedule</b><hr>`+
+			`<b>05 Mar 2030 • 18:00 UTC</b>: Coding &lt;3 (Software and Game Development)<br>`+
+			`<b>06 Mar 2030 • 18:00 UTC</b> (Just Chatting)<br>`+
+			`<b>07 Mar 2030 • 18:00 UTC</b>: No category</div>`)
+
+		c.Assert(response.Data, qt.DeepEquals, &resolver.ResponseData{
+			Kind:      resolver.DataKindSchedule,
+			Title:     "Coding <3",
+			Author:    "pajlada",
+			Published: "2030-03-05T18:00:00Z",
+			Count:     3,
+			Fields: map[string]string{
+				"category": "Software and Game Development",
+			},
+		})
+	})
+
+	c.Run("No segments", func(c *qt.C) {
+		expectUser()
+		expectSchedule(http.StatusNotFound, twitchapiclient.Schedule{})
+
+		response, _, err := loader.Load(i18n.OnContext(ctx, i18n.German), "pajlada", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Status, qt.Equals, 200)
+
+		tooltip, err := url.PathUnescape(response.Tooltip)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tooltip, qt.Equals, `<div style="text-align: left;"><b>pajlada - Zeitplan</b><hr>Keine geplanten Streams</div>`)
+		c.Assert(response.Data.Count, qt.Equals, uint64(0))
+		c.Assert(response.Data.Title, qt.Equals, "")
+	})
+
+	c.Run("Vacation", func(c *qt.C) {
+		expectUser()
+		expectSchedule(http.StatusOK, twitchapiclient.Schedule{
+			Vacation: &twitchapiclient.ScheduleVacation{
+				StartTime: start.Add(-24 * time.Hour),
+				EndTime:   start,
+			},
+		})
+
+		response, _, err := loader.Load(ctx, "pajlada", nil)
+		c.Assert(err, qt.IsNil)
+
+		tooltip, err := url.PathUnescape(response.Tooltip)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tooltip, qt.Equals, `<div style="text-align: left;"><b>pajlada - Schedule</b><hr>`+
+			`<b>On vacation until:</b> 04 Mar 2030 • 18:00 UTC<br>No upcoming streams</div>`)
+	})
+
+	c.Run("Past vacation", func(c *qt.C) {
+		expectUser()
+		expectSchedule(http.StatusOK, twitchapiclient.Schedule{
+			Vacation: &twitchapiclient.ScheduleVacation{
+				StartTime: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC),
+			},
+		})
+
+		response, _, err := loader.Load(ctx, "pajlada", nil)
+		c.Assert(err, qt.IsNil)
+
+		tooltip, err := url.PathUnescape(response.Tooltip)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tooltip, qt.Not(qt.Contains), "On vacation until")
+	})
+
+	c.Run("No user", func(c *qt.C) {
+		m.
+			EXPECT().
+			GetUsers(gomock.Eq(&helix.UsersParams{Logins: []string{"pajlada"}})).
+			Return(&helix.UsersResponse{}, nil)
+
+		response, _, err := loader.Load(ctx, "pajlada", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Status, qt.Equals, 404)
+		c.Assert(response.Message, qt.Equals, "No Twitch user with this name found")
+	})
+
+	c.Run("Schedule error", func(c *qt.C) {
+		expectUser()
+		m.
+			EXPECT().
+			GetSchedule(gomock.Any()).
+			Return(nil, errors.New("error"))
+
+		response, _, err := loader.Load(ctx, "pajlada", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Status, qt.Equals, 500)
+		c.Assert(response.Message, qt.Equals, "Twitch schedule load error: error")
+	})
+
+	c.Run("Schedule error response", func(c *qt.C) {
+		expectUser()
+		expectSchedule(http.StatusBadRequest, twitchapiclient.Schedule{})
+
+		response, _, err := loader.Load(ctx, "pajlada", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Status, qt.Equals, 500)
+		c.Assert(response.Message, qt.Equals, "Twitch schedule load error: 400 ")
+	})
+}
@@ -0,0 +1,57 @@
+package twitch
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+var scheduleRegex = regexp.MustCompile(`^\/([a-zA-Z0-9_]+)\/schedule\/?$`)
+
+type ScheduleResolver struct {
+	scheduleCache cache.Cache
+}
+
+func (r *ScheduleResolver) Check(ctx context.Context, url *url.URL) (context.Context, bool) {
+	if !utils.IsDomains(url, userDomains) {
+		return ctx, false
+	}
+
+	return ctx, scheduleRegex.MatchString(url.Path)
+}
+
+func (r *ScheduleResolver) Run(ctx context.Context, url *url.URL, req *http.Request) (*cache.Response, error) {
+	matches := scheduleRegex.FindStringSubmatch(url.Path)
+	if len(matches) != 2 {
+		return nil, errInvalidTwitchSchedule
+	}
+
+	return r.scheduleCache.Get(ctx, strings.ToLower(matches[1]), req)
+}
+
+func (r *ScheduleResolver) Name() string {
+	return "twitch:schedule"
+}
+
+func NewScheduleResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, helixAPI TwitchAPIClient) *ScheduleResolver {
+	scheduleLoader := &ScheduleLoader{
+		helixAPI: helixAPI,
+	}
+
+	r := &ScheduleResolver{
+		scheduleCache: cache.NewDefaultCache(
+			ctx, cfg, pool, cache.NewLocalizedKeyProvider("twitch:schedule"),
+			resolver.NewResponseMarshaller(scheduleLoader), cfg.TwitchScheduleCacheDuration,
+		),
+	}
+
+	return r
+}
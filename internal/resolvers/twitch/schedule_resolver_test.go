@@ -0,0 +1,81 @@
+package twitch
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/internal/mocks"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+	"github.com/jackc/pgx/v4"
+	"github.com/nicklaw5/helix"
+	"github.com/pashagolub/pgxmock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestScheduleResolver(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	c := qt.New(t)
+
+	pool, _ := pgxmock.NewPool()
+	cfg := config.APIConfig{}
+	helixClient := mocks.NewMockTwitchAPIClient(ctrl)
+
+	resolver := NewScheduleResolver(ctx, cfg, pool, helixClient)
+
+	c.Assert(resolver, qt.IsNotNil)
+
+	c.Run("Name", func(c *qt.C) {
+		c.Assert(resolver.Name(), qt.Equals, "twitch:schedule")
+	})
+
+	c.Run("Check", func(c *qt.C) {
+		type checkTest struct {
+			input    *url.URL
+			expected bool
+		}
+
+		tests := []checkTest{
+			{utils.MustParseURL("https://twitch.tv/pajlada/schedule"), true},
+			{utils.MustParseURL("https://www.twitch.tv/pajlada/schedule/"), true},
+			{utils.MustParseURL("https://m.twitch.tv/pajlada/schedule?tz=UTC"), true},
+			{utils.MustParseURL("https://twitch.tv/pajlada"), false},
+			{utils.MustParseURL("https://twitch.tv/pajlada/schedule/extra"), false},
+			{utils.MustParseURL("https://twitch.tv/pajlada/videos"), false},
+			{utils.MustParseURL("https://clips.twitch.tv/pajlada/schedule"), false},
+			{utils.MustParseURL("https://nottwitch.tv/pajlada/schedule"), false},
+		}
+
+		for _, test := range tests {
+			c.Run(test.input.String(), func(c *qt.C) {
+				_, output := resolver.Check(ctx, test.input)
+				c.Assert(output, qt.Equals, test.expected)
+			})
+		}
+	})
+
+	c.Run("Run", func(c *qt.C) {
+		c.Run("Non-matching link", func(c *qt.C) {
+			outputBytes, outputError := resolver.Run(ctx, utils.MustParseURL("https://twitch.tv/pajlada"), nil)
+			c.Assert(outputError, qt.Equals, errInvalidTwitchSchedule)
+			c.Assert(outputBytes, qt.IsNil)
+		})
+
+		c.Run("Lowercase login", func(c *qt.C) {
+			helixClient.EXPECT().GetUsers(&helix.UsersParams{Logins: []string{"pajlada"}}).Times(1).Return(&helix.UsersResponse{}, nil)
+			pool.ExpectQuery("SELECT").WillReturnError(pgx.ErrNoRows)
+			pool.ExpectExec("INSERT INTO cache").
+				WithArgs("twitch:schedule:pajlada", []byte(`{"status":404,"message":"No Twitch user with this name found"}`), http.StatusOK, "application/json", pgxmock.AnyArg()).
+				WillReturnResult(pgxmock.NewResult("INSERT", 1))
+			_, outputError := resolver.Run(ctx, utils.MustParseURL("https://twitch.tv/PajLada/schedule"), nil)
+			c.Assert(outputError, qt.IsNil)
+			c.Assert(pool.ExpectationsWereMet(), qt.IsNil)
+		})
+	})
+}
@@ -10,3 +10,18 @@ var noTwitchClipWithThisIDFound = &resolver.Response{
 	Status:  http.StatusNotFound,
 	Message: "No Twitch Clip with this ID found",
 }
+
+var noTwitchVideoWithThisIDFound = &resolver.Response{
+	Status:  http.StatusNotFound,
+	Message: "No Twitch video with this ID found",
+}
+
+var noTwitchCategoryWithThisNameFound = &resolver.Response{
+	Status:  http.StatusNotFound,
+	Message: "No Twitch category with this name found",
+}
+
+var noTwitchUserWithThisNameFound = &resolver.Response{
+	Status:  http.StatusNotFound,
+	Message: "No Twitch user with this name found",
+}
@@ -6,21 +6,34 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/internal/twitchapiclient"
 	"github.com/Chatterino/api/pkg/cache"
 	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	"github.com/nicklaw5/helix"
 )
 
+// Names of the broadcaster types shown next to the name of partners and affiliates
+var broadcasterTypeNames = map[string]string{
+	"partner":   "Partner",
+	"affiliate": "Affiliate",
+}
+
 type twitchUserTooltipData struct {
-	Name        string
-	CreatedAt   string
-	Description string
-	URL         string
+	Name            string
+	BroadcasterType string
+	CreatedAt       string
+	Description     string
+	URL             string
+	Followers       string
+	LastLive        string
+	LastVODTitle    string
 }
 
 type twitchUserLiveTooltipData struct {
@@ -36,6 +49,9 @@ type twitchUserLiveTooltipData struct {
 
 type UserLoader struct {
 	helixAPI TwitchAPIClient
+
+	// Value of the twitch-user-thumbnail option
+	thumbnail string
 }
 
 func (l *UserLoader) Load(ctx context.Context, login string, r *http.Request) (*resolver.Response, time.Duration, error) {
@@ -63,10 +79,10 @@ func (l *UserLoader) Load(ctx context.Context, login string, r *http.Request) (*
 
 	streamResponse, err := l.helixAPI.GetStreams(&helix.StreamsParams{UserLogins: []string{login}})
 	if err != nil || len(streamResponse.Data.Streams) == 0 {
-		return userResponse(login, user)
+		return l.userResponse(ctx, login, user)
 	}
 
-	return userLiveResponse(login, user, streamResponse.Data.Streams[0])
+	return userLiveResponse(ctx, login, user, streamResponse.Data.Streams[0])
 }
 
 func buildName(login string, user helix.User) string {
@@ -77,40 +93,147 @@ func buildName(login string, user helix.User) string {
 	}
 }
 
-func userResponse(login string, user helix.User) (*resolver.Response, time.Duration, error) {
+// followers returns the number of followers of the user, or false if it couldn't be loaded
+func (l *UserLoader) followers(ctx context.Context, user helix.User) (int, bool) {
+	log := logger.FromContext(ctx)
+
+	response, err := l.helixAPI.GetChannelFollowers(&twitchapiclient.ChannelFollowersParams{
+		BroadcasterID: user.ID,
+		First:         1,
+	})
+	if err != nil {
+		log.Warnw("[Twitch] Error getting followers",
+			"login", user.Login,
+			"error", err,
+		)
+		return 0, false
+	}
+
+	if response.StatusCode != http.StatusOK {
+		log.Warnw("[Twitch] Error getting followers",
+			"login", user.Login,
+			"status", response.StatusCode,
+			"error", response.ErrorMessage,
+		)
+		return 0, false
+	}
+
+	return response.Data.Total, true
+}
+
+// lastVOD returns the most recent past broadcast of the user, if they have any
+func (l *UserLoader) lastVOD(ctx context.Context, user helix.User) (helix.Video, bool) {
+	log := logger.FromContext(ctx)
+
+	response, err := l.helixAPI.GetVideos(&helix.VideosParams{
+		UserID: user.ID,
+		Type:   "archive",
+		First:  1,
+	})
+	if err != nil {
+		log.Warnw("[Twitch] Error getting last VOD",
+			"login", user.Login,
+			"error", err,
+		)
+		return helix.Video{}, false
+	}
+
+	if response.StatusCode != http.StatusOK {
+		log.Warnw("[Twitch] Error getting last VOD",
+			"login", user.Login,
+			"status", response.StatusCode,
+			"error", response.ErrorMessage,
+		)
+		return helix.Video{}, false
+	}
+
+	if len(response.Data.Videos) == 0 {
+		return helix.Video{}, false
+	}
+
+	return response.Data.Videos[0], true
+}
+
+func (l *UserLoader) userResponse(ctx context.Context, login string, user helix.User) (*resolver.Response, time.Duration, error) {
+	lang := i18n.FromContext(ctx)
+
 	data := twitchUserTooltipData{
-		Name:        buildName(login, user),
-		CreatedAt:   humanize.CreationDate(user.CreatedAt.Time),
+		Name:            buildName(login, user),
+		BroadcasterType: broadcasterTypeNames[user.BroadcasterType],
+		CreatedAt:       humanize.CreationDateIn(lang, user.CreatedAt.Time),
+		Description:     user.Description,
+		URL:             fmt.Sprintf("https://twitch.tv/%s", user.Login),
+	}
+
+	responseData := &resolver.ResponseData{
+		Kind:        resolver.DataKindUser,
+		Title:       data.Name,
 		Description: user.Description,
-		URL:         fmt.Sprintf("https://twitch.tv/%s", user.Login),
+		Published:   user.CreatedAt.Format(time.RFC3339),
+		Fields:      map[string]string{},
+	}
+
+	if user.BroadcasterType != "" {
+		responseData.Fields["broadcaster_type"] = user.BroadcasterType
+	}
+
+	if followers, ok := l.followers(ctx, user); ok {
+		data.Followers = humanize.NumberIn(lang, uint64(followers))
+		responseData.Fields["followers"] = strconv.Itoa(followers)
+	}
+
+	if vod, ok := l.lastVOD(ctx, user); ok {
+		data.LastVODTitle = vod.Title
+		responseData.Fields["last_vod_title"] = vod.Title
+
+		// Past broadcasts are created when the stream starts, so they end when the stream ended
+		if createdAt, err := time.Parse(time.RFC3339, vod.CreatedAt); err == nil {
+			duration, _ := time.ParseDuration(vod.Duration)
+			endedAt := createdAt.Add(duration)
+
+			data.LastLive = humanize.AgoIn(lang, time.Since(endedAt))
+			responseData.Fields["last_live"] = endedAt.UTC().Format(time.RFC3339)
+		}
+	}
+
+	if len(responseData.Fields) == 0 {
+		responseData.Fields = nil
 	}
 
 	var tooltip bytes.Buffer
-	if err := twitchUserTooltip.Execute(&tooltip, data); err != nil {
+	if err := twitchUserTooltip.Execute(&tooltip, lang, data); err != nil {
 		return resolver.Errorf("Twitch user template error: %s", err)
 	}
 
+	thumbnail := user.ProfileImageURL
+	if l.thumbnail == userThumbnailOfflineBanner && user.OfflineImageURL != "" {
+		thumbnail = user.OfflineImageURL
+	}
+
 	return &resolver.Response{
 		Status:    200,
 		Tooltip:   url.PathEscape(tooltip.String()),
-		Thumbnail: user.ProfileImageURL,
+		Thumbnail: thumbnail,
+		Data:      responseData,
 	}, cache.NoSpecialDur, nil
 }
 
-func userLiveResponse(login string, user helix.User, stream helix.Stream) (*resolver.Response, time.Duration, error) {
+func userLiveResponse(ctx context.Context, login string, user helix.User, stream helix.Stream) (*resolver.Response, time.Duration, error) {
+	lang := i18n.FromContext(ctx)
+
 	data := twitchUserLiveTooltipData{
 		Name:        buildName(login, user),
-		CreatedAt:   humanize.CreationDate(user.CreatedAt.Time),
+		CreatedAt:   humanize.CreationDateIn(lang, user.CreatedAt.Time),
 		Description: user.Description,
 		URL:         fmt.Sprintf("https://twitch.tv/%s", user.Login),
 		Title:       stream.Title,
 		Game:        stream.GameName,
-		Viewers:     humanize.Number(uint64(stream.ViewerCount)),
+		Viewers:     humanize.NumberIn(lang, uint64(stream.ViewerCount)),
 		Uptime:      humanize.Duration(time.Since(stream.StartedAt)),
 	}
 
 	var tooltip bytes.Buffer
-	if err := twitchUserLiveTooltip.Execute(&tooltip, data); err != nil {
+	if err := twitchUserLiveTooltip.Execute(&tooltip, lang, data); err != nil {
 		return resolver.Errorf("Twitch user template error: %s", err)
 	}
 
@@ -121,5 +244,17 @@ func userLiveResponse(login string, user helix.User, stream helix.Stream) (*reso
 		Status:    200,
 		Tooltip:   url.PathEscape(tooltip.String()),
 		Thumbnail: thumbnail,
+		Data: &resolver.ResponseData{
+			Kind:        resolver.DataKindLivestream,
+			Title:       stream.Title,
+			Description: user.Description,
+			Author:      data.Name,
+			Views:       uint64(stream.ViewerCount),
+			Published:   stream.StartedAt.Format(time.RFC3339),
+			Live:        true,
+			Fields: map[string]string{
+				"game": stream.GameName,
+			},
+		},
 	}, cache.NoSpecialDur, nil
 }
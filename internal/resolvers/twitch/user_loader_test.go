@@ -0,0 +1,172 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/internal/mocks"
+	"github.com/Chatterino/api/internal/twitchapiclient"
+	"github.com/Chatterino/api/pkg/i18n"
+	qt "github.com/frankban/quicktest"
+	"github.com/nicklaw5/helix"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserLoaderOffline(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+	mockCtrl := gomock.NewController(c)
+	m := mocks.NewMockTwitchAPIClient(mockCtrl)
+
+	user := helix.User{
+		ID:              "11148817",
+		Login:           "pajlada",
+		DisplayName:     "pajlada",
+		BroadcasterType: "affiliate",
+		Description:     "Chatterino developer",
+		ProfileImageURL: "https://example.com/profile.png",
+		OfflineImageURL: "https://example.com/offline.png",
+		CreatedAt: helix.Time{
+			Time: time.Date(2011, 5, 19, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	endedAt := time.Now().Add(-50 * time.Hour).UTC().Truncate(time.Second)
+
+	expectOffline := func(user helix.User) {
+		usersResponse := &helix.UsersResponse{}
+		usersResponse.Data.Users = []helix.User{user}
+
+		m.EXPECT().GetUsers(&helix.UsersParams{Logins: []string{"pajlada"}}).Return(usersResponse, nil)
+		m.EXPECT().GetStreams(&helix.StreamsParams{UserLogins: []string{"pajlada"}}).Return(&helix.StreamsResponse{}, nil)
+	}
+
+	expectFollowers := func(response *twitchapiclient.ChannelFollowersResponse, err error) {
+		m.EXPECT().GetChannelFollowers(&twitchapiclient.ChannelFollowersParams{BroadcasterID: "11148817", First: 1}).Return(response, err)
+	}
+
+	expectVODs := func(videos ...helix.Video) {
+		response := &helix.VideosResponse{}
+		response.StatusCode = http.StatusOK
+		response.Data.Videos = videos
+
+		m.EXPECT().GetVideos(&helix.VideosParams{UserID: "11148817", Type: "archive", First: 1}).Return(response, nil)
+	}
+
+	followers := &twitchapiclient.ChannelFollowersResponse{
+		ResponseCommon: helix.ResponseCommon{StatusCode: http.StatusOK},
+		Data:           twitchapiclient.ManyChannelFollowers{Total: 123456},
+	}
+	vod := helix.Video{
+		Title:     "Fixing bugs <3",
+		CreatedAt: endedAt.Add(-(3*time.Hour + 15*time.Minute)).Format(time.RFC3339),
+		Duration:  "3h15m0s",
+	}
+
+	c.Run("Last VOD", func(c *qt.C) {
+		loader := &UserLoader{helixAPI: m}
+
+		expectOffline(user)
+		expectFollowers(followers, nil)
+		expectVODs(vod)
+
+		response, _, err := loader.Load(ctx, "pajlada", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Status, qt.Equals, 200)
+		c.Assert(response.Thumbnail, qt.Equals, "https://example.com/profile.png")
+
+		tooltip, err := url.PathUnescape(response.Tooltip)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tooltip, qt.Equals, `<div style="text-align: left;">`+
+			`<b>pajlada - Twitch</b> <span style="color: #9146ff;">Affiliate</span><br>`+
+			`Chatterino developer<br>`+
+			`<b>Created:</b> 19 May 2011<br>`+
+			`<b>Followers:</b> 123,456<br>`+
+			`<b>Last live:</b> 2 days ago<br>`+
+			`<b>Last VOD:</b> Fixing bugs &lt;3<br>`+
+			`<b>URL:</b> https://twitch.tv/pajlada</div>`)
+
+		c.Assert(response.Data.Fields, qt.DeepEquals, map[string]string{
+			"broadcaster_type": "affiliate",
+			"followers":        "123456",
+			"last_vod_title":   "Fixing bugs <3",
+			"last_live":        endedAt.Format(time.RFC3339),
+		})
+	})
+
+	c.Run("German", func(c *qt.C) {
+		loader := &UserLoader{helixAPI: m}
+
+		expectOffline(user)
+		expectFollowers(followers, nil)
+		expectVODs(vod)
+
+		response, _, err := loader.Load(i18n.OnContext(ctx, i18n.German), "pajlada", nil)
+		c.Assert(err, qt.IsNil)
+
+		tooltip, err := url.PathUnescape(response.Tooltip)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tooltip, qt.Contains, `<b>Follower:</b> 123.456<br>`)
+		c.Assert(tooltip, qt.Contains, `<b>Zuletzt live:</b> vor 2 Tagen<br>`)
+		c.Assert(tooltip, qt.Contains, `<b>Letztes VOD:</b> Fixing bugs &lt;3<br>`)
+	})
+
+	c.Run("Offline banner", func(c *qt.C) {
+		loader := &UserLoader{helixAPI: m, thumbnail: userThumbnailOfflineBanner}
+
+		expectOffline(user)
+		expectFollowers(followers, nil)
+		expectVODs()
+
+		response, _, err := loader.Load(ctx, "pajlada", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Thumbnail, qt.Equals, "https://example.com/offline.png")
+	})
+
+	c.Run("No offline banner", func(c *qt.C) {
+		loader := &UserLoader{helixAPI: m, thumbnail: userThumbnailOfflineBanner}
+
+		noBanner := user
+		noBanner.OfflineImageURL = ""
+		expectOffline(noBanner)
+		expectFollowers(followers, nil)
+		expectVODs()
+
+		response, _, err := loader.Load(ctx, "pajlada", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Thumbnail, qt.Equals, "https://example.com/profile.png")
+	})
+
+	c.Run("Followers and VOD errors", func(c *qt.C) {
+		loader := &UserLoader{helixAPI: m}
+
+		regularUser := user
+		regularUser.BroadcasterType = ""
+		expectOffline(regularUser)
+		expectFollowers(&twitchapiclient.ChannelFollowersResponse{
+			ResponseCommon: helix.ResponseCommon{
+				StatusCode:   http.StatusUnauthorized,
+				ErrorMessage: "Missing User OAUTH Token",
+			},
+		}, nil)
+		m.EXPECT().GetVideos(gomock.Any()).Return(nil, errors.New("error"))
+
+		response, _, err := loader.Load(ctx, "pajlada", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Status, qt.Equals, 200)
+
+		tooltip, err := url.PathUnescape(response.Tooltip)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tooltip, qt.Equals, `<div style="text-align: left;">`+
+			`<b>pajlada - Twitch</b><br>`+
+			`Chatterino developer<br>`+
+			`<b>Created:</b> 19 May 2011<br>`+
+			`<b>URL:</b> https://twitch.tv/pajlada</div>`)
+		c.Assert(response.Data.Fields, qt.IsNil)
+	})
+}
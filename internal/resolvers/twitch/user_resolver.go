@@ -56,10 +56,13 @@ func (r *UserResolver) Name() string {
 }
 
 func NewUserResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, helixAPI TwitchAPIClient) *UserResolver {
-	userLoader := &UserLoader{helixAPI: helixAPI}
+	userLoader := &UserLoader{
+		helixAPI:  helixAPI,
+		thumbnail: cfg.TwitchUserThumbnail,
+	}
 
 	r := &UserResolver{
-		userCache: cache.NewPostgreSQLCache(ctx, cfg, pool, cache.NewPrefixKeyProvider("twitch:user"),
+		userCache: cache.NewDefaultCache(ctx, cfg, pool, cache.NewLocalizedKeyProvider("twitch:user"),
 			resolver.NewResponseMarshaller(userLoader), cfg.TwitchUsernameCacheDuration),
 	}
 
@@ -10,6 +10,7 @@ import (
 
 	"github.com/Chatterino/api/internal/logger"
 	"github.com/Chatterino/api/internal/mocks"
+	"github.com/Chatterino/api/internal/twitchapiclient"
 	"github.com/Chatterino/api/pkg/cache"
 	"github.com/Chatterino/api/pkg/config"
 	"github.com/Chatterino/api/pkg/utils"
@@ -82,11 +83,18 @@ func TestUserResolver(t *testing.T) {
 				expectedUserError       error
 				expectedStreamsResponse *helix.StreamsResponse
 				expectedStreamsError    error
-				expectedResponse        *cache.Response
-				expectedError           error
-				rowsReturned            int
+				// Only requested for offline users
+				expectedFollowersResponse *twitchapiclient.ChannelFollowersResponse
+				expectedFollowersError    error
+				expectedVideosResponse    *helix.VideosResponse
+				expectedVideosError       error
+				expectedResponse          *cache.Response
+				expectedError             error
+				rowsReturned              int
 			}
 
+			startedAt := time.Now()
+
 			tests := []runTest{
 				{
 					label:    "twitch",
@@ -97,8 +105,9 @@ func TestUserResolver(t *testing.T) {
 						Data: helix.ManyUsers{
 							Users: []helix.User{
 								{
-									Login:       "twitch",
-									DisplayName: "Twitch",
+									Login:           "twitch",
+									DisplayName:     "Twitch",
+									BroadcasterType: "partner",
 									CreatedAt: helix.Time{
 										Time: time.Date(2007, 5, 22, 0, 0, 0, 0, time.UTC),
 									},
@@ -115,8 +124,13 @@ func TestUserResolver(t *testing.T) {
 						},
 					},
 					expectedStreamsError: nil,
+					expectedFollowersResponse: &twitchapiclient.ChannelFollowersResponse{
+						ResponseCommon: helix.ResponseCommon{StatusCode: http.StatusOK},
+						Data:           twitchapiclient.ManyChannelFollowers{Total: 1234},
+					},
+					expectedVideosResponse: &helix.VideosResponse{ResponseCommon: helix.ResponseCommon{StatusCode: http.StatusOK}},
 					expectedResponse: &cache.Response{
-						Payload:     []byte(`{"status":200,"thumbnail":"https://example.com/thumbnail.png","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%3Cb%3ETwitch%20-%20Twitch%3C%2Fb%3E%3Cbr%3ETwitch%20is%20where%20thousands%20of%20communities%20come%20together%20for%20whatever%2C%20every%20day.%20%3Cbr%3E%3Cb%3ECreated:%3C%2Fb%3E%2022%20May%202007%3Cbr%3E%3Cb%3EURL:%3C%2Fb%3E%20https:%2F%2Ftwitch.tv%2Ftwitch%3C%2Fdiv%3E"}`),
+						Payload:     []byte(`{"status":200,"thumbnail":"https://example.com/thumbnail.png","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%3Cb%3ETwitch%20-%20Twitch%3C%2Fb%3E%20%3Cspan%20style=%22color:%20%239146ff%3B%22%3EPartner%3C%2Fspan%3E%3Cbr%3ETwitch%20is%20where%20thousands%20of%20communities%20come%20together%20for%20whatever%2C%20every%20day.%20%3Cbr%3E%3Cb%3ECreated:%3C%2Fb%3E%2022%20May%202007%3Cbr%3E%3Cb%3EFollowers:%3C%2Fb%3E%201%2C234%3Cbr%3E%3Cb%3EURL:%3C%2Fb%3E%20https:%2F%2Ftwitch.tv%2Ftwitch%3C%2Fdiv%3E","data":{"kind":"user","title":"Twitch","description":"Twitch is where thousands of communities come together for whatever, every day. ","published":"2007-05-22T00:00:00Z","fields":{"broadcaster_type":"partner","followers":"1234"}}}`),
 						StatusCode:  http.StatusOK,
 						ContentType: "application/json",
 					},
@@ -142,11 +156,15 @@ func TestUserResolver(t *testing.T) {
 							},
 						},
 					},
-					expectedUserError:       nil,
-					expectedStreamsResponse: nil,
-					expectedStreamsError:    errors.New("error"),
+					expectedUserError:         nil,
+					expectedStreamsResponse:   nil,
+					expectedStreamsError:      errors.New("error"),
+					expectedFollowersResponse: nil,
+					expectedFollowersError:    errors.New("error"),
+					expectedVideosResponse:    nil,
+					expectedVideosError:       errors.New("error"),
 					expectedResponse: &cache.Response{
-						Payload:     []byte(`{"status":200,"thumbnail":"https://example.com/thumbnail.png","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%3Cb%3ETwitch%20-%20Twitch%3C%2Fb%3E%3Cbr%3ETwitch%20is%20where%20thousands%20of%20communities%20come%20together%20for%20whatever%2C%20every%20day.%20%3Cbr%3E%3Cb%3ECreated:%3C%2Fb%3E%2022%20May%202007%3Cbr%3E%3Cb%3EURL:%3C%2Fb%3E%20https:%2F%2Ftwitch.tv%2Ftwitch%3C%2Fdiv%3E"}`),
+						Payload:     []byte(`{"status":200,"thumbnail":"https://example.com/thumbnail.png","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%3Cb%3ETwitch%20-%20Twitch%3C%2Fb%3E%3Cbr%3ETwitch%20is%20where%20thousands%20of%20communities%20come%20together%20for%20whatever%2C%20every%20day.%20%3Cbr%3E%3Cb%3ECreated:%3C%2Fb%3E%2022%20May%202007%3Cbr%3E%3Cb%3EURL:%3C%2Fb%3E%20https:%2F%2Ftwitch.tv%2Ftwitch%3C%2Fdiv%3E","data":{"kind":"user","title":"Twitch","description":"Twitch is where thousands of communities come together for whatever, every day. ","published":"2007-05-22T00:00:00Z"}}`),
 						StatusCode:  http.StatusOK,
 						ContentType: "application/json",
 					},
@@ -180,7 +198,7 @@ func TestUserResolver(t *testing.T) {
 									Title:        "title",
 									GameName:     "Just Chatting",
 									ViewerCount:  1234,
-									StartedAt:    time.Now(),
+									StartedAt:    startedAt,
 									ThumbnailURL: "https://example.com/thumbnail_{width}x{height}.png",
 								},
 							},
@@ -188,7 +206,7 @@ func TestUserResolver(t *testing.T) {
 					},
 					expectedStreamsError: nil,
 					expectedResponse: &cache.Response{
-						Payload:     []byte(`{"status":200,"thumbnail":"https://example.com/thumbnail_1280x720.png","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%3Cb%3ETwitch%20-%20Twitch%3C%2Fb%3E%3Cbr%3ETwitch%20is%20where%20thousands%20of%20communities%20come%20together%20for%20whatever%2C%20every%20day.%20%3Cbr%3E%3Cb%3ECreated:%3C%2Fb%3E%2022%20May%202007%3Cbr%3E%3Cb%3EURL:%3C%2Fb%3E%20https:%2F%2Ftwitch.tv%2Ftwitch%3Cbr%3E%3Cb%3E%3Cspan%20style=%22color:%20%23ff0000%3B%22%3ELive%3C%2Fspan%3E%3C%2Fb%3E%3Cbr%3E%3Cb%3ETitle%3C%2Fb%3E:%20title%3Cbr%3E%3Cb%3EGame%3C%2Fb%3E:%20Just%20Chatting%3Cbr%3E%3Cb%3EViewers%3C%2Fb%3E:%201%2C234%3Cbr%3E%3Cb%3EUptime%3C%2Fb%3E:%2000:00:00%3C%2Fdiv%3E"}`),
+						Payload:     []byte(`{"status":200,"thumbnail":"https://example.com/thumbnail_1280x720.png","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%3Cb%3ETwitch%20-%20Twitch%3C%2Fb%3E%3Cbr%3ETwitch%20is%20where%20thousands%20of%20communities%20come%20together%20for%20whatever%2C%20every%20day.%20%3Cbr%3E%3Cb%3ECreated:%3C%2Fb%3E%2022%20May%202007%3Cbr%3E%3Cb%3EURL:%3C%2Fb%3E%20https:%2F%2Ftwitch.tv%2Ftwitch%3Cbr%3E%3Cb%3E%3Cspan%20style=%22color:%20%23ff0000%3B%22%3ELive%3C%2Fspan%3E%3C%2Fb%3E%3Cbr%3E%3Cb%3ETitle%3C%2Fb%3E:%20title%3Cbr%3E%3Cb%3EGame%3C%2Fb%3E:%20Just%20Chatting%3Cbr%3E%3Cb%3EViewers%3C%2Fb%3E:%201%2C234%3Cbr%3E%3Cb%3EUptime%3C%2Fb%3E:%2000:00:00%3C%2Fdiv%3E","data":{"kind":"livestream","title":"title","description":"Twitch is where thousands of communities come together for whatever, every day. ","author":"Twitch","views":1234,"published":"` + startedAt.Format(time.RFC3339) + `","live":true,"fields":{"game":"Just Chatting"}}}`),
 						StatusCode:  http.StatusOK,
 						ContentType: "application/json",
 					},
@@ -200,6 +218,10 @@ func TestUserResolver(t *testing.T) {
 				c.Run(test.label, func(c *qt.C) {
 					helixClient.EXPECT().GetUsers(&helix.UsersParams{Logins: []string{test.login}}).Times(1).Return(test.expectedUsersResponse, test.expectedUserError)
 					helixClient.EXPECT().GetStreams(&helix.StreamsParams{UserLogins: []string{test.login}}).Times(1).Return(test.expectedStreamsResponse, test.expectedStreamsError)
+					if test.expectedStreamsResponse == nil || len(test.expectedStreamsResponse.Data.Streams) == 0 {
+						helixClient.EXPECT().GetChannelFollowers(&twitchapiclient.ChannelFollowersParams{First: 1}).Times(1).Return(test.expectedFollowersResponse, test.expectedFollowersError)
+						helixClient.EXPECT().GetVideos(&helix.VideosParams{Type: "archive", First: 1}).Times(1).Return(test.expectedVideosResponse, test.expectedVideosError)
+					}
 					pool.ExpectQuery("SELECT").WillReturnError(pgx.ErrNoRows)
 					pool.ExpectExec("INSERT INTO cache").
 						WithArgs("twitch:user:"+test.login, test.expectedResponse.Payload, test.expectedResponse.StatusCode, test.expectedResponse.ContentType, pgxmock.AnyArg()).
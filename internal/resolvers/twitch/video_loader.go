@@ -0,0 +1,119 @@
+package twitch
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/nicklaw5/helix"
+)
+
+// Names of the video types returned by Helix, as shown in the tooltip
+var videoTypeNames = map[string]string{
+	"archive":   "Past broadcast",
+	"highlight": "Highlight",
+	"upload":    "Upload",
+}
+
+type twitchVideoTooltipData struct {
+	Title         string
+	ChannelName   string
+	Type          string
+	Duration      string
+	StartOffset   string
+	PublishedDate string
+	Views         string
+}
+
+type VideoLoader struct {
+	helixAPI TwitchAPIClient
+}
+
+func (l *VideoLoader) Load(ctx context.Context, key string, r *http.Request) (*resolver.Response, time.Duration, error) {
+	log := logger.FromContext(ctx)
+	lang := i18n.FromContext(ctx)
+
+	videoID, offset := parseVideoKey(key)
+
+	log.Debugw("[Twitch] Get video",
+		"videoID", videoID,
+		"offset", offset,
+	)
+
+	response, err := l.helixAPI.GetVideos(&helix.VideosParams{IDs: []string{videoID}})
+	if err != nil {
+		log.Errorw("[Twitch] Error getting video",
+			"videoID", videoID,
+			"error", err,
+		)
+
+		return resolver.Errorf("Twitch video load error: %s", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return resolver.Errorf("Twitch video load error: %d %s", response.StatusCode, response.ErrorMessage)
+	}
+
+	if len(response.Data.Videos) != 1 {
+		return noTwitchVideoWithThisIDFound, cache.NoSpecialDur, nil
+	}
+
+	var video = response.Data.Videos[0]
+
+	duration, _ := time.ParseDuration(video.Duration)
+
+	videoType, ok := videoTypeNames[video.Type]
+	if !ok {
+		videoType = video.Type
+	}
+
+	data := twitchVideoTooltipData{
+		Title:         video.Title,
+		ChannelName:   video.UserName,
+		Type:          videoType,
+		Duration:      humanize.Duration(duration),
+		PublishedDate: humanize.CreationDateRFC3339In(lang, video.PublishedAt),
+		Views:         humanize.NumberIn(lang, uint64(video.ViewCount)),
+	}
+
+	fields := map[string]string{
+		"type": video.Type,
+	}
+
+	if offset > 0 {
+		data.StartOffset = humanize.Duration(offset)
+		fields["start_offset"] = strconv.Itoa(int(offset.Seconds()))
+	}
+
+	var tooltip bytes.Buffer
+	if err := twitchVideoTooltip.Execute(&tooltip, lang, data); err != nil {
+		return resolver.Errorf("Twitch video template error: %s", err)
+	}
+
+	thumbnail := strings.ReplaceAll(video.ThumbnailURL, "%{width}", "1280")
+	thumbnail = strings.ReplaceAll(thumbnail, "%{height}", "720")
+
+	return &resolver.Response{
+		Status:    200,
+		Tooltip:   url.PathEscape(tooltip.String()),
+		Thumbnail: thumbnail,
+		Data: &resolver.ResponseData{
+			Kind:        resolver.DataKindVideo,
+			Title:       video.Title,
+			Description: video.Description,
+			Author:      video.UserName,
+			Duration:    int64(duration.Seconds()),
+			Views:       uint64(video.ViewCount),
+			Published:   video.PublishedAt,
+			Fields:      fields,
+		},
+	}, cache.NoSpecialDur, nil
+}
@@ -0,0 +1,144 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/internal/mocks"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	qt "github.com/frankban/quicktest"
+	"github.com/nicklaw5/helix"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVideoLoader(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+	mockCtrl := gomock.NewController(c)
+	m := mocks.NewMockTwitchAPIClient(mockCtrl)
+
+	loader := &VideoLoader{
+		helixAPI: m,
+	}
+
+	video := helix.Video{
+		ID:           "123456789",
+		UserName:     "pajlada",
+		Title:        "Past broadcast <b>title</b>",
+		Description:  "Description",
+		PublishedAt:  "2023-04-01T12:00:00Z",
+		ThumbnailURL: "https://static-cdn.jtvnw.net/cf_vods/thumb0-%{width}x%{height}.jpg",
+		ViewCount:    12345,
+		Type:         "archive",
+		Duration:     "3h2m1s",
+	}
+
+	expectVideos := func(videos ...helix.Video) {
+		response := &helix.VideosResponse{}
+		response.StatusCode = http.StatusOK
+		response.Data.Videos = videos
+
+		m.
+			EXPECT().
+			GetVideos(gomock.Eq(&helix.VideosParams{IDs: []string{"123456789"}})).
+			Return(response, nil)
+	}
+
+	c.Run("Video", func(c *qt.C) {
+		expectVideos(video)
+
+		response, _, err := loader.Load(ctx, "123456789", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Status, qt.Equals, 200)
+		c.Assert(response.Thumbnail, qt.Equals, "https://static-cdn.jtvnw.net/cf_vods/thumb0-1280x720.jpg")
+
+		tooltip, err := url.PathUnescape(response.Tooltip)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tooltip, qt.Equals, `<div style="text-align: left;"><b>Past broadcast &lt;b&gt;title&lt;/b&gt;</b><hr><b>Channel:</b> pajlada<br><b>Type:</b> Past broadcast<br><b>Duration:</b> 03:02:01<br><b>Published:</b> 01 Apr 2023<br><b>Views:</b> 12,345</div>`)
+
+		c.Assert(response.Data, qt.DeepEquals, &resolver.ResponseData{
+			Kind:        resolver.DataKindVideo,
+			Title:       "Past broadcast <b>title</b>",
+			Description: "Description",
+			Author:      "pajlada",
+			Duration:    10921,
+			Views:       12345,
+			Published:   "2023-04-01T12:00:00Z",
+			Fields: map[string]string{
+				"type": "archive",
+			},
+		})
+	})
+
+	c.Run("Start offset", func(c *qt.C) {
+		highlight := video
+		highlight.Type = "highlight"
+		expectVideos(highlight)
+
+		response, _, err := loader.Load(ctx, "123456789?t=3723", nil)
+		c.Assert(err, qt.IsNil)
+
+		tooltip, err := url.PathUnescape(response.Tooltip)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tooltip, qt.Contains, `<b>Type:</b> Highlight<br>`)
+		c.Assert(tooltip, qt.Contains, `<b>Duration:</b> 03:02:01<br><b>Starts at:</b> 01:02:03<br>`)
+		c.Assert(response.Data.Fields, qt.DeepEquals, map[string]string{
+			"type":         "highlight",
+			"start_offset": "3723",
+		})
+	})
+
+	c.Run("German", func(c *qt.C) {
+		expectVideos(video)
+
+		response, _, err := loader.Load(i18n.OnContext(ctx, i18n.German), "123456789?t=60", nil)
+		c.Assert(err, qt.IsNil)
+
+		tooltip, err := url.PathUnescape(response.Tooltip)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tooltip, qt.Contains, `<b>Typ:</b> Vergangene Übertragung<br>`)
+		c.Assert(tooltip, qt.Contains, `<b>Beginnt bei:</b> 00:01:00<br>`)
+		c.Assert(tooltip, qt.Contains, `<b>Veröffentlicht:</b> 01.04.2023<br>`)
+	})
+
+	c.Run("No video", func(c *qt.C) {
+		expectVideos()
+
+		response, _, err := loader.Load(ctx, "123456789", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Status, qt.Equals, 404)
+		c.Assert(response.Message, qt.Equals, "No Twitch video with this ID found")
+	})
+
+	c.Run("Error", func(c *qt.C) {
+		m.
+			EXPECT().
+			GetVideos(gomock.Eq(&helix.VideosParams{IDs: []string{"123456789"}})).
+			Return(nil, errors.New("error"))
+
+		response, _, err := loader.Load(ctx, "123456789", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Status, qt.Equals, 500)
+		c.Assert(response.Message, qt.Equals, "Twitch video load error: error")
+	})
+
+	c.Run("Error response", func(c *qt.C) {
+		response := &helix.VideosResponse{}
+		response.StatusCode = http.StatusTooManyRequests
+		response.ErrorMessage = "Too Many Requests"
+		m.
+			EXPECT().
+			GetVideos(gomock.Eq(&helix.VideosParams{IDs: []string{"123456789"}})).
+			Return(response, nil)
+
+		loaded, _, err := loader.Load(ctx, "123456789", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(loaded.Status, qt.Equals, 500)
+		c.Assert(loaded.Message, qt.Equals, "Twitch video load error: 429 Too Many Requests")
+	})
+}
@@ -0,0 +1,93 @@
+package twitch
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+// Matches VODs, highlights and uploads, e.g. https://twitch.tv/videos/123456789
+var videoRegex = regexp.MustCompile(`^\/videos\/(\d+)\/?$`)
+
+// parseVideoOffset parses the start offset of a video link's t parameter, e.g. 1h2m3s or 3723
+func parseVideoOffset(t string) time.Duration {
+	if seconds, err := strconv.Atoi(t); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+
+	offset, err := time.ParseDuration(t)
+	if err != nil || offset < 0 {
+		return 0
+	}
+
+	return offset.Truncate(time.Second)
+}
+
+// videoKey returns the cache key of a video link. Links with a start offset are cached separately,
+// since the offset is part of their tooltip.
+func videoKey(videoID string, offset time.Duration) string {
+	if offset <= 0 {
+		return videoID
+	}
+
+	return videoID + "?t=" + strconv.Itoa(int(offset.Seconds()))
+}
+
+// parseVideoKey returns the video ID and start offset of a key returned by videoKey
+func parseVideoKey(key string) (string, time.Duration) {
+	videoID, t, _ := strings.Cut(key, "?t=")
+
+	return videoID, parseVideoOffset(t)
+}
+
+type VideoResolver struct {
+	videoCache cache.Cache
+}
+
+func (r *VideoResolver) Check(ctx context.Context, url *url.URL) (context.Context, bool) {
+	if !utils.IsDomains(url, userDomains) {
+		return ctx, false
+	}
+
+	return ctx, videoRegex.MatchString(url.Path)
+}
+
+func (r *VideoResolver) Run(ctx context.Context, url *url.URL, req *http.Request) (*cache.Response, error) {
+	matches := videoRegex.FindStringSubmatch(url.Path)
+	if len(matches) != 2 {
+		return nil, errInvalidTwitchVideo
+	}
+
+	offset := parseVideoOffset(url.Query().Get("t"))
+
+	return r.videoCache.Get(ctx, videoKey(matches[1], offset), req)
+}
+
+func (r *VideoResolver) Name() string {
+	return "twitch:video"
+}
+
+func NewVideoResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, helixAPI TwitchAPIClient) *VideoResolver {
+	videoLoader := &VideoLoader{
+		helixAPI: helixAPI,
+	}
+
+	r := &VideoResolver{
+		videoCache: cache.NewDefaultCache(
+			ctx, cfg, pool, cache.NewLocalizedKeyProvider("twitch:video"),
+			resolver.NewResponseMarshaller(videoLoader), cfg.TwitchVideoCacheDuration,
+		),
+	}
+
+	return r
+}
@@ -0,0 +1,133 @@
+package twitch
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/internal/mocks"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+	"github.com/jackc/pgx/v4"
+	"github.com/nicklaw5/helix"
+	"github.com/pashagolub/pgxmock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestParseVideoOffset(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		input    string
+		expected time.Duration
+	}{
+		{"", 0},
+		{"1h2m3s", time.Hour + 2*time.Minute + 3*time.Second},
+		{"90s", 90 * time.Second},
+		{"3723", time.Hour + 2*time.Minute + 3*time.Second},
+		{"1.5s", time.Second},
+		{"-5s", 0},
+		{"-5", 0},
+		{"forsen", 0},
+	}
+
+	for _, test := range tests {
+		c.Run(test.input, func(c *qt.C) {
+			c.Assert(parseVideoOffset(test.input), qt.Equals, test.expected)
+		})
+	}
+}
+
+func TestVideoKey(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(videoKey("123", 0), qt.Equals, "123")
+	c.Assert(videoKey("123", time.Hour), qt.Equals, "123?t=3600")
+
+	videoID, offset := parseVideoKey("123")
+	c.Assert(videoID, qt.Equals, "123")
+	c.Assert(offset, qt.Equals, time.Duration(0))
+
+	videoID, offset = parseVideoKey("123?t=3600")
+	c.Assert(videoID, qt.Equals, "123")
+	c.Assert(offset, qt.Equals, time.Hour)
+}
+
+func TestVideoResolver(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	c := qt.New(t)
+
+	pool, _ := pgxmock.NewPool()
+	cfg := config.APIConfig{}
+	helixClient := mocks.NewMockTwitchAPIClient(ctrl)
+
+	resolver := NewVideoResolver(ctx, cfg, pool, helixClient)
+
+	c.Assert(resolver, qt.IsNotNil)
+
+	c.Run("Name", func(c *qt.C) {
+		c.Assert(resolver.Name(), qt.Equals, "twitch:video")
+	})
+
+	c.Run("Check", func(c *qt.C) {
+		type checkTest struct {
+			input    *url.URL
+			expected bool
+		}
+
+		tests := []checkTest{
+			{utils.MustParseURL("https://twitch.tv/videos/123456789"), true},
+			{utils.MustParseURL("https://www.twitch.tv/videos/123456789/"), true},
+			{utils.MustParseURL("https://m.twitch.tv/videos/123456789?t=1h2m3s"), true},
+			{utils.MustParseURL("https://twitch.tv/videos/"), false},
+			{utils.MustParseURL("https://twitch.tv/videos/abc"), false},
+			{utils.MustParseURL("https://twitch.tv/pajlada/videos"), false},
+			{utils.MustParseURL("https://clips.twitch.tv/videos/123456789"), false},
+			{utils.MustParseURL("https://example.com/videos/123456789"), false},
+		}
+
+		for _, test := range tests {
+			c.Run(test.input.String(), func(c *qt.C) {
+				_, output := resolver.Check(ctx, test.input)
+				c.Assert(output, qt.Equals, test.expected)
+			})
+		}
+	})
+
+	c.Run("Run", func(c *qt.C) {
+		c.Run("Non-matching link", func(c *qt.C) {
+			outputBytes, outputError := resolver.Run(ctx, utils.MustParseURL("https://twitch.tv/videos/abc"), nil)
+			c.Assert(outputError, qt.Equals, errInvalidTwitchVideo)
+			c.Assert(outputBytes, qt.IsNil)
+		})
+
+		tests := []struct {
+			label       string
+			inputURL    *url.URL
+			expectedKey string
+		}{
+			{"Video", utils.MustParseURL("https://twitch.tv/videos/123456789"), "twitch:video:123456789"},
+			{"Start offset", utils.MustParseURL("https://twitch.tv/videos/123456789?t=1h2m3s"), "twitch:video:123456789?t=3723"},
+			{"Invalid start offset", utils.MustParseURL("https://twitch.tv/videos/123456789?t=xd"), "twitch:video:123456789"},
+		}
+
+		for _, test := range tests {
+			c.Run(test.label, func(c *qt.C) {
+				helixClient.EXPECT().GetVideos(&helix.VideosParams{IDs: []string{"123456789"}}).Times(1).Return(&helix.VideosResponse{ResponseCommon: helix.ResponseCommon{StatusCode: http.StatusOK}}, nil)
+				pool.ExpectQuery("SELECT").WillReturnError(pgx.ErrNoRows)
+				pool.ExpectExec("INSERT INTO cache").
+					WithArgs(test.expectedKey, []byte(`{"status":404,"message":"No Twitch video with this ID found"}`), http.StatusOK, "application/json", pgxmock.AnyArg()).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				_, outputError := resolver.Run(ctx, test.inputURL, nil)
+				c.Assert(outputError, qt.IsNil)
+				c.Assert(pool.ExpectationsWereMet(), qt.IsNil)
+			})
+		}
+	})
+}
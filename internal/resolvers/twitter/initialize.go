@@ -3,12 +3,12 @@ package twitter
 import (
 	"context"
 	"regexp"
-	"text/template"
 
 	"github.com/Chatterino/api/internal/db"
 	"github.com/Chatterino/api/internal/logger"
 	"github.com/Chatterino/api/pkg/cache"
 	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	"github.com/Chatterino/api/pkg/utils"
 )
@@ -19,7 +19,7 @@ const (
 <span style="white-space: pre-wrap; word-wrap: break-word;">
 {{.Text}}
 </span>
-<span style="color: #808892;">{{.Likes}} likes&nbsp;•&nbsp;{{.Retweets}} retweets&nbsp;•&nbsp;{{.Timestamp}}</span>
+<span style="color: #808892;">{{t "%s likes" .Likes}}&nbsp;•&nbsp;{{t "%s retweets" .Retweets}}&nbsp;•&nbsp;{{.Timestamp}}</span>
 </div>
 `
 
@@ -28,7 +28,7 @@ const (
 <span style="white-space: pre-wrap; word-wrap: break-word;">
 {{.Description}}
 </span>
-<span style="color: #808892;">{{.Followers}} followers</span>
+<span style="color: #808892;">{{t "%s followers" .Followers}}</span>
 </div>
 `
 )
@@ -57,9 +57,9 @@ var (
 		"privacy",
 	})
 
-	tweetTooltipTemplate = template.Must(template.New("tweetTooltip").Parse(tweetTooltip))
+	tweetTooltipTemplate = i18n.MustTextTemplate("tweetTooltip", tweetTooltip)
 
-	twitterUserTooltipTemplate = template.Must(template.New("twitterUserTooltip").Parse(twitterUserTooltip))
+	twitterUserTooltipTemplate = i18n.MustTextTemplate("twitterUserTooltip", twitterUserTooltip)
 )
 
 func Initialize(
@@ -81,8 +81,11 @@ func NewTwitterResolver(
 	tweetEndpointURLFormat string,
 	collageCache cache.DependentCache,
 ) *TwitterResolver {
-	tweetCacheKeyProvider := cache.NewPrefixKeyProvider("twitter:tweet")
-	userCacheKeyProvider := cache.NewPrefixKeyProvider("twitter:user")
+	tweetCacheKeyProvider := cache.NewLocalizedKeyProvider("twitter:tweet")
+	userCacheKeyProvider := cache.NewLocalizedKeyProvider("twitter:user")
+
+	// Tweets and users share the same API rate limits
+	upstream := resolver.NewUpstream(cfg, "twitter")
 
 	tweetLoader := NewTweetLoader(
 		cfg.BaseURL,
@@ -91,20 +94,22 @@ func NewTwitterResolver(
 		tweetCacheKeyProvider,
 		collageCache,
 		cfg.MaxThumbnailSize,
+		upstream,
 	)
 
 	userLoader := &UserLoader{
 		bearerKey:         cfg.TwitterBearerToken,
 		endpointURLFormat: userEndpointURLFormat,
+		upstream:          upstream,
 	}
 
-	tweetCache := cache.NewPostgreSQLCache(
+	tweetCache := cache.NewDefaultCache(
 		ctx, cfg, pool, tweetCacheKeyProvider, resolver.NewResponseMarshaller(tweetLoader),
 		cfg.TwitterTweetCacheDuration,
 	)
 	tweetCache.RegisterDependent(ctx, collageCache)
 
-	userCache := cache.NewPostgreSQLCache(
+	userCache := cache.NewDefaultCache(
 		ctx, cfg, pool, userCacheKeyProvider, resolver.NewResponseMarshaller(userLoader),
 		cfg.TwitterUserCacheDuration,
 	)
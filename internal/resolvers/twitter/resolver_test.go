@@ -11,6 +11,7 @@ import (
 	"github.com/Chatterino/api/internal/logger"
 	"github.com/Chatterino/api/pkg/cache"
 	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	"github.com/Chatterino/api/pkg/utils"
 	qt "github.com/frankban/quicktest"
@@ -158,7 +159,7 @@ func TestResolver(t *testing.T) {
 					inputURL:   utils.MustParseURL("https://twitter.com/pajlada/status/1507648130682077194"),
 					inputTweet: "1507648130682077194",
 					expectedResponse: &cache.Response{
-						Payload:     []byte(`{"status":200,"thumbnail":"https://pbs.twimg.com/ext_tw_video_thumb/1507648047609745413/pu/img/YZQAxKt-O68sKoXQ.jpg","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%0A%3Cb%3EPAJLADA%20%28@pajlada%29%3C%2Fb%3E%0A%3Cspan%20style=%22white-space:%20pre-wrap%3B%20word-wrap:%20break-word%3B%22%3E%0ADigging%20a%20hole%0A%3C%2Fspan%3E%0A%3Cspan%20style=%22color:%20%23808892%3B%22%3E69%20likes\u0026nbsp%3B%E2%80%A2\u0026nbsp%3B420%20retweets\u0026nbsp%3B%E2%80%A2\u0026nbsp%3B26%20Mar%202022%20%E2%80%A2%2017:15%20UTC%3C%2Fspan%3E%0A%3C%2Fdiv%3E%0A"}`),
+						Payload:     []byte(`{"status":200,"thumbnail":"https://pbs.twimg.com/ext_tw_video_thumb/1507648047609745413/pu/img/YZQAxKt-O68sKoXQ.jpg","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%0A%3Cb%3EPAJLADA%20%28@pajlada%29%3C%2Fb%3E%0A%3Cspan%20style=%22white-space:%20pre-wrap%3B%20word-wrap:%20break-word%3B%22%3E%0ADigging%20a%20hole%0A%3C%2Fspan%3E%0A%3Cspan%20style=%22color:%20%23808892%3B%22%3E69%20likes\u0026nbsp%3B%E2%80%A2\u0026nbsp%3B420%20retweets\u0026nbsp%3B%E2%80%A2\u0026nbsp%3B26%20Mar%202022%20%E2%80%A2%2017:15%20UTC%3C%2Fspan%3E%0A%3C%2Fdiv%3E%0A","data":{"kind":"post","description":"Digging a hole","author":"PAJLADA","likes":69,"published":"2022-03-26T17:15:50Z","fields":{"retweets":"420","username":"pajlada"}}}`),
 						StatusCode:  http.StatusOK,
 						ContentType: "application/json",
 					},
@@ -169,7 +170,7 @@ func TestResolver(t *testing.T) {
 					inputURL:   utils.MustParseURL("https://twitter.com/pajlada/status/1506968434134953986"),
 					inputTweet: "1506968434134953986",
 					expectedResponse: &cache.Response{
-						Payload:     []byte(`{"status":200,"thumbnail":"https://pbs.twimg.com/media/FOnTzeQWUAMU6L1?format=jpg\u0026name=medium","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%0A%3Cb%3EPAJLADA%20%28@pajlada%29%3C%2Fb%3E%0A%3Cspan%20style=%22white-space:%20pre-wrap%3B%20word-wrap:%20break-word%3B%22%3E%0A%0A%3C%2Fspan%3E%0A%3Cspan%20style=%22color:%20%23808892%3B%22%3E69%20likes\u0026nbsp%3B%E2%80%A2\u0026nbsp%3B420%20retweets\u0026nbsp%3B%E2%80%A2\u0026nbsp%3B26%20Mar%202022%20%E2%80%A2%2017:15%20UTC%3C%2Fspan%3E%0A%3C%2Fdiv%3E%0A"}`),
+						Payload:     []byte(`{"status":200,"thumbnail":"https://pbs.twimg.com/media/FOnTzeQWUAMU6L1?format=jpg\u0026name=medium","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%0A%3Cb%3EPAJLADA%20%28@pajlada%29%3C%2Fb%3E%0A%3Cspan%20style=%22white-space:%20pre-wrap%3B%20word-wrap:%20break-word%3B%22%3E%0A%0A%3C%2Fspan%3E%0A%3Cspan%20style=%22color:%20%23808892%3B%22%3E69%20likes\u0026nbsp%3B%E2%80%A2\u0026nbsp%3B420%20retweets\u0026nbsp%3B%E2%80%A2\u0026nbsp%3B26%20Mar%202022%20%E2%80%A2%2017:15%20UTC%3C%2Fspan%3E%0A%3C%2Fdiv%3E%0A","data":{"kind":"post","author":"PAJLADA","likes":69,"published":"2022-03-26T17:15:50Z","fields":{"retweets":"420","username":"pajlada"}}}`),
 						StatusCode:  http.StatusOK,
 						ContentType: "application/json",
 					},
@@ -251,7 +252,7 @@ func TestResolver(t *testing.T) {
 					inputURL:  utils.MustParseURL("https://twitter.com/pajlada"),
 					inputUser: "pajlada",
 					expectedResponse: &cache.Response{
-						Payload:     []byte(`{"status":200,"thumbnail":"https://pbs.twimg.com/profile_images/1385924241619628033/fW7givJA_400x400.jpg","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%0A%3Cb%3EPAJLADA%20%28@pajlada%29%3C%2Fb%3E%0A%3Cspan%20style=%22white-space:%20pre-wrap%3B%20word-wrap:%20break-word%3B%22%3E%0ACool%20memer%0A%3C%2Fspan%3E%0A%3Cspan%20style=%22color:%20%23808892%3B%22%3E69%20followers%3C%2Fspan%3E%0A%3C%2Fdiv%3E%0A"}`),
+						Payload:     []byte(`{"status":200,"thumbnail":"https://pbs.twimg.com/profile_images/1385924241619628033/fW7givJA_400x400.jpg","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%0A%3Cb%3EPAJLADA%20%28@pajlada%29%3C%2Fb%3E%0A%3Cspan%20style=%22white-space:%20pre-wrap%3B%20word-wrap:%20break-word%3B%22%3E%0ACool%20memer%0A%3C%2Fspan%3E%0A%3Cspan%20style=%22color:%20%23808892%3B%22%3E69%20followers%3C%2Fspan%3E%0A%3C%2Fdiv%3E%0A","data":{"kind":"user","title":"PAJLADA","description":"Cool memer","followers":69,"fields":{"username":"pajlada"}}}`),
 						StatusCode:  http.StatusOK,
 						ContentType: "application/json",
 					},
@@ -262,7 +263,7 @@ func TestResolver(t *testing.T) {
 					inputURL:  utils.MustParseURL("https://twitter.com/PAJLADA"),
 					inputUser: "pajlada",
 					expectedResponse: &cache.Response{
-						Payload:     []byte(`{"status":200,"thumbnail":"https://pbs.twimg.com/profile_images/1385924241619628033/fW7givJA_400x400.jpg","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%0A%3Cb%3EPAJLADA%20%28@pajlada%29%3C%2Fb%3E%0A%3Cspan%20style=%22white-space:%20pre-wrap%3B%20word-wrap:%20break-word%3B%22%3E%0ACool%20memer%0A%3C%2Fspan%3E%0A%3Cspan%20style=%22color:%20%23808892%3B%22%3E69%20followers%3C%2Fspan%3E%0A%3C%2Fdiv%3E%0A"}`),
+						Payload:     []byte(`{"status":200,"thumbnail":"https://pbs.twimg.com/profile_images/1385924241619628033/fW7givJA_400x400.jpg","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%0A%3Cb%3EPAJLADA%20%28@pajlada%29%3C%2Fb%3E%0A%3Cspan%20style=%22white-space:%20pre-wrap%3B%20word-wrap:%20break-word%3B%22%3E%0ACool%20memer%0A%3C%2Fspan%3E%0A%3Cspan%20style=%22color:%20%23808892%3B%22%3E69%20followers%3C%2Fspan%3E%0A%3C%2Fdiv%3E%0A","data":{"kind":"user","title":"PAJLADA","description":"Cool memer","followers":69,"fields":{"username":"pajlada"}}}`),
 						StatusCode:  http.StatusOK,
 						ContentType: "application/json",
 					},
@@ -318,3 +319,12 @@ func TestResolver(t *testing.T) {
 		})
 	})
 }
+
+func TestBuildCollageKey(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	// English keys are the same as before tweets were cached per language
+	c.Assert(buildCollageKey(ctx, "1234"), qt.Equals, "twitter:collage:1234")
+	c.Assert(buildCollageKey(i18n.OnContext(ctx, i18n.German), "1234"), qt.Equals, "twitter:collage:de:1234")
+}
@@ -6,19 +6,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"math"
 	"net/http"
 	"net/url"
-	"sync"
+	"strconv"
 	"time"
 
 	"github.com/Chatterino/api/internal/logger"
 	"github.com/Chatterino/api/pkg/cache"
 	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
-	"github.com/Chatterino/api/pkg/utils"
-	"github.com/davidbyttow/govips/v2/vips"
+	"github.com/Chatterino/api/pkg/thumbnail"
 )
 
 type TweetApiResponse struct {
@@ -72,12 +70,10 @@ type TweetLoader struct {
 	tweetCacheKeyProvider cache.KeyProvider
 	collageCache          cache.DependentCache
 	maxThumbnailSize      uint
+	upstream              *resolver.Upstream
 }
 
-var (
-	errTweetNotFound     = errors.New("tweet not found")
-	errNoMediaDownloaded = errors.New("couldn't download any of the attached media items")
-)
+var errTweetNotFound = errors.New("tweet not found")
 
 func NewTweetLoader(
 	baseURL string,
@@ -86,6 +82,7 @@ func NewTweetLoader(
 	tweetCacheKeyProvider cache.KeyProvider,
 	collageCache cache.DependentCache,
 	maxThumbnailSize uint,
+	upstream *resolver.Upstream,
 ) *TweetLoader {
 	return &TweetLoader{
 		baseURL:               baseURL,
@@ -94,11 +91,16 @@ func NewTweetLoader(
 		tweetCacheKeyProvider: tweetCacheKeyProvider,
 		collageCache:          collageCache,
 		maxThumbnailSize:      maxThumbnailSize,
+		upstream:              upstream,
 	}
 }
 
-func buildCollageKey(tweetID string) string {
-	return fmt.Sprintf("twitter:collage:%s", tweetID)
+// Tweets are cached per language, and their collages are removed along with them. Each language
+// gets its own collage so it doesn't disappear with the entry of another language.
+var collageKeyProvider = cache.NewLocalizedKeyProvider("twitter:collage")
+
+func buildCollageKey(ctx context.Context, tweetID string) string {
+	return collageKeyProvider.CacheKey(ctx, tweetID)
 }
 
 func (l *TweetLoader) getTweetByID(id string) (*TweetApiResponse, error) {
@@ -106,7 +108,9 @@ func (l *TweetLoader) getTweetByID(id string) (*TweetApiResponse, error) {
 	extraHeaders := map[string]string{
 		"Authorization": fmt.Sprintf("Bearer %s", l.bearerKey),
 	}
-	resp, err := resolver.RequestGETWithHeaders(endpointUrl, extraHeaders)
+	resp, err := l.upstream.Do(func() (*http.Response, error) {
+		return resolver.RequestGETWithHeaders(endpointUrl, extraHeaders)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -156,13 +160,17 @@ func (l *TweetLoader) Load(
 			}, cache.NoSpecialDur, nil
 		}
 
+		if errors.Is(err, resolver.ErrUpstreamUnavailable) {
+			return nil, cache.NoSpecialDur, err
+		}
+
 		return resolver.Errorf("Twitter tweet API error: %s", err)
 	}
 
 	tooltipData := l.buildTweetTooltip(ctx, tweetResp, r)
 
 	var tooltip bytes.Buffer
-	if err := tweetTooltipTemplate.Execute(&tooltip, tooltipData); err != nil {
+	if err := tweetTooltipTemplate.Execute(&tooltip, i18n.FromContext(ctx), tooltipData); err != nil {
 		return resolver.Errorf("Twitter tweet template error: %s", err)
 	}
 
@@ -170,6 +178,17 @@ func (l *TweetLoader) Load(
 		Status:    http.StatusOK,
 		Tooltip:   url.PathEscape(tooltip.String()),
 		Thumbnail: tooltipData.Thumbnail,
+		Data: &resolver.ResponseData{
+			Kind:        resolver.DataKindPost,
+			Description: tweetResp.Data.Text,
+			Author:      tooltipData.Name,
+			Likes:       tweetResp.Data.PublicMetrics.LikeCount,
+			Published:   tweetResp.Data.CreatedAt.Format(time.RFC3339),
+			Fields: map[string]string{
+				"username": tooltipData.Username,
+				"retweets": strconv.FormatUint(tweetResp.Data.PublicMetrics.RetweetCount, 10),
+			},
+		},
 	}, cache.NoSpecialDur, nil
 }
 
@@ -178,13 +197,15 @@ func (l *TweetLoader) buildTweetTooltip(
 	tweet *TweetApiResponse,
 	r *http.Request,
 ) *tweetTooltipData {
+	lang := i18n.FromContext(ctx)
+
 	data := &tweetTooltipData{}
 	data.Text = tweet.Data.Text
 	data.Name = tweet.Includes.Users[0].Name
 	data.Username = tweet.Includes.Users[0].Username
-	data.Likes = humanize.Number(tweet.Data.PublicMetrics.LikeCount)
-	data.Retweets = humanize.Number(tweet.Data.PublicMetrics.RetweetCount)
-	data.Timestamp = humanize.CreationDateTime(tweet.Data.CreatedAt)
+	data.Likes = humanize.NumberIn(lang, tweet.Data.PublicMetrics.LikeCount)
+	data.Retweets = humanize.NumberIn(lang, tweet.Data.PublicMetrics.RetweetCount)
+	data.Timestamp = humanize.CreationDateTimeIn(lang, tweet.Data.CreatedAt)
 	data.Thumbnail = l.buildThumbnailURL(ctx, tweet, r)
 
 	return data
@@ -195,8 +216,6 @@ func (l *TweetLoader) buildThumbnailURL(
 	tweet *TweetApiResponse,
 	r *http.Request,
 ) string {
-	log := logger.FromContext(ctx)
-
 	numMedia := len(tweet.Includes.Media)
 	if numMedia == 0 {
 		return ""
@@ -212,142 +231,24 @@ func (l *TweetLoader) buildThumbnailURL(
 	}
 
 	// More than one media item, need to compose a thumbnail
-	thumb, err := l.composeThumbnail(ctx, tweet.Includes.Media)
-	if err != nil {
-		log.Errorw("Couldn't compose Twitter collage",
-			"err", err,
-		)
-		return ""
-	}
-
-	outputBuf, metaData, err := thumb.ExportNative()
-	if err != nil {
-		log.Errorw("Couldn't export Twitter collage thumbnail",
-			"err", err,
-		)
-		return ""
-	}
-
-	parentKey := l.tweetCacheKeyProvider.CacheKey(ctx, tweet.Data.ID)
-	collageKey := buildCollageKey(tweet.Data.ID)
-	contentType := utils.MimeType(metaData.Format)
-
-	err = l.collageCache.Insert(ctx, collageKey, parentKey, outputBuf, contentType)
-	if err != nil {
-		log.Errorw("Couldn't insert Twitter collage thumbnail into cache",
-			"err", err,
-		)
-		return ""
-	}
-
-	return utils.FormatGeneratedThumbnailURL(l.baseURL, r, collageKey)
-}
-
-func (l *TweetLoader) composeThumbnail(
-	ctx context.Context,
-	mediaEntities []Media,
-) (*vips.ImageRef, error) {
-	log := logger.FromContext(ctx)
-
-	numMedia := len(mediaEntities)
-
-	// First, download all images
-	downloaded := make([]*vips.ImageRef, numMedia)
-	wg := new(sync.WaitGroup)
-	wg.Add(numMedia)
-
-	for idx, media := range mediaEntities {
-
-		url := media.URL
+	imageURLs := make([]string, 0, numMedia)
+	for _, media := range tweet.Includes.Media {
 		if media.Type == "video" {
-			url = media.PreviewImageUrl
-		}
-
-		go func() {
-			defer wg.Done()
-
-			resp, err := resolver.RequestGET(ctx, url)
-			if err != nil {
-				log.Errorw("Couldn't download Twitter media",
-					"url", url,
-					"err", err,
-				)
-				return
-			}
-
-			buf, err := io.ReadAll(resp.Body)
-			if err != nil {
-				log.Errorw("Couldn't read response body",
-					"url", url,
-					"err", err,
-				)
-				return
-			}
-
-			ref, err := vips.NewImageFromBuffer(buf)
-			if err != nil {
-				log.Errorw("Couldn't convert buffer to vips.ImageRef",
-					"err", err,
-				)
-				return
-			}
-
-			downloaded[idx] = ref
-		}()
-	}
-
-	wg.Wait()
-
-	// Prepare downloaded media for collage
-	var collageSource []*vips.ImageRef
-
-	// Keep track of smallest dimension for proper resizing later
-	smallestDimensionFound := math.MaxFloat64
-
-	// In a first pass, check downloaded media to determine the smallest dimension
-	for _, ref := range downloaded {
-		if ref != nil {
-			smallerDimensionCur := math.Min(float64(ref.Width()), float64(ref.Height()))
-			smallestDimensionFound = math.Min(smallestDimensionFound, smallerDimensionCur)
-
-			collageSource = append(collageSource, ref)
+			imageURLs = append(imageURLs, media.PreviewImageUrl)
+		} else {
+			imageURLs = append(imageURLs, media.URL)
 		}
 	}
 
-	// In the second pass, resize the images according to smallest dimension
-	for _, ref := range collageSource {
-		ref.ThumbnailWithSize(
-			int(smallestDimensionFound), int(smallestDimensionFound), vips.InterestingCentre,
-			vips.SizeDown,
-		)
-	}
-
-	if len(collageSource) == 0 {
-		log.Errorw("No Twitter media could be downloaded, cannot build collage")
-		return nil, errNoMediaDownloaded
-	}
-
-	// Now compose the thumbnail
-	stem := collageSource[0]
-
-	err := stem.ArrayJoin(collageSource[1:], 2)
-	if err != nil {
-		log.Errorw("Couldn't ArrayJoin imags",
-			"err", err,
-		)
-		return nil, err
-	}
-
-	maxThumbnailSize := int(l.maxThumbnailSize)
-	err = stem.ThumbnailWithSize(
-		maxThumbnailSize, maxThumbnailSize, vips.InterestingNone, vips.SizeDown,
-	)
-	if err != nil {
-		log.Errorw("Couldn't generate thumbnail",
-			"err", err,
-		)
-		return nil, err
+	collage := &thumbnail.Collage{
+		BaseURL:      l.baseURL,
+		CollageCache: l.collageCache,
+		ParentKey:    l.tweetCacheKeyProvider.CacheKey(ctx, tweet.Data.ID),
+		CollageKey:   buildCollageKey(ctx, tweet.Data.ID),
+		Options: thumbnail.CollageOptions{
+			MaxSize: l.maxThumbnailSize,
+		},
 	}
 
-	return stem, nil
+	return collage.BuildThumbnailURL(ctx, imageURLs, r)
 }
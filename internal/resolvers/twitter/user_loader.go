@@ -14,6 +14,7 @@ import (
 	"github.com/Chatterino/api/internal/logger"
 	"github.com/Chatterino/api/pkg/cache"
 	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 )
 
@@ -43,6 +44,7 @@ type twitterUserTooltipData struct {
 type UserLoader struct {
 	bearerKey         string
 	endpointURLFormat string
+	upstream          *resolver.Upstream
 }
 
 var errUserNotFound = errors.New("user not found")
@@ -52,7 +54,9 @@ func (l *UserLoader) getUserByName(userName string) (*TwitterUserApiResponse, er
 	extraHeaders := map[string]string{
 		"Authorization": fmt.Sprintf("Bearer %s", l.bearerKey),
 	}
-	resp, err := resolver.RequestGETWithHeaders(endpointUrl, extraHeaders)
+	resp, err := l.upstream.Do(func() (*http.Response, error) {
+		return resolver.RequestGETWithHeaders(endpointUrl, extraHeaders)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -102,12 +106,16 @@ func (l *UserLoader) Load(ctx context.Context, userName string, r *http.Request)
 			}, cache.NoSpecialDur, nil
 		}
 
+		if errors.Is(err, resolver.ErrUpstreamUnavailable) {
+			return nil, cache.NoSpecialDur, err
+		}
+
 		return resolver.Errorf("Twitter user API error: %s", err)
 	}
 
-	userData := buildTwitterUserTooltip(userResp)
+	userData := buildTwitterUserTooltip(ctx, userResp)
 	var tooltip bytes.Buffer
-	if err := twitterUserTooltipTemplate.Execute(&tooltip, userData); err != nil {
+	if err := twitterUserTooltipTemplate.Execute(&tooltip, i18n.FromContext(ctx), userData); err != nil {
 		return resolver.Errorf("Twitter user template error: %s", err)
 	}
 
@@ -115,15 +123,24 @@ func (l *UserLoader) Load(ctx context.Context, userName string, r *http.Request)
 		Status:    http.StatusOK,
 		Tooltip:   url.PathEscape(tooltip.String()),
 		Thumbnail: userData.Thumbnail,
+		Data: &resolver.ResponseData{
+			Kind:        resolver.DataKindUser,
+			Title:       userData.Name,
+			Description: userData.Description,
+			Followers:   userResp.Data[0].PublicMetrics.Followers,
+			Fields: map[string]string{
+				"username": userData.Username,
+			},
+		},
 	}, cache.NoSpecialDur, nil
 }
 
-func buildTwitterUserTooltip(user *TwitterUserApiResponse) *twitterUserTooltipData {
+func buildTwitterUserTooltip(ctx context.Context, user *TwitterUserApiResponse) *twitterUserTooltipData {
 	data := &twitterUserTooltipData{}
 	data.Name = user.Data[0].Name
 	data.Username = user.Data[0].Username
 	data.Description = user.Data[0].Description
-	data.Followers = humanize.Number(user.Data[0].PublicMetrics.Followers)
+	data.Followers = humanize.NumberIn(i18n.FromContext(ctx), user.Data[0].PublicMetrics.Followers)
 	data.Thumbnail = user.Data[0].ProfileImageUrl
 
 	return data
@@ -74,8 +74,8 @@ func NewArticleResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool,
 	}
 
 	r := &ArticleResolver{
-		articleCache: cache.NewPostgreSQLCache(
-			ctx, cfg, pool, cache.NewPrefixKeyProvider("wikipedia:article"),
+		articleCache: cache.NewDefaultCache(
+			ctx, cfg, pool, cache.NewLocalizedKeyProvider("wikipedia:article"),
 			resolver.NewResponseMarshaller(articleLoader), cfg.WikipediaArticleCacheDuration,
 		),
 	}
@@ -156,7 +156,7 @@ func TestArticleResolver(t *testing.T) {
 					label:    "Normal page (HTML)",
 					inputURL: utils.MustParseURL("https://wikipedia.org/wiki/test_html"),
 					expectedResponse: &cache.Response{
-						Payload:     []byte(`{"status":200,"tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%3Cb%3E\u0026lt%3Bb\u0026gt%3BTest%20title\u0026lt%3B%2Fb\u0026gt%3B\u0026nbsp%3B%E2%80%A2\u0026nbsp%3B\u0026lt%3Bb\u0026gt%3BTest%20description\u0026lt%3B%2Fb\u0026gt%3B%3C%2Fb%3E%3Cbr%3E\u0026lt%3Bb\u0026gt%3BTest%20extract\u0026lt%3B%2Fb\u0026gt%3B%3C%2Fdiv%3E"}`),
+						Payload:     []byte(`{"status":200,"tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%3Cb%3E\u0026lt%3Bb\u0026gt%3BTest%20title\u0026lt%3B%2Fb\u0026gt%3B\u0026nbsp%3B%E2%80%A2\u0026nbsp%3B\u0026lt%3Bb\u0026gt%3BTest%20description\u0026lt%3B%2Fb\u0026gt%3B%3C%2Fb%3E%3Cbr%3E\u0026lt%3Bb\u0026gt%3BTest%20extract\u0026lt%3B%2Fb\u0026gt%3B%3C%2Fdiv%3E","data":{"kind":"article","title":"\u003cb\u003eTest title\u003c/b\u003e","description":"\u003cb\u003eTest extract\u003c/b\u003e","fields":{"short_description":"\u003cb\u003eTest description\u003c/b\u003e"}}}`),
 						StatusCode:  http.StatusOK,
 						ContentType: "application/json",
 					},
@@ -165,7 +165,7 @@ func TestArticleResolver(t *testing.T) {
 					label:    "Normal page (No description)",
 					inputURL: utils.MustParseURL("https://wikipedia.org/wiki/test_no_description"),
 					expectedResponse: &cache.Response{
-						Payload:     []byte(`{"status":200,"tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%3Cb%3ETest%20title%3C%2Fb%3E%3Cbr%3ETest%20extract%3C%2Fdiv%3E"}`),
+						Payload:     []byte(`{"status":200,"tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%3Cb%3ETest%20title%3C%2Fb%3E%3Cbr%3ETest%20extract%3C%2Fdiv%3E","data":{"kind":"article","title":"Test title","description":"Test extract"}}`),
 						StatusCode:  http.StatusOK,
 						ContentType: "application/json",
 					},
@@ -174,7 +174,7 @@ func TestArticleResolver(t *testing.T) {
 					label:    "Normal page (with thumbnail)",
 					inputURL: utils.MustParseURL("https://wikipedia.org/wiki/thumbnail"),
 					expectedResponse: &cache.Response{
-						Payload:     []byte(`{"status":200,"thumbnail":"https://example.com/thumbnail.png","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%3Cb%3ETest%20title%3C%2Fb%3E%3Cbr%3ETest%20extract%3C%2Fdiv%3E"}`),
+						Payload:     []byte(`{"status":200,"thumbnail":"https://example.com/thumbnail.png","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%3Cb%3ETest%20title%3C%2Fb%3E%3Cbr%3ETest%20extract%3C%2Fdiv%3E","data":{"kind":"article","title":"Test title","description":"Test extract"}}`),
 						StatusCode:  http.StatusOK,
 						ContentType: "application/json",
 					},
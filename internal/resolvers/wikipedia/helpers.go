@@ -17,9 +17,21 @@ func buildTooltip(pageInfo *wikipediaTooltipData) (*resolver.Response, time.Dura
 		return resolver.Errorf("Wikipedia template error: %s", err.Error())
 	}
 
+	data := &resolver.ResponseData{
+		Kind:        resolver.DataKindArticle,
+		Title:       pageInfo.Title,
+		Description: pageInfo.Extract,
+	}
+	if pageInfo.Description != "" {
+		data.Fields = map[string]string{
+			"short_description": pageInfo.Description,
+		}
+	}
+
 	return &resolver.Response{
 		Status:    http.StatusOK,
 		Tooltip:   url.PathEscape(tooltip.String()),
 		Thumbnail: pageInfo.ThumbnailURL,
+		Data:      data,
 	}, cache.NoSpecialDur, nil
 }
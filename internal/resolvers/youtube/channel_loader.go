@@ -12,6 +12,7 @@ import (
 	"github.com/Chatterino/api/internal/staticresponse"
 	"github.com/Chatterino/api/pkg/cache"
 	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	youtubeAPI "google.golang.org/api/youtube/v3"
 )
@@ -24,14 +25,17 @@ type youtubeChannelTooltipData struct {
 }
 
 type YouTubeChannelLoader struct {
+	// nil if no youtube-api-key is set
 	youtubeClient *youtubeAPI.Service
+	keyless       *keylessClient
 }
 
-func buildChannelResponse(tooltip string, thumbnail string) []byte {
+func buildChannelResponse(tooltip string, thumbnail string, data *resolver.ResponseData) []byte {
 	response := &resolver.Response{
 		Status:    http.StatusOK,
 		Tooltip:   url.PathEscape(tooltip),
 		Thumbnail: thumbnail,
+		Data:      data,
 	}
 	payload, err := json.Marshal(response)
 	if err != nil {
@@ -43,26 +47,39 @@ func buildChannelResponse(tooltip string, thumbnail string) []byte {
 
 func (r *YouTubeChannelLoader) Load(ctx context.Context, channelCacheKey string, req *http.Request) ([]byte, *int, *string, time.Duration, error) {
 	log := logger.FromContext(ctx)
+	lang := i18n.FromContext(ctx)
 	youtubeChannelParts := []string{
 		"statistics",
 		"snippet",
 	}
 
+	channel := getChannelFromCacheKey(channelCacheKey)
+	if channel.Type == InvalidChannel {
+		return resolver.InternalServerErrorf("YouTube API channel type is invalid for key: %s", channelCacheKey)
+	}
+
+	if r.youtubeClient == nil {
+		return r.keyless.loadChannel(ctx, channel, cache.NoSpecialDur)
+	}
+
 	log.Debugw("[YouTube] GET channel",
 		"cacheKey", channelCacheKey,
 	)
 	builtRequest := r.youtubeClient.Channels.List(youtubeChannelParts)
 
-	channel := getChannelFromCacheKey(channelCacheKey)
 	if channel.Type == CustomChannel {
 		// Channels with custom URLs aren't searchable with the channel/list endpoint
 		// The only average way to do this at the moment is to do a YouTube search of that name
 		// and filter for channels. Not ideal...
 
 		searchRequest := r.youtubeClient.Search.List([]string{"snippet"}).Q(channel.ID).Type("channel")
-		response, err := searchRequest.MaxResults(1).Do()
+		response, err := callAPI(ctx, quotaCostSearch, searchRequest.MaxResults(1).Do)
 
 		if err != nil {
+			if apiUnavailable(err) {
+				return r.loadWithoutAPI(ctx, channel, err)
+			}
+
 			return resolver.InternalServerErrorf("YouTube search API error: %s", err)
 		}
 
@@ -86,13 +103,17 @@ func (r *YouTubeChannelLoader) Load(ctx context.Context, channelCacheKey string,
 		builtRequest = builtRequest.Id(channel.ID)
 	case CustomChannel:
 		builtRequest = builtRequest.Id(channel.ID)
-	case InvalidChannel:
-		return resolver.InternalServerErrorf("YouTube API channel type is invalid for key: %s", channelCacheKey)
+	case HandleChannel:
+		builtRequest = builtRequest.ForHandle(channel.ID)
 	}
 
-	youtubeResponse, err := builtRequest.Do()
+	youtubeResponse, err := callAPI(ctx, quotaCostList, builtRequest.Do)
 
 	if err != nil {
+		if apiUnavailable(err) {
+			return r.loadWithoutAPI(ctx, channel, err)
+		}
+
 		return resolver.InternalServerErrorf("YouTube API error: %s", err)
 	}
 
@@ -110,13 +131,13 @@ func (r *YouTubeChannelLoader) Load(ctx context.Context, channelCacheKey string,
 
 	data := youtubeChannelTooltipData{
 		Title:       youtubeChannel.Snippet.Title,
-		JoinedDate:  humanize.CreationDateRFC3339(youtubeChannel.Snippet.PublishedAt),
-		Subscribers: humanize.Number(youtubeChannel.Statistics.SubscriberCount),
-		Views:       humanize.Number(youtubeChannel.Statistics.ViewCount),
+		JoinedDate:  humanize.CreationDateRFC3339In(lang, youtubeChannel.Snippet.PublishedAt),
+		Subscribers: humanize.NumberIn(lang, youtubeChannel.Statistics.SubscriberCount),
+		Views:       humanize.NumberIn(lang, youtubeChannel.Statistics.ViewCount),
 	}
 
 	var tooltip bytes.Buffer
-	if err := youtubeChannelTooltipTemplate.Execute(&tooltip, data); err != nil {
+	if err := youtubeChannelTooltipTemplate.Execute(&tooltip, lang, data); err != nil {
 		return resolver.InternalServerErrorf("YouTube template error: %s", err.Error())
 	}
 
@@ -128,12 +149,32 @@ func (r *YouTubeChannelLoader) Load(ctx context.Context, channelCacheKey string,
 	statusCode := http.StatusOK
 	contentType := "application/json"
 
-	return buildChannelResponse(tooltip.String(), thumbnail), &statusCode, &contentType, cache.NoSpecialDur, nil
+	responseData := &resolver.ResponseData{
+		Kind:      resolver.DataKindChannel,
+		Title:     youtubeChannel.Snippet.Title,
+		Views:     youtubeChannel.Statistics.ViewCount,
+		Followers: youtubeChannel.Statistics.SubscriberCount,
+		Published: youtubeChannel.Snippet.PublishedAt,
+	}
+
+	return buildChannelResponse(tooltip.String(), thumbnail, responseData), &statusCode, &contentType, youtubeQuota.cacheDuration(), nil
+}
+
+// loadWithoutAPI loads the channel with the keyless client after the API call failed with err
+// because the API is unavailable
+func (r *YouTubeChannelLoader) loadWithoutAPI(ctx context.Context, channel Channel, err error) ([]byte, *int, *string, time.Duration, error) {
+	if cache.IsStaleRefresh(ctx) {
+		// Keep serving the expired tooltip, it has more information than the keyless one
+		return nil, nil, nil, cache.NoSpecialDur, err
+	}
+
+	return r.keyless.loadChannel(ctx, channel, keylessFallbackCacheDuration)
 }
 
 func NewYouTubeChannelLoader(youtubeClient *youtubeAPI.Service) *YouTubeChannelLoader {
 	loader := &YouTubeChannelLoader{
 		youtubeClient: youtubeClient,
+		keyless:       newKeylessClient(),
 	}
 
 	return loader
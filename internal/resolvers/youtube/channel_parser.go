@@ -17,6 +17,14 @@ func (c *Channel) ToCacheKey() string {
 // getChannelFromPath parsers a path to a YouTube channel to a Channel struct containing the ID of the channel,
 // and a helper type which helps us figure out which API we need to send the ID to
 func getChannelFromPath(path string) Channel {
+	// Handles are case-insensitive
+	if match := youtubeHandleRegex.FindStringSubmatch(path); match != nil {
+		return Channel{
+			ID:   strings.ToLower(match[1]),
+			Type: HandleChannel,
+		}
+	}
+
 	match := youtubeChannelRegex.FindStringSubmatch(path)
 	if match == nil || len(match) != 3 {
 		return Channel{
@@ -62,6 +70,8 @@ func getChannelTypeFromString(channelType string) channelType {
 		return UserChannel
 	case "channel":
 		return IdentifierChannel
+	case "handle":
+		return HandleChannel
 	case "":
 		return CustomChannel
 	}
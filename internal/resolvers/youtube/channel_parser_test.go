@@ -48,6 +48,22 @@ func TestGetChannelIDFromPath(t *testing.T) {
 				Type: CustomChannel,
 			},
 		},
+		{
+			label: "Handle channel (/@)",
+			path:  "/@NymNion",
+			expected: Channel{
+				ID:   "nymnion",
+				Type: HandleChannel,
+			},
+		},
+		{
+			label: "Handle channel with dots and underscores (/@)",
+			path:  "/@nymn.ion_/",
+			expected: Channel{
+				ID:   "nymn.ion_",
+				Type: HandleChannel,
+			},
+		},
 		{
 			label: "Invalid watch (actually a video!)",
 			path:  "/watch?v=asd",
@@ -15,7 +15,10 @@ import (
 	youtubeAPI "google.golang.org/api/youtube/v3"
 )
 
-var youtubeChannelRegex = regexp.MustCompile(`^/(c\/|channel\/|user\/)?([a-zA-Z0-9\-]{1,})$`)
+var (
+	youtubeChannelRegex = regexp.MustCompile(`^/(c\/|channel\/|user\/)?([a-zA-Z0-9\-]{1,})$`)
+	youtubeHandleRegex  = regexp.MustCompile(`^/@([a-zA-Z0-9._\-]{3,30})/?$`)
+)
 
 type YouTubeChannelResolver struct {
 	channelCache cache.Cache
@@ -36,7 +39,7 @@ func (r *YouTubeChannelResolver) Check(ctx context.Context, url *url.URL) (conte
 		return ctx, false
 	}
 
-	matches := youtubeChannelRegex.MatchString(url.Path)
+	matches := youtubeChannelRegex.MatchString(url.Path) || youtubeHandleRegex.MatchString(url.Path)
 	return ctx, matches
 }
 
@@ -63,8 +66,8 @@ func NewYouTubeChannelResolver(ctx context.Context, cfg config.APIConfig, pool d
 	loader := NewYouTubeChannelLoader(youtubeClient)
 
 	r := &YouTubeChannelResolver{
-		channelCache: cache.NewPostgreSQLCache(
-			ctx, cfg, pool, cache.NewPrefixKeyProvider("youtube:channel"), loader, cfg.YoutubeChannelCacheDuration,
+		channelCache: cache.NewDefaultCache(
+			ctx, cfg, pool, cache.NewLocalizedKeyProvider("youtube:channel"), loader, cfg.YoutubeChannelCacheDuration,
 		),
 	}
 
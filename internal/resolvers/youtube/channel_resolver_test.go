@@ -135,6 +135,21 @@ func TestChannelResolver(t *testing.T) {
 				input:    utils.MustParseURL("https://www.youtube.com/user/aragusea"),
 				expected: true,
 			},
+			{
+				label:    "Correct domain, handle path",
+				input:    utils.MustParseURL("https://www.youtube.com/@Aragusea"),
+				expected: true,
+			},
+			{
+				label:    "Correct domain, bad (handle subpage) path",
+				input:    utils.MustParseURL("https://www.youtube.com/@aragusea/videos"),
+				expected: false,
+			},
+			{
+				label:    "Correct domain, bad (live) path",
+				input:    utils.MustParseURL("https://www.youtube.com/live/foobar"),
+				expected: false,
+			},
 			{
 				label:    "Correct domain, no path",
 				input:    utils.MustParseURL("https://youtube.com"),
@@ -210,7 +225,7 @@ func TestChannelResolver(t *testing.T) {
 				// 	inputURL:      utils.MustParseURL("https://youtube.com/watch?v=foobar"),
 				// 	inputVideoID:  "foobar",
 				// 	inputReq:      nil,
-				// 	expectedBytes: []byte(`{"status":200,"thumbnail":"https://example.com/thumbnail.png","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%0A%3Cb%3EVideo%20Title%3C%2Fb%3E%0A%3Cbr%3E%3Cb%3EChannel:%3C%2Fb%3E%20Channel%20Title%0A%3Cbr%3E%3Cb%3EDuration:%3C%2Fb%3E%2000:00:00%0A%3Cbr%3E%3Cb%3EPublished:%3C%2Fb%3E%2012%20Oct%202019%0A%3Cbr%3E%3Cb%3EViews:%3C%2Fb%3E%2050%0A%3Cbr%3E%3Cb%3E%3Cspan%20style=%22color:%20red%3B%22%3EAGE%20RESTRICTED%3C%2Fspan%3E%3C%2Fb%3E%0A%3Cbr%3E%3Cspan%20style=%22color:%20%232ecc71%3B%22%3E10%20likes%3C%2Fspan%3E\u0026nbsp%3B%E2%80%A2\u0026nbsp%3B%3Cspan%20style=%22color:%20%23808892%3B%22%3E5%20comments%3C%2Fspan%3E%0A%3C%2Fdiv%3E%0A"}`),
+				// 	expectedBytes: []byte(`{"status":200,"thumbnail":"https://example.com/thumbnail.png","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%0A%3Cb%3EVideo%20Title%3C%2Fb%3E%0A%3Cbr%3E%3Cb%3EChannel:%3C%2Fb%3E%20Channel%20Title%0A%3Cbr%3E%3Cb%3EDuration:%3C%2Fb%3E%2000:00:00%0A%3Cbr%3E%3Cb%3EPublished:%3C%2Fb%3E%2012%20Oct%202019%0A%3Cbr%3E%3Cb%3EViews:%3C%2Fb%3E%2050%0A%3Cbr%3E%3Cb%3E%3Cspan%20style=%22color:%20red%3B%22%3EAGE%20RESTRICTED%3C%2Fspan%3E%3C%2Fb%3E%0A%3Cbr%3E%3Cspan%20style=%22color:%20%232ecc71%3B%22%3E10%20likes%3C%2Fspan%3E\u0026nbsp%3B%E2%80%A2\u0026nbsp%3B%3Cspan%20style=%22color:%20%23808892%3B%22%3E5%20comments%3C%2Fspan%3E%0A%3C%2Fdiv%3E%0A","data":{"kind":"video","title":"Video Title","author":"Channel Title","views":50,"likes":10,"comments":5,"published":"2019-10-12T07:20:50.52Z","nsfw":true}}`),
 				// },
 				{
 					label:        "Channel:404",
@@ -262,7 +277,7 @@ func TestChannelResolver(t *testing.T) {
 					inputVideoID: "c:custom",
 					inputReq:     nil,
 					expectedResponse: &cache.Response{
-						Payload:     []byte(`{"status":200,"thumbnail":"https://example.com/thumbnail.png","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%0A%3Cb%3ECool%20YouTube%20Channel%3C%2Fb%3E%0A%3Cbr%3E%3Cb%3EJoined%20Date:%3C%2Fb%3E%2012%20Oct%202019%0A%3Cbr%3E%3Cb%3ESubscribers:%3C%2Fb%3E%2069%0A%3Cbr%3E%3Cb%3EViews:%3C%2Fb%3E%20420%0A%3C%2Fdiv%3E%0A"}`),
+						Payload:     []byte(`{"status":200,"thumbnail":"https://example.com/thumbnail.png","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%0A%3Cb%3ECool%20YouTube%20Channel%3C%2Fb%3E%0A%3Cbr%3E%3Cb%3EJoined%20Date:%3C%2Fb%3E%2012%20Oct%202019%0A%3Cbr%3E%3Cb%3ESubscribers:%3C%2Fb%3E%2069%0A%3Cbr%3E%3Cb%3EViews:%3C%2Fb%3E%20420%0A%3C%2Fdiv%3E%0A","data":{"kind":"channel","title":"Cool YouTube Channel","views":420,"followers":69,"published":"2019-10-12T07:20:50.52Z"}}`),
 						StatusCode:  http.StatusOK,
 						ContentType: "application/json",
 					},
@@ -273,7 +288,7 @@ func TestChannelResolver(t *testing.T) {
 					inputVideoID: "user:zneix",
 					inputReq:     nil,
 					expectedResponse: &cache.Response{
-						Payload:     []byte(`{"status":200,"thumbnail":"https://example.com/thumbnail.png","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%0A%3Cb%3ECool%20YouTube%20Channel%3C%2Fb%3E%0A%3Cbr%3E%3Cb%3EJoined%20Date:%3C%2Fb%3E%2012%20Oct%202019%0A%3Cbr%3E%3Cb%3ESubscribers:%3C%2Fb%3E%2069%0A%3Cbr%3E%3Cb%3EViews:%3C%2Fb%3E%20420%0A%3C%2Fdiv%3E%0A"}`),
+						Payload:     []byte(`{"status":200,"thumbnail":"https://example.com/thumbnail.png","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%0A%3Cb%3ECool%20YouTube%20Channel%3C%2Fb%3E%0A%3Cbr%3E%3Cb%3EJoined%20Date:%3C%2Fb%3E%2012%20Oct%202019%0A%3Cbr%3E%3Cb%3ESubscribers:%3C%2Fb%3E%2069%0A%3Cbr%3E%3Cb%3EViews:%3C%2Fb%3E%20420%0A%3C%2Fdiv%3E%0A","data":{"kind":"channel","title":"Cool YouTube Channel","views":420,"followers":69,"published":"2019-10-12T07:20:50.52Z"}}`),
 						StatusCode:  http.StatusOK,
 						ContentType: "application/json",
 					},
@@ -291,7 +306,7 @@ func TestChannelResolver(t *testing.T) {
 					inputVideoID: "channel:mediumtn",
 					inputReq:     nil,
 					expectedResponse: &cache.Response{
-						Payload:     []byte(`{"status":200,"thumbnail":"https://example.com/medium.png","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%0A%3Cb%3ECool%20YouTube%20Channel%3C%2Fb%3E%0A%3Cbr%3E%3Cb%3EJoined%20Date:%3C%2Fb%3E%2012%20Oct%202019%0A%3Cbr%3E%3Cb%3ESubscribers:%3C%2Fb%3E%2069%0A%3Cbr%3E%3Cb%3EViews:%3C%2Fb%3E%20420%0A%3C%2Fdiv%3E%0A"}`),
+						Payload:     []byte(`{"status":200,"thumbnail":"https://example.com/medium.png","tooltip":"%3Cdiv%20style=%22text-align:%20left%3B%22%3E%0A%3Cb%3ECool%20YouTube%20Channel%3C%2Fb%3E%0A%3Cbr%3E%3Cb%3EJoined%20Date:%3C%2Fb%3E%2012%20Oct%202019%0A%3Cbr%3E%3Cb%3ESubscribers:%3C%2Fb%3E%2069%0A%3Cbr%3E%3Cb%3EViews:%3C%2Fb%3E%20420%0A%3C%2Fdiv%3E%0A","data":{"kind":"channel","title":"Cool YouTube Channel","views":420,"followers":69,"published":"2019-10-12T07:20:50.52Z"}}`),
 						StatusCode:  http.StatusOK,
 						ContentType: "application/json",
 					},
@@ -0,0 +1,123 @@
+package youtube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/internal/staticresponse"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+// Matches clip links, e.g. https://www.youtube.com/clip/UgkxU2HSeGL_NvmDJ-nQJrlLwllwMDBdGZFs
+var youtubeClipRegex = regexp.MustCompile(`^/clip/([a-zA-Z0-9_-]+)/?$`)
+
+var errInvalidClipLink = errors.New("invalid clip link")
+
+type ClipLoader struct {
+	keyless    *keylessClient
+	videoCache cache.Cache
+}
+
+// Load builds the clip tooltip from the tooltip of its video, which is loaded with the video cache
+func (l *ClipLoader) Load(ctx context.Context, clipID string, req *http.Request) ([]byte, *int, *string, time.Duration, error) {
+	log := logger.FromContext(ctx)
+
+	log.Debugw("[YouTube] Get clip",
+		"clipID", clipID,
+	)
+
+	clip, statusCode, err := l.keyless.clip(ctx, clipID)
+	if err != nil {
+		if errors.Is(err, resolver.ErrUpstreamUnavailable) {
+			return nil, nil, nil, cache.NoSpecialDur, err
+		}
+
+		return resolver.InternalServerErrorf("YouTube clip page error: %s", err)
+	}
+
+	switch statusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		clip = nil
+	case http.StatusTooManyRequests:
+		return nil, nil, nil, cache.NoSpecialDur, fmt.Errorf("%w: YouTube pages rate limited", resolver.ErrUpstreamUnavailable)
+	default:
+		return resolver.InternalServerErrorf("YouTube clip page returned status %d", statusCode)
+	}
+
+	if clip == nil {
+		return staticresponse.NotFoundf("No YouTube clip with the ID %s found", clipID).
+			WithCacheDuration(24 * time.Hour).
+			Return()
+	}
+
+	videoResponse, err := l.videoCache.Get(ctx, clip.VideoID, req)
+	if err != nil {
+		return nil, nil, nil, cache.NoSpecialDur, err
+	}
+
+	position := videoPosition{
+		Start:     clip.Start,
+		End:       clip.End,
+		Clip:      true,
+		ClipTitle: clip.Title,
+	}
+
+	response, err := videoAt(ctx, videoResponse, position)
+	if err != nil {
+		return resolver.InternalServerErrorf("YouTube template error: %s", err)
+	}
+
+	return response.Payload, &response.StatusCode, &response.ContentType, cache.NoSpecialDur, nil
+}
+
+type YouTubeClipResolver struct {
+	clipCache cache.Cache
+}
+
+func (r *YouTubeClipResolver) Check(ctx context.Context, url *url.URL) (context.Context, bool) {
+	if !utils.IsSubdomainOf(url, "youtube.com") {
+		return ctx, false
+	}
+
+	return ctx, youtubeClipRegex.MatchString(url.Path)
+}
+
+func (r *YouTubeClipResolver) Run(ctx context.Context, url *url.URL, req *http.Request) (*cache.Response, error) {
+	matches := youtubeClipRegex.FindStringSubmatch(url.Path)
+	if len(matches) != 2 {
+		return nil, errInvalidClipLink
+	}
+
+	return r.clipCache.Get(ctx, matches[1], req)
+}
+
+func (r *YouTubeClipResolver) Name() string {
+	return "youtube:clip"
+}
+
+// NewYouTubeClipResolver creates the clip resolver, which loads the videos of clips with videoCache
+func NewYouTubeClipResolver(ctx context.Context, cfg config.APIConfig, pool db.Pool, videoCache cache.Cache) *YouTubeClipResolver {
+	loader := &ClipLoader{
+		keyless:    newKeylessClient(),
+		videoCache: videoCache,
+	}
+
+	r := &YouTubeClipResolver{
+		clipCache: cache.NewDefaultCache(
+			ctx, cfg, pool, cache.NewLocalizedKeyProvider("youtube:clip"), loader, cfg.YoutubeVideoCacheDuration,
+		),
+	}
+
+	return r
+}
@@ -0,0 +1,90 @@
+package youtube
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+)
+
+func TestClipResolver(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	ts := keylessTestServer()
+	defer ts.Close()
+
+	loader := &ClipLoader{
+		keyless: &keylessClient{
+			oEmbedURL: ts.URL + "/oembed",
+			baseURL:   ts.URL,
+		},
+		videoCache: newTestVideoCache(c, ctx),
+	}
+	clipResolver := &YouTubeClipResolver{
+		clipCache: cache.NewMemoryCache(config.APIConfig{}, cache.NewLocalizedKeyProvider("youtube:clip"), loader, time.Hour),
+	}
+
+	c.Run("Name", func(c *qt.C) {
+		c.Assert(clipResolver.Name(), qt.Equals, "youtube:clip")
+	})
+
+	c.Run("Check", func(c *qt.C) {
+		tests := []struct {
+			input    *url.URL
+			expected bool
+		}{
+			{utils.MustParseURL("https://www.youtube.com/clip/UgkxClip"), true},
+			{utils.MustParseURL("https://youtube.com/clip/UgkxClip/"), true},
+			{utils.MustParseURL("https://m.youtube.com/clip/Ugkx-Clip_2?si=abc"), true},
+			{utils.MustParseURL("https://www.youtube.com/clip/"), false},
+			{utils.MustParseURL("https://www.youtube.com/clip/UgkxClip/more"), false},
+			{utils.MustParseURL("https://www.youtube.com/watch?v=foobar"), false},
+			{utils.MustParseURL("https://example.com/clip/UgkxClip"), false},
+		}
+
+		for _, test := range tests {
+			c.Run(test.input.String(), func(c *qt.C) {
+				_, output := clipResolver.Check(ctx, test.input)
+				c.Assert(output, qt.Equals, test.expected)
+			})
+		}
+	})
+
+	c.Run("Clip", func(c *qt.C) {
+		response, err := clipResolver.Run(ctx, utils.MustParseURL("https://www.youtube.com/clip/UgkxClip"), nil)
+		c.Assert(err, qt.IsNil)
+
+		raw, data := decodeResponse(c, response)
+		c.Assert(raw, qt.Not(qt.Contains), "chapters")
+		c.Assert(data.Status, qt.Equals, 200)
+		c.Assert(data.Tooltip, qt.Contains, "<b>Video Title</b>")
+		c.Assert(data.Tooltip, qt.Contains, "<br><b>Clip:</b> Best part (00:12:34 – 00:12:49)\n<br><b>Chapter:</b> Second part\n</div>")
+		c.Assert(data.Data.Kind, qt.Equals, resolver.DataKindClip)
+		c.Assert(data.Data.Fields, qt.DeepEquals, map[string]string{
+			"start_offset": "754",
+			"end_offset":   "769",
+			"chapter":      "Second part",
+			"clip_title":   "Best part",
+		})
+	})
+
+	c.Run("Not a clip", func(c *qt.C) {
+		response, err := clipResolver.Run(ctx, utils.MustParseURL("https://www.youtube.com/clip/UgkxNoConfig"), nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(response.Payload), qt.Equals, `{"status":404,"message":"No YouTube clip with the ID UgkxNoConfig found"}`)
+	})
+
+	c.Run("Not found", func(c *qt.C) {
+		response, err := clipResolver.Run(ctx, utils.MustParseURL("https://www.youtube.com/clip/UgkxMissing"), nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(response.Payload), qt.Equals, `{"status":404,"message":"No YouTube clip with the ID UgkxMissing found"}`)
+	})
+}
@@ -69,6 +69,32 @@ func init() {
 		},
 	}
 
+	videos["chapters"] = &youtubeAPI.VideoListResponse{
+		Items: []*youtubeAPI.Video{
+			{
+				ContentDetails: &youtubeAPI.VideoContentDetails{
+					Duration: "PT20M",
+				},
+				Snippet: &youtubeAPI.VideoSnippet{
+					Title:        "Video Title",
+					ChannelTitle: "Channel Title",
+					Description:  "Chapters:\n0:00 Intro\n5:00 - First <part>\n12:30 Second part\n\nThanks for watching!",
+					PublishedAt:  "2019-10-12T07:20:50.52Z",
+					Thumbnails: &youtubeAPI.ThumbnailDetails{
+						Default: &youtubeAPI.Thumbnail{
+							Url: "https://example.com/thumbnail.png",
+						},
+					},
+				},
+				Statistics: &youtubeAPI.VideoStatistics{
+					ViewCount:    50,
+					LikeCount:    10,
+					CommentCount: 5,
+				},
+			},
+		},
+	}
+
 	videos["404"] = &youtubeAPI.VideoListResponse{
 		Items: []*youtubeAPI.Video{},
 	}
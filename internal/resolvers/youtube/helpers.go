@@ -4,6 +4,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 )
 
 type channelType string
@@ -17,6 +18,8 @@ const (
 	IdentifierChannel = "channel"
 	// CustomChannel channel uses a custom URL and requires a Search call for the ID
 	CustomChannel = "c"
+	// HandleChannel channel ID is a handle (youtube.com/@handle)
+	HandleChannel = "handle"
 )
 
 func getYoutubeVideoIDFromURL(url *url.URL) string {
@@ -24,8 +27,8 @@ func getYoutubeVideoIDFromURL(url *url.URL) string {
 		return path.Base(url.Path)
 	}
 
-	// ex: https://www.youtube.com/shorts/nSW6scUfnFw
-	if base, rest := path.Split(url.Path); base == "/shorts/" {
+	// ex: https://www.youtube.com/shorts/nSW6scUfnFw or https://www.youtube.com/live/jfKfPfyJRdk
+	if base, rest := path.Split(url.Path); base == "/shorts/" || base == "/live/" {
 		return rest
 	}
 
@@ -51,3 +54,10 @@ func getYoutubeVideoIDFromURL2(url *url.URL) string {
 
 	return fields[0]
 }
+
+// parseDurationPT parses the ISO 8601 durations returned by the YouTube API, e.g. PT1H2M3S
+func parseDurationPT(pt string) time.Duration {
+	pt = strings.Replace(strings.ToLower(pt), "pt", "", 1)
+	duration, _ := time.ParseDuration(pt)
+	return duration
+}
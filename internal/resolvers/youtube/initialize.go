@@ -2,12 +2,12 @@ package youtube
 
 import (
 	"context"
-	"html/template"
 
 	"github.com/Chatterino/api/internal/db"
 	"github.com/Chatterino/api/internal/logger"
 	"github.com/Chatterino/api/pkg/cache"
 	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	"google.golang.org/api/option"
 
@@ -17,91 +17,112 @@ import (
 const (
 	youtubeVideoTooltip = `<div style="text-align: left;">
 <b>{{.Title}}</b>
-<br><b>Channel:</b> {{.ChannelTitle}}
-<br><b>Duration:</b> {{.Duration}}
-<br><b>Published:</b> {{.PublishDate}}
-<br><b>Views:</b> {{.Views}}
-{{ if .AgeRestricted }}<br><b><span style="color: red;">AGE RESTRICTED</span></b>{{ end }}
-<br><span style="color: #2ecc71;">{{.LikeCount}} likes</span>&nbsp;•&nbsp;<span style="color: #808892;">{{.CommentCount}} comments</span>
-</div>
+<br><b>{{t "Channel"}}:</b> {{.ChannelTitle}}
+{{ if .Duration }}<br><b>{{t "Duration"}}:</b> {{.Duration}}
+{{ end }}{{ if .PublishDate }}<br><b>{{t "Published"}}:</b> {{.PublishDate}}
+{{ end }}{{ if .Views }}<br><b>{{t "Views"}}:</b> {{.Views}}
+{{ end }}{{ if .AgeRestricted }}<br><b><span style="color: red;">{{t "AGE RESTRICTED"}}</span></b>{{ end }}
+{{ if .LikeCount }}<br><span style="color: #2ecc71;">{{t "%s likes" .LikeCount}}</span>&nbsp;•&nbsp;<span style="color: #808892;">{{t "%s comments" .CommentCount}}</span>
+{{ end }}</div>
 `
 
+	// Added to the video tooltip for links with a timestamp and clip links
+	youtubeVideoPositionTooltip = `{{ if .Clip }}<br><b>{{t "Clip"}}:</b> {{ if .ClipTitle }}{{.ClipTitle}} {{ end }}({{.Start}} – {{.End}})
+{{ else }}<br><b>{{t "Starts at"}}:</b> {{.Start}}
+{{ end }}{{ if .Chapter }}<br><b>{{t "Chapter"}}:</b> {{.Chapter}}
+{{ end }}`
+
 	youtubeChannelTooltip = `<div style="text-align: left;">
 <b>{{.Title}}</b>
-<br><b>Joined Date:</b> {{.JoinedDate}}
-<br><b>Subscribers:</b> {{.Subscribers}}
-<br><b>Views:</b> {{.Views}}
-</div>
+{{ if .JoinedDate }}<br><b>{{t "Joined Date"}}:</b> {{.JoinedDate}}
+{{ end }}{{ if .Subscribers }}<br><b>{{t "Subscribers"}}:</b> {{.Subscribers}}
+{{ end }}{{ if .Views }}<br><b>{{t "Views"}}:</b> {{.Views}}
+{{ end }}</div>
 `
 
 	youtubePlaylistTooltip = `<div style="text-align: left;">
 <b>{{.Title}}</b>
-<br><b>Description:</b> {{.Description}}
-<br><b>Channel:</b> {{.Channel}}
-<br><b>Videos:</b> {{.VideoCount}}
-<br><b>Published:</b> {{.PublishedAt}}
+<br><b>{{t "Description"}}:</b> {{.Description}}
+<br><b>{{t "Channel"}}:</b> {{.Channel}}
+<br><b>{{t "Videos"}}:</b> {{.VideoCount}}
+<br><b>{{t "Published"}}:</b> {{.PublishedAt}}
 </div>
 `
 
 	youtubeStreamTooltip = `<div style="text-align: left;">
 <b>{{.Title}}</b>
-<br><b>Channel:</b> {{.ChannelTitle}}
-<br><b>Uptime:</b> {{.Uptime}}
-<br><b>Viewers:</b> {{.Viewers}}
-<br><b><span style="color: #ff0000;">Live</span></b>&nbsp;•&nbsp;<span style="color: #2ecc71;">{{.LikeCount}} likes</span>
+<br><b>{{t "Channel"}}:</b> {{.ChannelTitle}}
+<br><b>{{t "Uptime"}}:</b> {{.Uptime}}
+<br><b>{{t "Viewers"}}:</b> {{.Viewers}}
+<br><b><span style="color: #ff0000;">{{t "Live"}}</span></b>&nbsp;•&nbsp;<span style="color: #2ecc71;">{{t "%s likes" .LikeCount}}</span>
 </div>
 `
 )
 
 var (
-	youtubeVideoTooltipTemplate    = template.Must(template.New("youtubeVideoTooltip").Parse(youtubeVideoTooltip))
-	youtubeChannelTooltipTemplate  = template.Must(template.New("youtubeChannelTooltip").Parse(youtubeChannelTooltip))
-	youtubePlaylistTooltipTemplate = template.Must(template.New("youtubePlaylistTooltip").Parse(youtubePlaylistTooltip))
-	youtubeStreamTooltipTemplate   = template.Must(template.New("youtubeStreamTooltip").Parse(youtubeStreamTooltip))
+	youtubeVideoTooltipTemplate         = i18n.MustTemplate("youtubeVideoTooltip", youtubeVideoTooltip)
+	youtubeVideoPositionTooltipTemplate = i18n.MustTemplate("youtubeVideoPositionTooltip", youtubeVideoPositionTooltip)
+	youtubeChannelTooltipTemplate       = i18n.MustTemplate("youtubeChannelTooltip", youtubeChannelTooltip)
+	youtubePlaylistTooltipTemplate      = i18n.MustTemplate("youtubePlaylistTooltip", youtubePlaylistTooltip)
+	youtubeStreamTooltipTemplate        = i18n.MustTemplate("youtubeStreamTooltip", youtubeStreamTooltip)
 )
 
-func NewYouTubeVideoResolvers(ctx context.Context, cfg config.APIConfig, pool db.Pool, youtubeClient *youtubeAPI.Service) (resolver.Resolver, resolver.Resolver) {
+// NewYouTubeVideoResolvers creates the video, short URL and clip resolvers, which share the video cache
+func NewYouTubeVideoResolvers(ctx context.Context, cfg config.APIConfig, pool db.Pool, youtubeClient *youtubeAPI.Service) (resolver.Resolver, resolver.Resolver, resolver.Resolver) {
 	videoLoader := NewVideoLoader(youtubeClient)
-	videoCache := cache.NewPostgreSQLCache(
-		ctx, cfg, pool, cache.NewPrefixKeyProvider("youtube:video"), videoLoader, cfg.YoutubeVideoCacheDuration,
+	videoCache := cache.NewDefaultCache(
+		ctx, cfg, pool, cache.NewLocalizedKeyProvider("youtube:video"), videoLoader, cfg.YoutubeVideoCacheDuration,
 	)
 
 	videoResolver := NewYouTubeVideoResolver(videoCache)
 	videoShortURLResolver := NewYouTubeVideoShortURLResolver(videoCache)
+	clipResolver := NewYouTubeClipResolver(ctx, cfg, pool, videoCache)
 
-	return videoResolver, videoShortURLResolver
+	return videoResolver, videoShortURLResolver, clipResolver
 }
 
 func Initialize(ctx context.Context, cfg config.APIConfig, pool db.Pool, resolvers *[]resolver.Resolver) {
 	log := logger.FromContext(ctx)
 
-	if cfg.YoutubeApiKey == "" {
-		log.Warnw("[Config] youtube-api-key missing, won't do special responses for YouTube")
-		return
+	youtubeUpstream = resolver.NewUpstream(cfg, "youtube")
+	keylessUpstream = resolver.NewUpstream(cfg, "youtube-oembed")
 
-	}
+	// Videos and channels are loaded without the API if there's no YouTube client
+	var youtubeClient *youtubeAPI.Service
 
-	youtubeClient, err := youtubeAPI.NewService(ctx, option.WithAPIKey(cfg.YoutubeApiKey))
-	if err != nil {
-		log.Warnw("[Config] Failed to create youtube client, won't do special responses for YouTube",
-			"error", err,
-		)
-		return
+	if cfg.YoutubeApiKey == "" {
+		log.Warnw("[Config] youtube-api-key missing, YouTube videos and channels will only show information available without the API")
+	} else {
+		var err error
+		youtubeClient, err = youtubeAPI.NewService(ctx, option.WithAPIKey(cfg.YoutubeApiKey))
+		if err != nil {
+			log.Warnw("[Config] Failed to create youtube client, YouTube videos and channels will only show information available without the API",
+				"error", err,
+			)
+			youtubeClient = nil
+		}
 	}
 
-	playlistResolver := NewYouTubePlaylistResolver(ctx, cfg, pool, youtubeClient)
+	if youtubeClient != nil {
+		youtubeQuota = newQuotaTracker(ctx, cfg, pool)
+
+		playlistResolver := NewYouTubePlaylistResolver(ctx, cfg, pool, youtubeClient)
 
-	// Handle YouTube playlists
-	*resolvers = append(*resolvers, playlistResolver)
+		// Handle YouTube playlists
+		*resolvers = append(*resolvers, playlistResolver)
+	}
 
-	// Handle YouTube channels (youtube.com/c/chan, youtube.com/chan, youtube.com/user/chan)
+	// Handle YouTube channels (youtube.com/c/chan, youtube.com/chan, youtube.com/user/chan, youtube.com/@handle)
 	*resolvers = append(*resolvers, NewYouTubeChannelResolver(ctx, cfg, pool, youtubeClient))
 
-	videoResolver, videoShortURLResolver := NewYouTubeVideoResolvers(ctx, cfg, pool, youtubeClient)
+	videoResolver, videoShortURLResolver, clipResolver := NewYouTubeVideoResolvers(ctx, cfg, pool, youtubeClient)
 
 	// Handle YouTube video URLs
 	*resolvers = append(*resolvers, videoResolver)
 
 	// Handle shortened YouTube video URLs
 	*resolvers = append(*resolvers, videoShortURLResolver)
+
+	// Handle YouTube clips (youtube.com/clip/id)
+	*resolvers = append(*resolvers, clipResolver)
 }
@@ -29,7 +29,8 @@ func TestInitialize(t *testing.T) {
 		customResolvers := []resolver.Resolver{}
 		c.Assert(customResolvers, qt.HasLen, 0)
 		Initialize(ctx, cfg, pool, &customResolvers)
-		c.Assert(customResolvers, qt.HasLen, 0)
+		// Playlists can't be resolved without the API
+		c.Assert(customResolvers, qt.HasLen, 4)
 	})
 	c.Run("With YouTube API key", func(c *qt.C) {
 		cfg := config.APIConfig{
@@ -38,6 +39,6 @@ func TestInitialize(t *testing.T) {
 		customResolvers := []resolver.Resolver{}
 		c.Assert(customResolvers, qt.HasLen, 0)
 		Initialize(ctx, cfg, pool, &customResolvers)
-		c.Assert(customResolvers, qt.HasLen, 4)
+		c.Assert(customResolvers, qt.HasLen, 5)
 	})
 }
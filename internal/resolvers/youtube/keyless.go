@@ -0,0 +1,385 @@
+package youtube
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/internal/staticresponse"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	youtubeBaseURL   = "https://www.youtube.com"
+	youtubeOEmbedURL = "https://www.youtube.com/oembed"
+
+	// Maximum size of the YouTube pages metadata is read from
+	maxPageSize = 5 * 1024 * 1024
+
+	// How long responses are cached for when the API is unavailable, so the full information is
+	// loaded again once it's available
+	keylessFallbackCacheDuration = time.Hour
+)
+
+// Shared by the keyless clients, set by Initialize
+var keylessUpstream *resolver.Upstream
+
+type oEmbedResponse struct {
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name"`
+	AuthorURL    string `json:"author_url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+// pageMetadata holds the content of a YouTube page's meta tags, keyed by their property, name or itemprop
+type pageMetadata map[string]string
+
+// first returns the first of the keys that's set
+func (m pageMetadata) first(keys ...string) string {
+	for _, key := range keys {
+		if value := m[key]; value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// keylessClient loads videos and channels without the YouTube API, using YouTube's oEmbed endpoint
+// and the metadata of its pages. Used when no youtube-api-key is set, or the API quota is exhausted.
+type keylessClient struct {
+	oEmbedURL string
+	baseURL   string
+}
+
+func newKeylessClient() *keylessClient {
+	return &keylessClient{
+		oEmbedURL: youtubeOEmbedURL,
+		baseURL:   youtubeBaseURL,
+	}
+}
+
+func (c *keylessClient) get(ctx context.Context, requestURL string) (*http.Response, error) {
+	return keylessUpstream.Do(func() (*http.Response, error) {
+		return resolver.RequestGET(ctx, requestURL)
+	})
+}
+
+// oEmbed returns the oEmbed response of the video and the status code of the request
+func (c *keylessClient) oEmbed(ctx context.Context, videoID string) (*oEmbedResponse, int, error) {
+	query := url.Values{}
+	query.Set("format", "json")
+	query.Set("url", youtubeBaseURL+"/watch?v="+url.QueryEscape(videoID))
+
+	resp, err := c.get(ctx, c.oEmbedURL+"?"+query.Encode())
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode, nil
+	}
+
+	var embed oEmbedResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxPageSize)).Decode(&embed); err != nil {
+		return nil, resp.StatusCode, err
+	}
+
+	return &embed, resp.StatusCode, nil
+}
+
+// pageBody returns the content of the YouTube page at the path and the status code of the request
+func (c *keylessClient) pageBody(ctx context.Context, path string) ([]byte, int, error) {
+	resp, err := c.get(ctx, c.baseURL+path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageSize))
+	if err != nil {
+		return nil, resp.StatusCode, err
+	}
+
+	return body, resp.StatusCode, nil
+}
+
+// page returns the metadata of the YouTube page at the path and the status code of the request
+func (c *keylessClient) page(ctx context.Context, path string) (pageMetadata, int, error) {
+	body, statusCode, err := c.pageBody(ctx, path)
+	if err != nil || body == nil {
+		return nil, statusCode, err
+	}
+
+	metadata, err := parsePageMetadata(body)
+	return metadata, statusCode, err
+}
+
+// parsePageMetadata reads the meta tags of a YouTube page
+func parsePageMetadata(body []byte) (pageMetadata, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := pageMetadata{}
+	doc.Find("meta[content]").Each(func(i int, s *goquery.Selection) {
+		content, _ := s.Attr("content")
+		for _, attribute := range []string{"property", "name", "itemprop"} {
+			if key, ok := s.Attr(attribute); ok {
+				if _, exists := metadata[key]; !exists {
+					metadata[key] = content
+				}
+				break
+			}
+		}
+	})
+
+	return metadata, nil
+}
+
+// loadVideo builds the video tooltip from the oEmbed response of the video, and the metadata of its
+// watch page where available
+func (c *keylessClient) loadVideo(ctx context.Context, videoID string, cacheDuration time.Duration) ([]byte, *int, *string, time.Duration, error) {
+	log := logger.FromContext(ctx)
+	lang := i18n.FromContext(ctx)
+
+	log.Debugw("[YouTube] Get video without API",
+		"videoID", videoID,
+	)
+
+	embed, statusCode, err := c.oEmbed(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, resolver.ErrUpstreamUnavailable) {
+			return nil, nil, nil, cache.NoSpecialDur, err
+		}
+
+		return resolver.InternalServerErrorf("YouTube oEmbed error: %s", err)
+	}
+
+	switch statusCode {
+	case http.StatusOK:
+	case http.StatusBadRequest, http.StatusNotFound:
+		return staticresponse.NotFoundf("No YouTube video with the ID %s found", videoID).
+			WithCacheDuration(24 * time.Hour).
+			Return()
+	case http.StatusUnauthorized, http.StatusForbidden:
+		// Videos that can't be embedded have no oEmbed response, but still have a watch page
+	case http.StatusTooManyRequests:
+		return nil, nil, nil, cache.NoSpecialDur, fmt.Errorf("%w: YouTube oEmbed rate limited", resolver.ErrUpstreamUnavailable)
+	default:
+		return resolver.InternalServerErrorf("YouTube oEmbed returned status %d", statusCode)
+	}
+
+	// The watch page only adds details to the tooltip, so it failing to load isn't an error
+	metadata, _, err := c.page(ctx, "/watch?v="+url.QueryEscape(videoID))
+	if err != nil {
+		log.Debugw("[YouTube] Error loading watch page",
+			"videoID", videoID,
+			"error", err,
+		)
+	}
+
+	if embed == nil {
+		title := metadata.first("og:title", "title")
+		if title == "" {
+			return resolver.InternalServerErrorf("YouTube video unavailable")
+		}
+
+		embed = &oEmbedResponse{
+			Title:        title,
+			ThumbnailURL: metadata["og:image"],
+		}
+	}
+
+	data := youtubeVideoTooltipData{
+		Title:         embed.Title,
+		ChannelTitle:  embed.AuthorName,
+		AgeRestricted: metadata["isFamilyFriendly"] == "false",
+	}
+
+	responseData := &resolver.ResponseData{
+		Kind:   resolver.DataKindVideo,
+		Title:  embed.Title,
+		Author: embed.AuthorName,
+		NSFW:   data.AgeRestricted,
+	}
+
+	if duration := metadata["duration"]; duration != "" {
+		data.Duration = humanize.DurationPT(duration)
+		responseData.Duration = int64(parseDurationPT(duration).Seconds())
+	}
+
+	if published, err := time.Parse(time.RFC3339, metadata.first("datePublished", "uploadDate")); err == nil {
+		data.PublishDate = humanize.CreationDateIn(lang, published.UTC())
+		responseData.Published = published.UTC().Format(time.RFC3339)
+	}
+
+	if views, err := strconv.ParseUint(metadata["interactionCount"], 10, 64); err == nil {
+		data.Views = humanize.NumberIn(lang, views)
+		responseData.Views = views
+	}
+
+	var tooltip bytes.Buffer
+	if err := youtubeVideoTooltipTemplate.Execute(&tooltip, lang, data); err != nil {
+		return resolver.InternalServerErrorf("YouTube template error: %s", err)
+	}
+
+	successCode := http.StatusOK
+	contentType := "application/json"
+
+	return buildChannelResponse(tooltip.String(), embed.ThumbnailURL, responseData), &successCode, &contentType, cacheDuration, nil
+}
+
+// channelPath returns the path of the channel's page
+func channelPath(channel Channel) string {
+	switch channel.Type {
+	case HandleChannel:
+		return "/@" + url.PathEscape(channel.ID)
+	case UserChannel:
+		return "/user/" + url.PathEscape(channel.ID)
+	case IdentifierChannel:
+		return "/channel/" + url.PathEscape(channel.ID)
+	default:
+		return "/c/" + url.PathEscape(channel.ID)
+	}
+}
+
+// loadChannel builds the channel tooltip from the metadata of the channel's page
+func (c *keylessClient) loadChannel(ctx context.Context, channel Channel, cacheDuration time.Duration) ([]byte, *int, *string, time.Duration, error) {
+	log := logger.FromContext(ctx)
+	lang := i18n.FromContext(ctx)
+
+	log.Debugw("[YouTube] Get channel without API",
+		"channel", channel.ToCacheKey(),
+	)
+
+	metadata, statusCode, err := c.page(ctx, channelPath(channel))
+	if err != nil {
+		if errors.Is(err, resolver.ErrUpstreamUnavailable) {
+			return nil, nil, nil, cache.NoSpecialDur, err
+		}
+
+		return resolver.InternalServerErrorf("YouTube channel page error: %s", err)
+	}
+
+	switch statusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return staticresponse.NotFoundf("No YouTube channel with the ID %s found", channel.ID).
+			WithCacheDuration(24 * time.Hour).
+			Return()
+	case http.StatusTooManyRequests:
+		return nil, nil, nil, cache.NoSpecialDur, fmt.Errorf("%w: YouTube pages rate limited", resolver.ErrUpstreamUnavailable)
+	default:
+		return resolver.InternalServerErrorf("YouTube channel page returned status %d", statusCode)
+	}
+
+	title := metadata.first("og:title", "name", "title")
+	if title == "" {
+		return resolver.InternalServerErrorf("YouTube channel unavailable")
+	}
+
+	data := youtubeChannelTooltipData{
+		Title: title,
+	}
+
+	var tooltip bytes.Buffer
+	if err := youtubeChannelTooltipTemplate.Execute(&tooltip, lang, data); err != nil {
+		return resolver.InternalServerErrorf("YouTube template error: %s", err.Error())
+	}
+
+	responseData := &resolver.ResponseData{
+		Kind:        resolver.DataKindChannel,
+		Title:       title,
+		Description: strings.TrimSpace(metadata.first("og:description", "description")),
+	}
+
+	successCode := http.StatusOK
+	contentType := "application/json"
+
+	return buildChannelResponse(tooltip.String(), metadata["og:image"], responseData), &successCode, &contentType, cacheDuration, nil
+}
+
+var (
+	// The clip's start and end, part of the player config embedded in clip pages
+	clipConfigRegex = regexp.MustCompile(`"clipConfig":\{[^}]*\}`)
+	clipStartRegex  = regexp.MustCompile(`"startTimeMs":"(\d+)"`)
+	clipEndRegex    = regexp.MustCompile(`"endTimeMs":"(\d+)"`)
+	clipVideoRegex  = regexp.MustCompile(`"videoId":"([a-zA-Z0-9_-]{11})"`)
+)
+
+type youtubeClip struct {
+	VideoID string
+	Title   string
+	Start   time.Duration
+	End     time.Duration
+}
+
+// clip reads the video and the part of it a clip is made of from the clip's page. Returns nil if
+// the page has no clip.
+func (c *keylessClient) clip(ctx context.Context, clipID string) (*youtubeClip, int, error) {
+	body, statusCode, err := c.pageBody(ctx, "/clip/"+url.PathEscape(clipID))
+	if err != nil || body == nil {
+		return nil, statusCode, err
+	}
+
+	clipConfig := clipConfigRegex.Find(body)
+	if clipConfig == nil {
+		return nil, statusCode, nil
+	}
+
+	start := clipStartRegex.FindSubmatch(clipConfig)
+	end := clipEndRegex.FindSubmatch(clipConfig)
+	if start == nil || end == nil {
+		return nil, statusCode, nil
+	}
+
+	metadata, err := parsePageMetadata(body)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	clip := &youtubeClip{
+		Title: metadata.first("og:title", "title"),
+		Start: parseClipTime(string(start[1])),
+		End:   parseClipTime(string(end[1])),
+	}
+
+	// The embed URL of the clip links to its video
+	if embedURL, err := url.Parse(metadata.first("og:video:url", "og:video:secure_url")); err == nil && strings.HasPrefix(embedURL.Path, "/embed/") {
+		clip.VideoID = path.Base(embedURL.Path)
+	} else if video := clipVideoRegex.FindSubmatch(body); video != nil {
+		clip.VideoID = string(video[1])
+	} else {
+		return nil, statusCode, nil
+	}
+
+	return clip, statusCode, nil
+}
+
+// parseClipTime parses the millisecond timestamps of clip configs
+func parseClipTime(ms string) time.Duration {
+	value, _ := strconv.ParseInt(ms, 10, 64)
+	return (time.Duration(value) * time.Millisecond).Truncate(time.Second)
+}
@@ -0,0 +1,264 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/api/option"
+	youtubeAPI "google.golang.org/api/youtube/v3"
+)
+
+const (
+	watchPage = `<html><head>` +
+		`<meta property="og:title" content="Video Title">` +
+		`<meta property="og:image" content="https://i.ytimg.com/vi/foobar/maxresdefault.jpg">` +
+		`<meta itemprop="duration" content="PT3M33S">` +
+		`<meta itemprop="isFamilyFriendly" content="true">` +
+		`<meta itemprop="interactionCount" content="1234567">` +
+		`<meta itemprop="datePublished" content="2009-10-24T23:57:33-07:00">` +
+		`</head></html>`
+
+	clipPage = `<html><head>` +
+		`<meta property="og:title" content="Best part">` +
+		`<meta property="og:video:url" content="https://www.youtube.com/embed/chapters?clip=UgkxClip&amp;clipt=EgYI">` +
+		`</head><body><script>var ytInitialPlayerResponse = {"videoDetails":{"videoId":"chapters"},` +
+		`"clipConfig":{"postId":"UgkxClip","startTimeMs":"754000","endTimeMs":"769500"}};</script></body></html>`
+
+	channelPage = `<html><head>` +
+		`<meta property="og:title" content="Adam Ragusea">` +
+		`<meta property="og:image" content="https://yt3.ggpht.com/aragusea.jpg">` +
+		`<meta property="og:description" content=" Cooking videos ">` +
+		`</head></html>`
+)
+
+func keylessTestServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oembed", func(w http.ResponseWriter, r *http.Request) {
+		videoURL, err := url.Parse(r.URL.Query().Get("url"))
+		if err != nil || r.URL.Query().Get("format") != "json" {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		switch videoURL.Query().Get("v") {
+		case "foobar":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"title":"Video Title","author_name":"Channel Title","author_url":"https://www.youtube.com/@channel","thumbnail_url":"https://i.ytimg.com/vi/foobar/hqdefault.jpg"}`))
+		case "noembed", "private":
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		case "ratelimited":
+			http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+		default:
+			http.Error(w, "Not Found", http.StatusNotFound)
+		}
+	})
+	mux.HandleFunc("/watch", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("v") {
+		case "foobar":
+			w.Write([]byte(watchPage))
+		case "noembed":
+			w.Write([]byte(`<html><head><meta property="og:title" content="Not embeddable"><meta itemprop="isFamilyFriendly" content="false"></head></html>`))
+		default:
+			w.Write([]byte(`<html><head></head></html>`))
+		}
+	})
+	mux.HandleFunc("/@aragusea", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(channelPage))
+	})
+	mux.HandleFunc("/clip/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/clip/UgkxClip":
+			w.Write([]byte(clipPage))
+		case "/clip/UgkxNoConfig":
+			w.Write([]byte(watchPage))
+		default:
+			http.Error(w, "Not Found", http.StatusNotFound)
+		}
+	})
+
+	return httptest.NewServer(mux)
+}
+
+// decodeLoaded decodes the payload returned by a loader
+func decodeLoaded(c *qt.C, payload []byte) resolver.Response {
+	var response resolver.Response
+	c.Assert(json.Unmarshal(payload, &response), qt.IsNil)
+	return response
+}
+
+func TestKeylessVideoLoader(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	ts := keylessTestServer()
+	defer ts.Close()
+
+	loader := NewVideoLoader(nil)
+	loader.keyless = &keylessClient{
+		oEmbedURL: ts.URL + "/oembed",
+		baseURL:   ts.URL,
+	}
+
+	c.Run("Video", func(c *qt.C) {
+		payload, _, _, cacheDuration, err := loader.Load(ctx, "foobar", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cacheDuration, qt.Equals, cache.NoSpecialDur)
+
+		response := decodeLoaded(c, payload)
+		c.Assert(response.Status, qt.Equals, http.StatusOK)
+		c.Assert(response.Thumbnail, qt.Equals, "https://i.ytimg.com/vi/foobar/hqdefault.jpg")
+
+		tooltip, err := url.PathUnescape(response.Tooltip)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tooltip, qt.Equals, `<div style="text-align: left;">
+<b>Video Title</b>
+<br><b>Channel:</b> Channel Title
+<br><b>Duration:</b> 00:03:33
+<br><b>Published:</b> 25 Oct 2009
+<br><b>Views:</b> 1.2M
+
+</div>
+`)
+
+		c.Assert(response.Data, qt.DeepEquals, &resolver.ResponseData{
+			Kind:      resolver.DataKindVideo,
+			Title:     "Video Title",
+			Author:    "Channel Title",
+			Duration:  213,
+			Views:     1234567,
+			Published: "2009-10-25T06:57:33Z",
+		})
+	})
+
+	c.Run("German", func(c *qt.C) {
+		payload, _, _, _, err := loader.Load(i18n.OnContext(ctx, i18n.German), "foobar", nil)
+		c.Assert(err, qt.IsNil)
+
+		tooltip, err := url.PathUnescape(decodeLoaded(c, payload).Tooltip)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tooltip, qt.Contains, `<br><b>Veröffentlicht:</b> 25.10.2009`)
+		c.Assert(tooltip, qt.Contains, `<br><b>Aufrufe:</b> 1,2 Mio.`)
+	})
+
+	c.Run("Not embeddable", func(c *qt.C) {
+		payload, _, _, _, err := loader.Load(ctx, "noembed", nil)
+		c.Assert(err, qt.IsNil)
+
+		response := decodeLoaded(c, payload)
+		c.Assert(response.Status, qt.Equals, http.StatusOK)
+
+		tooltip, err := url.PathUnescape(response.Tooltip)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tooltip, qt.Contains, `<b>Not embeddable</b>`)
+		c.Assert(tooltip, qt.Contains, `AGE RESTRICTED`)
+		c.Assert(response.Data.NSFW, qt.IsTrue)
+	})
+
+	c.Run("Private", func(c *qt.C) {
+		payload, _, _, _, err := loader.Load(ctx, "private", nil)
+		c.Assert(err, qt.IsNil)
+
+		response := decodeLoaded(c, payload)
+		c.Assert(response.Status, qt.Equals, http.StatusInternalServerError)
+		c.Assert(response.Message, qt.Equals, "YouTube video unavailable")
+	})
+
+	c.Run("Not found", func(c *qt.C) {
+		payload, _, _, cacheDuration, err := loader.Load(ctx, "404", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cacheDuration, qt.Equals, 24*time.Hour)
+
+		response := decodeLoaded(c, payload)
+		c.Assert(response.Status, qt.Equals, http.StatusNotFound)
+		c.Assert(response.Message, qt.Equals, "No YouTube video with the ID 404 found")
+	})
+
+	c.Run("Rate limited", func(c *qt.C) {
+		_, _, _, _, err := loader.Load(ctx, "ratelimited", nil)
+		c.Assert(err, qt.ErrorIs, resolver.ErrUpstreamUnavailable)
+	})
+
+	c.Run("API quota exceeded", func(c *qt.C) {
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":{"code":403,"message":"The request cannot be completed because you have exceeded your quota.","errors":[{"message":"quota","domain":"youtube.quota","reason":"quotaExceeded"}]}}`))
+		}))
+		defer api.Close()
+
+		youtubeClient, err := youtubeAPI.NewService(ctx, option.WithAPIKey("test"), option.WithEndpoint(api.URL))
+		c.Assert(err, qt.IsNil)
+
+		apiLoader := NewVideoLoader(youtubeClient)
+		apiLoader.keyless = loader.keyless
+
+		payload, _, _, cacheDuration, err := apiLoader.Load(ctx, "foobar", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cacheDuration, qt.Equals, keylessFallbackCacheDuration)
+		c.Assert(decodeLoaded(c, payload).Data.Title, qt.Equals, "Video Title")
+	})
+}
+
+func TestKeylessChannelLoader(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	ts := keylessTestServer()
+	defer ts.Close()
+
+	loader := NewYouTubeChannelLoader(nil)
+	loader.keyless = &keylessClient{
+		oEmbedURL: ts.URL + "/oembed",
+		baseURL:   ts.URL,
+	}
+
+	c.Run("Handle", func(c *qt.C) {
+		payload, _, _, _, err := loader.Load(ctx, "handle:aragusea", nil)
+		c.Assert(err, qt.IsNil)
+
+		response := decodeLoaded(c, payload)
+		c.Assert(response.Status, qt.Equals, http.StatusOK)
+		c.Assert(response.Thumbnail, qt.Equals, "https://yt3.ggpht.com/aragusea.jpg")
+
+		tooltip, err := url.PathUnescape(response.Tooltip)
+		c.Assert(err, qt.IsNil)
+		c.Assert(tooltip, qt.Equals, `<div style="text-align: left;">
+<b>Adam Ragusea</b>
+</div>
+`)
+
+		c.Assert(response.Data, qt.DeepEquals, &resolver.ResponseData{
+			Kind:        resolver.DataKindChannel,
+			Title:       "Adam Ragusea",
+			Description: "Cooking videos",
+		})
+	})
+
+	c.Run("Not found", func(c *qt.C) {
+		payload, _, _, _, err := loader.Load(ctx, "c:aragusea", nil)
+		c.Assert(err, qt.IsNil)
+
+		response := decodeLoaded(c, payload)
+		c.Assert(response.Status, qt.Equals, http.StatusNotFound)
+		c.Assert(response.Message, qt.Equals, "No YouTube channel with the ID aragusea found")
+	})
+}
+
+func TestChannelPath(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(channelPath(Channel{ID: "aragusea", Type: HandleChannel}), qt.Equals, "/@aragusea")
+	c.Assert(channelPath(Channel{ID: "aragusea", Type: UserChannel}), qt.Equals, "/user/aragusea")
+	c.Assert(channelPath(Channel{ID: "UCabc", Type: IdentifierChannel}), qt.Equals, "/channel/UCabc")
+	c.Assert(channelPath(Channel{ID: "aragusea", Type: CustomChannel}), qt.Equals, "/c/aragusea")
+}
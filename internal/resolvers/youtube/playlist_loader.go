@@ -13,6 +13,7 @@ import (
 	"github.com/Chatterino/api/internal/staticresponse"
 	"github.com/Chatterino/api/pkg/cache"
 	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	"github.com/Chatterino/api/pkg/utils"
 	youtubeAPI "google.golang.org/api/youtube/v3"
@@ -44,6 +45,7 @@ func (r *YouTubePlaylistLoader) Load(ctx context.Context, playlistCacheKey strin
 	const MaxDescriptionLength = 400
 
 	log := logger.FromContext(ctx)
+	lang := i18n.FromContext(ctx)
 	log.Debugw("[YouTube] GET playlist",
 		"cacheKey", playlistCacheKey,
 	)
@@ -58,8 +60,12 @@ func (r *YouTubePlaylistLoader) Load(ctx context.Context, playlistCacheKey strin
 		"contentDetails",
 	}
 
-	youtubeResponse, err := r.youtubeClient.Playlists.List(youtubePlaylistParts).Id(playlistId).Do()
+	youtubeResponse, err := callAPI(ctx, quotaCostList, r.youtubeClient.Playlists.List(youtubePlaylistParts).Id(playlistId).Do)
 	if err != nil {
+		if errors.Is(err, resolver.ErrUpstreamUnavailable) {
+			return nil, nil, nil, cache.NoSpecialDur, err
+		}
+
 		return resolver.InternalServerErrorf("YouTube API error: %s", err)
 	}
 
@@ -79,12 +85,12 @@ func (r *YouTubePlaylistLoader) Load(ctx context.Context, playlistCacheKey strin
 		Title:       youtubePlaylist.Snippet.Title,
 		Description: utils.TruncateString(youtubePlaylist.Snippet.Description, MaxDescriptionLength),
 		Channel:     youtubePlaylist.Snippet.ChannelTitle,
-		VideoCount:  humanize.NumberInt64(youtubePlaylist.ContentDetails.ItemCount),
-		PublishedAt: humanize.CreationDateRFC3339(youtubePlaylist.Snippet.PublishedAt),
+		VideoCount:  humanize.NumberInt64In(lang, youtubePlaylist.ContentDetails.ItemCount),
+		PublishedAt: humanize.CreationDateRFC3339In(lang, youtubePlaylist.Snippet.PublishedAt),
 	}
 
 	var tooltip bytes.Buffer
-	if err := youtubePlaylistTooltipTemplate.Execute(&tooltip, data); err != nil {
+	if err := youtubePlaylistTooltipTemplate.Execute(&tooltip, lang, data); err != nil {
 		return resolver.InternalServerErrorf("YouTube template error: %s", err.Error())
 	}
 
@@ -95,6 +101,14 @@ func (r *YouTubePlaylistLoader) Load(ctx context.Context, playlistCacheKey strin
 		Status:    statusCode,
 		Tooltip:   tooltip.String(),
 		Thumbnail: getThumbnailUrl(youtubePlaylist.Snippet.Thumbnails),
+		Data: &resolver.ResponseData{
+			Kind:        resolver.DataKindPlaylist,
+			Title:       youtubePlaylist.Snippet.Title,
+			Description: data.Description,
+			Author:      youtubePlaylist.Snippet.ChannelTitle,
+			Count:       uint64(youtubePlaylist.ContentDetails.ItemCount),
+			Published:   youtubePlaylist.Snippet.PublishedAt,
+		},
 	}
 
 	payload, err := json.Marshal(response)
@@ -102,7 +116,7 @@ func (r *YouTubePlaylistLoader) Load(ctx context.Context, playlistCacheKey strin
 		return resolver.InternalServerErrorf("YouTube marshaling error: %s", err.Error())
 	}
 
-	return payload, &statusCode, &contentType, cache.NoSpecialDur, nil
+	return payload, &statusCode, &contentType, youtubeQuota.cacheDuration(), nil
 }
 
 func getPlaylistFromCacheKey(cacheKey string) (string, error) {
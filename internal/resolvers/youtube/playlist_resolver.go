@@ -61,8 +61,8 @@ func NewYouTubePlaylistResolver(ctx context.Context, cfg config.APIConfig, pool
 	loader := NewYouTubePlaylistLoader(youtubeClient)
 
 	r := &YouTubePlaylistResolver{
-		playlistCache: cache.NewPostgreSQLCache(
-			ctx, cfg, pool, cache.NewPrefixKeyProvider("youtube:playlist"), loader, cfg.YoutubeChannelCacheDuration,
+		playlistCache: cache.NewDefaultCache(
+			ctx, cfg, pool, cache.NewLocalizedKeyProvider("youtube:playlist"), loader, cfg.YoutubeChannelCacheDuration,
 		),
 	}
 
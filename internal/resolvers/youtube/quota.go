@@ -0,0 +1,234 @@
+package youtube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	// The quota resets at midnight Pacific Time, which needs the time zone database
+	_ "time/tzdata"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/jackc/pgx/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Units of the daily quota used by the YouTube API calls we make, see
+// https://developers.google.com/youtube/v3/determine_quota_cost
+const (
+	// videos.list, channels.list and playlists.list
+	quotaCostList = 1
+	// search.list
+	quotaCostSearch = 100
+)
+
+const quotaDayFormat = "2006-01-02"
+
+var youtubeQuotaUsed = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "youtube_quota_used_units",
+		Help: "Units of the daily YouTube API quota used so far today",
+	},
+)
+
+func init() {
+	prometheus.MustRegister(youtubeQuotaUsed)
+}
+
+var quotaLocation = func() *time.Location {
+	location, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		panic(err)
+	}
+	return location
+}()
+
+// Shared by all YouTube loaders, set by Initialize. nil if quota tracking is disabled.
+var youtubeQuota *quotaTracker
+
+// quotaTracker keeps track of the units of the daily YouTube API quota we used.
+// Usage is stored in the database if there is one, so it's shared between instances and survives
+// restarts.
+type quotaTracker struct {
+	pool db.Pool
+
+	limit int
+	// Number of used units after which responses are cached for extendedCacheDuration
+	extendAt              int
+	extendedCacheDuration time.Duration
+
+	now func() time.Time
+
+	mutex sync.Mutex
+	// Start of the current quota day
+	day  time.Time
+	used int
+}
+
+// quotaDay returns the start of the quota day t is in
+func quotaDay(t time.Time) time.Time {
+	t = t.In(quotaLocation)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, quotaLocation)
+}
+
+// rollover starts a new quota day if the quota has been reset. The mutex must be held.
+func (q *quotaTracker) rollover() {
+	day := quotaDay(q.now())
+	if day.Equal(q.day) {
+		return
+	}
+
+	q.day = day
+	q.used = 0
+	youtubeQuotaUsed.Set(0)
+}
+
+// spend records that an API call costing cost units is made.
+// Returns ErrUpstreamUnavailable if the call would go over the quota, in which case it must not be made.
+func (q *quotaTracker) spend(ctx context.Context, cost int) error {
+	if q == nil {
+		return nil
+	}
+
+	q.mutex.Lock()
+	q.rollover()
+
+	if q.used+cost > q.limit {
+		resetsAt := q.day.AddDate(0, 0, 1)
+		q.mutex.Unlock()
+		return fmt.Errorf("%w: youtube quota used up until %s", resolver.ErrUpstreamUnavailable, resetsAt.Format(time.RFC3339))
+	}
+
+	q.used += cost
+	youtubeQuotaUsed.Set(float64(q.used))
+	day := q.day
+	q.mutex.Unlock()
+
+	q.store(ctx, day, cost)
+
+	return nil
+}
+
+// exhaust marks the quota as used up, e.g. because the API told us so
+func (q *quotaTracker) exhaust(ctx context.Context) {
+	if q == nil {
+		return
+	}
+
+	q.mutex.Lock()
+	q.rollover()
+	remaining := q.limit - q.used
+	q.used = q.limit
+	youtubeQuotaUsed.Set(float64(q.used))
+	day := q.day
+	q.mutex.Unlock()
+
+	if remaining > 0 {
+		q.store(ctx, day, remaining)
+	}
+}
+
+// store adds units to the usage stored in the database for the day.
+// The stored usage includes the units used by other instances, so it replaces ours if it's higher.
+func (q *quotaTracker) store(ctx context.Context, day time.Time, units int) {
+	if q.pool == nil {
+		return
+	}
+
+	// The units have been used even if the request is cancelled
+	ctx = context.WithoutCancel(ctx)
+
+	var used int
+	err := q.pool.QueryRow(ctx,
+		"INSERT INTO youtube_quota (day, units) VALUES ($1, $2) ON CONFLICT (day) DO UPDATE SET units = youtube_quota.units + EXCLUDED.units RETURNING units",
+		day.Format(quotaDayFormat), units).Scan(&used)
+	if err != nil {
+		logger.FromContext(ctx).Warnw("[YouTube] Error storing quota usage",
+			"error", err,
+		)
+		return
+	}
+
+	q.sync(day, used)
+}
+
+// load reads the usage of the current day from the database
+func (q *quotaTracker) load(ctx context.Context) {
+	if q.pool == nil {
+		return
+	}
+
+	day := quotaDay(q.now())
+
+	var used int
+	err := q.pool.QueryRow(ctx, "SELECT units FROM youtube_quota WHERE day=$1", day.Format(quotaDayFormat)).Scan(&used)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			logger.FromContext(ctx).Warnw("[YouTube] Error loading quota usage",
+				"error", err,
+			)
+		}
+		return
+	}
+
+	q.sync(day, used)
+}
+
+// sync updates our usage with the usage stored in the database for the day
+func (q *quotaTracker) sync(day time.Time, used int) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.rollover()
+	if day.Equal(q.day) && used > q.used {
+		q.used = used
+		youtubeQuotaUsed.Set(float64(q.used))
+	}
+}
+
+// cacheDuration returns how long responses loaded with the API are cached for.
+// Once the quota is almost used up they're cached for longer, so fewer calls are made.
+func (q *quotaTracker) cacheDuration() time.Duration {
+	if q == nil {
+		return cache.NoSpecialDur
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.rollover()
+	if q.used >= q.extendAt {
+		return q.extendedCacheDuration
+	}
+
+	return cache.NoSpecialDur
+}
+
+// newQuotaTracker creates the quota tracker described by the config, returns nil if quota tracking
+// is disabled
+func newQuotaTracker(ctx context.Context, cfg config.APIConfig, pool db.Pool) *quotaTracker {
+	if cfg.YoutubeQuotaLimit <= 0 {
+		return nil
+	}
+
+	if pool == nil {
+		logger.FromContext(ctx).Warnw("[Config] youtube-quota-limit is set without the postgres cache backend, quota usage is only tracked by this instance and is lost on restart")
+	}
+
+	q := &quotaTracker{
+		pool:                  pool,
+		limit:                 cfg.YoutubeQuotaLimit,
+		extendAt:              cfg.YoutubeQuotaLimit * cfg.YoutubeQuotaExtendPercent / 100,
+		extendedCacheDuration: cfg.YoutubeQuotaExtendedCacheDuration,
+		now:                   time.Now,
+	}
+	q.load(ctx)
+
+	return q
+}
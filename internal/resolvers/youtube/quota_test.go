@@ -0,0 +1,239 @@
+package youtube
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/resolver"
+	qt "github.com/frankban/quicktest"
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	youtubeAPI "google.golang.org/api/youtube/v3"
+)
+
+func newTestQuotaTracker(now *time.Time) *quotaTracker {
+	return &quotaTracker{
+		limit:                 100,
+		extendAt:              80,
+		extendedCacheDuration: 24 * time.Hour,
+		now: func() time.Time {
+			return *now
+		},
+	}
+}
+
+func TestQuotaDay(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		input    time.Time
+		expected string
+	}{
+		// Pacific Standard Time is UTC-8
+		{time.Date(2024, 1, 15, 7, 59, 0, 0, time.UTC), "2024-01-14"},
+		{time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC), "2024-01-15"},
+		// Pacific Daylight Time is UTC-7
+		{time.Date(2024, 7, 15, 6, 59, 0, 0, time.UTC), "2024-07-14"},
+		{time.Date(2024, 7, 15, 7, 0, 0, 0, time.UTC), "2024-07-15"},
+	}
+
+	for _, test := range tests {
+		c.Run(test.input.String(), func(c *qt.C) {
+			c.Assert(quotaDay(test.input).Format(quotaDayFormat), qt.Equals, test.expected)
+		})
+	}
+}
+
+func TestQuotaTracker(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	c.Run("Disabled", func(c *qt.C) {
+		var q *quotaTracker
+		c.Assert(q.spend(ctx, quotaCostSearch), qt.IsNil)
+		c.Assert(q.cacheDuration(), qt.Equals, cache.NoSpecialDur)
+	})
+
+	c.Run("Budget", func(c *qt.C) {
+		now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+		q := newTestQuotaTracker(&now)
+
+		for range 79 {
+			c.Assert(q.spend(ctx, quotaCostList), qt.IsNil)
+		}
+		c.Assert(q.cacheDuration(), qt.Equals, cache.NoSpecialDur)
+
+		// Cache durations are extended near the limit
+		c.Assert(q.spend(ctx, quotaCostList), qt.IsNil)
+		c.Assert(q.cacheDuration(), qt.Equals, 24*time.Hour)
+
+		// Calls that would go over the limit aren't made
+		err := q.spend(ctx, quotaCostSearch)
+		c.Assert(err, qt.ErrorIs, resolver.ErrUpstreamUnavailable)
+		c.Assert(err, qt.ErrorMatches, ".*youtube quota used up until 2024-01-16T00:00:00-08:00")
+		c.Assert(q.used, qt.Equals, 80)
+
+		for range 20 {
+			c.Assert(q.spend(ctx, quotaCostList), qt.IsNil)
+		}
+		c.Assert(q.spend(ctx, quotaCostList), qt.ErrorIs, resolver.ErrUpstreamUnavailable)
+
+		// The quota resets at midnight Pacific Time
+		now = time.Date(2024, 1, 16, 8, 0, 0, 0, time.UTC)
+		c.Assert(q.cacheDuration(), qt.Equals, cache.NoSpecialDur)
+		c.Assert(q.spend(ctx, quotaCostSearch), qt.IsNil)
+		c.Assert(q.used, qt.Equals, 100)
+	})
+
+	c.Run("Exhaust", func(c *qt.C) {
+		now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+		q := newTestQuotaTracker(&now)
+
+		q.exhaust(ctx)
+		c.Assert(q.spend(ctx, quotaCostList), qt.ErrorIs, resolver.ErrUpstreamUnavailable)
+	})
+
+	c.Run("Stored usage", func(c *qt.C) {
+		pool, err := pgxmock.NewPool()
+		c.Assert(err, qt.IsNil)
+
+		now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+		q := newTestQuotaTracker(&now)
+		q.pool = pool
+
+		pool.ExpectQuery("SELECT units FROM youtube_quota").
+			WithArgs("2024-01-15").
+			WillReturnRows(pgxmock.NewRows([]string{"units"}).AddRow(50))
+		q.load(ctx)
+		c.Assert(q.used, qt.Equals, 50)
+
+		// Other instances used units too
+		pool.ExpectQuery("INSERT INTO youtube_quota .* ON CONFLICT \\(day\\)").
+			WithArgs("2024-01-15", quotaCostList).
+			WillReturnRows(pgxmock.NewRows([]string{"units"}).AddRow(90))
+		c.Assert(q.spend(ctx, quotaCostList), qt.IsNil)
+		c.Assert(q.used, qt.Equals, 90)
+
+		c.Assert(pool.ExpectationsWereMet(), qt.IsNil)
+	})
+
+	c.Run("No stored usage", func(c *qt.C) {
+		pool, err := pgxmock.NewPool()
+		c.Assert(err, qt.IsNil)
+
+		cfg := config.APIConfig{
+			YoutubeQuotaLimit:                 10000,
+			YoutubeQuotaExtendPercent:         80,
+			YoutubeQuotaExtendedCacheDuration: time.Hour,
+		}
+
+		pool.ExpectQuery("SELECT units FROM youtube_quota").
+			WithArgs(pgxmock.AnyArg()).
+			WillReturnError(pgx.ErrNoRows)
+		q := newQuotaTracker(ctx, cfg, pool)
+		c.Assert(q, qt.IsNotNil)
+		c.Assert(q.used, qt.Equals, 0)
+		c.Assert(q.extendAt, qt.Equals, 8000)
+
+		c.Assert(pool.ExpectationsWereMet(), qt.IsNil)
+	})
+
+	c.Run("Disabled in config", func(c *qt.C) {
+		c.Assert(newQuotaTracker(ctx, config.APIConfig{}, nil), qt.IsNil)
+	})
+}
+
+func TestQuotaVideoLoader(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	var apiCalls atomic.Int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiCalls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items":[]}`))
+	}))
+	defer api.Close()
+
+	ts := keylessTestServer()
+	defer ts.Close()
+
+	youtubeClient, err := youtubeAPI.NewService(ctx, option.WithAPIKey("test"), option.WithEndpoint(api.URL))
+	c.Assert(err, qt.IsNil)
+
+	loader := NewVideoLoader(youtubeClient)
+	loader.keyless = &keylessClient{
+		oEmbedURL: ts.URL + "/oembed",
+		baseURL:   ts.URL,
+	}
+
+	now := time.Now()
+	youtubeQuota = newTestQuotaTracker(&now)
+	defer func() {
+		youtubeQuota = nil
+	}()
+
+	c.Run("Within budget", func(c *qt.C) {
+		_, _, _, _, err := loader.Load(ctx, "foobar", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(apiCalls.Load(), qt.Equals, int32(1))
+	})
+
+	c.Run("Over budget", func(c *qt.C) {
+		youtubeQuota.exhaust(ctx)
+
+		payload, _, _, cacheDuration, err := loader.Load(ctx, "foobar", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cacheDuration, qt.Equals, keylessFallbackCacheDuration)
+		c.Assert(decodeLoaded(c, payload).Data.Title, qt.Equals, "Video Title")
+		c.Assert(apiCalls.Load(), qt.Equals, int32(1))
+	})
+}
+
+func TestQuotaHalfOpenUpstream(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	youtubeUpstream = resolver.NewUpstream(config.APIConfig{
+		UpstreamFailureThreshold: 1,
+		UpstreamOpenDuration:     time.Millisecond,
+	}, "youtube")
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	youtubeQuota = newTestQuotaTracker(&now)
+	defer func() {
+		youtubeUpstream = nil
+		youtubeQuota = nil
+	}()
+
+	var calls int
+	do := func(opts ...googleapi.CallOption) (bool, error) {
+		calls++
+		return true, nil
+	}
+
+	// Open the circuit, and wait until it's half-open
+	c.Assert(youtubeUpstream.Allow(), qt.IsNil)
+	youtubeUpstream.ObserveStatus(http.StatusInternalServerError, nil, nil)
+	time.Sleep(5 * time.Millisecond)
+
+	youtubeQuota.exhaust(ctx)
+	_, err := callAPI(ctx, quotaCostList, do)
+	c.Assert(err, qt.ErrorMatches, ".*youtube quota used up.*")
+	c.Assert(calls, qt.Equals, 0)
+
+	// Once the quota resets, the API is checked again instead of the circuit staying open
+	now = now.AddDate(0, 0, 1)
+	ok, err := callAPI(ctx, quotaCostList, do)
+	c.Assert(err, qt.IsNil)
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(calls, qt.Equals, 1)
+}
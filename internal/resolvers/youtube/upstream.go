@@ -0,0 +1,89 @@
+package youtube
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Chatterino/api/pkg/resolver"
+	"google.golang.org/api/googleapi"
+)
+
+// Shared by all YouTube loaders since they use the same API quota, set by Initialize
+var youtubeUpstream *resolver.Upstream
+
+// callAPI makes the YouTube API call using do if the API is available and there's enough quota
+// left for its cost, and records its outcome
+func callAPI[T any](ctx context.Context, cost int, do func(opts ...googleapi.CallOption) (T, error)) (T, error) {
+	if err := youtubeUpstream.Allow(); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	if err := youtubeQuota.spend(ctx, cost); err != nil {
+		// The call isn't made, so it can't be the probe of a half-open circuit
+		youtubeUpstream.Release()
+		var zero T
+		return zero, err
+	}
+
+	response, err := do()
+	observeAPIError(err)
+
+	if hasErrorReason(err, "quotaExceeded") {
+		// Our usage is off, e.g. because the key is also used elsewhere
+		youtubeQuota.exhaust(ctx)
+	}
+
+	return response, err
+}
+
+func observeAPIError(err error) {
+	if err == nil {
+		youtubeUpstream.ObserveStatus(http.StatusOK, nil, nil)
+		return
+	}
+
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		youtubeUpstream.ObserveStatus(0, nil, err)
+		return
+	}
+
+	statusCode := apiErr.Code
+	if isQuotaExceeded(err) {
+		// The API responds with 403 Forbidden when we're out of quota
+		statusCode = http.StatusTooManyRequests
+	}
+
+	youtubeUpstream.ObserveStatus(statusCode, apiErr.Header, nil)
+}
+
+// isQuotaExceeded returns whether the API call failed because we're out of quota
+func isQuotaExceeded(err error) bool {
+	return hasErrorReason(err, "quotaExceeded", "rateLimitExceeded")
+}
+
+// hasErrorReason returns whether the API call failed for one of the reasons
+func hasErrorReason(err error, reasons ...string) bool {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	for _, item := range apiErr.Errors {
+		for _, reason := range reasons {
+			if item.Reason == reason {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// apiUnavailable returns whether the API call failed because the API can't be used right now, in
+// which case the keyless client is used instead
+func apiUnavailable(err error) bool {
+	return errors.Is(err, resolver.ErrUpstreamUnavailable) || isQuotaExceeded(err)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/Chatterino/api/internal/staticresponse"
 	"github.com/Chatterino/api/pkg/cache"
 	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
 	"github.com/Chatterino/api/pkg/resolver"
 	youtubeAPI "google.golang.org/api/youtube/v3"
 )
@@ -34,11 +35,14 @@ type youtubeStreamTooltipData struct {
 }
 
 type VideoLoader struct {
+	// nil if no youtube-api-key is set
 	youtubeClient *youtubeAPI.Service
+	keyless       *keylessClient
 }
 
 func (r *VideoLoader) Load(ctx context.Context, videoID string, req *http.Request) ([]byte, *int, *string, time.Duration, error) {
 	log := logger.FromContext(ctx)
+	lang := i18n.FromContext(ctx)
 	youtubeVideoParts := []string{
 		"statistics",
 		"snippet",
@@ -46,12 +50,25 @@ func (r *VideoLoader) Load(ctx context.Context, videoID string, req *http.Reques
 		"liveStreamingDetails",
 	}
 
+	if r.youtubeClient == nil {
+		return r.keyless.loadVideo(ctx, videoID, cache.NoSpecialDur)
+	}
+
 	log.Debugw("[YouTube] Get video",
 		"videoID", videoID,
 	)
 
-	youtubeResponse, err := r.youtubeClient.Videos.List(youtubeVideoParts).Id(videoID).Do()
+	youtubeResponse, err := callAPI(ctx, quotaCostList, r.youtubeClient.Videos.List(youtubeVideoParts).Id(videoID).Do)
 	if err != nil {
+		if apiUnavailable(err) {
+			if cache.IsStaleRefresh(ctx) {
+				// Keep serving the expired tooltip, it has more information than the keyless one
+				return nil, nil, nil, cache.NoSpecialDur, err
+			}
+
+			return r.keyless.loadVideo(ctx, videoID, keylessFallbackCacheDuration)
+		}
+
 		return resolver.InternalServerErrorf("YouTube API error: %s", err)
 	}
 
@@ -73,6 +90,8 @@ func (r *VideoLoader) Load(ctx context.Context, videoID string, req *http.Reques
 	}
 
 	var tooltip bytes.Buffer
+	var responseData *resolver.ResponseData
+	var chapters []videoChapter
 
 	if video.Snippet.LiveBroadcastContent == "live" {
 		if video.LiveStreamingDetails == nil {
@@ -88,13 +107,23 @@ func (r *VideoLoader) Load(ctx context.Context, videoID string, req *http.Reques
 			Title:        video.Snippet.Title,
 			ChannelTitle: video.Snippet.ChannelTitle,
 			Uptime:       humanize.Duration(time.Since(startTime)),
-			Viewers:      humanize.Number(video.LiveStreamingDetails.ConcurrentViewers),
-			LikeCount:    humanize.Number(video.Statistics.LikeCount),
+			Viewers:      humanize.NumberIn(lang, video.LiveStreamingDetails.ConcurrentViewers),
+			LikeCount:    humanize.NumberIn(lang, video.Statistics.LikeCount),
 		}
 
-		if err := youtubeStreamTooltipTemplate.Execute(&tooltip, data); err != nil {
+		if err := youtubeStreamTooltipTemplate.Execute(&tooltip, lang, data); err != nil {
 			return resolver.InternalServerErrorf("YouTube template error: %s", err)
 		}
+
+		responseData = &resolver.ResponseData{
+			Kind:      resolver.DataKindLivestream,
+			Title:     video.Snippet.Title,
+			Author:    video.Snippet.ChannelTitle,
+			Views:     video.LiveStreamingDetails.ConcurrentViewers,
+			Likes:     video.Statistics.LikeCount,
+			Published: video.LiveStreamingDetails.ActualStartTime,
+			Live:      true,
+		}
 	} else {
 		if video.ContentDetails == nil {
 			return resolver.InternalServerErrorf("YouTube video unavailable")
@@ -112,16 +141,30 @@ func (r *VideoLoader) Load(ctx context.Context, videoID string, req *http.Reques
 			Title:         video.Snippet.Title,
 			ChannelTitle:  video.Snippet.ChannelTitle,
 			Duration:      humanize.DurationPT(video.ContentDetails.Duration),
-			PublishDate:   humanize.CreationDateRFC3339(video.Snippet.PublishedAt),
-			Views:         humanize.Number(video.Statistics.ViewCount),
-			LikeCount:     humanize.Number(video.Statistics.LikeCount),
-			CommentCount:  humanize.Number(video.Statistics.CommentCount),
+			PublishDate:   humanize.CreationDateRFC3339In(lang, video.Snippet.PublishedAt),
+			Views:         humanize.NumberIn(lang, video.Statistics.ViewCount),
+			LikeCount:     humanize.NumberIn(lang, video.Statistics.LikeCount),
+			CommentCount:  humanize.NumberIn(lang, video.Statistics.CommentCount),
 			AgeRestricted: ageRestricted,
 		}
 
-		if err := youtubeVideoTooltipTemplate.Execute(&tooltip, data); err != nil {
+		if err := youtubeVideoTooltipTemplate.Execute(&tooltip, lang, data); err != nil {
 			return resolver.InternalServerErrorf("YouTube template error: %s", err)
 		}
+
+		responseData = &resolver.ResponseData{
+			Kind:      resolver.DataKindVideo,
+			Title:     video.Snippet.Title,
+			Author:    video.Snippet.ChannelTitle,
+			Duration:  int64(parseDurationPT(video.ContentDetails.Duration).Seconds()),
+			Views:     video.Statistics.ViewCount,
+			Likes:     video.Statistics.LikeCount,
+			Comments:  video.Statistics.CommentCount,
+			Published: video.Snippet.PublishedAt,
+			NSFW:      ageRestricted,
+		}
+
+		chapters = parseChapters(video.Snippet.Description)
 	}
 
 	thumbnail := video.Snippet.Thumbnails.Default.Url
@@ -132,12 +175,13 @@ func (r *VideoLoader) Load(ctx context.Context, videoID string, req *http.Reques
 	statusCode := http.StatusOK
 	contentType := "application/json"
 
-	return buildChannelResponse(tooltip.String(), thumbnail), &statusCode, &contentType, cache.NoSpecialDur, nil
+	return buildVideoResponse(tooltip.String(), thumbnail, responseData, chapters), &statusCode, &contentType, youtubeQuota.cacheDuration(), nil
 }
 
 func NewVideoLoader(youtubeClient *youtubeAPI.Service) *VideoLoader {
 	loader := &VideoLoader{
 		youtubeClient: youtubeClient,
+		keyless:       newKeylessClient(),
 	}
 
 	return loader
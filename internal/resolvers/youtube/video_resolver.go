@@ -54,7 +54,13 @@ func (r *YouTubeVideoResolver) Run(ctx context.Context, url *url.URL, req *http.
 		return nil, errInvalidVideoLink
 	}
 
-	return r.videoCache.Get(ctx, videoID, req)
+	// Links with a timestamp use the cached response of the video
+	response, err := r.videoCache.Get(ctx, videoID, req)
+	if err != nil {
+		return response, err
+	}
+
+	return videoAt(ctx, response, videoLinkPosition(url))
 }
 
 func (r *YouTubeVideoResolver) Name() string {
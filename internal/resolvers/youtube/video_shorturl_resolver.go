@@ -29,7 +29,12 @@ func (r *YouTubeVideoShortURLResolver) Run(ctx context.Context, url *url.URL, re
 		return nil, errInvalidVideoLink
 	}
 
-	return r.videoCache.Get(ctx, videoID, req)
+	response, err := r.videoCache.Get(ctx, videoID, req)
+	if err != nil {
+		return response, err
+	}
+
+	return videoAt(ctx, response, videoLinkPosition(url))
 }
 
 func (r *YouTubeVideoShortURLResolver) Name() string {
@@ -0,0 +1,239 @@
+package youtube
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+)
+
+// Maximum length of the chapter titles shown in tooltips
+const maxChapterTitleLength = 100
+
+// Matches the lines of a video description listing chapters, e.g. "12:34 - Chapter title"
+var chapterRegex = regexp.MustCompile(`^\s*[\[(]?((?:\d{1,2}:)?\d{1,2}:\d{2})[\])]?\s*(?:[-–—:|•]\s*)?(.+?)\s*$`)
+
+type videoChapter struct {
+	// In seconds
+	Start int64  `json:"start"`
+	Title string `json:"title"`
+}
+
+// videoResponse is the response stored in the video cache. Links with a timestamp are cached with
+// the video, so the chapters needed for their tooltips are stored along with it. They're removed
+// before the response is sent to clients.
+type videoResponse struct {
+	resolver.Response
+
+	Chapters []videoChapter `json:"chapters,omitempty"`
+}
+
+// videoPosition is the part of a video a link points to
+type videoPosition struct {
+	Start time.Duration
+
+	// Only set for clip links
+	Clip      bool
+	ClipTitle string
+	End       time.Duration
+}
+
+type youtubeVideoPositionTooltipData struct {
+	Start     string
+	End       string
+	Clip      bool
+	ClipTitle string
+	Chapter   string
+}
+
+func buildVideoResponse(tooltip string, thumbnail string, data *resolver.ResponseData, chapters []videoChapter) []byte {
+	response := &videoResponse{
+		Response: resolver.Response{
+			Status:    http.StatusOK,
+			Tooltip:   url.PathEscape(tooltip),
+			Thumbnail: thumbnail,
+			Data:      data,
+		},
+		Chapters: chapters,
+	}
+	payload, err := json.Marshal(response)
+	if err != nil {
+		panic(err)
+	}
+
+	return payload
+}
+
+// parseTimestamp parses a timestamp of a video link or a chapter list, e.g. 754, 12m34s or 12:34
+func parseTimestamp(timestamp string) time.Duration {
+	timestamp = strings.TrimSpace(timestamp)
+
+	if strings.Contains(timestamp, ":") {
+		var seconds int64
+		for _, part := range strings.Split(timestamp, ":") {
+			value, err := strconv.ParseInt(part, 10, 64)
+			if err != nil || value < 0 {
+				return 0
+			}
+			seconds = seconds*60 + value
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if seconds, err := strconv.ParseInt(timestamp, 10, 64); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+
+	duration, err := time.ParseDuration(timestamp)
+	if err != nil || duration < 0 {
+		return 0
+	}
+
+	return duration.Truncate(time.Second)
+}
+
+// videoLinkPosition returns the position set by the t or start parameter of a video link
+func videoLinkPosition(url *url.URL) videoPosition {
+	query := url.Query()
+
+	t := query.Get("t")
+	if t == "" {
+		t = query.Get("start")
+	}
+
+	return videoPosition{
+		Start: parseTimestamp(t),
+	}
+}
+
+// parseChapters reads the chapters listed in a video description. Like YouTube, the list is only
+// used if it starts at 0:00 and has at least 3 chapters in ascending order.
+func parseChapters(description string) []videoChapter {
+	var chapters []videoChapter
+
+	for _, line := range strings.Split(description, "\n") {
+		matches := chapterRegex.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		chapter := videoChapter{
+			Start: int64(parseTimestamp(matches[1]).Seconds()),
+			Title: utils.TruncateString(matches[2], maxChapterTitleLength),
+		}
+
+		if len(chapters) == 0 && chapter.Start != 0 {
+			return nil
+		}
+		if len(chapters) > 0 && chapter.Start <= chapters[len(chapters)-1].Start {
+			return nil
+		}
+
+		chapters = append(chapters, chapter)
+	}
+
+	if len(chapters) < 3 {
+		return nil
+	}
+
+	return chapters
+}
+
+// chapterAt returns the title of the chapter the position is in, if the video has chapters
+func chapterAt(chapters []videoChapter, position time.Duration) string {
+	title := ""
+	for _, chapter := range chapters {
+		if time.Duration(chapter.Start)*time.Second > position {
+			break
+		}
+		title = chapter.Title
+	}
+
+	return title
+}
+
+// videoAt builds the response of a link to the position of a video from the video's cached response
+func videoAt(ctx context.Context, cached *cache.Response, position videoPosition) (*cache.Response, error) {
+	if cached == nil {
+		return cached, nil
+	}
+
+	var response videoResponse
+	if err := json.Unmarshal(cached.Payload, &response); err != nil || response.Status != http.StatusOK {
+		return cached, nil
+	}
+
+	if position.Start > 0 || position.Clip {
+		tooltip, err := url.PathUnescape(response.Tooltip)
+		if err != nil {
+			return cached, nil
+		}
+
+		lang := i18n.FromContext(ctx)
+		chapter := chapterAt(response.Chapters, position.Start)
+
+		data := youtubeVideoPositionTooltipData{
+			Start:     humanize.Duration(position.Start),
+			End:       humanize.Duration(position.End),
+			Clip:      position.Clip,
+			ClipTitle: position.ClipTitle,
+			Chapter:   chapter,
+		}
+
+		var positionTooltip bytes.Buffer
+		if err := youtubeVideoPositionTooltipTemplate.Execute(&positionTooltip, lang, data); err != nil {
+			return nil, err
+		}
+
+		// The lines are added at the end of the tooltip, inside its div
+		end := strings.LastIndex(tooltip, "</div>")
+		if end == -1 {
+			end = len(tooltip)
+		}
+		tooltip = tooltip[:end] + positionTooltip.String() + tooltip[end:]
+		response.Tooltip = url.PathEscape(tooltip)
+
+		if response.Data != nil {
+			fields := map[string]string{}
+			for key, value := range response.Data.Fields {
+				fields[key] = value
+			}
+
+			fields["start_offset"] = strconv.Itoa(int(position.Start.Seconds()))
+			if chapter != "" {
+				fields["chapter"] = chapter
+			}
+			if position.Clip {
+				response.Data.Kind = resolver.DataKindClip
+				fields["end_offset"] = strconv.Itoa(int(position.End.Seconds()))
+				if position.ClipTitle != "" {
+					fields["clip_title"] = position.ClipTitle
+				}
+			}
+
+			response.Data.Fields = fields
+		}
+	}
+
+	payload, err := json.Marshal(&response.Response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cache.Response{
+		Payload:     payload,
+		StatusCode:  cached.StatusCode,
+		ContentType: cached.ContentType,
+	}, nil
+}
@@ -0,0 +1,221 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/api/option"
+	youtubeAPI "google.golang.org/api/youtube/v3"
+)
+
+// newTestVideoCache returns a video cache loading the videos in data_test.go
+func newTestVideoCache(c *qt.C, ctx context.Context) cache.Cache {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if resp, ok := videos[r.URL.Query().Get("id")]; ok {
+			json.NewEncoder(w).Encode(resp)
+			return
+		}
+
+		http.Error(w, http.StatusText(404), 404)
+	}))
+	c.Cleanup(ts.Close)
+
+	youtubeClient, err := youtubeAPI.NewService(ctx, option.WithAPIKey("test"), option.WithEndpoint(ts.URL))
+	c.Assert(err, qt.IsNil)
+
+	return cache.NewMemoryCache(config.APIConfig{}, cache.NewLocalizedKeyProvider("youtube:video"), NewVideoLoader(youtubeClient), time.Hour)
+}
+
+// decodeResponse decodes the payload of a resolver response, and returns it with its tooltip unescaped
+func decodeResponse(c *qt.C, response *cache.Response) (map[string]any, resolver.Response) {
+	var raw map[string]any
+	c.Assert(json.Unmarshal(response.Payload, &raw), qt.IsNil)
+
+	var data resolver.Response
+	c.Assert(json.Unmarshal(response.Payload, &data), qt.IsNil)
+
+	tooltip, err := url.PathUnescape(data.Tooltip)
+	c.Assert(err, qt.IsNil)
+	data.Tooltip = tooltip
+
+	return raw, data
+}
+
+func TestParseTimestamp(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		input    string
+		expected time.Duration
+	}{
+		{"754", 754 * time.Second},
+		{"754s", 754 * time.Second},
+		{"12m34s", 754 * time.Second},
+		{"1h2m3s", 3723 * time.Second},
+		{"12:34", 754 * time.Second},
+		{"1:02:03", 3723 * time.Second},
+		{"", 0},
+		{"-5", 0},
+		{"-5s", 0},
+		{"abc", 0},
+		{"1:ab", 0},
+	}
+
+	for _, test := range tests {
+		c.Run(test.input, func(c *qt.C) {
+			c.Assert(parseTimestamp(test.input), qt.Equals, test.expected)
+		})
+	}
+}
+
+func TestParseChapters(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		label       string
+		description string
+		expected    []videoChapter
+	}{
+		{
+			label:       "Chapters",
+			description: "My video\n\n0:00 Intro\n(1:30) Setup\n12:34 - The end\n1:02:03 | Bonus\nThanks!",
+			expected: []videoChapter{
+				{Start: 0, Title: "Intro"},
+				{Start: 90, Title: "Setup"},
+				{Start: 754, Title: "The end"},
+				{Start: 3723, Title: "Bonus"},
+			},
+		},
+		{
+			label:       "Doesn't start at 0:00",
+			description: "0:10 Intro\n1:30 Setup\n12:34 The end",
+		},
+		{
+			label:       "Less than 3 chapters",
+			description: "0:00 Intro\n1:30 Setup",
+		},
+		{
+			label:       "Not in order",
+			description: "0:00 Intro\n12:34 The end\n1:30 Setup",
+		},
+		{
+			label:       "No chapters",
+			description: "Just a video",
+		},
+	}
+
+	for _, test := range tests {
+		c.Run(test.label, func(c *qt.C) {
+			c.Assert(parseChapters(test.description), qt.DeepEquals, test.expected)
+		})
+	}
+}
+
+func TestChapterAt(t *testing.T) {
+	c := qt.New(t)
+
+	chapters := []videoChapter{
+		{Start: 0, Title: "Intro"},
+		{Start: 90, Title: "Setup"},
+		{Start: 754, Title: "The end"},
+	}
+
+	c.Assert(chapterAt(chapters, 0), qt.Equals, "Intro")
+	c.Assert(chapterAt(chapters, 89*time.Second), qt.Equals, "Intro")
+	c.Assert(chapterAt(chapters, 90*time.Second), qt.Equals, "Setup")
+	c.Assert(chapterAt(chapters, time.Hour), qt.Equals, "The end")
+	c.Assert(chapterAt(nil, time.Hour), qt.Equals, "")
+}
+
+func TestVideoTimestamp(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	videoCache := newTestVideoCache(c, ctx)
+	videoResolver := NewYouTubeVideoResolver(videoCache)
+	shortURLResolver := NewYouTubeVideoShortURLResolver(videoCache)
+
+	c.Run("Timestamp", func(c *qt.C) {
+		response, err := shortURLResolver.Run(ctx, utils.MustParseURL("https://youtu.be/chapters?t=754"), nil)
+		c.Assert(err, qt.IsNil)
+
+		raw, data := decodeResponse(c, response)
+		c.Assert(raw, qt.Not(qt.Contains), "chapters")
+		c.Assert(data.Tooltip, qt.Equals, `<div style="text-align: left;">
+<b>Video Title</b>
+<br><b>Channel:</b> Channel Title
+<br><b>Duration:</b> 00:20:00
+<br><b>Published:</b> 12 Oct 2019
+<br><b>Views:</b> 50
+
+<br><span style="color: #2ecc71;">10 likes</span>&nbsp;•&nbsp;<span style="color: #808892;">5 comments</span>
+<br><b>Starts at:</b> 00:12:34
+<br><b>Chapter:</b> Second part
+</div>
+`)
+		c.Assert(data.Data.Kind, qt.Equals, resolver.DataKindVideo)
+		c.Assert(data.Data.Fields, qt.DeepEquals, map[string]string{
+			"start_offset": "754",
+			"chapter":      "Second part",
+		})
+	})
+
+	c.Run("Chapter titles are escaped", func(c *qt.C) {
+		response, err := videoResolver.Run(ctx, utils.MustParseURL("https://www.youtube.com/watch?v=chapters&t=5m30s"), nil)
+		c.Assert(err, qt.IsNil)
+
+		_, data := decodeResponse(c, response)
+		c.Assert(data.Tooltip, qt.Contains, "<br><b>Starts at:</b> 00:05:30\n<br><b>Chapter:</b> First &lt;part&gt;\n</div>")
+		c.Assert(data.Data.Fields["chapter"], qt.Equals, "First <part>")
+	})
+
+	c.Run("Embed start", func(c *qt.C) {
+		response, err := videoResolver.Run(ctx, utils.MustParseURL("https://www.youtube.com/embed/foobar?start=90"), nil)
+		c.Assert(err, qt.IsNil)
+
+		_, data := decodeResponse(c, response)
+		c.Assert(data.Tooltip, qt.Contains, "<br><b>Starts at:</b> 00:01:30\n</div>")
+		c.Assert(data.Tooltip, qt.Not(qt.Contains), "Chapter")
+	})
+
+	c.Run("No timestamp", func(c *qt.C) {
+		response, err := videoResolver.Run(ctx, utils.MustParseURL("https://www.youtube.com/watch?v=chapters"), nil)
+		c.Assert(err, qt.IsNil)
+
+		raw, data := decodeResponse(c, response)
+		c.Assert(raw, qt.Not(qt.Contains), "chapters")
+		c.Assert(data.Tooltip, qt.Not(qt.Contains), "Starts at")
+		c.Assert(data.Data.Fields, qt.IsNil)
+	})
+
+	c.Run("German", func(c *qt.C) {
+		response, err := videoResolver.Run(i18n.OnContext(ctx, i18n.German), utils.MustParseURL("https://www.youtube.com/watch?v=chapters&t=0"), nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(response.Payload), qt.Not(qt.Contains), "Beginnt")
+
+		response, err = videoResolver.Run(i18n.OnContext(ctx, i18n.German), utils.MustParseURL("https://www.youtube.com/watch?v=chapters&t=1"), nil)
+		c.Assert(err, qt.IsNil)
+
+		_, data := decodeResponse(c, response)
+		c.Assert(data.Tooltip, qt.Contains, "<br><b>Beginnt bei:</b> 00:00:01\n<br><b>Kapitel:</b> Intro\n")
+	})
+
+	c.Run("Not found", func(c *qt.C) {
+		response, err := videoResolver.Run(ctx, utils.MustParseURL("https://www.youtube.com/watch?v=404&t=10"), nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(response.Payload), qt.Equals, `{"status":404,"message":"No YouTube video with the ID 404 found"}`)
+	})
+}
@@ -2,12 +2,27 @@ package twitchapiclient
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/Chatterino/api/pkg/config"
 	"github.com/nicklaw5/helix"
 )
 
+const helixBaseURL = "https://api.twitch.tv/helix"
+
+// Client is a helix.Client that also supports the Helix endpoints missing from the helix package
+type Client struct {
+	*helix.Client
+
+	clientID   string
+	baseURL    string
+	httpClient *http.Client
+}
+
 // New returns a helix.Client that has requested an AppAccessToken and will keep it refreshed every 24h
 func New(ctx context.Context, cfg config.APIConfig) (*helix.Client, error) {
 	if cfg.TwitchClientID == "" {
@@ -36,3 +51,54 @@ func New(ctx context.Context, cfg config.APIConfig) (*helix.Client, error) {
 
 	return helixClient, nil
 }
+
+// Extend returns a Client making its requests with the helix client, returns nil if helixClient is nil
+func Extend(helixClient *helix.Client, clientID string) *Client {
+	if helixClient == nil {
+		return nil
+	}
+
+	return newClient(helixClient, clientID, helixBaseURL)
+}
+
+func newClient(helixClient *helix.Client, clientID string, baseURL string) *Client {
+	return &Client{
+		Client: helixClient,
+
+		clientID: clientID,
+		baseURL:  baseURL,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// get requests an endpoint the helix package doesn't support, decoding a successful response into
+// data and an error response into response
+func (c *Client) get(path string, query url.Values, response *helix.ResponseCommon, data any) error {
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Client-ID", c.clientID)
+	req.Header.Set("Authorization", "Bearer "+c.GetAppAccessToken())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	response.StatusCode = resp.StatusCode
+	response.Header = resp.Header
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		// Error responses look like {"error":"Not Found","status":404,"message":"..."}, the status code
+		// is enough to tell what went wrong if they don't
+		_ = json.NewDecoder(resp.Body).Decode(response)
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(data)
+}
@@ -1 +1,133 @@
 package twitchapiclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-chi/chi/v5"
+	"github.com/nicklaw5/helix"
+)
+
+func testServer() *httptest.Server {
+	r := chi.NewRouter()
+	r.Get("/schedule", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Header.Get("Client-ID") != "client-id" || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"Unauthorized","status":401,"message":"Invalid OAuth token"}`))
+			return
+		}
+
+		switch r.URL.Query().Get("broadcaster_id") {
+		case "11148817":
+			w.Write([]byte(`{"data":{"segments":[{"id":"segment","start_time":"2030-01-02T18:00:00Z","end_time":"2030-01-02T20:00:00Z","title":"Dank stream","canceled_until":null,"category":{"id":"509658","name":"Just Chatting"},"is_recurring":true}],"broadcaster_id":"11148817","broadcaster_name":"pajlada","broadcaster_login":"pajlada","vacation":null},"pagination":{}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Not Found","status":404,"message":"segments were either not found or empty"}`))
+		}
+	})
+	r.Get("/channels/followers", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Query().Get("broadcaster_id") {
+		case "11148817":
+			w.Write([]byte(`{"total":12345,"data":[],"pagination":{}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"Bad Request","status":400,"message":"The ID in broadcaster_id is not valid."}`))
+		}
+	})
+	r.Get("/search/categories", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Query().Get("query") != "just chatting" || r.URL.Query().Get("first") != "10" {
+			w.Write([]byte(`{"data":[],"pagination":{}}`))
+			return
+		}
+
+		w.Write([]byte(`{"data":[{"id":"509658","name":"Just Chatting","box_art_url":"https://static-cdn.jtvnw.net/ttv-boxart/509658-52x72.jpg"}],"pagination":{}}`))
+	})
+
+	return httptest.NewServer(r)
+}
+
+func TestClient(t *testing.T) {
+	c := qt.New(t)
+
+	ts := testServer()
+	defer ts.Close()
+
+	helixClient, err := helix.NewClient(&helix.Options{ClientID: "client-id"})
+	c.Assert(err, qt.IsNil)
+	helixClient.SetAppAccessToken("token")
+
+	client := newClient(helixClient, "client-id", ts.URL)
+
+	c.Run("Extend", func(c *qt.C) {
+		c.Assert(Extend(nil, "client-id"), qt.IsNil)
+		c.Assert(Extend(helixClient, "client-id").Client, qt.Equals, helixClient)
+	})
+
+	c.Run("GetSchedule", func(c *qt.C) {
+		response, err := client.GetSchedule(&ScheduleParams{BroadcasterID: "11148817", First: 3})
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.StatusCode, qt.Equals, http.StatusOK)
+		c.Assert(response.Data.BroadcasterName, qt.Equals, "pajlada")
+		c.Assert(response.Data.Segments, qt.DeepEquals, []ScheduleSegment{{
+			ID:          "segment",
+			StartTime:   time.Date(2030, 1, 2, 18, 0, 0, 0, time.UTC),
+			EndTime:     time.Date(2030, 1, 2, 20, 0, 0, 0, time.UTC),
+			Title:       "Dank stream",
+			Category:    &ScheduleCategory{ID: "509658", Name: "Just Chatting"},
+			IsRecurring: true,
+		}})
+	})
+
+	c.Run("GetSchedule without segments", func(c *qt.C) {
+		response, err := client.GetSchedule(&ScheduleParams{BroadcasterID: "1"})
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.StatusCode, qt.Equals, http.StatusNotFound)
+		c.Assert(response.ErrorMessage, qt.Equals, "segments were either not found or empty")
+		c.Assert(response.Data.Segments, qt.HasLen, 0)
+	})
+
+	c.Run("GetChannelFollowers", func(c *qt.C) {
+		response, err := client.GetChannelFollowers(&ChannelFollowersParams{BroadcasterID: "11148817", First: 1})
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.StatusCode, qt.Equals, http.StatusOK)
+		c.Assert(response.Data.Total, qt.Equals, 12345)
+		c.Assert(response.Data.Followers, qt.HasLen, 0)
+	})
+
+	c.Run("GetChannelFollowers invalid broadcaster", func(c *qt.C) {
+		response, err := client.GetChannelFollowers(&ChannelFollowersParams{BroadcasterID: "invalid"})
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.StatusCode, qt.Equals, http.StatusBadRequest)
+		c.Assert(response.ErrorMessage, qt.Equals, "The ID in broadcaster_id is not valid.")
+	})
+
+	c.Run("SearchCategories", func(c *qt.C) {
+		response, err := client.SearchCategories(&SearchCategoriesParams{Query: "just chatting", First: 10})
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.StatusCode, qt.Equals, http.StatusOK)
+		c.Assert(response.Data.Categories, qt.DeepEquals, []helix.Game{{
+			ID:        "509658",
+			Name:      "Just Chatting",
+			BoxArtURL: "https://static-cdn.jtvnw.net/ttv-boxart/509658-52x72.jpg",
+		}})
+	})
+
+	c.Run("Unauthorized", func(c *qt.C) {
+		helixClient.SetAppAccessToken("expired")
+		defer helixClient.SetAppAccessToken("token")
+
+		response, err := client.GetSchedule(&ScheduleParams{BroadcasterID: "11148817"})
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.StatusCode, qt.Equals, http.StatusUnauthorized)
+		c.Assert(response.ErrorMessage, qt.Equals, "Invalid OAuth token")
+	})
+}
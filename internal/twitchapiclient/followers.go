@@ -0,0 +1,51 @@
+package twitchapiclient
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/nicklaw5/helix"
+)
+
+type ChannelFollowersParams struct {
+	BroadcasterID string
+
+	// Optional
+	First int // Limit 100
+}
+
+type ChannelFollower struct {
+	UserID     string     `json:"user_id"`
+	UserLogin  string     `json:"user_login"`
+	UserName   string     `json:"user_name"`
+	FollowedAt helix.Time `json:"followed_at"`
+}
+
+type ManyChannelFollowers struct {
+	// Total number of users following the broadcaster
+	Total      int               `json:"total"`
+	Followers  []ChannelFollower `json:"data"`
+	Pagination helix.Pagination  `json:"pagination"`
+}
+
+type ChannelFollowersResponse struct {
+	helix.ResponseCommon
+	Data ManyChannelFollowers
+}
+
+// GetChannelFollowers returns the users following a broadcaster.
+// With an app access token, only the total number of followers is returned.
+func (c *Client) GetChannelFollowers(params *ChannelFollowersParams) (*ChannelFollowersResponse, error) {
+	query := url.Values{}
+	query.Set("broadcaster_id", params.BroadcasterID)
+	if params.First > 0 {
+		query.Set("first", strconv.Itoa(params.First))
+	}
+
+	followers := &ChannelFollowersResponse{}
+	if err := c.get("/channels/followers", query, &followers.ResponseCommon, &followers.Data); err != nil {
+		return nil, err
+	}
+
+	return followers, nil
+}
@@ -0,0 +1,72 @@
+package twitchapiclient
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/nicklaw5/helix"
+)
+
+type ScheduleCategory struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type ScheduleSegment struct {
+	ID        string    `json:"id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Title     string    `json:"title"`
+	// Set if the segment was canceled
+	CanceledUntil *time.Time        `json:"canceled_until"`
+	Category      *ScheduleCategory `json:"category"`
+	IsRecurring   bool              `json:"is_recurring"`
+}
+
+type ScheduleVacation struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+type Schedule struct {
+	Segments         []ScheduleSegment `json:"segments"`
+	BroadcasterID    string            `json:"broadcaster_id"`
+	BroadcasterName  string            `json:"broadcaster_name"`
+	BroadcasterLogin string            `json:"broadcaster_login"`
+	Vacation         *ScheduleVacation `json:"vacation"`
+}
+
+type ScheduleParams struct {
+	BroadcasterID string
+
+	// Optional
+	First int // Limit 25
+}
+
+type ScheduleResponse struct {
+	helix.ResponseCommon
+	Data Schedule
+}
+
+// GetSchedule returns the upcoming segments of a broadcaster's stream schedule.
+// Broadcasters without any segments get a 404 response.
+func (c *Client) GetSchedule(params *ScheduleParams) (*ScheduleResponse, error) {
+	query := url.Values{}
+	query.Set("broadcaster_id", params.BroadcasterID)
+	if params.First > 0 {
+		query.Set("first", strconv.Itoa(params.First))
+	}
+
+	var data struct {
+		Data Schedule `json:"data"`
+	}
+
+	schedule := &ScheduleResponse{}
+	if err := c.get("/schedule", query, &schedule.ResponseCommon, &data); err != nil {
+		return nil, err
+	}
+	schedule.Data = data.Data
+
+	return schedule, nil
+}
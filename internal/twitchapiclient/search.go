@@ -0,0 +1,40 @@
+package twitchapiclient
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/nicklaw5/helix"
+)
+
+type SearchCategoriesParams struct {
+	Query string
+
+	// Optional
+	First int // Limit 100
+}
+
+type ManyCategories struct {
+	Categories []helix.Game `json:"data"`
+}
+
+type SearchCategoriesResponse struct {
+	helix.ResponseCommon
+	Data ManyCategories
+}
+
+// SearchCategories returns the categories (games) whose name matches the query
+func (c *Client) SearchCategories(params *SearchCategoriesParams) (*SearchCategoriesResponse, error) {
+	query := url.Values{}
+	query.Set("query", params.Query)
+	if params.First > 0 {
+		query.Set("first", strconv.Itoa(params.First))
+	}
+
+	categories := &SearchCategoriesResponse{}
+	if err := c.get("/search/categories", query, &categories.ResponseCommon, &categories.Data); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
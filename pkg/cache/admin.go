@@ -0,0 +1,162 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
+)
+
+// ErrPurged is returned by Get for keys requested with a context made by WithPurge
+var ErrPurged = errors.New("cache entry purged")
+
+// ListOrder decides which entries Admin.List returns
+type ListOrder string
+
+const (
+	// ListBiggest lists the entries with the biggest payload first
+	ListBiggest ListOrder = "biggest"
+	// ListRecent lists the most recently cached entries first
+	ListRecent ListOrder = "recent"
+)
+
+// Entry describes a value stored in the cache
+type Entry struct {
+	Key         string `json:"key"`
+	Size        int    `json:"size"`
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type"`
+	// Zero for entries stored before the backend kept track of it
+	CreatedOn   time.Time `json:"created_on,omitzero"`
+	CachedUntil time.Time `json:"cached_until,omitzero"`
+
+	// Only set by Admin.Inspect
+	Payload []byte `json:"-"`
+}
+
+// MarshalPayload returns the payload as a JSON value: JSON payloads are kept as they are, other
+// payloads become a string
+func (e *Entry) MarshalPayload() json.RawMessage {
+	if strings.HasPrefix(e.ContentType, "application/json") && json.Valid(e.Payload) {
+		return e.Payload
+	}
+
+	payload, _ := json.Marshal(string(e.Payload))
+	return payload
+}
+
+// Admin gives access to the entries stored by a cache backend, regardless of which cache stored them.
+// All keys are cache keys as generated by a KeyProvider, e.g. "youtube:video:dQw4w9WgXcQ".
+type Admin interface {
+	// Inspect returns the entry stored under the key, or nil if there is none
+	Inspect(ctx context.Context, cacheKey string) (*Entry, error)
+
+	// Purge deletes the entries stored under the keys, along with their dependent values.
+	// Returns the number of deleted entries.
+	Purge(ctx context.Context, cacheKeys ...string) (int, error)
+
+	// PurgePrefix deletes all entries of a key prefix (e.g. "youtube:video"), along with their
+	// dependent values. Returns the number of deleted entries.
+	PurgePrefix(ctx context.Context, prefix string) (int, error)
+
+	// List returns up to limit entries in the given order, without their payload. The Redis backend
+	// only looks at the first 10000 entries it finds.
+	List(ctx context.Context, order ListOrder, limit int) ([]Entry, error)
+}
+
+// NewDefaultAdmin creates an Admin for the backend configured in `cache-backend`.
+// The pool is only used by the PostgreSQL backend and may be nil for the other backends.
+func NewDefaultAdmin(ctx context.Context, cfg config.APIConfig, pool db.Pool) Admin {
+	switch cfg.CacheBackend {
+	case BackendMemory:
+		return NewMemoryAdmin()
+
+	case BackendBolt:
+		return NewBoltAdmin(mustGetBoltDB(ctx))
+
+	case BackendRedis:
+		return NewRedisAdmin(cfg, mustGetRedisClient(ctx))
+	}
+
+	return NewPostgreSQLAdmin(pool)
+}
+
+// hasKeyPrefix returns true if the cache key was generated by a key provider using the prefix
+func hasKeyPrefix(cacheKey string, prefix string) bool {
+	return strings.HasPrefix(cacheKey, prefix+":")
+}
+
+// sortEntries sorts the entries in the given order and keeps the first limit entries
+func sortEntries(entries []Entry, order ListOrder, limit int) []Entry {
+	switch order {
+	case ListRecent:
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].CreatedOn.After(entries[j].CreatedOn)
+		})
+	default:
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Size > entries[j].Size
+		})
+	}
+
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries
+}
+
+type adminModeKey struct{}
+
+type adminMode int
+
+const (
+	adminModeRefresh adminMode = iota + 1
+	adminModePurge
+)
+
+// WithRefresh makes values requested with the returned context be loaded again, replacing the
+// values cached for them in every language
+func WithRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, adminModeKey{}, adminModeRefresh)
+}
+
+// WithPurge makes values requested with the returned context be deleted from the cache in every
+// language instead of being loaded. Get returns ErrPurged for them.
+func WithPurge(ctx context.Context) context.Context {
+	return context.WithValue(ctx, adminModeKey{}, adminModePurge)
+}
+
+// purgeForAdmin handles Get calls made with a context from WithRefresh or WithPurge.
+// Returns true if Get must return the error instead of looking up the key.
+func purgeForAdmin(ctx context.Context, admin Admin, keyProvider KeyProvider, key string) (bool, error) {
+	mode, ok := ctx.Value(adminModeKey{}).(adminMode)
+	if !ok {
+		return false, nil
+	}
+
+	cacheKeys := make([]string, 0, len(i18n.Supported))
+	for _, lang := range i18n.Supported {
+		cacheKey := keyProvider.CacheKey(i18n.OnContext(ctx, lang), key)
+		if !slices.Contains(cacheKeys, cacheKey) {
+			cacheKeys = append(cacheKeys, cacheKey)
+		}
+	}
+
+	if _, err := admin.Purge(ctx, cacheKeys...); err != nil {
+		return true, err
+	}
+
+	if mode == adminModePurge {
+		return true, ErrPurged
+	}
+
+	return false, nil
+}
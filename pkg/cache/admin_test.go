@@ -0,0 +1,321 @@
+package cache
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
+	"github.com/alicebob/miniredis/v2"
+	qt "github.com/frankban/quicktest"
+	"github.com/pashagolub/pgxmock"
+	"github.com/redis/go-redis/v9"
+)
+
+type adminTestBackend struct {
+	admin        Admin
+	newCache     func(keyProvider KeyProvider, loader Loader) Cache
+	newDependent func(keyProvider KeyProvider) DependentCache
+}
+
+func testAdmin(c *qt.C, ctx context.Context, backend adminTestBackend) {
+	c.Run("Inspect", func(c *qt.C) {
+		cache := backend.newCache(NewPrefixKeyProvider("admin:inspect"), &testLoader{payload: []byte(`{"status":200}`)})
+		_, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+
+		entry, err := backend.admin.Inspect(ctx, "admin:inspect:a")
+		c.Assert(err, qt.IsNil)
+		c.Assert(entry, qt.IsNotNil)
+		c.Assert(entry.Key, qt.Equals, "admin:inspect:a")
+		c.Assert(entry.Payload, qt.DeepEquals, []byte(`{"status":200}`))
+		c.Assert(entry.Size, qt.Equals, 14)
+		c.Assert(entry.StatusCode, qt.Equals, defaultStatusCode)
+		c.Assert(entry.ContentType, qt.Equals, defaultContentType)
+		c.Assert(time.Since(entry.CreatedOn) < time.Minute, qt.IsTrue)
+		c.Assert(time.Until(entry.CachedUntil) <= time.Minute, qt.IsTrue)
+		c.Assert(string(entry.MarshalPayload()), qt.Equals, `{"status":200}`)
+
+		entry, err = backend.admin.Inspect(ctx, "admin:inspect:missing")
+		c.Assert(err, qt.IsNil)
+		c.Assert(entry, qt.IsNil)
+	})
+
+	c.Run("List", func(c *qt.C) {
+		small := backend.newCache(NewPrefixKeyProvider("admin:list"), &testLoader{payload: []byte("small")})
+		big := backend.newCache(NewPrefixKeyProvider("admin:list"), &testLoader{payload: []byte("a bigger payload")})
+
+		_, err := big.Get(ctx, "big", nil)
+		c.Assert(err, qt.IsNil)
+		time.Sleep(time.Millisecond)
+		_, err = small.Get(ctx, "small", nil)
+		c.Assert(err, qt.IsNil)
+
+		entries, err := backend.admin.List(ctx, ListBiggest, 1)
+		c.Assert(err, qt.IsNil)
+		c.Assert(entries, qt.HasLen, 1)
+		c.Assert(entries[0].Key, qt.Equals, "admin:list:big")
+		c.Assert(entries[0].Size, qt.Equals, 16)
+		c.Assert(entries[0].Payload, qt.IsNil)
+
+		entries, err = backend.admin.List(ctx, ListRecent, 1)
+		c.Assert(err, qt.IsNil)
+		c.Assert(entries, qt.HasLen, 1)
+		c.Assert(entries[0].Key, qt.Equals, "admin:list:small")
+	})
+
+	c.Run("Purge deletes dependent values", func(c *qt.C) {
+		dependent := backend.newDependent(NewPrefixKeyProvider("admin:dependent"))
+		parentKeyProvider := NewPrefixKeyProvider("admin:parent")
+		loader := &testLoader{payload: []byte("parent")}
+		loader.onLoad = func(ctx context.Context, key string) {
+			err := dependent.Insert(ctx, key, parentKeyProvider.CacheKey(ctx, key), []byte("child"), "image/png")
+			c.Assert(err, qt.IsNil)
+		}
+		cache := backend.newCache(parentKeyProvider, loader)
+		cache.RegisterDependent(ctx, dependent)
+
+		_, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+
+		purged, err := backend.admin.Purge(ctx, "admin:parent:a", "admin:parent:missing")
+		c.Assert(err, qt.IsNil)
+		c.Assert(purged, qt.Equals, 1)
+
+		c.Assert(cache.GetOnly(ctx, "a"), qt.IsNil)
+		value, _, err := dependent.Get(ctx, "a")
+		c.Assert(err, qt.IsNil)
+		c.Assert(value, qt.IsNil)
+
+		// The dependent value can be inserted again when the parent is loaded again
+		_, err = cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(loader.calls.Load(), qt.Equals, int32(2))
+	})
+
+	c.Run("Purge prefix", func(c *qt.C) {
+		loader := &testLoader{payload: []byte("hello")}
+		purgedCache := backend.newCache(NewPrefixKeyProvider("admin:prefix"), loader)
+		keptCache := backend.newCache(NewPrefixKeyProvider("admin:prefixes"), loader)
+
+		for _, key := range []string{"a", "b"} {
+			_, err := purgedCache.Get(ctx, key, nil)
+			c.Assert(err, qt.IsNil)
+			_, err = keptCache.Get(ctx, key, nil)
+			c.Assert(err, qt.IsNil)
+		}
+
+		purged, err := backend.admin.PurgePrefix(ctx, "admin:prefix")
+		c.Assert(err, qt.IsNil)
+		c.Assert(purged, qt.Equals, 2)
+
+		c.Assert(purgedCache.GetOnly(ctx, "a"), qt.IsNil)
+		c.Assert(purgedCache.GetOnly(ctx, "b"), qt.IsNil)
+		c.Assert(keptCache.GetOnly(ctx, "a"), qt.IsNotNil)
+		c.Assert(keptCache.GetOnly(ctx, "b"), qt.IsNotNil)
+	})
+
+	c.Run("Get with purge context", func(c *qt.C) {
+		loader := &testLoader{payload: []byte("hello")}
+		cache := backend.newCache(NewLocalizedKeyProvider("admin:purge"), loader)
+
+		_, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+		_, err = cache.Get(i18n.OnContext(ctx, i18n.German), "a", nil)
+		c.Assert(err, qt.IsNil)
+
+		response, err := cache.Get(WithPurge(ctx), "a", nil)
+		c.Assert(err, qt.Equals, ErrPurged)
+		c.Assert(response, qt.IsNil)
+
+		// Every language is purged
+		c.Assert(cache.GetOnly(ctx, "a"), qt.IsNil)
+		c.Assert(cache.GetOnly(i18n.OnContext(ctx, i18n.German), "a"), qt.IsNil)
+		c.Assert(loader.calls.Load(), qt.Equals, int32(2))
+	})
+
+	c.Run("Get with refresh context", func(c *qt.C) {
+		loader := &testLoader{payload: []byte("old")}
+		cache := backend.newCache(NewLocalizedKeyProvider("admin:refresh"), loader)
+
+		_, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+		_, err = cache.Get(i18n.OnContext(ctx, i18n.German), "a", nil)
+		c.Assert(err, qt.IsNil)
+
+		loader.payload = []byte("new")
+		response, err := cache.Get(WithRefresh(ctx), "a", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Payload, qt.DeepEquals, []byte("new"))
+		c.Assert(cache.GetOnly(ctx, "a").Payload, qt.DeepEquals, []byte("new"))
+
+		// Other languages are loaded again when they're requested
+		c.Assert(cache.GetOnly(i18n.OnContext(ctx, i18n.German), "a"), qt.IsNil)
+		c.Assert(loader.calls.Load(), qt.Equals, int32(3))
+	})
+}
+
+func TestMemoryAdmin(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+	cfg := config.APIConfig{}
+
+	// The memory cache is global, make sure values from other tests don't show up in the lists
+	kvCache.Flush()
+
+	testAdmin(c, ctx, adminTestBackend{
+		admin: NewMemoryAdmin(),
+		newCache: func(keyProvider KeyProvider, loader Loader) Cache {
+			return NewMemoryCache(cfg, keyProvider, loader, time.Minute)
+		},
+		newDependent: func(keyProvider KeyProvider) DependentCache {
+			return NewMemoryDependentCache(ctx, cfg, keyProvider)
+		},
+	})
+}
+
+func TestBoltAdmin(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+	cfg := config.APIConfig{}
+
+	db, err := OpenBoltDB(filepath.Join(t.TempDir(), "cache.db"))
+	c.Assert(err, qt.IsNil)
+	defer db.Close()
+
+	testAdmin(c, ctx, adminTestBackend{
+		admin: NewBoltAdmin(db),
+		newCache: func(keyProvider KeyProvider, loader Loader) Cache {
+			return NewBoltCache(ctx, cfg, db, keyProvider, loader, time.Minute)
+		},
+		newDependent: func(keyProvider KeyProvider) DependentCache {
+			return NewBoltDependentCache(ctx, cfg, db, keyProvider)
+		},
+	})
+}
+
+func TestRedisAdmin(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+	cfg := config.APIConfig{
+		CacheRedisKeyPrefix: "test:",
+	}
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	testAdmin(c, ctx, adminTestBackend{
+		admin: NewRedisAdmin(cfg, client),
+		newCache: func(keyProvider KeyProvider, loader Loader) Cache {
+			return NewRedisCache(ctx, cfg, client, keyProvider, loader, time.Minute)
+		},
+		newDependent: func(keyProvider KeyProvider) DependentCache {
+			return NewRedisDependentCache(ctx, cfg, client, keyProvider)
+		},
+	})
+
+	c.Run("Prefixes are not patterns", func(c *qt.C) {
+		cache := NewRedisCache(ctx, cfg, client, NewPrefixKeyProvider("admin:pattern"), &testLoader{payload: []byte("hello")}, time.Minute)
+		_, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+
+		purged, err := NewRedisAdmin(cfg, client).PurgePrefix(ctx, "admin:*")
+		c.Assert(err, qt.IsNil)
+		c.Assert(purged, qt.Equals, 0)
+		c.Assert(cache.GetOnly(ctx, "a"), qt.IsNotNil)
+	})
+
+	c.Run("List in batches", func(c *qt.C) {
+		server.FlushAll()
+		for _, payload := range []string{"a", "bb", "ccc"} {
+			cache := NewRedisCache(ctx, cfg, client, NewPrefixKeyProvider("admin:batches"), &testLoader{payload: []byte(payload)}, time.Minute)
+			_, err := cache.Get(ctx, payload, nil)
+			c.Assert(err, qt.IsNil)
+		}
+
+		admin := NewRedisAdmin(cfg, client)
+		admin.listBatchSize = 2
+		entries, err := admin.List(ctx, ListBiggest, 2)
+		c.Assert(err, qt.IsNil)
+		c.Assert(entries, qt.HasLen, 2)
+		c.Assert(entries[0].Key, qt.Equals, "admin:batches:ccc")
+		c.Assert(entries[1].Key, qt.Equals, "admin:batches:bb")
+
+		admin.listMaxKeys = 1
+		entries, err = admin.List(ctx, ListBiggest, 2)
+		c.Assert(err, qt.IsNil)
+		c.Assert(entries, qt.HasLen, 1)
+	})
+}
+
+func TestPostgreSQLAdmin(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	c.Run("Purge", func(c *qt.C) {
+		pool, err := pgxmock.NewPool()
+		c.Assert(err, qt.IsNil)
+		admin := NewPostgreSQLAdmin(pool)
+
+		pool.ExpectQuery("DELETE FROM cache WHERE key = ANY").
+			WithArgs([]string{"test:a", "test:b"}).
+			WillReturnRows(pgxmock.NewRows([]string{"key"}).AddRow("test:a"))
+		pool.ExpectExec("DELETE FROM dependent_values WHERE parent_key = ANY").
+			WithArgs([]string{"test:a"}).
+			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+		purged, err := admin.Purge(ctx, "test:a", "test:b")
+		c.Assert(err, qt.IsNil)
+		c.Assert(purged, qt.Equals, 1)
+		c.Assert(pool.ExpectationsWereMet(), qt.IsNil)
+	})
+
+	c.Run("Purge prefix", func(c *qt.C) {
+		pool, err := pgxmock.NewPool()
+		c.Assert(err, qt.IsNil)
+		admin := NewPostgreSQLAdmin(pool)
+
+		pool.ExpectQuery("DELETE FROM cache WHERE left\\(key, length\\(\\$1\\)\\) = \\$1").
+			WithArgs("youtube:video:").
+			WillReturnRows(pgxmock.NewRows([]string{"key"}).AddRow("youtube:video:a").AddRow("youtube:video:b"))
+		pool.ExpectExec("DELETE FROM dependent_values WHERE parent_key = ANY").
+			WithArgs([]string{"youtube:video:a", "youtube:video:b"}).
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+		purged, err := admin.PurgePrefix(ctx, "youtube:video")
+		c.Assert(err, qt.IsNil)
+		c.Assert(purged, qt.Equals, 2)
+		c.Assert(pool.ExpectationsWereMet(), qt.IsNil)
+	})
+
+	c.Run("List", func(c *qt.C) {
+		pool, err := pgxmock.NewPool()
+		c.Assert(err, qt.IsNil)
+		admin := NewPostgreSQLAdmin(pool)
+
+		createdOn := time.Now().Add(-time.Minute)
+		cachedUntil := time.Now().Add(time.Hour)
+		pool.ExpectQuery("SELECT key, length\\(value\\), .* FROM cache ORDER BY length\\(value\\) DESC LIMIT").
+			WithArgs(10).
+			WillReturnRows(pgxmock.NewRows([]string{"key", "length", "http_status_code", "http_content_type", "created_on", "cached_until"}).
+				AddRow("test:a", 1234, 200, "application/json", createdOn, cachedUntil))
+
+		entries, err := admin.List(ctx, ListBiggest, 10)
+		c.Assert(err, qt.IsNil)
+		c.Assert(entries, qt.DeepEquals, []Entry{
+			{
+				Key:         "test:a",
+				Size:        1234,
+				StatusCode:  200,
+				ContentType: "application/json",
+				CreatedOn:   createdOn,
+				CachedUntil: cachedUntil,
+			},
+		})
+		c.Assert(pool.ExpectationsWereMet(), qt.IsNil)
+	})
+}
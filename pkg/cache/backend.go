@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/Chatterino/api/internal/db"
+	"github.com/Chatterino/api/pkg/config"
+)
+
+const (
+	BackendPostgreSQL = "postgres"
+	BackendMemory     = "memory"
+	BackendBolt       = "bolt"
+	BackendRedis      = "redis"
+)
+
+// IsValidBackend returns true if the given cache backend name is one we know how to create
+func IsValidBackend(backend string) bool {
+	switch backend {
+	case "", BackendPostgreSQL, BackendMemory, BackendBolt, BackendRedis:
+		return true
+	}
+
+	return false
+}
+
+// NewDefaultCache creates a Cache using the backend configured in `cache-backend`.
+// The pool is only used by the PostgreSQL backend and may be nil for the other backends.
+func NewDefaultCache(ctx context.Context, cfg config.APIConfig, pool db.Pool, keyProvider KeyProvider, loader Loader, cacheDuration time.Duration) Cache {
+	switch cfg.CacheBackend {
+	case BackendMemory:
+		return NewMemoryCache(cfg, keyProvider, loader, cacheDuration)
+
+	case BackendBolt:
+		return NewBoltCache(ctx, cfg, mustGetBoltDB(ctx), keyProvider, loader, cacheDuration)
+
+	case BackendRedis:
+		return NewRedisCache(ctx, cfg, mustGetRedisClient(ctx), keyProvider, loader, cacheDuration)
+	}
+
+	return NewPostgreSQLCache(ctx, cfg, pool, keyProvider, loader, cacheDuration)
+}
+
+// NewDefaultDependentCache creates a DependentCache using the backend configured in `cache-backend`.
+// The pool is only used by the PostgreSQL backend and may be nil for the other backends.
+func NewDefaultDependentCache(ctx context.Context, cfg config.APIConfig, pool db.Pool, keyProvider KeyProvider) DependentCache {
+	switch cfg.CacheBackend {
+	case BackendMemory:
+		return NewMemoryDependentCache(ctx, cfg, keyProvider)
+
+	case BackendBolt:
+		return NewBoltDependentCache(ctx, cfg, mustGetBoltDB(ctx), keyProvider)
+
+	case BackendRedis:
+		return NewRedisDependentCache(ctx, cfg, mustGetRedisClient(ctx), keyProvider)
+	}
+
+	return NewPostgreSQLDependentCache(ctx, cfg, pool, keyProvider)
+}
@@ -0,0 +1,717 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/config"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	boltCacheBucket            = []byte("cache")
+	boltDependentValuesBucket  = []byte("dependent_values")
+	boltDependentParentsBucket = []byte("dependent_parents")
+
+	errDependentValueExists = errors.New("dependent value already exists")
+)
+
+// boltDB is the database shared by all bolt caches, opened by InitializeBolt
+var boltDB *bolt.DB
+
+type boltEntry struct {
+	Payload     []byte    `json:"payload"`
+	StatusCode  int       `json:"status_code"`
+	ContentType string    `json:"content_type"`
+	CreatedOn   time.Time `json:"created_on"`
+	CachedUntil time.Time `json:"cached_until"`
+}
+
+type boltDependentEntry struct {
+	ParentKey           string    `json:"parent_key"`
+	Value               []byte    `json:"value"`
+	ContentType         string    `json:"content_type"`
+	Committed           bool      `json:"committed"`
+	ExpirationTimestamp time.Time `json:"expiration_timestamp"`
+}
+
+// OpenBoltDB opens (or creates) the bolt database at path and ensures all buckets we use exist
+func OpenBoltDB(path string) (*bolt.DB, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{boltCacheBucket, boltDependentValuesBucket, boltDependentParentsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// InitializeBolt opens the bolt database used by the bolt cache backend
+func InitializeBolt(path string) error {
+	db, err := OpenBoltDB(path)
+	if err != nil {
+		return err
+	}
+
+	boltDB = db
+	return nil
+}
+
+func ShutdownBolt() {
+	if boltDB != nil {
+		boltDB.Close()
+	}
+}
+
+func mustGetBoltDB(ctx context.Context) *bolt.DB {
+	if boltDB == nil {
+		logger.FromContext(ctx).Fatalw("Bolt cache backend was requested before being initialized")
+	}
+
+	return boltDB
+}
+
+// deleteBoltDependents deletes all dependent values belonging to the given parent keys.
+// If onlyUncommitted is set, committed dependent values are left untouched.
+func deleteBoltDependents(tx *bolt.Tx, parentKeys []string, onlyUncommitted bool) error {
+	values := tx.Bucket(boltDependentValuesBucket)
+	parents := tx.Bucket(boltDependentParentsBucket)
+
+	for _, parentKey := range parentKeys {
+		children := parents.Bucket([]byte(parentKey))
+		if children == nil {
+			continue
+		}
+
+		var deletedChildren [][]byte
+		err := children.ForEach(func(childKey, _ []byte) error {
+			if onlyUncommitted {
+				var entry boltDependentEntry
+				if raw := values.Get(childKey); raw != nil && json.Unmarshal(raw, &entry) == nil && entry.Committed {
+					return nil
+				}
+			}
+
+			deletedChildren = append(deletedChildren, append([]byte(nil), childKey...))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, childKey := range deletedChildren {
+			if err := values.Delete(childKey); err != nil {
+				return err
+			}
+			if err := children.Delete(childKey); err != nil {
+				return err
+			}
+		}
+
+		if k, _ := children.Cursor().First(); k == nil {
+			if err := parents.DeleteBucket([]byte(parentKey)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Returns the number of deleted tooltip entries
+func clearOldBoltEntries(ctx context.Context, db *bolt.DB) (int, error) {
+	now := time.Now()
+
+	var deletedParents []string
+	err := db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltCacheBucket)
+
+		err := bucket.ForEach(func(k, v []byte) error {
+			var entry boltEntry
+			if err := json.Unmarshal(v, &entry); err != nil || now.After(entry.CachedUntil) {
+				deletedParents = append(deletedParents, string(k))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, key := range deletedParents {
+			if err := bucket.Delete([]byte(key)); err != nil {
+				return err
+			}
+		}
+
+		return deleteBoltDependents(tx, deletedParents, false)
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	return len(deletedParents), nil
+}
+
+// This is meant as a safety measure in case a DependentCache user does not properly manage parent
+// keys.
+func clearExpiredBoltDependentValues(ctx context.Context, db *bolt.DB) error {
+	now := time.Now()
+
+	return db.Update(func(tx *bolt.Tx) error {
+		values := tx.Bucket(boltDependentValuesBucket)
+		parents := tx.Bucket(boltDependentParentsBucket)
+
+		var expired []boltDependentEntry
+		var expiredKeys [][]byte
+		err := values.ForEach(func(k, v []byte) error {
+			var entry boltDependentEntry
+			if err := json.Unmarshal(v, &entry); err != nil || now.After(entry.ExpirationTimestamp) {
+				expired = append(expired, entry)
+				expiredKeys = append(expiredKeys, append([]byte(nil), k...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for i, key := range expiredKeys {
+			if err := values.Delete(key); err != nil {
+				return err
+			}
+			if children := parents.Bucket([]byte(expired[i].ParentKey)); children != nil {
+				if err := children.Delete(key); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+func StartBoltCacheClearer(ctx context.Context) {
+	log := logger.FromContext(ctx)
+	db := mustGetBoltDB(ctx)
+
+	tooltipTicker := time.NewTicker(1 * time.Minute)
+	dependentValuesTicker := time.NewTicker(12 * time.Hour)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-tooltipTicker.C:
+			if numDeleted, err := clearOldBoltEntries(ctx, db); err != nil {
+				log.Errorw("Error clearing old tooltips", "error", err)
+			} else {
+				clearedEntries.Add(float64(numDeleted))
+				log.Debugw("Cleared old tooltips", "rowsAffected", numDeleted)
+			}
+
+		case <-dependentValuesTicker.C:
+			if err := clearExpiredBoltDependentValues(ctx, db); err != nil {
+				log.Errorw("Error clearing expired dependent values", "error", err)
+			}
+		}
+	}
+}
+
+type BoltCache struct {
+	loader Loader
+
+	cacheDuration time.Duration
+
+	policy cachePolicy
+
+	keyProvider KeyProvider
+
+	db *bolt.DB
+
+	// Used to purge entries for requests made with WithRefresh or WithPurge
+	admin Admin
+
+	dependentCaches []DependentCache
+
+	requestsMutex sync.Mutex
+	requests      map[string][]chan wrappedResponse
+}
+
+func (c *BoltCache) load(ctx context.Context, key string, r *http.Request) (*Response, error) {
+	log := logger.FromContext(ctx)
+
+	payload, statusCode, contentType, overrideDuration, err := c.loader.Load(ctx, key, r)
+	// If the parent cannot be inserted into the cache, rollback the dependents
+	defer c.rollbackDependents(ctx, key)
+
+	if statusCode == nil {
+		log.Debugw("Missing status code, setting to 200 default")
+		statusCode = &defaultStatusCode
+	}
+	if contentType == nil {
+		log.Debugw("Missing content type, setting to application/json default")
+		contentType = &defaultContentType
+	}
+
+	dur := c.cacheDuration
+	if overrideDuration != 0 {
+		dur = overrideDuration
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	dur = c.policy.loadedDuration(ctx, *statusCode, *contentType, payload, dur)
+
+	cacheKey := c.keyProvider.CacheKey(ctx, key)
+	now := time.Now()
+	entry, err := json.Marshal(boltEntry{
+		Payload:     payload,
+		StatusCode:  *statusCode,
+		ContentType: *contentType,
+		CreatedOn:   now,
+		CachedUntil: now.Add(dur),
+	})
+	if err == nil {
+		err = c.db.Update(func(tx *bolt.Tx) error {
+			return tx.Bucket(boltCacheBucket).Put([]byte(cacheKey), entry)
+		})
+	}
+	if err != nil {
+		log.Errorw("Error inserting tooltip into cache",
+			"cacheKey", cacheKey,
+			"key", key,
+			"error", err,
+		)
+	}
+	// Parent entry was inserted correctly, commit the dependents to prevent them from being rolled
+	// back
+	c.commitDependents(ctx, key)
+
+	return &Response{
+		Payload:     payload,
+		StatusCode:  *statusCode,
+		ContentType: *contentType,
+	}, nil
+}
+
+func (c *BoltCache) loadFromDatabase(ctx context.Context, cacheKey string) (*Response, error) {
+	var raw []byte
+	err := c.db.View(func(tx *bolt.Tx) error {
+		// Values returned by Get are only valid during the transaction
+		raw = append(raw, tx.Bucket(boltCacheBucket).Get([]byte(cacheKey))...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if raw == nil {
+		return nil, nil
+	}
+
+	var entry boltEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return nil, err
+	}
+
+	// Expired entries are removed by the cache clearer, until then we act as if they don't exist
+	if time.Now().After(entry.CachedUntil) {
+		return nil, nil
+	}
+
+	return &Response{
+		Payload:     entry.Payload,
+		StatusCode:  entry.StatusCode,
+		ContentType: entry.ContentType,
+	}, nil
+}
+
+func (c *BoltCache) Get(ctx context.Context, key string, r *http.Request) (*Response, error) {
+	log := logger.FromContext(ctx)
+	cacheKey := c.keyProvider.CacheKey(ctx, key)
+
+	if done, err := purgeForAdmin(ctx, c.admin, c.keyProvider, key); done {
+		return nil, err
+	}
+
+	cacheResponse, err := c.loadFromDatabase(ctx, cacheKey)
+	if err != nil {
+		log.Warnw("Unhandled bolt error", "error", err)
+		tooltipInternalError := Response{
+			Payload:     []byte(`{"status":500,"message":"Internal server error (bolt) loading thumbnail"}`),
+			StatusCode:  500,
+			ContentType: "application/json",
+		}
+		return &tooltipInternalError, err
+	} else if cacheResponse != nil {
+		cacheHits.Inc()
+		log.Debugw("Bolt Get cache hit", "cacheKey", cacheKey)
+		return cacheResponse, nil
+	}
+
+	// If key is not in cache, sign up as a listener and ensure loader is only called once
+	cacheMisses.Inc()
+	log.Debugw("Bolt Get cache miss", "cacheKey", cacheKey)
+	responseChannel := make(chan wrappedResponse)
+
+	c.requestsMutex.Lock()
+
+	c.requests[cacheKey] = append(c.requests[cacheKey], responseChannel)
+
+	first := len(c.requests[cacheKey]) == 1
+
+	c.requestsMutex.Unlock()
+
+	if first {
+		go func() {
+			response, err := c.load(ctx, key, r)
+
+			r := wrappedResponse{
+				response,
+				err,
+			}
+			c.requestsMutex.Lock()
+			for _, ch := range c.requests[cacheKey] {
+				ch <- r
+			}
+			delete(c.requests, cacheKey)
+			c.requestsMutex.Unlock()
+		}()
+	}
+
+	// Wait for loader to complete, then return value from loader
+	response := <-responseChannel
+	return response.response, response.err
+}
+
+func (c *BoltCache) GetOnly(ctx context.Context, key string) *Response {
+	log := logger.FromContext(ctx)
+	cacheKey := c.keyProvider.CacheKey(ctx, key)
+
+	value, err := c.loadFromDatabase(ctx, cacheKey)
+	if err != nil {
+		log.Warnw("Unhandled bolt error", "error", err)
+		return nil
+	} else if value != nil {
+		cacheHits.Inc()
+		log.Debugw("Bolt GetOnly cache hit", "cacheKey", cacheKey)
+		return value
+	}
+
+	cacheMisses.Inc()
+	log.Debugw("Bolt GetOnly cache miss", "cacheKey", cacheKey)
+	return nil
+}
+
+func (c *BoltCache) RegisterDependent(ctx context.Context, dependent DependentCache) {
+	c.dependentCaches = append(c.dependentCaches, dependent)
+}
+
+func (c *BoltCache) commitDependents(ctx context.Context, key string) error {
+	parentKey := c.keyProvider.CacheKey(ctx, key)
+
+	for _, dependent := range c.dependentCaches {
+		err := dependent.commit(ctx, parentKey)
+		if err != nil {
+			continue
+		}
+	}
+
+	return nil
+}
+
+func (c *BoltCache) rollbackDependents(ctx context.Context, key string) error {
+	parentKey := c.keyProvider.CacheKey(ctx, key)
+
+	for _, dependent := range c.dependentCaches {
+		err := dependent.rollback(ctx, parentKey)
+		if err != nil {
+			continue
+		}
+	}
+
+	return nil
+}
+
+func NewBoltCache(ctx context.Context, cfg config.APIConfig, db *bolt.DB, keyProvider KeyProvider, loader Loader, cacheDuration time.Duration) *BoltCache {
+	return &BoltCache{
+		keyProvider:   keyProvider,
+		loader:        loader,
+		cacheDuration: cacheDuration,
+		policy:        newCachePolicy(cfg, keyProvider),
+		db:            db,
+		admin:         NewBoltAdmin(db),
+		requests:      make(map[string][]chan wrappedResponse),
+	}
+}
+
+var _ Cache = (*BoltCache)(nil)
+
+type BoltDependentCache struct {
+	keyProvider KeyProvider
+
+	db *bolt.DB
+}
+
+func (c *BoltDependentCache) Get(ctx context.Context, key string) ([]byte, string, error) {
+	log := logger.FromContext(ctx)
+
+	cacheKey := c.keyProvider.CacheKey(ctx, key)
+
+	var raw []byte
+	err := c.db.View(func(tx *bolt.Tx) error {
+		raw = append(raw, tx.Bucket(boltDependentValuesBucket).Get([]byte(cacheKey))...)
+		return nil
+	})
+	if err != nil {
+		log.Warnw("Unhandled bolt error", "error", err)
+		return nil, "", err
+	}
+
+	if raw == nil {
+		// Cache entry didn't exist
+		return nil, "", nil
+	}
+
+	var entry boltDependentEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return nil, "", err
+	}
+
+	return entry.Value, entry.ContentType, nil
+}
+
+func (c *BoltDependentCache) Insert(
+	ctx context.Context, key string, parentKey string, value []byte, contentType string,
+) error {
+	log := logger.FromContext(ctx)
+
+	cacheKey := c.keyProvider.CacheKey(ctx, key)
+	entry, err := json.Marshal(boltDependentEntry{
+		ParentKey:           parentKey,
+		Value:               value,
+		ContentType:         contentType,
+		ExpirationTimestamp: time.Now().Add(dependentExpirationDuration),
+	})
+	if err == nil {
+		err = c.db.Update(func(tx *bolt.Tx) error {
+			values := tx.Bucket(boltDependentValuesBucket)
+			if values.Get([]byte(cacheKey)) != nil {
+				return errDependentValueExists
+			}
+			if err := values.Put([]byte(cacheKey), entry); err != nil {
+				return err
+			}
+
+			children, err := tx.Bucket(boltDependentParentsBucket).CreateBucketIfNotExists([]byte(parentKey))
+			if err != nil {
+				return err
+			}
+			return children.Put([]byte(cacheKey), nil)
+		})
+	}
+	if err != nil {
+		log.Errorw("Error inserting dependent value",
+			"cacheKey", cacheKey,
+			"parentKey", parentKey,
+			"error", err,
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (c *BoltDependentCache) commit(ctx context.Context, parentKey string) error {
+	log := logger.FromContext(ctx)
+
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		values := tx.Bucket(boltDependentValuesBucket)
+		children := tx.Bucket(boltDependentParentsBucket).Bucket([]byte(parentKey))
+		if children == nil {
+			return nil
+		}
+
+		return children.ForEach(func(childKey, _ []byte) error {
+			var entry boltDependentEntry
+			raw := values.Get(childKey)
+			if raw == nil {
+				return nil
+			}
+			if err := json.Unmarshal(raw, &entry); err != nil {
+				return err
+			}
+			if entry.Committed {
+				return nil
+			}
+
+			entry.Committed = true
+			updated, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			return values.Put(childKey, updated)
+		})
+	})
+	if err != nil {
+		log.Errorw("Error committing dependent values",
+			"parentKey", parentKey,
+			"err", err,
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (c *BoltDependentCache) rollback(ctx context.Context, parentKey string) error {
+	log := logger.FromContext(ctx)
+
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		return deleteBoltDependents(tx, []string{parentKey}, true)
+	})
+	if err != nil {
+		log.Errorw("Error rolling back dependent values",
+			"err", err,
+		)
+		return err
+	}
+
+	return nil
+}
+
+func NewBoltDependentCache(ctx context.Context, cfg config.APIConfig, db *bolt.DB, keyProvider KeyProvider) *BoltDependentCache {
+	return &BoltDependentCache{
+		keyProvider: keyProvider,
+		db:          db,
+	}
+}
+
+var _ DependentCache = (*BoltDependentCache)(nil)
+
+type BoltAdmin struct {
+	db *bolt.DB
+}
+
+func (a *BoltAdmin) Inspect(ctx context.Context, cacheKey string) (*Entry, error) {
+	var raw []byte
+	err := a.db.View(func(tx *bolt.Tx) error {
+		raw = append(raw, tx.Bucket(boltCacheBucket).Get([]byte(cacheKey))...)
+		return nil
+	})
+	if err != nil || raw == nil {
+		return nil, err
+	}
+
+	var value boltEntry
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+
+	return &Entry{
+		Key:         cacheKey,
+		Size:        len(value.Payload),
+		StatusCode:  value.StatusCode,
+		ContentType: value.ContentType,
+		CreatedOn:   value.CreatedOn,
+		CachedUntil: value.CachedUntil,
+		Payload:     value.Payload,
+	}, nil
+}
+
+// purge deletes the entries returned by keys, along with their dependent values
+func (a *BoltAdmin) purge(keys func(bucket *bolt.Bucket) []string) (int, error) {
+	var deletedParents []string
+	err := a.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltCacheBucket)
+
+		for _, key := range keys(bucket) {
+			if bucket.Get([]byte(key)) == nil {
+				continue
+			}
+			if err := bucket.Delete([]byte(key)); err != nil {
+				return err
+			}
+			deletedParents = append(deletedParents, key)
+		}
+
+		return deleteBoltDependents(tx, deletedParents, false)
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	return len(deletedParents), nil
+}
+
+func (a *BoltAdmin) Purge(ctx context.Context, cacheKeys ...string) (int, error) {
+	return a.purge(func(*bolt.Bucket) []string {
+		return cacheKeys
+	})
+}
+
+func (a *BoltAdmin) PurgePrefix(ctx context.Context, prefix string) (int, error) {
+	return a.purge(func(bucket *bolt.Bucket) []string {
+		// Keys are sorted, so all keys with the prefix come right after each other
+		var cacheKeys []string
+		c := bucket.Cursor()
+		for k, _ := c.Seek([]byte(prefix + ":")); k != nil && hasKeyPrefix(string(k), prefix); k, _ = c.Next() {
+			cacheKeys = append(cacheKeys, string(k))
+		}
+		return cacheKeys
+	})
+}
+
+func (a *BoltAdmin) List(ctx context.Context, order ListOrder, limit int) ([]Entry, error) {
+	entries := []Entry{}
+	err := a.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltCacheBucket).ForEach(func(k, v []byte) error {
+			var value boltEntry
+			if err := json.Unmarshal(v, &value); err != nil {
+				return nil
+			}
+
+			entries = append(entries, Entry{
+				Key:         string(k),
+				Size:        len(value.Payload),
+				StatusCode:  value.StatusCode,
+				ContentType: value.ContentType,
+				CreatedOn:   value.CreatedOn,
+				CachedUntil: value.CachedUntil,
+			})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sortEntries(entries, order, limit), nil
+}
+
+func NewBoltAdmin(db *bolt.DB) *BoltAdmin {
+	return &BoltAdmin{
+		db: db,
+	}
+}
+
+var _ Admin = (*BoltAdmin)(nil)
@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/config"
+	qt "github.com/frankban/quicktest"
+)
+
+func TestBoltCache(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+	cfg := config.APIConfig{}
+
+	db, err := OpenBoltDB(filepath.Join(t.TempDir(), "cache.db"))
+	c.Assert(err, qt.IsNil)
+	defer db.Close()
+
+	c.Run("Get loads once and caches", func(c *qt.C) {
+		loader := &testLoader{payload: []byte("hello")}
+		cache := NewBoltCache(ctx, cfg, db, NewPrefixKeyProvider("test:bolt:get"), loader, time.Minute)
+
+		c.Assert(cache.GetOnly(ctx, "a"), qt.IsNil)
+
+		response, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Payload, qt.DeepEquals, []byte("hello"))
+		c.Assert(response.StatusCode, qt.Equals, defaultStatusCode)
+		c.Assert(response.ContentType, qt.Equals, defaultContentType)
+
+		response, err = cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Payload, qt.DeepEquals, []byte("hello"))
+		c.Assert(loader.calls.Load(), qt.Equals, int32(1))
+	})
+
+	c.Run("Expired entries are reloaded and cleared", func(c *qt.C) {
+		loader := &testLoader{payload: []byte("hello")}
+		cache := NewBoltCache(ctx, cfg, db, NewPrefixKeyProvider("test:bolt:expired"), loader, -time.Minute)
+
+		_, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(cache.GetOnly(ctx, "a"), qt.IsNil)
+
+		_, err = cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(loader.calls.Load(), qt.Equals, int32(2))
+
+		numDeleted, err := clearOldBoltEntries(ctx, db)
+		c.Assert(err, qt.IsNil)
+		c.Assert(numDeleted, qt.Equals, 1)
+	})
+
+	c.Run("Dependent values follow their parent", func(c *qt.C) {
+		dependent := NewBoltDependentCache(ctx, cfg, db, NewPrefixKeyProvider("test:bolt:dependent"))
+		parentKeyProvider := NewPrefixKeyProvider("test:bolt:parent")
+		loader := &testLoader{payload: []byte("parent")}
+		loader.onLoad = func(ctx context.Context, key string) {
+			err := dependent.Insert(ctx, key, parentKeyProvider.CacheKey(ctx, key), []byte("child"), "image/png")
+			c.Assert(err, qt.IsNil)
+		}
+		cache := NewBoltCache(ctx, cfg, db, parentKeyProvider, loader, -time.Minute)
+		cache.RegisterDependent(ctx, dependent)
+
+		_, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+
+		value, contentType, err := dependent.Get(ctx, "a")
+		c.Assert(err, qt.IsNil)
+		c.Assert(value, qt.DeepEquals, []byte("child"))
+		c.Assert(contentType, qt.Equals, "image/png")
+
+		// Inserting the same dependent value twice is an error
+		err = dependent.Insert(ctx, "a", parentKeyProvider.CacheKey(ctx, "a"), []byte("child"), "image/png")
+		c.Assert(err, qt.IsNotNil)
+
+		// The parent has expired, clearing it should remove the dependent value too
+		_, err = clearOldBoltEntries(ctx, db)
+		c.Assert(err, qt.IsNil)
+		value, _, err = dependent.Get(ctx, "a")
+		c.Assert(err, qt.IsNil)
+		c.Assert(value, qt.IsNil)
+	})
+
+	c.Run("Dependent values are rolled back if the parent fails", func(c *qt.C) {
+		dependent := NewBoltDependentCache(ctx, cfg, db, NewPrefixKeyProvider("test:bolt:dependent"))
+		parentKeyProvider := NewPrefixKeyProvider("test:bolt:failingparent")
+		loader := &testLoader{err: errors.New("oops")}
+		loader.onLoad = func(ctx context.Context, key string) {
+			err := dependent.Insert(ctx, key, parentKeyProvider.CacheKey(ctx, key), []byte("child"), "image/png")
+			c.Assert(err, qt.IsNil)
+		}
+		cache := NewBoltCache(ctx, cfg, db, parentKeyProvider, loader, time.Minute)
+		cache.RegisterDependent(ctx, dependent)
+
+		_, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.ErrorMatches, "oops")
+
+		value, _, err := dependent.Get(ctx, "a")
+		c.Assert(err, qt.IsNil)
+		c.Assert(value, qt.IsNil)
+	})
+}
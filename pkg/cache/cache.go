@@ -43,8 +43,6 @@ type DependentCache interface {
 
 var NoSpecialDur time.Duration
 
-var NewDefaultCache = NewPostgreSQLCache
-
 var (
 	defaultStatusCode  int    = 200
 	defaultContentType string = "application/json"
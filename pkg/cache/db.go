@@ -32,6 +32,12 @@ var (
 			Help: "Number of cache entries cleared",
 		},
 	)
+	staleHits = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "db_cache_stale_hits_total",
+			Help: "Number of DB cache hits that served an expired entry while it was being refreshed",
+		},
+	)
 )
 
 type wrappedResponse struct {
@@ -43,6 +49,7 @@ func init() {
 	prometheus.MustRegister(cacheHits)
 	prometheus.MustRegister(cacheMisses)
 	prometheus.MustRegister(clearedEntries)
+	prometheus.MustRegister(staleHits)
 }
 
 type PostgreSQLCache struct {
@@ -50,10 +57,19 @@ type PostgreSQLCache struct {
 
 	cacheDuration time.Duration
 
+	policy cachePolicy
+
+	// How long after cacheDuration an entry may still be served while it's being refreshed in the
+	// background. 0 disables serving stale entries.
+	staleDuration time.Duration
+
 	keyProvider KeyProvider
 
 	pool db.Pool
 
+	// Used to purge entries for requests made with WithRefresh or WithPurge
+	admin Admin
+
 	dependentCaches []DependentCache
 
 	requestsMutex sync.Mutex
@@ -65,13 +81,20 @@ var tooltipInternalError = []byte("internal error")
 
 // Returns the number of deleted tooltip entries
 func clearOldTooltips(ctx context.Context, pool db.Pool) (int, error) {
-	log := logger.FromContext(ctx)
+	const query = "DELETE FROM cache WHERE now() > cached_until AND (stale_until IS NULL OR now() > stale_until) RETURNING key;"
 
-	const query = "DELETE FROM cache WHERE now() > cached_until RETURNING key;"
+	return deleteTooltips(ctx, pool, query)
+}
+
+// deleteTooltips runs the query deleting tooltips from the cache, and deletes the dependent values
+// of the deleted tooltips. The query must return the deleted keys.
+// Returns the number of deleted tooltip entries.
+func deleteTooltips(ctx context.Context, pool db.Pool, query string, args ...any) (int, error) {
+	log := logger.FromContext(ctx)
 
-	rows, err := pool.Query(ctx, query)
+	rows, err := pool.Query(ctx, query, args...)
 	if err != nil {
-		log.Errorw("Error deleting old tooltips from cache",
+		log.Errorw("Error deleting tooltips from cache",
 			"error", err,
 		)
 		return -1, err
@@ -144,8 +167,10 @@ func (c *PostgreSQLCache) load(ctx context.Context, key string, r *http.Request)
 		return nil, err
 	}
 
+	dur = c.policy.loadedDuration(ctx, *statusCode, *contentType, payload, dur)
+
 	cacheKey := c.keyProvider.CacheKey(ctx, key)
-	if _, err := c.pool.Exec(ctx, "INSERT INTO cache (key, value, http_status_code, http_content_type, cached_until) VALUES ($1, $2, $3, $4, $5)", cacheKey, payload, *statusCode, *contentType, time.Now().Add(dur)); err != nil {
+	if err := c.insert(ctx, cacheKey, payload, *statusCode, *contentType, dur); err != nil {
 		log.Errorw("Error inserting tooltip into cache",
 			"cacheKey", cacheKey,
 			"key", key,
@@ -163,49 +188,69 @@ func (c *PostgreSQLCache) load(ctx context.Context, key string, r *http.Request)
 	}, nil
 }
 
-func (c *PostgreSQLCache) loadFromDatabase(ctx context.Context, cacheKey string) (*Response, error) {
+func (c *PostgreSQLCache) insert(ctx context.Context, cacheKey string, payload []byte, statusCode int, contentType string, dur time.Duration) error {
+	cachedUntil := time.Now().Add(dur)
+
+	if c.staleDuration == 0 {
+		// An expired entry may still be in the cache if it was stored while the prefix had a stale duration
+		_, err := c.pool.Exec(ctx, "INSERT INTO cache (key, value, http_status_code, http_content_type, cached_until) VALUES ($1, $2, $3, $4, $5) "+
+			"ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, http_status_code = EXCLUDED.http_status_code, http_content_type = EXCLUDED.http_content_type, created_on = EXCLUDED.created_on, cached_until = EXCLUDED.cached_until, stale_until = NULL",
+			cacheKey, payload, statusCode, contentType, cachedUntil)
+		return err
+	}
+
+	// The stale entry is still in the cache when it's being refreshed, so it's replaced
+	_, err := c.pool.Exec(ctx, "INSERT INTO cache (key, value, http_status_code, http_content_type, cached_until, stale_until) VALUES ($1, $2, $3, $4, $5, $6) "+
+		"ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, http_status_code = EXCLUDED.http_status_code, http_content_type = EXCLUDED.http_content_type, created_on = EXCLUDED.created_on, cached_until = EXCLUDED.cached_until, stale_until = EXCLUDED.stale_until",
+		cacheKey, payload, statusCode, contentType, cachedUntil, cachedUntil.Add(c.staleDuration))
+	return err
+}
+
+// Returns the cached response, and whether it has expired and should be refreshed
+func (c *PostgreSQLCache) loadFromDatabase(ctx context.Context, cacheKey string) (*Response, bool, error) {
 	var response Response
-	err := c.pool.QueryRow(ctx, "SELECT value, http_status_code, http_content_type FROM cache WHERE key=$1", cacheKey).Scan(&response.Payload, &response.StatusCode, &response.ContentType)
+	var err error
+	stale := false
+
+	if c.staleDuration == 0 {
+		// Expired entries stored while the prefix had a stale duration are kept around until their
+		// stale_until, but they mustn't be served anymore
+		err = c.pool.QueryRow(ctx, "SELECT value, http_status_code, http_content_type FROM cache WHERE key=$1 AND (stale_until IS NULL OR now() <= cached_until)", cacheKey).Scan(&response.Payload, &response.StatusCode, &response.ContentType)
+	} else {
+		err = c.pool.QueryRow(ctx, "SELECT value, http_status_code, http_content_type, now() > cached_until FROM cache WHERE key=$1", cacheKey).Scan(&response.Payload, &response.StatusCode, &response.ContentType, &stale)
+	}
 	if err == nil {
-		return &response, nil
+		return &response, stale, nil
 	}
 
 	if err != pgx.ErrNoRows {
-		return nil, err
+		return nil, false, err
 	}
 
-	return nil, nil
+	return nil, false, nil
 }
 
-func (c *PostgreSQLCache) Get(ctx context.Context, key string, r *http.Request) (*Response, error) {
-	log := logger.FromContext(ctx)
-	cacheKey := c.keyProvider.CacheKey(ctx, key)
+type staleRefreshKey struct{}
 
-	cacheResponse, err := c.loadFromDatabase(ctx, cacheKey)
-	if err != nil {
-		log.Warnw("Unhandled sql error", "error", err)
-		tooltipInternalError := Response{
-			Payload:     []byte(`{"status":500,"message":"Internal server error (PSQL) loading thumbnail"}`),
-			StatusCode:  500,
-			ContentType: "application/json",
-		}
-		return &tooltipInternalError, err
-	} else if cacheResponse != nil {
-		cacheHits.Inc()
-		log.Debugw("DB Get cache hit", "cacheKey", cacheKey)
-		return cacheResponse, nil
-	}
+// IsStaleRefresh returns whether the value is being loaded to refresh an expired entry that was
+// served from the cache. Loaders may return an error instead of a degraded response then, so the
+// expired entry is kept.
+func IsStaleRefresh(ctx context.Context) bool {
+	refresh, _ := ctx.Value(staleRefreshKey{}).(bool)
+	return refresh
+}
 
-	// If key is not in cache, sign up as a listener and ensure loader is only called once
-	cacheMisses.Inc()
-	log.Debugw("DB Get cache miss", "cacheKey", cacheKey)
-	responseChannel := make(chan wrappedResponse)
+// subscribe adds the channel as a listener for the result of loading the key, and starts loading
+// it unless it's already being loaded. Loads are shared per cache key, so requests for different
+// languages of the same key are loaded separately.
+func (c *PostgreSQLCache) subscribe(ctx context.Context, key string, r *http.Request, responseChannel chan wrappedResponse) {
+	cacheKey := c.keyProvider.CacheKey(ctx, key)
 
 	c.requestsMutex.Lock()
 
-	c.requests[key] = append(c.requests[key], responseChannel)
+	c.requests[cacheKey] = append(c.requests[cacheKey], responseChannel)
 
-	first := len(c.requests[key]) == 1
+	first := len(c.requests[cacheKey]) == 1
 
 	c.requestsMutex.Unlock()
 
@@ -218,13 +263,53 @@ func (c *PostgreSQLCache) Get(ctx context.Context, key string, r *http.Request)
 				err,
 			}
 			c.requestsMutex.Lock()
-			for _, ch := range c.requests[key] {
+			for _, ch := range c.requests[cacheKey] {
 				ch <- r
 			}
-			delete(c.requests, key)
+			delete(c.requests, cacheKey)
 			c.requestsMutex.Unlock()
 		}()
 	}
+}
+
+func (c *PostgreSQLCache) Get(ctx context.Context, key string, r *http.Request) (*Response, error) {
+	log := logger.FromContext(ctx)
+	cacheKey := c.keyProvider.CacheKey(ctx, key)
+
+	if done, err := purgeForAdmin(ctx, c.admin, c.keyProvider, key); done {
+		return nil, err
+	}
+
+	cacheResponse, stale, err := c.loadFromDatabase(ctx, cacheKey)
+	if err != nil {
+		log.Warnw("Unhandled sql error", "error", err)
+		tooltipInternalError := Response{
+			Payload:     []byte(`{"status":500,"message":"Internal server error (PSQL) loading thumbnail"}`),
+			StatusCode:  500,
+			ContentType: "application/json",
+		}
+		return &tooltipInternalError, err
+	} else if cacheResponse != nil {
+		cacheHits.Inc()
+		if stale {
+			// Serve the expired entry right away and refresh it in the background. The buffered channel
+			// makes sure the loader never waits on us.
+			staleHits.Inc()
+			log.Debugw("DB Get stale cache hit, refreshing", "cacheKey", cacheKey)
+			refreshCtx := context.WithValue(context.WithoutCancel(ctx), staleRefreshKey{}, true)
+			c.subscribe(refreshCtx, key, r, make(chan wrappedResponse, 1))
+		} else {
+			log.Debugw("DB Get cache hit", "cacheKey", cacheKey)
+		}
+		return cacheResponse, nil
+	}
+
+	// If key is not in cache, sign up as a listener and ensure loader is only called once
+	cacheMisses.Inc()
+	log.Debugw("DB Get cache miss", "cacheKey", cacheKey)
+	responseChannel := make(chan wrappedResponse)
+
+	c.subscribe(ctx, key, r, responseChannel)
 
 	// Wait for loader to complete, then return value from loader
 	response := <-responseChannel
@@ -235,7 +320,8 @@ func (c *PostgreSQLCache) GetOnly(ctx context.Context, key string) *Response {
 	log := logger.FromContext(ctx)
 	cacheKey := c.keyProvider.CacheKey(ctx, key)
 
-	value, err := c.loadFromDatabase(ctx, cacheKey)
+	// Stale entries are fine here, refreshing them is up to Get
+	value, _, err := c.loadFromDatabase(ctx, cacheKey)
 	if err != nil {
 		log.Warnw("Unhandled sql error", "error", err)
 		return nil
@@ -312,13 +398,26 @@ func (c *PostgreSQLCache) rollbackDependents(ctx context.Context, key string) er
 	return nil
 }
 
+// staleDurationFor returns how long stale entries may be served for the cache using the given key
+// provider, as configured in cache-stale-durations
+func staleDurationFor(cfg config.APIConfig, keyProvider KeyProvider) time.Duration {
+	if prefix, ok := keyPrefix(keyProvider); ok {
+		return cfg.CacheStaleDurations[prefix]
+	}
+
+	return 0
+}
+
 func NewPostgreSQLCache(ctx context.Context, cfg config.APIConfig, pool db.Pool, keyProvider KeyProvider, loader Loader, cacheDuration time.Duration) *PostgreSQLCache {
 	// Create connection pool if it's not already initialized
 	return &PostgreSQLCache{
 		keyProvider:   keyProvider,
 		loader:        loader,
 		cacheDuration: cacheDuration,
+		policy:        newCachePolicy(cfg, keyProvider),
+		staleDuration: staleDurationFor(cfg, keyProvider),
 		pool:          pool,
+		admin:         NewPostgreSQLAdmin(pool),
 		requests:      make(map[string][]chan wrappedResponse),
 	}
 }
@@ -449,3 +548,71 @@ func NewPostgreSQLDependentCache(
 }
 
 var _ DependentCache = (*PostgreSQLDependentCache)(nil)
+
+type PostgreSQLAdmin struct {
+	pool db.Pool
+}
+
+func (a *PostgreSQLAdmin) Inspect(ctx context.Context, cacheKey string) (*Entry, error) {
+	entry := Entry{
+		Key: cacheKey,
+	}
+
+	err := a.pool.QueryRow(ctx,
+		"SELECT value, http_status_code, http_content_type, created_on, cached_until FROM cache WHERE key=$1",
+		cacheKey,
+	).Scan(&entry.Payload, &entry.StatusCode, &entry.ContentType, &entry.CreatedOn, &entry.CachedUntil)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entry.Size = len(entry.Payload)
+	return &entry, nil
+}
+
+func (a *PostgreSQLAdmin) Purge(ctx context.Context, cacheKeys ...string) (int, error) {
+	return deleteTooltips(ctx, a.pool, "DELETE FROM cache WHERE key = ANY($1) RETURNING key;", cacheKeys)
+}
+
+func (a *PostgreSQLAdmin) PurgePrefix(ctx context.Context, prefix string) (int, error) {
+	// Not using LIKE, so prefixes containing % or _ are matched as they are
+	return deleteTooltips(ctx, a.pool, "DELETE FROM cache WHERE left(key, length($1)) = $1 RETURNING key;", prefix+":")
+}
+
+func (a *PostgreSQLAdmin) List(ctx context.Context, order ListOrder, limit int) ([]Entry, error) {
+	orderBy := "length(value) DESC"
+	if order == ListRecent {
+		orderBy = "created_on DESC"
+	}
+
+	rows, err := a.pool.Query(ctx,
+		"SELECT key, length(value), http_status_code, http_content_type, created_on, cached_until FROM cache ORDER BY "+orderBy+" LIMIT $1",
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []Entry{}
+	for rows.Next() {
+		var entry Entry
+		if err := rows.Scan(&entry.Key, &entry.Size, &entry.StatusCode, &entry.ContentType, &entry.CreatedOn, &entry.CachedUntil); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func NewPostgreSQLAdmin(pool db.Pool) *PostgreSQLAdmin {
+	return &PostgreSQLAdmin{
+		pool: pool,
+	}
+}
+
+var _ Admin = (*PostgreSQLAdmin)(nil)
@@ -1,9 +1,125 @@
 package cache
 
 import (
+	"context"
 	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/config"
+	qt "github.com/frankban/quicktest"
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
 )
 
 func TestCacheClearer(t *testing.T) {
 	// TODO
 }
+
+func TestPostgreSQLCacheStale(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		CacheStaleDurations: map[string]time.Duration{
+			"test:stale": time.Hour,
+		},
+	}
+
+	c.Run("Stale duration is looked up by prefix", func(c *qt.C) {
+		c.Assert(staleDurationFor(cfg, NewPrefixKeyProvider("test:stale")), qt.Equals, time.Hour)
+		c.Assert(staleDurationFor(cfg, NewPrefixKeyProvider("test:fresh")), qt.Equals, time.Duration(0))
+	})
+
+	c.Run("Fresh entry", func(c *qt.C) {
+		pool, err := pgxmock.NewPool()
+		c.Assert(err, qt.IsNil)
+		loader := &testLoader{payload: []byte("new")}
+		cache := NewPostgreSQLCache(ctx, cfg, pool, NewPrefixKeyProvider("test:stale"), loader, time.Minute)
+
+		rows := pgxmock.NewRows([]string{"value", "http_status_code", "http_content_type", "stale"}).AddRow([]byte("old"), 200, "application/json", false)
+		pool.ExpectQuery("SELECT").WithArgs("test:stale:a").WillReturnRows(rows)
+
+		response, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Payload, qt.DeepEquals, []byte("old"))
+		c.Assert(loader.calls.Load(), qt.Equals, int32(0))
+		c.Assert(pool.ExpectationsWereMet(), qt.IsNil)
+	})
+
+	c.Run("Stale entry is served and refreshed", func(c *qt.C) {
+		pool, err := pgxmock.NewPool()
+		c.Assert(err, qt.IsNil)
+		refreshed := make(chan struct{})
+		loader := &testLoader{payload: []byte("new")}
+		cache := NewPostgreSQLCache(ctx, cfg, pool, NewPrefixKeyProvider("test:stale"), loader, time.Minute)
+
+		rows := pgxmock.NewRows([]string{"value", "http_status_code", "http_content_type", "stale"}).AddRow([]byte("old"), 200, "application/json", true)
+		pool.ExpectQuery("SELECT").WithArgs("test:stale:a").WillReturnRows(rows)
+		pool.ExpectExec("INSERT INTO cache .* ON CONFLICT \\(key\\) DO UPDATE").
+			WithArgs("test:stale:a", []byte("new"), 200, "application/json", pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		loader.onLoad = func(ctx context.Context, key string) {
+			c.Check(IsStaleRefresh(ctx), qt.IsTrue)
+			close(refreshed)
+		}
+
+		response, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Payload, qt.DeepEquals, []byte("old"))
+
+		<-refreshed
+		c.Assert(waitForExpectations(pool), qt.IsNil)
+		c.Assert(loader.calls.Load(), qt.Equals, int32(1))
+	})
+
+	c.Run("Missing entry is loaded", func(c *qt.C) {
+		pool, err := pgxmock.NewPool()
+		c.Assert(err, qt.IsNil)
+		loader := &testLoader{payload: []byte("new")}
+		cache := NewPostgreSQLCache(ctx, cfg, pool, NewPrefixKeyProvider("test:stale"), loader, time.Minute)
+
+		pool.ExpectQuery("SELECT").WithArgs("test:stale:a").WillReturnError(pgx.ErrNoRows)
+		pool.ExpectExec("INSERT INTO cache").
+			WithArgs("test:stale:a", []byte("new"), 200, "application/json", pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		loader.onLoad = func(ctx context.Context, key string) {
+			c.Check(IsStaleRefresh(ctx), qt.IsFalse)
+		}
+
+		response, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Payload, qt.DeepEquals, []byte("new"))
+		c.Assert(pool.ExpectationsWereMet(), qt.IsNil)
+	})
+
+	c.Run("Stale entries are ignored without a stale duration", func(c *qt.C) {
+		pool, err := pgxmock.NewPool()
+		c.Assert(err, qt.IsNil)
+		loader := &testLoader{payload: []byte("new")}
+		cache := NewPostgreSQLCache(ctx, cfg, pool, NewPrefixKeyProvider("test:fresh"), loader, time.Minute)
+
+		pool.ExpectQuery("SELECT .* AND \\(stale_until IS NULL OR now\\(\\) <= cached_until\\)").WithArgs("test:fresh:a").WillReturnError(pgx.ErrNoRows)
+		pool.ExpectExec("INSERT INTO cache .* ON CONFLICT \\(key\\) DO UPDATE .* stale_until = NULL").
+			WithArgs("test:fresh:a", []byte("new"), 200, "application/json", pgxmock.AnyArg()).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		response, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Payload, qt.DeepEquals, []byte("new"))
+		c.Assert(loader.calls.Load(), qt.Equals, int32(1))
+		c.Assert(pool.ExpectationsWereMet(), qt.IsNil)
+	})
+}
+
+// waitForExpectations gives background work a moment to run the expected queries
+func waitForExpectations(pool pgxmock.PgxPoolIface) error {
+	var err error
+	for range 50 {
+		if err = pool.ExpectationsWereMet(); err == nil {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return err
+}
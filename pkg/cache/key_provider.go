@@ -1,6 +1,10 @@
 package cache
 
-import "context"
+import (
+	"context"
+
+	"github.com/Chatterino/api/pkg/i18n"
+)
 
 type KeyProvider interface {
 	// Returns the name of the cache key generated for the query
@@ -20,3 +24,29 @@ func NewPrefixKeyProvider(prefix string) *PrefixKeyProvider {
 func (p *PrefixKeyProvider) CacheKey(ctx context.Context, query string) string {
 	return p.prefix + ":" + query
 }
+
+func (p *PrefixKeyProvider) Prefix() string {
+	return p.prefix
+}
+
+// LocalizedKeyProvider stores responses per language, for loaders whose responses depend on the
+// language of the client (see i18n.FromContext).
+// Keys of English responses match the ones generated by PrefixKeyProvider.
+type LocalizedKeyProvider struct {
+	*PrefixKeyProvider
+}
+
+func NewLocalizedKeyProvider(prefix string) *LocalizedKeyProvider {
+	return &LocalizedKeyProvider{
+		PrefixKeyProvider: NewPrefixKeyProvider(prefix),
+	}
+}
+
+func (p *LocalizedKeyProvider) CacheKey(ctx context.Context, query string) string {
+	lang := i18n.FromContext(ctx)
+	if lang == i18n.English {
+		return p.PrefixKeyProvider.CacheKey(ctx, query)
+	}
+
+	return p.prefix + ":" + lang.String() + ":" + query
+}
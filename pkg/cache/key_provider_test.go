@@ -0,0 +1,24 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Chatterino/api/pkg/i18n"
+	qt "github.com/frankban/quicktest"
+)
+
+func TestLocalizedKeyProvider(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	kp := NewLocalizedKeyProvider("youtube:video")
+
+	// English keys match the ones of the prefix key provider, so existing cache entries are kept
+	c.Assert(kp.CacheKey(ctx, "abc"), qt.Equals, NewPrefixKeyProvider("youtube:video").CacheKey(ctx, "abc"))
+	c.Assert(kp.CacheKey(i18n.OnContext(ctx, i18n.English), "abc"), qt.Equals, "youtube:video:abc")
+	c.Assert(kp.CacheKey(i18n.OnContext(ctx, i18n.German), "abc"), qt.Equals, "youtube:video:de:abc")
+
+	prefix, ok := keyPrefix(kp)
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(prefix, qt.Equals, "youtube:video")
+}
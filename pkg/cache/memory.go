@@ -3,7 +3,6 @@ package cache
 import (
 	"context"
 	"errors"
-	"fmt"
 	"net/http"
 	"sync"
 	"time"
@@ -15,47 +14,92 @@ import (
 
 var kvCache *pCache.Cache
 
+var (
+	memoryDependentCachesMutex sync.Mutex
+	memoryDependentCaches      []*MemoryDependentCache
+)
+
 func init() {
 	kvCache = pCache.New(30*time.Minute, 10*time.Minute)
+
+	// When a parent value expires, its dependent values must go with it
+	kvCache.OnEvicted(func(cacheKey string, _ any) {
+		memoryDependentCachesMutex.Lock()
+		defer memoryDependentCachesMutex.Unlock()
+
+		for _, dependent := range memoryDependentCaches {
+			dependent.removeParent(cacheKey)
+		}
+	})
+}
+
+// memoryEntry is the value stored in kvCache
+type memoryEntry struct {
+	Response
+	createdOn time.Time
 }
 
 type MemoryCache struct {
 	loader Loader
 
 	requestsMutex sync.Mutex
-	requests      map[string][]chan Response
+	requests      map[string][]chan wrappedResponse
 
 	cacheDuration time.Duration
 
+	policy cachePolicy
+
 	keyProvider KeyProvider
+
+	// Used to purge entries for requests made with WithRefresh or WithPurge
+	admin Admin
+
+	dependentCaches []DependentCache
 }
 
-func (*MemoryCache) RegisterDependent(ctx context.Context, dependent DependentCache) {
-	// Required for Cache interface
-	panic("DependentCache management is unimplemented for MemoryCache")
+func (c *MemoryCache) RegisterDependent(ctx context.Context, dependent DependentCache) {
+	c.dependentCaches = append(c.dependentCaches, dependent)
 }
 
-func (*MemoryCache) commitDependents(ctx context.Context, key string) error {
-	// Required for Cache interface
-	panic("DependentCache management is unimplemented for MemoryCache")
+func (c *MemoryCache) commitDependents(ctx context.Context, key string) error {
+	parentKey := c.keyProvider.CacheKey(ctx, key)
+
+	for _, dependent := range c.dependentCaches {
+		err := dependent.commit(ctx, parentKey)
+		if err != nil {
+			continue
+		}
+	}
+
+	return nil
 }
 
-func (*MemoryCache) rollbackDependents(ctx context.Context, key string) error {
-	// Required for Cache interface
-	panic("DependentCache management is unimplemented for MemoryCache")
+func (c *MemoryCache) rollbackDependents(ctx context.Context, key string) error {
+	parentKey := c.keyProvider.CacheKey(ctx, key)
+
+	for _, dependent := range c.dependentCaches {
+		err := dependent.rollback(ctx, parentKey)
+		if err != nil {
+			continue
+		}
+	}
+
+	return nil
 }
 
-func (c *MemoryCache) load(ctx context.Context, key string, r *http.Request) {
+func (c *MemoryCache) load(ctx context.Context, key string, r *http.Request) (*Response, error) {
 	log := logger.FromContext(ctx)
 
 	payload, statusCode, contentType, overrideDuration, err := c.loader.Load(ctx, key, r)
+	// If the parent cannot be inserted into the cache, rollback the dependents
+	defer c.rollbackDependents(ctx, key)
 
 	if statusCode == nil {
-		log.Warnw("Missing status code, setting to 200 default")
+		log.Debugw("Missing status code, setting to 200 default")
 		statusCode = &defaultStatusCode
 	}
 	if contentType == nil {
-		log.Warnw("Missing content type, setting to application/json default")
+		log.Debugw("Missing content type, setting to application/json default")
 		contentType = &defaultContentType
 	}
 
@@ -64,61 +108,78 @@ func (c *MemoryCache) load(ctx context.Context, key string, r *http.Request) {
 		dur = overrideDuration
 	}
 
+	if err != nil {
+		return nil, err
+	}
+
+	dur = c.policy.loadedDuration(ctx, *statusCode, *contentType, payload, dur)
+
 	response := Response{
 		Payload:     payload,
 		StatusCode:  *statusCode,
 		ContentType: *contentType,
 	}
 
-	// Cache it
-	if err == nil {
-		cacheKey := c.keyProvider.CacheKey(ctx, key)
-		kvCache.Set(cacheKey, response, dur)
-	} else {
-		fmt.Println("Error when some load function was called:", err)
-	}
+	cacheKey := c.keyProvider.CacheKey(ctx, key)
+	kvCache.Set(cacheKey, memoryEntry{response, time.Now()}, dur)
 
-	c.requestsMutex.Lock()
-	for _, ch := range c.requests[key] {
-		ch <- response
-	}
-	delete(c.requests, key)
-	c.requestsMutex.Unlock()
+	// Parent entry was inserted correctly, commit the dependents to prevent them from being rolled
+	// back
+	c.commitDependents(ctx, key)
+
+	return &response, nil
 }
 
 func (c *MemoryCache) Get(ctx context.Context, key string, r *http.Request) (*Response, error) {
 	log := logger.FromContext(ctx)
 	cacheKey := c.keyProvider.CacheKey(ctx, key)
 
+	if done, err := purgeForAdmin(ctx, c.admin, c.keyProvider, key); done {
+		return nil, err
+	}
+
 	// If key is in cache, return value
 	if value, found := kvCache.Get(cacheKey); found && value != nil {
 		log.Debugw("Memory Get cache hit", "cacheKey", cacheKey)
-		if response, ok := value.(Response); ok {
-			return &response, nil
+		if entry, ok := value.(memoryEntry); ok {
+			return &entry.Response, nil
 		}
 
 		return nil, errors.New("error getting stuff from kvcache")
 	}
 
-	responseChannel := make(chan Response)
+	// If key is not in cache, sign up as a listener and ensure loader is only called once
+	log.Debugw("Memory Get cache miss", "cacheKey", cacheKey)
+	responseChannel := make(chan wrappedResponse)
 
 	c.requestsMutex.Lock()
 
-	c.requests[key] = append(c.requests[key], responseChannel)
+	c.requests[cacheKey] = append(c.requests[cacheKey], responseChannel)
 
-	first := len(c.requests[key]) == 1
+	first := len(c.requests[cacheKey]) == 1
 
 	c.requestsMutex.Unlock()
 
 	if first {
-		log.Debugw("Memory Get cache miss", "cacheKey", cacheKey)
-		go c.load(ctx, key, r)
+		go func() {
+			response, err := c.load(ctx, key, r)
+
+			r := wrappedResponse{
+				response,
+				err,
+			}
+			c.requestsMutex.Lock()
+			for _, ch := range c.requests[cacheKey] {
+				ch <- r
+			}
+			delete(c.requests, cacheKey)
+			c.requestsMutex.Unlock()
+		}()
 	}
 
-	// If key is not in cache, sign up as a listener and ensure loader is only called once
 	// Wait for loader to complete, then return value from loader
 	response := <-responseChannel
-	return &response, nil
+	return response.response, response.err
 }
 
 func (c *MemoryCache) GetOnly(ctx context.Context, key string) *Response {
@@ -127,8 +188,8 @@ func (c *MemoryCache) GetOnly(ctx context.Context, key string) *Response {
 
 	if value, _ := kvCache.Get(cacheKey); value != nil {
 		log.Debugw("Memory GetOnly cache hit", "cacheKey", cacheKey)
-		if response, ok := value.(Response); ok {
-			return &response
+		if entry, ok := value.(memoryEntry); ok {
+			return &entry.Response
 		}
 
 		log.Debugw("Memory GetOnly cache type mismatch", "cacheKey", cacheKey)
@@ -143,9 +204,205 @@ func NewMemoryCache(cfg config.APIConfig, keyProvider KeyProvider, loader Loader
 	return &MemoryCache{
 		keyProvider:   keyProvider,
 		loader:        loader,
-		requests:      make(map[string][]chan Response),
+		requests:      make(map[string][]chan wrappedResponse),
 		cacheDuration: cacheDuration,
+		policy:        newCachePolicy(cfg, keyProvider),
+		admin:         NewMemoryAdmin(),
 	}
 }
 
 var _ Cache = (*MemoryCache)(nil)
+
+// MemoryAdmin manages the entries of the in-memory cache shared by all memory caches
+type MemoryAdmin struct{}
+
+func (a *MemoryAdmin) entry(cacheKey string, item pCache.Item) (Entry, bool) {
+	value, ok := item.Object.(memoryEntry)
+	if !ok {
+		return Entry{}, false
+	}
+
+	entry := Entry{
+		Key:         cacheKey,
+		Size:        len(value.Payload),
+		StatusCode:  value.StatusCode,
+		ContentType: value.ContentType,
+		CreatedOn:   value.createdOn,
+	}
+	if item.Expiration > 0 {
+		entry.CachedUntil = time.Unix(0, item.Expiration)
+	}
+
+	return entry, true
+}
+
+func (a *MemoryAdmin) Inspect(ctx context.Context, cacheKey string) (*Entry, error) {
+	value, expiration, found := kvCache.GetWithExpiration(cacheKey)
+	if !found {
+		return nil, nil
+	}
+
+	entry, ok := a.entry(cacheKey, pCache.Item{Object: value, Expiration: expiration.UnixNano()})
+	if !ok {
+		return nil, errors.New("unexpected value type in kvcache")
+	}
+
+	entry.Payload = value.(memoryEntry).Payload
+	return &entry, nil
+}
+
+// Dependent values are deleted by the eviction handler of kvCache
+func (a *MemoryAdmin) Purge(ctx context.Context, cacheKeys ...string) (int, error) {
+	deleted := 0
+	for _, cacheKey := range cacheKeys {
+		if _, found := kvCache.Get(cacheKey); found {
+			kvCache.Delete(cacheKey)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+func (a *MemoryAdmin) PurgePrefix(ctx context.Context, prefix string) (int, error) {
+	var cacheKeys []string
+	for cacheKey := range kvCache.Items() {
+		if hasKeyPrefix(cacheKey, prefix) {
+			cacheKeys = append(cacheKeys, cacheKey)
+		}
+	}
+
+	return a.Purge(ctx, cacheKeys...)
+}
+
+func (a *MemoryAdmin) List(ctx context.Context, order ListOrder, limit int) ([]Entry, error) {
+	entries := []Entry{}
+	for cacheKey, item := range kvCache.Items() {
+		if entry, ok := a.entry(cacheKey, item); ok {
+			entries = append(entries, entry)
+		}
+	}
+
+	return sortEntries(entries, order, limit), nil
+}
+
+func NewMemoryAdmin() *MemoryAdmin {
+	return &MemoryAdmin{}
+}
+
+var _ Admin = (*MemoryAdmin)(nil)
+
+type memoryDependentValue struct {
+	value       []byte
+	contentType string
+}
+
+type MemoryDependentCache struct {
+	keyProvider KeyProvider
+
+	values *pCache.Cache
+
+	// Maps parent keys to the cache keys of their dependent values, and whether each value has been
+	// committed yet
+	childrenMutex sync.Mutex
+	children      map[string]map[string]bool
+}
+
+func (c *MemoryDependentCache) Get(ctx context.Context, key string) ([]byte, string, error) {
+	cacheKey := c.keyProvider.CacheKey(ctx, key)
+
+	value, found := c.values.Get(cacheKey)
+	if !found {
+		// Cache entry didn't exist
+		return nil, "", nil
+	}
+
+	dependentValue, ok := value.(memoryDependentValue)
+	if !ok {
+		return nil, "", errors.New("error getting dependent value from kvcache")
+	}
+
+	return dependentValue.value, dependentValue.contentType, nil
+}
+
+func (c *MemoryDependentCache) Insert(
+	ctx context.Context, key string, parentKey string, value []byte, contentType string,
+) error {
+	cacheKey := c.keyProvider.CacheKey(ctx, key)
+
+	c.childrenMutex.Lock()
+	defer c.childrenMutex.Unlock()
+
+	if _, found := c.values.Get(cacheKey); found {
+		return errors.New("dependent value already exists")
+	}
+
+	c.values.Set(cacheKey, memoryDependentValue{
+		value:       value,
+		contentType: contentType,
+	}, dependentExpirationDuration)
+
+	if c.children[parentKey] == nil {
+		c.children[parentKey] = make(map[string]bool)
+	}
+	c.children[parentKey][cacheKey] = false
+
+	return nil
+}
+
+func (c *MemoryDependentCache) commit(ctx context.Context, parentKey string) error {
+	c.childrenMutex.Lock()
+	defer c.childrenMutex.Unlock()
+
+	for cacheKey := range c.children[parentKey] {
+		c.children[parentKey][cacheKey] = true
+	}
+
+	return nil
+}
+
+func (c *MemoryDependentCache) rollback(ctx context.Context, parentKey string) error {
+	c.childrenMutex.Lock()
+	defer c.childrenMutex.Unlock()
+
+	for cacheKey, committed := range c.children[parentKey] {
+		if !committed {
+			c.values.Delete(cacheKey)
+			delete(c.children[parentKey], cacheKey)
+		}
+	}
+
+	if len(c.children[parentKey]) == 0 {
+		delete(c.children, parentKey)
+	}
+
+	return nil
+}
+
+// removeParent deletes all dependent values that belong to the given parent key
+func (c *MemoryDependentCache) removeParent(parentKey string) {
+	c.childrenMutex.Lock()
+	defer c.childrenMutex.Unlock()
+
+	for cacheKey := range c.children[parentKey] {
+		c.values.Delete(cacheKey)
+	}
+
+	delete(c.children, parentKey)
+}
+
+func NewMemoryDependentCache(ctx context.Context, cfg config.APIConfig, keyProvider KeyProvider) *MemoryDependentCache {
+	c := &MemoryDependentCache{
+		keyProvider: keyProvider,
+		values:      pCache.New(dependentExpirationDuration, 10*time.Minute),
+		children:    make(map[string]map[string]bool),
+	}
+
+	memoryDependentCachesMutex.Lock()
+	memoryDependentCaches = append(memoryDependentCaches, c)
+	memoryDependentCachesMutex.Unlock()
+
+	return c
+}
+
+var _ DependentCache = (*MemoryDependentCache)(nil)
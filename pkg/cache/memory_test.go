@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/Chatterino/api/pkg/i18n"
+	qt "github.com/frankban/quicktest"
+	"golang.org/x/text/language"
+)
+
+type testLoader struct {
+	calls atomic.Int32
+
+	payload []byte
+	err     error
+
+	// Called from within Load, allowing dependent values to be inserted while loading
+	onLoad func(ctx context.Context, key string)
+}
+
+func (l *testLoader) Load(ctx context.Context, key string, r *http.Request) ([]byte, *int, *string, time.Duration, error) {
+	l.calls.Add(1)
+	if l.onLoad != nil {
+		l.onLoad(ctx, key)
+	}
+	if l.err != nil {
+		return nil, nil, nil, NoSpecialDur, l.err
+	}
+	return l.payload, nil, nil, NoSpecialDur, nil
+}
+
+func TestMemoryCache(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+	cfg := config.APIConfig{}
+
+	// The memory cache is global, make sure values from previous runs don't leak into this one
+	kvCache.Flush()
+
+	c.Run("Get loads once and caches", func(c *qt.C) {
+		loader := &testLoader{payload: []byte("hello")}
+		cache := NewMemoryCache(cfg, NewPrefixKeyProvider("test:memory:get"), loader, time.Minute)
+
+		c.Assert(cache.GetOnly(ctx, "a"), qt.IsNil)
+
+		response, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Payload, qt.DeepEquals, []byte("hello"))
+		c.Assert(response.StatusCode, qt.Equals, defaultStatusCode)
+		c.Assert(response.ContentType, qt.Equals, defaultContentType)
+
+		response, err = cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Payload, qt.DeepEquals, []byte("hello"))
+		c.Assert(loader.calls.Load(), qt.Equals, int32(1))
+
+		c.Assert(cache.GetOnly(ctx, "a"), qt.IsNotNil)
+	})
+
+	c.Run("Loader errors are not cached", func(c *qt.C) {
+		loader := &testLoader{err: errors.New("oops")}
+		cache := NewMemoryCache(cfg, NewPrefixKeyProvider("test:memory:error"), loader, time.Minute)
+
+		_, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.ErrorMatches, "oops")
+		c.Assert(cache.GetOnly(ctx, "a"), qt.IsNil)
+	})
+
+	c.Run("Dependent values are committed with their parent", func(c *qt.C) {
+		dependent := NewMemoryDependentCache(ctx, cfg, NewPrefixKeyProvider("test:memory:dependent"))
+		parentKeyProvider := NewPrefixKeyProvider("test:memory:parent")
+		loader := &testLoader{payload: []byte("parent")}
+		loader.onLoad = func(ctx context.Context, key string) {
+			err := dependent.Insert(ctx, key, parentKeyProvider.CacheKey(ctx, key), []byte("child"), "image/png")
+			c.Assert(err, qt.IsNil)
+		}
+		cache := NewMemoryCache(cfg, parentKeyProvider, loader, time.Minute)
+		cache.RegisterDependent(ctx, dependent)
+
+		_, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+
+		value, contentType, err := dependent.Get(ctx, "a")
+		c.Assert(err, qt.IsNil)
+		c.Assert(value, qt.DeepEquals, []byte("child"))
+		c.Assert(contentType, qt.Equals, "image/png")
+
+		// Evicting the parent removes its dependent values
+		kvCache.Delete(parentKeyProvider.CacheKey(ctx, "a"))
+		value, _, err = dependent.Get(ctx, "a")
+		c.Assert(err, qt.IsNil)
+		c.Assert(value, qt.IsNil)
+	})
+
+	c.Run("Dependent values are rolled back if the parent fails", func(c *qt.C) {
+		dependent := NewMemoryDependentCache(ctx, cfg, NewPrefixKeyProvider("test:memory:dependent"))
+		parentKeyProvider := NewPrefixKeyProvider("test:memory:failingparent")
+		loader := &testLoader{err: errors.New("oops")}
+		loader.onLoad = func(ctx context.Context, key string) {
+			err := dependent.Insert(ctx, key, parentKeyProvider.CacheKey(ctx, key), []byte("child"), "image/png")
+			c.Assert(err, qt.IsNil)
+		}
+		cache := NewMemoryCache(cfg, parentKeyProvider, loader, time.Minute)
+		cache.RegisterDependent(ctx, dependent)
+
+		_, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNotNil)
+
+		value, _, err := dependent.Get(ctx, "a")
+		c.Assert(err, qt.IsNil)
+		c.Assert(value, qt.IsNil)
+	})
+
+	c.Run("Loads are only shared within a language", func(c *qt.C) {
+		started := make(chan struct{}, 2)
+		release := make(chan struct{})
+		loader := &testLoader{payload: []byte("hello")}
+		loader.onLoad = func(ctx context.Context, key string) {
+			started <- struct{}{}
+			<-release
+		}
+		cache := NewMemoryCache(cfg, NewLocalizedKeyProvider("test:memory:localized"), loader, time.Minute)
+
+		done := make(chan error, 2)
+		get := func(lang language.Tag) {
+			_, err := cache.Get(i18n.OnContext(ctx, lang), "a", nil)
+			done <- err
+		}
+
+		go get(i18n.English)
+		<-started
+		go get(i18n.German)
+
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			c.Fatal("German load waited for the English one")
+		}
+
+		close(release)
+		c.Assert(<-done, qt.IsNil)
+		c.Assert(<-done, qt.IsNil)
+		c.Assert(loader.calls.Load(), qt.Equals, int32(2))
+	})
+}
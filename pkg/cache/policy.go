@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Chatterino/api/pkg/config"
+)
+
+type ResponseClass int
+
+const (
+	ResponseClassSuccess ResponseClass = iota
+	// The upstream told us the thing doesn't exist (or that we can't have it). Retrying soon is
+	// unlikely to give a different result.
+	ResponseClassNotFound
+	// Something went wrong that's likely to be fixed by retrying later, e.g. an upstream outage
+	ResponseClassTransientError
+)
+
+func (c ResponseClass) String() string {
+	switch c {
+	case ResponseClassNotFound:
+		return "not-found"
+	case ResponseClassTransientError:
+		return "error"
+	}
+
+	return "success"
+}
+
+// ClassifyResponse classifies a loaded response by its status code.
+// Tooltips are usually served with a 200 status code and carry the upstream status in their
+// payload, so for JSON payloads the "status" field is used instead.
+func ClassifyResponse(statusCode int, contentType string, payload []byte) ResponseClass {
+	if statusCode >= 200 && statusCode < 300 && strings.HasPrefix(contentType, "application/json") {
+		var r struct {
+			Status int `json:"status"`
+		}
+		if err := json.Unmarshal(payload, &r); err == nil && r.Status != 0 {
+			statusCode = r.Status
+		}
+	}
+
+	switch {
+	case statusCode == http.StatusTooManyRequests || statusCode >= 500:
+		return ResponseClassTransientError
+	case statusCode >= 400:
+		return ResponseClassNotFound
+	}
+
+	return ResponseClassSuccess
+}
+
+// keyPrefix returns the prefix used by the key provider, if it has one
+func keyPrefix(keyProvider KeyProvider) (string, bool) {
+	if p, ok := keyProvider.(interface{ Prefix() string }); ok {
+		return p.Prefix(), true
+	}
+
+	return "", false
+}
+
+// cachePolicy decides how long a loaded response is cached for, based on its ResponseClass
+type cachePolicy struct {
+	config.CachePolicy
+}
+
+// duration returns how long the response should be cached for.
+// dur is the duration picked by the loader or the cache, and is used for any class that has no
+// configured duration.
+func (p cachePolicy) duration(statusCode int, contentType string, payload []byte, dur time.Duration) time.Duration {
+	var classDuration time.Duration
+
+	switch ClassifyResponse(statusCode, contentType, payload) {
+	case ResponseClassSuccess:
+		classDuration = p.Success
+	case ResponseClassNotFound:
+		classDuration = p.NotFound
+	case ResponseClassTransientError:
+		classDuration = p.Error
+	}
+
+	if classDuration == 0 {
+		return dur
+	}
+
+	return classDuration
+}
+
+type successDurationKey struct{}
+
+// WithSuccessDuration makes responses successfully loaded with the returned context be cached for
+// dur, regardless of the duration picked by the loader or the cache policies
+func WithSuccessDuration(ctx context.Context, dur time.Duration) context.Context {
+	return context.WithValue(ctx, successDurationKey{}, dur)
+}
+
+// WithDefaultSuccessDuration works like WithSuccessDuration, but keeps the duration if the context
+// already has one
+func WithDefaultSuccessDuration(ctx context.Context, dur time.Duration) context.Context {
+	if _, ok := ctx.Value(successDurationKey{}).(time.Duration); ok {
+		return ctx
+	}
+
+	return WithSuccessDuration(ctx, dur)
+}
+
+// loadedDuration returns how long a freshly loaded response should be cached for, taking the
+// success duration set with WithSuccessDuration into account
+func (p cachePolicy) loadedDuration(ctx context.Context, statusCode int, contentType string, payload []byte, dur time.Duration) time.Duration {
+	if successDur, ok := ctx.Value(successDurationKey{}).(time.Duration); ok {
+		if ClassifyResponse(statusCode, contentType, payload) == ResponseClassSuccess {
+			return successDur
+		}
+	}
+
+	return p.duration(statusCode, contentType, payload, dur)
+}
+
+// newCachePolicy builds the policy for the cache using the given key provider.
+// Durations from cache-policies for the key prefix take priority over the global
+// cache-not-found-duration and cache-error-duration.
+func newCachePolicy(cfg config.APIConfig, keyProvider KeyProvider) cachePolicy {
+	policy := config.CachePolicy{
+		NotFound: cfg.CacheNotFoundDuration,
+		Error:    cfg.CacheErrorDuration,
+	}
+
+	if prefix, ok := keyPrefix(keyProvider); ok {
+		if prefixPolicy, ok := cfg.CachePolicies[prefix]; ok {
+			if prefixPolicy.Success != 0 {
+				policy.Success = prefixPolicy.Success
+			}
+			if prefixPolicy.NotFound != 0 {
+				policy.NotFound = prefixPolicy.NotFound
+			}
+			if prefixPolicy.Error != 0 {
+				policy.Error = prefixPolicy.Error
+			}
+		}
+	}
+
+	return cachePolicy{policy}
+}
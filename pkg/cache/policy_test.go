@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/config"
+	qt "github.com/frankban/quicktest"
+)
+
+func TestClassifyResponse(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		name        string
+		statusCode  int
+		contentType string
+		payload     string
+		expected    ResponseClass
+	}{
+		{
+			name:        "Tooltip",
+			statusCode:  http.StatusOK,
+			contentType: "application/json",
+			payload:     `{"status":200,"tooltip":"xd"}`,
+			expected:    ResponseClassSuccess,
+		},
+		{
+			name:        "Tooltip not found",
+			statusCode:  http.StatusOK,
+			contentType: "application/json",
+			payload:     `{"status":404,"message":"Could not fetch link info: No link info found"}`,
+			expected:    ResponseClassNotFound,
+		},
+		{
+			name:        "Tooltip internal server error",
+			statusCode:  http.StatusOK,
+			contentType: "application/json",
+			payload:     `{"status":500,"message":"Internal server error"}`,
+			expected:    ResponseClassTransientError,
+		},
+		{
+			name:        "Tooltip rate limited",
+			statusCode:  http.StatusOK,
+			contentType: "application/json; charset=utf-8",
+			payload:     `{"status":429,"message":"Too many requests"}`,
+			expected:    ResponseClassTransientError,
+		},
+		{
+			name:        "Thumbnail",
+			statusCode:  http.StatusOK,
+			contentType: "image/png",
+			payload:     "\x89PNG",
+			expected:    ResponseClassSuccess,
+		},
+		{
+			name:        "Thumbnail not found",
+			statusCode:  http.StatusNotFound,
+			contentType: "application/json",
+			payload:     `{"status":404,"message":"Could not fetch thumbnail"}`,
+			expected:    ResponseClassNotFound,
+		},
+		{
+			name:        "Status code takes priority over payload",
+			statusCode:  http.StatusInternalServerError,
+			contentType: "application/json",
+			payload:     `{"status":200}`,
+			expected:    ResponseClassTransientError,
+		},
+		{
+			name:        "Invalid JSON",
+			statusCode:  http.StatusOK,
+			contentType: "application/json",
+			payload:     `xd`,
+			expected:    ResponseClassSuccess,
+		},
+	}
+
+	for _, test := range tests {
+		c.Run(test.name, func(c *qt.C) {
+			c.Assert(ClassifyResponse(test.statusCode, test.contentType, []byte(test.payload)), qt.Equals, test.expected)
+		})
+	}
+}
+
+func TestCachePolicy(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	cfg := config.APIConfig{
+		CacheNotFoundDuration: 0,
+		CacheErrorDuration:    time.Minute,
+		CachePolicies: map[string]config.CachePolicy{
+			"test:policy": {
+				Success:  2 * time.Hour,
+				NotFound: 24 * time.Hour,
+			},
+		},
+	}
+
+	notFound := []byte(`{"status":404}`)
+	internalError := []byte(`{"status":500}`)
+	success := []byte(`{"status":200}`)
+
+	c.Run("Global durations", func(c *qt.C) {
+		policy := newCachePolicy(cfg, NewPrefixKeyProvider("test:other"))
+
+		c.Assert(policy.duration(200, "application/json", success, time.Hour), qt.Equals, time.Hour)
+		c.Assert(policy.duration(200, "application/json", notFound, time.Hour), qt.Equals, time.Hour)
+		c.Assert(policy.duration(200, "application/json", internalError, time.Hour), qt.Equals, time.Minute)
+	})
+
+	c.Run("Prefix durations", func(c *qt.C) {
+		policy := newCachePolicy(cfg, NewPrefixKeyProvider("test:policy"))
+
+		c.Assert(policy.duration(200, "application/json", success, time.Hour), qt.Equals, 2*time.Hour)
+		c.Assert(policy.duration(200, "application/json", notFound, time.Hour), qt.Equals, 24*time.Hour)
+		// Not set for the prefix, falls back to the global duration
+		c.Assert(policy.duration(200, "application/json", internalError, time.Hour), qt.Equals, time.Minute)
+	})
+
+	c.Run("Applied when caching", func(c *qt.C) {
+		loader := &testLoader{payload: internalError}
+		cache := NewMemoryCache(cfg, NewPrefixKeyProvider("test:policy"), loader, time.Hour)
+
+		_, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+
+		_, expiration, found := kvCache.GetWithExpiration("test:policy:a")
+		c.Assert(found, qt.IsTrue)
+		c.Assert(time.Until(expiration) <= time.Minute, qt.IsTrue)
+	})
+	c.Run("Success duration from context", func(c *qt.C) {
+		policy := newCachePolicy(cfg, NewPrefixKeyProvider("test:policy"))
+		ctx := WithSuccessDuration(ctx, 5*time.Minute)
+
+		c.Assert(policy.loadedDuration(ctx, 200, "application/json", success, time.Hour), qt.Equals, 5*time.Minute)
+		c.Assert(policy.loadedDuration(ctx, 200, "application/json", notFound, time.Hour), qt.Equals, 24*time.Hour)
+		c.Assert(policy.loadedDuration(ctx, 200, "application/json", internalError, time.Hour), qt.Equals, time.Minute)
+	})
+	c.Run("Default success duration from context", func(c *qt.C) {
+		policy := newCachePolicy(cfg, NewPrefixKeyProvider("test:policy"))
+
+		c.Assert(policy.loadedDuration(WithDefaultSuccessDuration(ctx, 5*time.Minute), 200, "application/json", success, time.Hour), qt.Equals, 5*time.Minute)
+
+		// A duration already set on the context is kept
+		ctx := WithDefaultSuccessDuration(WithSuccessDuration(ctx, 10*time.Minute), 5*time.Minute)
+		c.Assert(policy.loadedDuration(ctx, 200, "application/json", success, time.Hour), qt.Equals, 10*time.Minute)
+	})
+}
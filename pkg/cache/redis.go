@@ -0,0 +1,736 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// How long a replica may hold the lock for loading a key before other replicas are allowed to
+	// try loading it themselves. This should be longer than the HTTP client timeout.
+	redisLockDuration = 30 * time.Second
+
+	// How often replicas waiting on another replica's load check whether the value has been stored
+	redisLockPollInterval = 50 * time.Millisecond
+)
+
+// releases the lock only if it's still held by us
+var redisUnlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// redisClient is the client shared by all redis caches, created by InitializeRedis
+var redisClient *redis.Client
+
+type redisEntry struct {
+	Payload     []byte    `json:"payload"`
+	StatusCode  int       `json:"status_code"`
+	ContentType string    `json:"content_type"`
+	CreatedOn   time.Time `json:"created_on"`
+}
+
+type redisDependentEntry struct {
+	Value       []byte `json:"value"`
+	ContentType string `json:"content_type"`
+}
+
+// InitializeRedis connects to the Redis server used by the redis cache backend
+func InitializeRedis(ctx context.Context, cfg config.APIConfig) error {
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.CacheRedisAddress,
+		Password: cfg.CacheRedisPassword,
+		DB:       cfg.CacheRedisDB,
+	})
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return err
+	}
+
+	redisClient = client
+	return nil
+}
+
+func ShutdownRedis() {
+	if redisClient != nil {
+		redisClient.Close()
+	}
+}
+
+func mustGetRedisClient(ctx context.Context) *redis.Client {
+	if redisClient == nil {
+		logger.FromContext(ctx).Fatalw("Redis cache backend was requested before being initialized")
+	}
+
+	return redisClient
+}
+
+// redisChildrenKey is the set of committed dependent values that belong to the parent
+func redisChildrenKey(keyPrefix string, parentKey string) string {
+	return keyPrefix + "dependent-children:" + parentKey
+}
+
+func newRedisLockToken() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+type RedisCache struct {
+	loader Loader
+
+	cacheDuration time.Duration
+
+	policy cachePolicy
+
+	keyProvider KeyProvider
+
+	client *redis.Client
+
+	// Prefix added to every key we store in Redis
+	keyPrefix string
+
+	// Used to purge entries for requests made with WithRefresh or WithPurge
+	admin Admin
+
+	dependentCaches []DependentCache
+
+	requestsMutex sync.Mutex
+	requests      map[string][]chan wrappedResponse
+}
+
+func (c *RedisCache) valueKey(cacheKey string) string {
+	return c.keyPrefix + "cache:" + cacheKey
+}
+
+func (c *RedisCache) lockKey(cacheKey string) string {
+	return c.keyPrefix + "lock:" + cacheKey
+}
+
+func (c *RedisCache) load(ctx context.Context, key string, r *http.Request) (*Response, error) {
+	log := logger.FromContext(ctx)
+
+	payload, statusCode, contentType, overrideDuration, err := c.loader.Load(ctx, key, r)
+	// If the parent cannot be inserted into the cache, rollback the dependents
+	defer c.rollbackDependents(ctx, key)
+
+	if statusCode == nil {
+		log.Debugw("Missing status code, setting to 200 default")
+		statusCode = &defaultStatusCode
+	}
+	if contentType == nil {
+		log.Debugw("Missing content type, setting to application/json default")
+		contentType = &defaultContentType
+	}
+
+	dur := c.cacheDuration
+	if overrideDuration != 0 {
+		dur = overrideDuration
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	dur = c.policy.loadedDuration(ctx, *statusCode, *contentType, payload, dur)
+
+	cacheKey := c.keyProvider.CacheKey(ctx, key)
+	entry, err := json.Marshal(redisEntry{
+		Payload:     payload,
+		StatusCode:  *statusCode,
+		ContentType: *contentType,
+		CreatedOn:   time.Now(),
+	})
+	if err == nil {
+		err = c.client.Set(ctx, c.valueKey(cacheKey), entry, dur).Err()
+	}
+	if err != nil {
+		log.Errorw("Error inserting tooltip into cache",
+			"cacheKey", cacheKey,
+			"key", key,
+			"error", err,
+		)
+	}
+	// Parent entry was inserted correctly, commit the dependents to prevent them from being rolled
+	// back
+	c.commitDependents(ctx, key)
+
+	return &Response{
+		Payload:     payload,
+		StatusCode:  *statusCode,
+		ContentType: *contentType,
+	}, nil
+}
+
+// loadShared makes sure only one replica loads the given key at a time.
+// Replicas that don't get the lock wait for the value to show up in Redis instead.
+func (c *RedisCache) loadShared(ctx context.Context, key string, r *http.Request) (*Response, error) {
+	log := logger.FromContext(ctx)
+	cacheKey := c.keyProvider.CacheKey(ctx, key)
+	lockKey := c.lockKey(cacheKey)
+	token := newRedisLockToken()
+
+	for {
+		acquired, err := c.client.SetNX(ctx, lockKey, token, redisLockDuration).Result()
+		if err != nil {
+			log.Warnw("Error acquiring redis lock, loading without it", "cacheKey", cacheKey, "error", err)
+			return c.load(ctx, key, r)
+		}
+
+		if acquired {
+			defer func() {
+				if err := redisUnlockScript.Run(ctx, c.client, []string{lockKey}, token).Err(); err != nil {
+					log.Warnw("Error releasing redis lock", "cacheKey", cacheKey, "error", err)
+				}
+			}()
+
+			// Another replica might have finished loading between our cache miss and acquiring the lock
+			if response, err := c.loadFromRedis(ctx, cacheKey); err == nil && response != nil {
+				return response, nil
+			}
+
+			return c.load(ctx, key, r)
+		}
+
+		log.Debugw("Redis key is being loaded by another replica, waiting", "cacheKey", cacheKey)
+
+		// Wait until the value has been stored or the lock is released. If the lock is released without
+		// a value being stored, the other replica failed to load it and we try to load it ourselves.
+		for {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(redisLockPollInterval):
+			}
+
+			response, err := c.loadFromRedis(ctx, cacheKey)
+			if err != nil {
+				return nil, err
+			}
+			if response != nil {
+				return response, nil
+			}
+
+			exists, err := c.client.Exists(ctx, lockKey).Result()
+			if err != nil {
+				return nil, err
+			}
+			if exists == 0 {
+				break
+			}
+		}
+	}
+}
+
+func (c *RedisCache) loadFromRedis(ctx context.Context, cacheKey string) (*Response, error) {
+	raw, err := c.client.Get(ctx, c.valueKey(cacheKey)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entry redisEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return nil, err
+	}
+
+	return &Response{
+		Payload:     entry.Payload,
+		StatusCode:  entry.StatusCode,
+		ContentType: entry.ContentType,
+	}, nil
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string, r *http.Request) (*Response, error) {
+	log := logger.FromContext(ctx)
+	cacheKey := c.keyProvider.CacheKey(ctx, key)
+
+	if done, err := purgeForAdmin(ctx, c.admin, c.keyProvider, key); done {
+		return nil, err
+	}
+
+	cacheResponse, err := c.loadFromRedis(ctx, cacheKey)
+	if err != nil {
+		log.Warnw("Unhandled redis error", "error", err)
+		tooltipInternalError := Response{
+			Payload:     []byte(`{"status":500,"message":"Internal server error (redis) loading thumbnail"}`),
+			StatusCode:  500,
+			ContentType: "application/json",
+		}
+		return &tooltipInternalError, err
+	} else if cacheResponse != nil {
+		cacheHits.Inc()
+		log.Debugw("Redis Get cache hit", "cacheKey", cacheKey)
+		return cacheResponse, nil
+	}
+
+	// If key is not in cache, sign up as a listener and ensure loader is only called once
+	cacheMisses.Inc()
+	log.Debugw("Redis Get cache miss", "cacheKey", cacheKey)
+	responseChannel := make(chan wrappedResponse)
+
+	c.requestsMutex.Lock()
+
+	c.requests[cacheKey] = append(c.requests[cacheKey], responseChannel)
+
+	first := len(c.requests[cacheKey]) == 1
+
+	c.requestsMutex.Unlock()
+
+	if first {
+		go func() {
+			response, err := c.loadShared(ctx, key, r)
+
+			r := wrappedResponse{
+				response,
+				err,
+			}
+			c.requestsMutex.Lock()
+			for _, ch := range c.requests[cacheKey] {
+				ch <- r
+			}
+			delete(c.requests, cacheKey)
+			c.requestsMutex.Unlock()
+		}()
+	}
+
+	// Wait for loader to complete, then return value from loader
+	response := <-responseChannel
+	return response.response, response.err
+}
+
+func (c *RedisCache) GetOnly(ctx context.Context, key string) *Response {
+	log := logger.FromContext(ctx)
+	cacheKey := c.keyProvider.CacheKey(ctx, key)
+
+	value, err := c.loadFromRedis(ctx, cacheKey)
+	if err != nil {
+		log.Warnw("Unhandled redis error", "error", err)
+		return nil
+	} else if value != nil {
+		cacheHits.Inc()
+		log.Debugw("Redis GetOnly cache hit", "cacheKey", cacheKey)
+		return value
+	}
+
+	cacheMisses.Inc()
+	log.Debugw("Redis GetOnly cache miss", "cacheKey", cacheKey)
+	return nil
+}
+
+func (c *RedisCache) RegisterDependent(ctx context.Context, dependent DependentCache) {
+	c.dependentCaches = append(c.dependentCaches, dependent)
+}
+
+func (c *RedisCache) commitDependents(ctx context.Context, key string) error {
+	parentKey := c.keyProvider.CacheKey(ctx, key)
+
+	for _, dependent := range c.dependentCaches {
+		err := dependent.commit(ctx, parentKey)
+		if err != nil {
+			continue
+		}
+	}
+
+	return nil
+}
+
+func (c *RedisCache) rollbackDependents(ctx context.Context, key string) error {
+	parentKey := c.keyProvider.CacheKey(ctx, key)
+
+	for _, dependent := range c.dependentCaches {
+		err := dependent.rollback(ctx, parentKey)
+		if err != nil {
+			continue
+		}
+	}
+
+	return nil
+}
+
+func NewRedisCache(ctx context.Context, cfg config.APIConfig, client *redis.Client, keyProvider KeyProvider, loader Loader, cacheDuration time.Duration) *RedisCache {
+	return &RedisCache{
+		keyProvider:   keyProvider,
+		loader:        loader,
+		cacheDuration: cacheDuration,
+		policy:        newCachePolicy(cfg, keyProvider),
+		client:        client,
+		keyPrefix:     cfg.CacheRedisKeyPrefix,
+		admin:         NewRedisAdmin(cfg, client),
+		requests:      make(map[string][]chan wrappedResponse),
+	}
+}
+
+var _ Cache = (*RedisCache)(nil)
+
+// RedisDependentCache stores values that belong to a parent cache entry.
+// Once committed, a dependent value gets the same TTL as its parent so they expire together.
+type RedisDependentCache struct {
+	keyProvider KeyProvider
+
+	client *redis.Client
+
+	keyPrefix string
+}
+
+func (c *RedisDependentCache) valueKey(cacheKey string) string {
+	return c.keyPrefix + "dependent:" + cacheKey
+}
+
+// uncommittedKey is the set of dependent values that belong to the parent and haven't been committed yet
+func (c *RedisDependentCache) uncommittedKey(parentKey string) string {
+	return c.keyPrefix + "dependent-uncommitted:" + parentKey
+}
+
+func (c *RedisDependentCache) parentValueKey(parentKey string) string {
+	return c.keyPrefix + "cache:" + parentKey
+}
+
+func (c *RedisDependentCache) Get(ctx context.Context, key string) ([]byte, string, error) {
+	log := logger.FromContext(ctx)
+
+	cacheKey := c.keyProvider.CacheKey(ctx, key)
+
+	raw, err := c.client.Get(ctx, c.valueKey(cacheKey)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		// Cache entry didn't exist
+		return nil, "", nil
+	}
+	if err != nil {
+		log.Warnw("Unhandled redis error", "error", err)
+		return nil, "", err
+	}
+
+	var entry redisDependentEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return nil, "", err
+	}
+
+	return entry.Value, entry.ContentType, nil
+}
+
+func (c *RedisDependentCache) Insert(
+	ctx context.Context, key string, parentKey string, value []byte, contentType string,
+) error {
+	log := logger.FromContext(ctx)
+
+	cacheKey := c.keyProvider.CacheKey(ctx, key)
+	entry, err := json.Marshal(redisDependentEntry{
+		Value:       value,
+		ContentType: contentType,
+	})
+	if err == nil {
+		var inserted bool
+		inserted, err = c.client.SetNX(ctx, c.valueKey(cacheKey), entry, dependentExpirationDuration).Result()
+		if err == nil && !inserted {
+			err = errDependentValueExists
+		}
+	}
+	if err == nil {
+		uncommittedKey := c.uncommittedKey(parentKey)
+		_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.SAdd(ctx, uncommittedKey, cacheKey)
+			pipe.Expire(ctx, uncommittedKey, dependentExpirationDuration)
+			return nil
+		})
+	}
+	if err != nil {
+		log.Errorw("Error inserting dependent value",
+			"cacheKey", cacheKey,
+			"parentKey", parentKey,
+			"error", err,
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (c *RedisDependentCache) commit(ctx context.Context, parentKey string) error {
+	log := logger.FromContext(ctx)
+
+	uncommittedKey := c.uncommittedKey(parentKey)
+	cacheKeys, err := c.client.SMembers(ctx, uncommittedKey).Result()
+	if err == nil && len(cacheKeys) > 0 {
+		var parentTTL time.Duration
+		parentTTL, err = c.client.PTTL(ctx, c.parentValueKey(parentKey)).Result()
+		if err == nil {
+			// Remembered so purging the parent can delete its dependent values
+			childrenKey := redisChildrenKey(c.keyPrefix, parentKey)
+			childrenTTL := dependentExpirationDuration
+			if parentTTL > 0 {
+				childrenTTL = parentTTL
+			}
+
+			_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				for _, cacheKey := range cacheKeys {
+					// Negative TTLs mean the parent either has no expiration or doesn't exist, in which case
+					// the value keeps its own expiration
+					if parentTTL > 0 {
+						pipe.PExpire(ctx, c.valueKey(cacheKey), parentTTL)
+					}
+					pipe.SAdd(ctx, childrenKey, cacheKey)
+				}
+				pipe.PExpire(ctx, childrenKey, childrenTTL)
+				pipe.Del(ctx, uncommittedKey)
+				return nil
+			})
+		}
+	}
+	if err != nil {
+		log.Errorw("Error committing dependent values",
+			"parentKey", parentKey,
+			"err", err,
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (c *RedisDependentCache) rollback(ctx context.Context, parentKey string) error {
+	log := logger.FromContext(ctx)
+
+	uncommittedKey := c.uncommittedKey(parentKey)
+	cacheKeys, err := c.client.SMembers(ctx, uncommittedKey).Result()
+	if err == nil && len(cacheKeys) > 0 {
+		_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, cacheKey := range cacheKeys {
+				pipe.Del(ctx, c.valueKey(cacheKey))
+			}
+			pipe.Del(ctx, uncommittedKey)
+			return nil
+		})
+	}
+	if err != nil {
+		log.Errorw("Error rolling back dependent values",
+			"err", err,
+		)
+		return err
+	}
+
+	return nil
+}
+
+func NewRedisDependentCache(ctx context.Context, cfg config.APIConfig, client *redis.Client, keyProvider KeyProvider) *RedisDependentCache {
+	return &RedisDependentCache{
+		keyProvider: keyProvider,
+		client:      client,
+		keyPrefix:   cfg.CacheRedisKeyPrefix,
+	}
+}
+
+var _ DependentCache = (*RedisDependentCache)(nil)
+
+type RedisAdmin struct {
+	client *redis.Client
+
+	keyPrefix string
+
+	// List loads this many entries at a time, so it only holds their payloads in memory
+	listBatchSize int
+	// List only looks at this many entries, so it doesn't go through the whole keyspace of big caches
+	listMaxKeys int
+}
+
+func (a *RedisAdmin) valueKey(cacheKey string) string {
+	return a.keyPrefix + "cache:" + cacheKey
+}
+
+func (a *RedisAdmin) dependentValueKey(cacheKey string) string {
+	return a.keyPrefix + "dependent:" + cacheKey
+}
+
+func (a *RedisAdmin) entry(cacheKey string, raw []byte, ttl time.Duration) (Entry, error) {
+	var value redisEntry
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return Entry{}, err
+	}
+
+	entry := Entry{
+		Key:         cacheKey,
+		Size:        len(value.Payload),
+		StatusCode:  value.StatusCode,
+		ContentType: value.ContentType,
+		CreatedOn:   value.CreatedOn,
+		Payload:     value.Payload,
+	}
+	if ttl > 0 {
+		entry.CachedUntil = time.Now().Add(ttl)
+	}
+
+	return entry, nil
+}
+
+func (a *RedisAdmin) Inspect(ctx context.Context, cacheKey string) (*Entry, error) {
+	var get *redis.StringCmd
+	var pttl *redis.DurationCmd
+	_, err := a.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, a.valueKey(cacheKey))
+		pttl = pipe.PTTL(ctx, a.valueKey(cacheKey))
+		return nil
+	})
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	raw, _ := get.Bytes()
+	entry, err := a.entry(cacheKey, raw, pttl.Val())
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (a *RedisAdmin) Purge(ctx context.Context, cacheKeys ...string) (int, error) {
+	deleted := 0
+	for _, cacheKey := range cacheKeys {
+		childrenKey := redisChildrenKey(a.keyPrefix, cacheKey)
+		children, err := a.client.SMembers(ctx, childrenKey).Result()
+		if err != nil {
+			return -1, err
+		}
+
+		var del *redis.IntCmd
+		_, err = a.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			del = pipe.Del(ctx, a.valueKey(cacheKey))
+			for _, child := range children {
+				pipe.Del(ctx, a.dependentValueKey(child))
+			}
+			pipe.Del(ctx, childrenKey)
+			return nil
+		})
+		if err != nil {
+			return -1, err
+		}
+
+		deleted += int(del.Val())
+	}
+
+	return deleted, nil
+}
+
+// scan returns the cache keys of all entries matching the pattern
+func (a *RedisAdmin) scan(ctx context.Context, pattern string) ([]string, error) {
+	var cacheKeys []string
+
+	iter := a.client.Scan(ctx, 0, a.valueKey(pattern), 1000).Iterator()
+	for iter.Next(ctx) {
+		cacheKeys = append(cacheKeys, strings.TrimPrefix(iter.Val(), a.valueKey("")))
+	}
+
+	return cacheKeys, iter.Err()
+}
+
+// escapes the characters that have a special meaning in SCAN patterns
+var redisPatternEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+
+func (a *RedisAdmin) PurgePrefix(ctx context.Context, prefix string) (int, error) {
+	cacheKeys, err := a.scan(ctx, redisPatternEscaper.Replace(prefix+":")+"*")
+	if err != nil {
+		return -1, err
+	}
+
+	return a.Purge(ctx, cacheKeys...)
+}
+
+func (a *RedisAdmin) List(ctx context.Context, order ListOrder, limit int) ([]Entry, error) {
+	entries := []Entry{}
+	cacheKeys := make([]string, 0, a.listBatchSize)
+	scanned := 0
+
+	iter := a.client.Scan(ctx, 0, a.valueKey("*"), 1000).Iterator()
+	for scanned < a.listMaxKeys && iter.Next(ctx) {
+		scanned++
+		cacheKeys = append(cacheKeys, strings.TrimPrefix(iter.Val(), a.valueKey("")))
+		if len(cacheKeys) < a.listBatchSize {
+			continue
+		}
+
+		batch, err := a.listEntries(ctx, cacheKeys)
+		if err != nil {
+			return nil, err
+		}
+		entries = sortEntries(append(entries, batch...), order, limit)
+		cacheKeys = cacheKeys[:0]
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	batch, err := a.listEntries(ctx, cacheKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return sortEntries(append(entries, batch...), order, limit), nil
+}
+
+// listEntries loads the entries of the cache keys without their payload
+func (a *RedisAdmin) listEntries(ctx context.Context, cacheKeys []string) ([]Entry, error) {
+	if len(cacheKeys) == 0 {
+		return nil, nil
+	}
+
+	gets := make([]*redis.StringCmd, len(cacheKeys))
+	pttls := make([]*redis.DurationCmd, len(cacheKeys))
+	_, err := a.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, cacheKey := range cacheKeys {
+			gets[i] = pipe.Get(ctx, a.valueKey(cacheKey))
+			pttls[i] = pipe.PTTL(ctx, a.valueKey(cacheKey))
+		}
+		return nil
+	})
+	// Entries expiring while we list them make the pipeline return redis.Nil
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(cacheKeys))
+	for i, cacheKey := range cacheKeys {
+		raw, err := gets[i].Bytes()
+		if err != nil {
+			continue
+		}
+
+		entry, err := a.entry(cacheKey, raw, pttls[i].Val())
+		if err != nil {
+			continue
+		}
+		entry.Payload = nil
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func NewRedisAdmin(cfg config.APIConfig, client *redis.Client) *RedisAdmin {
+	return &RedisAdmin{
+		client:        client,
+		keyPrefix:     cfg.CacheRedisKeyPrefix,
+		listBatchSize: 100,
+		listMaxKeys:   10000,
+	}
+}
+
+var _ Admin = (*RedisAdmin)(nil)
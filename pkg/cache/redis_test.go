@@ -0,0 +1,179 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/alicebob/miniredis/v2"
+	qt "github.com/frankban/quicktest"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRedisCache(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+	cfg := config.APIConfig{
+		CacheRedisKeyPrefix: "test:",
+	}
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	c.Run("Get loads once and caches", func(c *qt.C) {
+		loader := &testLoader{payload: []byte("hello")}
+		cache := NewRedisCache(ctx, cfg, client, NewPrefixKeyProvider("redis:get"), loader, time.Minute)
+
+		c.Assert(cache.GetOnly(ctx, "a"), qt.IsNil)
+
+		response, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Payload, qt.DeepEquals, []byte("hello"))
+		c.Assert(response.StatusCode, qt.Equals, defaultStatusCode)
+		c.Assert(response.ContentType, qt.Equals, defaultContentType)
+
+		response, err = cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Payload, qt.DeepEquals, []byte("hello"))
+		c.Assert(loader.calls.Load(), qt.Equals, int32(1))
+
+		c.Assert(server.Exists("test:cache:redis:get:a"), qt.IsTrue)
+		c.Assert(server.TTL("test:cache:redis:get:a"), qt.Equals, time.Minute)
+		// The lock must be released after loading
+		c.Assert(server.Exists("test:lock:redis:get:a"), qt.IsFalse)
+	})
+
+	c.Run("Entries expire using redis TTLs", func(c *qt.C) {
+		loader := &testLoader{payload: []byte("hello")}
+		cache := NewRedisCache(ctx, cfg, client, NewPrefixKeyProvider("redis:expire"), loader, time.Minute)
+
+		_, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+
+		server.FastForward(2 * time.Minute)
+		c.Assert(cache.GetOnly(ctx, "a"), qt.IsNil)
+
+		_, err = cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(loader.calls.Load(), qt.Equals, int32(2))
+	})
+
+	c.Run("Loader errors are not cached", func(c *qt.C) {
+		loader := &testLoader{err: errors.New("oops")}
+		cache := NewRedisCache(ctx, cfg, client, NewPrefixKeyProvider("redis:error"), loader, time.Minute)
+
+		_, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.ErrorMatches, "oops")
+		c.Assert(cache.GetOnly(ctx, "a"), qt.IsNil)
+		c.Assert(server.Exists("test:lock:redis:error:a"), qt.IsFalse)
+	})
+
+	c.Run("Replicas share a single load", func(c *qt.C) {
+		// Two caches with the same key provider act like two replicas of the API
+		loadStarted := make(chan struct{})
+		finishLoad := make(chan struct{})
+		loader := &testLoader{payload: []byte("shared")}
+		loader.onLoad = func(ctx context.Context, key string) {
+			close(loadStarted)
+			<-finishLoad
+		}
+		replicaA := NewRedisCache(ctx, cfg, client, NewPrefixKeyProvider("redis:shared"), loader, time.Minute)
+		replicaB := NewRedisCache(ctx, cfg, client, NewPrefixKeyProvider("redis:shared"), loader, time.Minute)
+
+		var wg sync.WaitGroup
+		responses := make([]*Response, 2)
+		get := func(i int, cache *RedisCache) {
+			defer wg.Done()
+			response, err := cache.Get(ctx, "a", nil)
+			c.Check(err, qt.IsNil)
+			responses[i] = response
+		}
+
+		wg.Add(1)
+		go get(0, replicaA)
+		<-loadStarted
+
+		wg.Add(1)
+		go get(1, replicaB)
+
+		// Give replica B time to notice the lock before letting replica A finish
+		time.Sleep(3 * redisLockPollInterval)
+		close(finishLoad)
+		wg.Wait()
+
+		c.Assert(loader.calls.Load(), qt.Equals, int32(1))
+		c.Assert(responses[0].Payload, qt.DeepEquals, []byte("shared"))
+		c.Assert(responses[1].Payload, qt.DeepEquals, []byte("shared"))
+	})
+
+	c.Run("Waiting replica loads itself if the lock is released without a value", func(c *qt.C) {
+		loader := &testLoader{payload: []byte("hello")}
+		cache := NewRedisCache(ctx, cfg, client, NewPrefixKeyProvider("redis:abandoned"), loader, time.Minute)
+
+		c.Assert(server.Set("test:lock:redis:abandoned:a", "someone-else"), qt.IsNil)
+		go func() {
+			time.Sleep(3 * redisLockPollInterval)
+			server.Del("test:lock:redis:abandoned:a")
+		}()
+
+		response, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response.Payload, qt.DeepEquals, []byte("hello"))
+		c.Assert(loader.calls.Load(), qt.Equals, int32(1))
+	})
+
+	c.Run("Committed dependent values share the parent TTL", func(c *qt.C) {
+		dependent := NewRedisDependentCache(ctx, cfg, client, NewPrefixKeyProvider("redis:dependent"))
+		parentKeyProvider := NewPrefixKeyProvider("redis:parent")
+		loader := &testLoader{payload: []byte("parent")}
+		loader.onLoad = func(ctx context.Context, key string) {
+			err := dependent.Insert(ctx, key, parentKeyProvider.CacheKey(ctx, key), []byte("child"), "image/png")
+			c.Assert(err, qt.IsNil)
+		}
+		cache := NewRedisCache(ctx, cfg, client, parentKeyProvider, loader, time.Hour)
+		cache.RegisterDependent(ctx, dependent)
+
+		_, err := cache.Get(ctx, "a", nil)
+		c.Assert(err, qt.IsNil)
+
+		value, contentType, err := dependent.Get(ctx, "a")
+		c.Assert(err, qt.IsNil)
+		c.Assert(value, qt.DeepEquals, []byte("child"))
+		c.Assert(contentType, qt.Equals, "image/png")
+		c.Assert(server.TTL("test:dependent:redis:dependent:a"), qt.Equals, time.Hour)
+		c.Assert(server.Exists("test:dependent-uncommitted:redis:parent:a"), qt.IsFalse)
+
+		// Inserting the same dependent value twice is an error
+		err = dependent.Insert(ctx, "a", parentKeyProvider.CacheKey(ctx, "a"), []byte("child"), "image/png")
+		c.Assert(err, qt.IsNotNil)
+
+		server.FastForward(2 * time.Hour)
+		value, _, err = dependent.Get(ctx, "a")
+		c.Assert(err, qt.IsNil)
+		c.Assert(value, qt.IsNil)
+	})
+
+	c.Run("Dependent values are rolled back if the parent fails", func(c *qt.C) {
+		dependent := NewRedisDependentCache(ctx, cfg, client, NewPrefixKeyProvider("redis:dependent"))
+		parentKeyProvider := NewPrefixKeyProvider("redis:failingparent")
+		loader := &testLoader{err: errors.New("oops")}
+		loader.onLoad = func(ctx context.Context, key string) {
+			err := dependent.Insert(ctx, key, parentKeyProvider.CacheKey(ctx, key), []byte("child"), "image/png")
+			c.Assert(err, qt.IsNil)
+		}
+		cache := NewRedisCache(ctx, cfg, client, parentKeyProvider, loader, time.Minute)
+		cache.RegisterDependent(ctx, dependent)
+
+		_, err := cache.Get(ctx, "b", nil)
+		c.Assert(err, qt.ErrorMatches, "oops")
+
+		value, _, err := dependent.Get(ctx, "b")
+		c.Assert(err, qt.IsNil)
+		c.Assert(value, qt.IsNil)
+	})
+}
@@ -66,23 +66,49 @@ func init() {
 	pflag.Uint64("max-content-length", 5*1024*1024, "Max content size in bytes - requests with body bigger than this value will be skipped")
 	pflag.Bool("enable-animated-thumbnails", true, "When enabled, will attempt to use libvips library to build animated thumbnails. Can increase CPU usage and cache storage by a lot. Enabled by default")
 	pflag.Uint("max-thumbnail-size", 300, "Maximum width/height pixel size count of the thumbnails sent to the clients.")
+	pflag.Int("link-resolver-batch-max-urls", 50, "Maximum number of URLs accepted in a single batch link resolver request")
+	pflag.Int("link-resolver-batch-concurrency", 8, "Maximum number of URLs resolved at the same time for a single batch link resolver request")
+	pflag.Duration("link-resolver-batch-item-timeout", 5*time.Second, "How long a batch link resolver request waits for a single URL before responding with a timeout for it")
+	pflag.Bool("link-resolver-respect-robots", false, "When enabled, links disallowed by robots.txt, X-Robots-Tag or <meta name=\"robots\"> only get a minimal tooltip containing the URL")
 	pflag.Duration("twitch-username-cache-duration", 10*time.Minute, "Cache timeout for twitch usernames")
+	pflag.Duration("bluesky-post-cache-duration", 1*time.Hour, "Cache timeout for bluesky posts")
 	pflag.Duration("bttv-emote-cache-duration", 1*time.Hour, "Cache timeout for bttv emotes")
 	pflag.Duration("thumbnail-cache-duration", 10*time.Minute, "Cache timeout for default thumbnails")
 	pflag.Duration("default-link-cache-duration", 10*time.Minute, "Cache timeout for default links")
 	pflag.Duration("discord-invite-cache-duration", 6*time.Hour, "Cache timeout for discord invite")
 	pflag.Duration("ffz-emote-cache-duration", 1*time.Hour, "Cache timeout for ffz emotes")
+	pflag.Duration("github-repo-cache-duration", 1*time.Hour, "Cache timeout for github repositories")
+	pflag.Duration("github-issue-cache-duration", 10*time.Minute, "Cache timeout for github issues and pull requests")
+	pflag.Duration("github-commit-cache-duration", 24*time.Hour, "Cache timeout for github commits")
+	pflag.Duration("github-release-cache-duration", 1*time.Hour, "Cache timeout for github releases")
+	pflag.Duration("github-gist-cache-duration", 1*time.Hour, "Cache timeout for github gists")
 	pflag.Duration("imgur-cache-duration", 1*time.Hour, "Cache timeout for imgur")
+	pflag.Duration("kick-channel-cache-duration", 10*time.Minute, "Cache timeout for kick channels")
+	pflag.Duration("kick-clip-cache-duration", 1*time.Hour, "Cache timeout for kick clips")
+	pflag.Duration("kick-video-cache-duration", 1*time.Hour, "Cache timeout for kick videos")
 	pflag.Duration("livestreamfails-clip-cache-duration", 1*time.Hour, "Cache timeout for livestreamfails clips")
+	pflag.Duration("mastodon-status-cache-duration", 1*time.Hour, "Cache timeout for mastodon statuses")
+	pflag.Duration("mastodon-instance-cache-duration", 24*time.Hour, "Cache timeout for whether a host is a mastodon (or compatible) instance")
 	pflag.Duration("oembed-cache-duration", 1*time.Hour, "Cache timeout for oembed")
+	pflag.Duration("reddit-post-cache-duration", 10*time.Minute, "Cache timeout for reddit posts")
+	pflag.Duration("reddit-comment-cache-duration", 10*time.Minute, "Cache timeout for reddit comments")
+	pflag.Duration("reddit-subreddit-cache-duration", 1*time.Hour, "Cache timeout for subreddits")
 	pflag.Duration("seventv-emote-cache-duration", 1*time.Hour, "Cache timeout for seventv emotes")
 	pflag.Duration("supinic-track-cache-duration", 1*time.Hour, "Cache timeout for supinic tracks")
 	pflag.Duration("twitch-clip-cache-duration", 1*time.Hour, "Cache timeout for twitch clips")
+	pflag.Duration("twitch-video-cache-duration", 1*time.Hour, "Cache timeout for twitch videos")
+	pflag.Duration("twitch-category-cache-duration", 10*time.Minute, "Cache timeout for twitch categories")
+	pflag.Duration("twitch-schedule-cache-duration", 1*time.Hour, "Cache timeout for twitch schedules")
+	pflag.String("twitch-user-thumbnail", "profile-image", "Thumbnail shown for offline Twitch channels. Available thumbnails: profile-image, offline-banner. Channels without an offline banner show their profile image")
 	pflag.Duration("twitter-tweet-cache-duration", 24*time.Hour, "Cache timeout for twitter tweets")
 	pflag.Duration("twitter-user-cache-duration", 24*time.Hour, "Cache timeout for twitter users")
 	pflag.Duration("wikipedia-article-cache-duration", 1*time.Hour, "Cache timeout for wikipedia articles")
 	pflag.Duration("youtube-channel-cache-duration", 48*time.Hour, "Cache timeout for youtube channels")
 	pflag.Duration("youtube-video-cache-duration", 48*time.Hour, "Cache timeout for youtube videos")
+	pflag.Int("youtube-quota-limit", 10000, "Daily YouTube API quota in units. No API calls are made once it's used up, until it resets at midnight Pacific Time. 0 disables quota tracking")
+	pflag.Int("youtube-quota-extend-percent", 80, "Percentage of youtube-quota-limit after which YouTube links are cached for youtube-quota-extended-cache-duration")
+	pflag.Duration("youtube-quota-extended-cache-duration", 24*time.Hour, "Cache timeout for YouTube links loaded after youtube-quota-extend-percent of the quota was used")
+	pflag.Duration("robots-txt-cache-duration", 1*time.Hour, "Cache timeout for robots.txt files")
 	pflag.String("log-level", "info", "Minimum level of log message importance required for the log message to not be filtered out. Available levels: debug, info, warn, error")
 	pflag.Bool("log-development", false, "Enables much more verbose logging, useful for debugging. This makes all log messages include a stack trace to see where they were called from.")
 	pflag.String("discord-token", "", "Discord token")
@@ -91,12 +117,31 @@ func init() {
 	pflag.String("youtube-api-key", "", "YouTube API key")
 	pflag.String("twitter-bearer-token", "", "Twitter bearer token")
 	pflag.String("imgur-client-id", "", "Imgur client ID")
+	pflag.String("github-token", "", "GitHub token, optional. Raises the rate limit of the GitHub API")
 	pflag.String("oembed-facebook-app-id", "", "oEmbed Facebook app ID")
 	pflag.String("oembed-facebook-app-secret", "", "oEmbed Facebook app secret")
 	pflag.String("oembed-providers-path", "./data/oembed/providers.json", "Path to a json file containing supported oEmbed resolvers")
+	pflag.String("cache-backend", "postgres", "Backend used for caching resolved links and thumbnails. Available backends: postgres, memory, bolt, redis")
+	pflag.String("cache-bolt-path", "./chatterino-api.db", "Path to the database file used by the bolt cache backend")
+	pflag.String("cache-redis-address", "localhost:6379", "Address (host:port) of the Redis server used by the redis cache backend")
+	pflag.String("cache-redis-password", "", "Password used to authenticate with the Redis server used by the redis cache backend")
+	pflag.Int("cache-redis-db", 0, "Redis database number used by the redis cache backend")
+	pflag.String("cache-redis-key-prefix", "chatterino-api:", "Prefix added to all keys stored by the redis cache backend")
+	pflag.Duration("cache-not-found-duration", 0, "Cache timeout for responses saying the link doesn't exist (4xx). 0 uses the cache timeout chosen by each resolver")
+	pflag.Duration("cache-error-duration", 1*time.Minute, "Cache timeout for responses caused by a likely temporary error (5xx or rate limited). 0 uses the cache timeout chosen by each resolver")
 	pflag.String("dsn", "", "Connection string for the PostgreSQL cache")
+	pflag.String("host-policies-path", "", "Path to a yaml file containing additional host-policies rules. The file is reloaded when it changes")
+	pflag.Duration("host-policies-reload-interval", 30*time.Second, "How often the host-policies-path file is checked for changes")
+	pflag.Int("upstream-failure-threshold", 5, "Number of consecutive failed requests to an upstream API (e.g. Discord or Twitter) after which no requests are made to it for upstream-open-duration")
+	pflag.Duration("upstream-open-duration", 30*time.Second, "How long no requests are made to an upstream API after it failed upstream-failure-threshold times in a row")
+	pflag.Bool("ssrf-protection", true, "When enabled, links resolving to loopback, private, link-local and other internal addresses will not be fetched")
+	pflag.StringSlice("ssrf-allow-cidrs", []string{}, "CIDRs that links may be fetched from even though SSRF protection would block them, e.g. 10.1.2.0/24")
+	pflag.StringSlice("ssrf-deny-cidrs", []string{}, "CIDRs that links may never be fetched from, in addition to the ones blocked by SSRF protection")
 	pflag.Bool("enable-prometheus", true, "When enabled, will host a Prometheus metrics HTTP server on the prometheus-bind-address")
 	pflag.String("prometheus-bind-address", "127.0.0.1:9382", "Address to which the API will host its Prometheus metrics")
+	pflag.Bool("enable-admin", false, "When enabled, will host the cache admin HTTP API on the admin-bind-address. Requires admin-token to be set")
+	pflag.String("admin-bind-address", "127.0.0.1:9383", "Address to which the API will host its cache admin HTTP API")
+	pflag.String("admin-token", "", "Token required in the Authorization header (Bearer <token>) of cache admin API requests")
 	pflag.Parse()
 }
 
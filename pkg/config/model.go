@@ -11,32 +11,101 @@ type APIConfig struct {
 	EnableAnimatedThumbnails bool   `mapstructure:"enable-animated-thumbnails" json:"enable-animated-thumbnails"`
 	MaxThumbnailSize         uint   `mapstructure:"max-thumbnail-size" json:"max-thumbnail-size"`
 
+	LinkResolverBatchMaxURLs     int           `mapstructure:"link-resolver-batch-max-urls" json:"link-resolver-batch-max-urls"`
+	LinkResolverBatchConcurrency int           `mapstructure:"link-resolver-batch-concurrency" json:"link-resolver-batch-concurrency"`
+	LinkResolverBatchItemTimeout time.Duration `mapstructure:"link-resolver-batch-item-timeout" json:"link-resolver-batch-item-timeout"`
+	LinkResolverRespectRobots    bool          `mapstructure:"link-resolver-respect-robots" json:"link-resolver-respect-robots"`
+
+	BlueskyPostCacheDuration         time.Duration `mapstructure:"bluesky-post-cache-duration" json:"bluesky-post-cache-duration"`
 	BttvEmoteCacheDuration           time.Duration `mapstructure:"bttv-emote-cache-duration" json:"bttv-emote-cache-duration"`
 	ThumbnailCacheDuration           time.Duration `mapstructure:"thumbnail-cache-duration" json:"thumbnail-cache-duration"`
 	DefaultLinkCacheDuration         time.Duration `mapstructure:"default-link-cache-duration" json:"default-link-cache-duration"`
 	DiscordInviteCacheDuration       time.Duration `mapstructure:"discord-invite-cache-duration" json:"discord-invite-cache-duration"`
 	FfzEmoteCacheDuration            time.Duration `mapstructure:"ffz-emote-cache-duration" json:"ffz-emote-cache-duration"`
+	GithubRepoCacheDuration          time.Duration `mapstructure:"github-repo-cache-duration" json:"github-repo-cache-duration"`
+	GithubIssueCacheDuration         time.Duration `mapstructure:"github-issue-cache-duration" json:"github-issue-cache-duration"`
+	GithubCommitCacheDuration        time.Duration `mapstructure:"github-commit-cache-duration" json:"github-commit-cache-duration"`
+	GithubReleaseCacheDuration       time.Duration `mapstructure:"github-release-cache-duration" json:"github-release-cache-duration"`
+	GithubGistCacheDuration          time.Duration `mapstructure:"github-gist-cache-duration" json:"github-gist-cache-duration"`
 	ImgurCacheDuration               time.Duration `mapstructure:"imgur-cache-duration" json:"imgur-cache-duration"`
+	KickChannelCacheDuration         time.Duration `mapstructure:"kick-channel-cache-duration" json:"kick-channel-cache-duration"`
+	KickClipCacheDuration            time.Duration `mapstructure:"kick-clip-cache-duration" json:"kick-clip-cache-duration"`
+	KickVideoCacheDuration           time.Duration `mapstructure:"kick-video-cache-duration" json:"kick-video-cache-duration"`
 	LivestreamfailsClipCacheDuration time.Duration `mapstructure:"livestreamfails-clip-cache-duration" json:"livestreamfails-clip-cache-duration"`
+	MastodonStatusCacheDuration      time.Duration `mapstructure:"mastodon-status-cache-duration" json:"mastodon-status-cache-duration"`
+	MastodonInstanceCacheDuration    time.Duration `mapstructure:"mastodon-instance-cache-duration" json:"mastodon-instance-cache-duration"`
 	OembedCacheDuration              time.Duration `mapstructure:"oembed-cache-duration" json:"oembed-cache-duration"`
+	RedditPostCacheDuration          time.Duration `mapstructure:"reddit-post-cache-duration" json:"reddit-post-cache-duration"`
+	RedditCommentCacheDuration       time.Duration `mapstructure:"reddit-comment-cache-duration" json:"reddit-comment-cache-duration"`
+	RedditSubredditCacheDuration     time.Duration `mapstructure:"reddit-subreddit-cache-duration" json:"reddit-subreddit-cache-duration"`
 	SeventvEmoteCacheDuration        time.Duration `mapstructure:"seventv-emote-cache-duration" json:"seventv-emote-cache-duration"`
 	SupinicTrackCacheDuration        time.Duration `mapstructure:"supinic-track-cache-duration" json:"supinic-track-cache-duration"`
 	TwitchClipCacheDuration          time.Duration `mapstructure:"twitch-clip-cache-duration" json:"twitch-clip-cache-duration"`
+	TwitchVideoCacheDuration         time.Duration `mapstructure:"twitch-video-cache-duration" json:"twitch-video-cache-duration"`
+	TwitchCategoryCacheDuration      time.Duration `mapstructure:"twitch-category-cache-duration" json:"twitch-category-cache-duration"`
+	TwitchScheduleCacheDuration      time.Duration `mapstructure:"twitch-schedule-cache-duration" json:"twitch-schedule-cache-duration"`
 	TwitterTweetCacheDuration        time.Duration `mapstructure:"twitter-tweet-cache-duration" json:"twitter-tweet-cache-duration"`
 	TwitterUserCacheDuration         time.Duration `mapstructure:"twitter-user-cache-duration" json:"twitter-user-cache-duration"`
 	WikipediaArticleCacheDuration    time.Duration `mapstructure:"wikipedia-article-cache-duration" json:"wikipedia-article-cache-duration"`
 	YoutubeChannelCacheDuration      time.Duration `mapstructure:"youtube-channel-cache-duration" json:"youtube-channel-cache-duration"`
 	YoutubeVideoCacheDuration        time.Duration `mapstructure:"youtube-video-cache-duration" json:"youtube-video-cache-duration"`
+	RobotsTxtCacheDuration           time.Duration `mapstructure:"robots-txt-cache-duration" json:"robots-txt-cache-duration"`
 	TwitchUsernameCacheDuration      time.Duration `mapstructure:"twitch-username-cache-duration" json:"twitch-user-cache-duration"`
 
+	// Thumbnail of offline Twitch channels, "profile-image" or "offline-banner"
+	TwitchUserThumbnail string `mapstructure:"twitch-user-thumbnail" json:"twitch-user-thumbnail"`
+
 	LogLevel       string `mapstructure:"log-level" json:"log-level"`
 	LogDevelopment bool   `mapstructure:"log-development" json:"log-development"`
 
+	CacheBackend  string `mapstructure:"cache-backend" json:"cache-backend"`
+	CacheBoltPath string `mapstructure:"cache-bolt-path" json:"cache-bolt-path"`
+
+	// Maps cache key prefixes (e.g. "youtube:video") to how long expired entries may still be served
+	// while they're being refreshed
+	CacheStaleDurations map[string]time.Duration `mapstructure:"cache-stale-durations" json:"cache-stale-durations"`
+
+	CacheNotFoundDuration time.Duration `mapstructure:"cache-not-found-duration" json:"cache-not-found-duration"`
+	CacheErrorDuration    time.Duration `mapstructure:"cache-error-duration" json:"cache-error-duration"`
+	// Maps cache key prefixes (e.g. "youtube:video") to the cache durations used for each class of response
+	CachePolicies map[string]CachePolicy `mapstructure:"cache-policies" json:"cache-policies"`
+
+	CacheRedisAddress   string `mapstructure:"cache-redis-address" json:"cache-redis-address"`
+	CacheRedisDB        int    `mapstructure:"cache-redis-db" json:"cache-redis-db"`
+	CacheRedisKeyPrefix string `mapstructure:"cache-redis-key-prefix" json:"cache-redis-key-prefix"`
+
 	DSN string `mapstructure:"dsn" json:"dsn"`
 
+	// Maps resolver names (e.g. "youtube:video") to how the resolver is used
+	Resolvers map[string]ResolverConfig `mapstructure:"resolvers" json:"resolvers"`
+	// Resolvers for links whose tooltip is built from a JSON API, defined without writing Go
+	SiteResolvers []SiteResolver `mapstructure:"site-resolvers" json:"site-resolvers"`
+
+	// Rules deciding how links to specific hosts are handled, e.g. to honor a site owner's opt-out request.
+	// Rules from host-policies-path are checked after these.
+	HostPolicies               []HostPolicy  `mapstructure:"host-policies" json:"host-policies"`
+	HostPoliciesPath           string        `mapstructure:"host-policies-path" json:"host-policies-path"`
+	HostPoliciesReloadInterval time.Duration `mapstructure:"host-policies-reload-interval" json:"host-policies-reload-interval"`
+
+	UpstreamFailureThreshold int           `mapstructure:"upstream-failure-threshold" json:"upstream-failure-threshold"`
+	UpstreamOpenDuration     time.Duration `mapstructure:"upstream-open-duration" json:"upstream-open-duration"`
+	// Maps upstream names (e.g. "discord") to the maximum number of requests per second made to them
+	UpstreamRateLimits map[string]float64 `mapstructure:"upstream-rate-limits" json:"upstream-rate-limits"`
+
+	YoutubeQuotaLimit                 int           `mapstructure:"youtube-quota-limit" json:"youtube-quota-limit"`
+	YoutubeQuotaExtendPercent         int           `mapstructure:"youtube-quota-extend-percent" json:"youtube-quota-extend-percent"`
+	YoutubeQuotaExtendedCacheDuration time.Duration `mapstructure:"youtube-quota-extended-cache-duration" json:"youtube-quota-extended-cache-duration"`
+
+	SSRFProtection bool     `mapstructure:"ssrf-protection" json:"ssrf-protection"`
+	SSRFAllowCIDRs []string `mapstructure:"ssrf-allow-cidrs" json:"ssrf-allow-cidrs"`
+	SSRFDenyCIDRs  []string `mapstructure:"ssrf-deny-cidrs" json:"ssrf-deny-cidrs"`
+
 	EnablePrometheus      bool   `mapstructure:"enable-prometheus" json:"enable-prometheus"`
 	PrometheusBindAddress string `mapstructure:"prometheus-bind-address" json:"prometheus-bind-address"`
 
+	EnableAdmin      bool   `mapstructure:"enable-admin" json:"enable-admin"`
+	AdminBindAddress string `mapstructure:"admin-bind-address" json:"admin-bind-address"`
+
 	// Secrets
 
 	DiscordToken            string `mapstructure:"discord-token" json:"discord-token"`
@@ -45,7 +114,77 @@ type APIConfig struct {
 	YoutubeApiKey           string `mapstructure:"youtube-api-key" json:"youtube-api-key"`
 	TwitterBearerToken      string `mapstructure:"twitter-bearer-token" json:"twitter-bearer-token"`
 	ImgurClientID           string `mapstructure:"imgur-client-id" json:"imgur-client-id"`
+	GithubToken             string `mapstructure:"github-token" json:"github-token"`
 	OembedFacebookAppID     string `mapstructure:"oembed-facebook-app-id" json:"oembed-facebook-app-id"`
 	OembedFacebookAppSecret string `mapstructure:"oembed-facebook-app-secret" json:"oembed-facebook-app-secret"`
 	OembedProvidersPath     string `mapstructure:"oembed-providers-path" json:"oembed-providers-path"`
+	CacheRedisPassword      string `mapstructure:"cache-redis-password" json:"cache-redis-password"`
+	AdminToken              string `mapstructure:"admin-token" json:"admin-token"`
+}
+
+// CachePolicy holds how long each class of response is cached for. A zero duration means the
+// duration chosen by the resolver is used.
+type CachePolicy struct {
+	Success  time.Duration `mapstructure:"success" json:"success"`
+	NotFound time.Duration `mapstructure:"not-found" json:"not-found"`
+	Error    time.Duration `mapstructure:"error" json:"error"`
+}
+
+// ResolverConfig decides whether and how a custom resolver is used
+type ResolverConfig struct {
+	// Resolvers are enabled unless this is set to false
+	Enabled *bool `mapstructure:"enabled" json:"enabled"`
+	// Resolvers are checked in ascending order. Resolvers with the same order keep their default order.
+	Order int `mapstructure:"order" json:"order"`
+	// Overrides how long successful responses of the resolver are cached for
+	CacheDuration time.Duration `mapstructure:"cache-duration" json:"cache-duration"`
+	// Maximum time the resolver may take for a link before falling back to the default resolver
+	Timeout time.Duration `mapstructure:"timeout" json:"timeout"`
+}
+
+// SiteResolver describes a resolver that builds tooltips from the response of a JSON API.
+// Named groups of the Host and Path regular expressions can be used in the URL and Tooltip templates.
+type SiteResolver struct {
+	// Must be unique, e.g. "kick:channel". Also used as the cache key prefix and upstream name.
+	Name string `mapstructure:"name" json:"name"`
+	// Regular expressions the host and path of links must match
+	Host string `mapstructure:"host" json:"host"`
+	Path string `mapstructure:"path" json:"path"`
+
+	// text/template of the API URL, e.g. "https://api.example.com/users/{{.user}}"
+	URL     string            `mapstructure:"url" json:"url"`
+	Headers map[string]string `mapstructure:"headers" json:"headers"`
+	Auth    SiteResolverAuth  `mapstructure:"auth" json:"auth"`
+
+	// Maps field names to JSONPath expressions selecting them from the API response, e.g. "$.user.name"
+	Fields map[string]string `mapstructure:"fields" json:"fields"`
+	// html/template of the tooltip
+	Tooltip string `mapstructure:"tooltip" json:"tooltip"`
+	// JSONPath expression selecting the thumbnail URL from the API response
+	Thumbnail string `mapstructure:"thumbnail" json:"thumbnail"`
+	// Kind of the structured tooltip data (e.g. "user"), no data is sent if empty
+	Kind string `mapstructure:"kind" json:"kind"`
+
+	CacheDuration time.Duration `mapstructure:"cache-duration" json:"cache-duration"`
+}
+
+// SiteResolverAuth adds a token read from the environment to the API requests of a SiteResolver
+type SiteResolverAuth struct {
+	// Name of the environment variable containing the token. The resolver is disabled if it's empty.
+	Env string `mapstructure:"env" json:"env"`
+	// Defaults to Authorization
+	Header string `mapstructure:"header" json:"header"`
+	// Prepended to the token, e.g. "Bearer "
+	Prefix string `mapstructure:"prefix" json:"prefix"`
+}
+
+// HostPolicy decides how links to the matching hosts are handled.
+// Exactly one of Host or Regex should be set. Host may start with "*." to match all subdomains.
+type HostPolicy struct {
+	Host  string `mapstructure:"host" json:"host"`
+	Regex string `mapstructure:"regex" json:"regex"`
+	// One of block, tooltip-only, force-minimal or cache-duration
+	Policy string `mapstructure:"policy" json:"policy"`
+	// Used by the cache-duration policy
+	CacheDuration time.Duration `mapstructure:"cache-duration" json:"cache-duration"`
 }
@@ -1,6 +1,11 @@
 package humanize
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/Chatterino/api/pkg/i18n"
+	"golang.org/x/text/language"
+)
 
 var bytesSteps = []string{"B", "KB", "MB", "GB"}
 
@@ -15,3 +20,19 @@ func Bytes(n uint64) string {
 	}
 	return fmt.Sprintf("%.1f %s", val, "TB")
 }
+
+// BytesIn formats the size like Bytes, using the decimal separator of the given language
+func BytesIn(lang language.Tag, n uint64) string {
+	p := i18n.Printer(lang)
+
+	div := float64(1000)
+	val := float64(n)
+	for _, step := range bytesSteps {
+		if val < 1000 {
+			return p.Sprintf("%.1f %s", val, step)
+		}
+		val /= div
+	}
+
+	return p.Sprintf("%.1f %s", val, "TB")
+}
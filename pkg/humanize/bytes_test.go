@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
 	qt "github.com/frankban/quicktest"
+	"golang.org/x/text/language"
 )
 
 func TestBytes(t *testing.T) {
@@ -32,3 +34,26 @@ func TestBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestBytesIn(t *testing.T) {
+	c := qt.New(t)
+	type testCase struct {
+		lang     language.Tag
+		input    uint64
+		expected string
+	}
+	cases := []testCase{
+		{i18n.English, 1501, "1.5 KB"},
+		{i18n.English, 1234 * 1000 * 1000, "1.2 GB"},
+		{i18n.German, 0, "0,0 B"},
+		{i18n.German, 1501, "1,5 KB"},
+		{i18n.German, 1234 * 1000 * 1000, "1,2 GB"},
+	}
+
+	for _, tc := range cases {
+		c.Run("", func(c *qt.C) {
+			res := humanize.BytesIn(tc.lang, tc.input)
+			c.Assert(res, qt.Equals, tc.expected)
+		})
+	}
+}
@@ -5,6 +5,19 @@ import (
 	"log"
 	"strings"
 	"time"
+
+	"github.com/Chatterino/api/pkg/i18n"
+	"golang.org/x/text/language"
+)
+
+// Layouts used by the *In functions for languages that don't use the English ones
+var (
+	dateLayouts = map[language.Tag]string{
+		i18n.German: "02.01.2006",
+	}
+	dateTimeLayouts = map[language.Tag]string{
+		i18n.German: "02.01.2006 • 15:04 UTC",
+	}
 )
 
 // Duration takes a `time.Duration` and converts it to the nearest-second string output
@@ -85,3 +98,63 @@ func CreationDateTimeUnix(unix int64) string {
 	t := time.Unix(unix, 0)
 	return CreationDateTime(t.UTC())
 }
+
+// CreationDateIn returns the `time.Time`'s date formatted like CreationDate, using the date format of the given language
+// Example output (German): 02.12.2016
+func CreationDateIn(lang language.Tag, t time.Time) string {
+	if layout, ok := dateLayouts[lang]; ok {
+		return t.Format(layout)
+	}
+
+	return CreationDate(t)
+}
+
+// CreationDateRFC3339In parses the incoming string as an RFC3339-formatted date and then formats it with CreationDateIn
+// If the given string is not a valid RFC3339-formatted date, we will return an empty string
+func CreationDateRFC3339In(lang language.Tag, str string) string {
+	t, err := time.Parse(time.RFC3339, str)
+	if err != nil {
+		return ""
+	}
+
+	return CreationDateIn(lang, t)
+}
+
+// CreationDateTimeIn returns the `time.Time`'s date formatted like CreationDateTime, using the date format of the given language
+// Example output (German): 02.01.2006 • 15:04 UTC
+func CreationDateTimeIn(lang language.Tag, t time.Time) string {
+	if layout, ok := dateTimeLayouts[lang]; ok {
+		return t.Format(layout)
+	}
+
+	return CreationDateTime(t)
+}
+
+// Units used by AgoIn, from the largest to the smallest
+var agoUnits = []struct {
+	unit time.Duration
+	one  string
+	many string
+}{
+	{365 * 24 * time.Hour, "a year ago", "%d years ago"},
+	{30 * 24 * time.Hour, "a month ago", "%d months ago"},
+	{24 * time.Hour, "a day ago", "%d days ago"},
+	{time.Hour, "an hour ago", "%d hours ago"},
+	{time.Minute, "a minute ago", "%d minutes ago"},
+}
+
+// AgoIn returns how long ago something happened in the largest whole unit, in the given language
+// Example output: 3 days ago, vor 3 Tagen (German)
+func AgoIn(lang language.Tag, duration time.Duration) string {
+	for _, u := range agoUnits {
+		n := int64(duration / u.unit)
+		if n == 1 {
+			return i18n.T(lang, u.one)
+		}
+		if n > 1 {
+			return i18n.T(lang, u.many, n)
+		}
+	}
+
+	return i18n.T(lang, "just now")
+}
@@ -1 +1,56 @@
 package humanize
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/pkg/i18n"
+	qt "github.com/frankban/quicktest"
+	"golang.org/x/text/language"
+)
+
+func TestCreationDateIn(t *testing.T) {
+	c := qt.New(t)
+	date := time.Date(2016, time.December, 2, 15, 4, 0, 0, time.UTC)
+
+	c.Assert(CreationDateIn(i18n.English, date), qt.Equals, "02 Dec 2016")
+	c.Assert(CreationDateIn(i18n.German, date), qt.Equals, "02.12.2016")
+	c.Assert(CreationDateIn(language.French, date), qt.Equals, "02 Dec 2016")
+
+	c.Assert(CreationDateRFC3339In(i18n.German, "2016-12-02T15:04:00Z"), qt.Equals, "02.12.2016")
+	c.Assert(CreationDateRFC3339In(i18n.German, "invalid"), qt.Equals, "")
+
+	c.Assert(CreationDateTimeIn(i18n.English, date), qt.Equals, "02 Dec 2016 • 15:04 UTC")
+	c.Assert(CreationDateTimeIn(i18n.German, date), qt.Equals, "02.12.2016 • 15:04 UTC")
+}
+
+func TestAgoIn(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		duration time.Duration
+		english  string
+		german   string
+	}{
+		{-time.Minute, "just now", "gerade eben"},
+		{30 * time.Second, "just now", "gerade eben"},
+		{time.Minute, "a minute ago", "vor einer Minute"},
+		{59 * time.Minute, "59 minutes ago", "vor 59 Minuten"},
+		{90 * time.Minute, "an hour ago", "vor einer Stunde"},
+		{5 * time.Hour, "5 hours ago", "vor 5 Stunden"},
+		{36 * time.Hour, "a day ago", "vor einem Tag"},
+		{29 * 24 * time.Hour, "29 days ago", "vor 29 Tagen"},
+		{45 * 24 * time.Hour, "a month ago", "vor einem Monat"},
+		{300 * 24 * time.Hour, "10 months ago", "vor 10 Monaten"},
+		{400 * 24 * time.Hour, "a year ago", "vor einem Jahr"},
+		{3 * 365 * 24 * time.Hour, "3 years ago", "vor 3 Jahren"},
+	}
+
+	for _, test := range tests {
+		c.Run(test.english, func(c *qt.C) {
+			c.Assert(AgoIn(i18n.English, test.duration), qt.Equals, test.english)
+			c.Assert(AgoIn(i18n.German, test.duration), qt.Equals, test.german)
+			c.Assert(AgoIn(language.French, test.duration), qt.Equals, test.english)
+		})
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"fmt"
 	"strconv"
+
+	"github.com/Chatterino/api/pkg/i18n"
+	"golang.org/x/text/language"
 )
 
 func insertCommas(str string, n int) string {
@@ -36,3 +39,24 @@ func NumberInt64(number int64) string {
 	inMillions := float64(number) / 1_000_000
 	return fmt.Sprintf("%.1fM", inMillions)
 }
+
+// NumberIn formats the number like Number, using the separators of the given language
+// Example output (German): 1.234 or 1,5 Mio.
+func NumberIn(lang language.Tag, number uint64) string {
+	p := i18n.Printer(lang)
+	if number < 1_000_000 {
+		return p.Sprintf("%d", number)
+	}
+
+	return p.Sprintf("%.1fM", float64(number)/1_000_000)
+}
+
+// NumberInt64In formats the number like NumberInt64, using the separators of the given language
+func NumberInt64In(lang language.Tag, number int64) string {
+	p := i18n.Printer(lang)
+	if number < 1_000_000 {
+		return p.Sprintf("%d", number)
+	}
+
+	return p.Sprintf("%.1fM", float64(number)/1_000_000)
+}
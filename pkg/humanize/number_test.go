@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/Chatterino/api/pkg/humanize"
+	"github.com/Chatterino/api/pkg/i18n"
 	qt "github.com/frankban/quicktest"
+	"golang.org/x/text/language"
 )
 
 func TestNumber(t *testing.T) {
@@ -56,3 +58,26 @@ func TestNumberInt64(t *testing.T) {
 		})
 	}
 }
+
+func TestNumberIn(t *testing.T) {
+	c := qt.New(t)
+	type testCase struct {
+		lang     language.Tag
+		input    uint64
+		expected string
+	}
+	cases := []testCase{
+		{i18n.English, 1_000, "1,000"},
+		{i18n.English, 1_500_000, "1.5M"},
+		{i18n.German, 100, "100"},
+		{i18n.German, 1_000, "1.000"},
+		{i18n.German, 1_500_000, "1,5 Mio."},
+	}
+
+	for _, tc := range cases {
+		c.Run("", func(c *qt.C) {
+			res := humanize.NumberIn(tc.lang, tc.input)
+			c.Assert(res, qt.Equals, tc.expected)
+		})
+	}
+}
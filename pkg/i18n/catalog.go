@@ -0,0 +1,130 @@
+package i18n
+
+import (
+	"golang.org/x/text/language"
+	"golang.org/x/text/message/catalog"
+)
+
+// Translations of the messages used in tooltips, keyed by the English message.
+// Messages missing from a language are shown in English.
+var translations = map[string]map[string]string{
+	"de": {
+		// Labels
+		"Author":          "Autor",
+		"By":              "Von",
+		"Category":        "Kategorie",
+		"Channel":         "Kanal",
+		"Chapter":         "Kapitel",
+		"Clipped by":      "Geclippt von",
+		"Content warning": "Inhaltswarnung",
+		"Created":         "Erstellt",
+		"Description":     "Beschreibung",
+		"Duration":        "Dauer",
+		"Files":           "Dateien",
+		"Followers":       "Follower",
+		"Game":            "Spiel",
+		"Inviter":         "Eingeladen von",
+		"Joined Date":     "Beigetreten",
+		"Language":        "Sprache",
+		"Last VOD":        "Letztes VOD",
+		"Last live":       "Zuletzt live",
+		"Live channels":   "Live-Kanäle",
+		"Members":         "Mitglieder",
+		"Platform":        "Plattform",
+		"Published":       "Veröffentlicht",
+		"Reddit score":    "Reddit-Punkte",
+		"Schedule":        "Zeitplan",
+		"Score":           "Punkte",
+		"Server Created":  "Server erstellt",
+		"Server Perks":    "Server-Vorteile",
+		"Size":            "Größe",
+		"Stars":           "Sterne",
+		"Starts at":       "Beginnt bei",
+		"Subscribers":     "Abonnenten",
+		"Tags":            "Tags",
+		"Title":           "Titel",
+		"Track ID":        "Track-ID",
+		"Type":            "Typ",
+		"Uploaded":        "Hochgeladen",
+		"Uptime":          "Laufzeit",
+		"Videos":          "Videos",
+		"Viewers":         "Zuschauer",
+		"Views":           "Aufrufe",
+
+		// Flags
+		"AGE RESTRICTED": "ALTERSBESCHRÄNKT",
+		"ANIMATED":       "ANIMIERT",
+		"ARCHIVED":       "ARCHIVIERT",
+		"PRE-RELEASE":    "VORABVERSION",
+		"SECRET":         "GEHEIM",
+		"UNLISTED":       "NICHT GELISTET",
+
+		// Counts
+		"%.1fM":            "%.1f Mio.",
+		"%d pages":         "%d Seiten",
+		"%s boosts":        "%s Boosts",
+		"%s comments":      "%s Kommentare",
+		"%s files changed": "%s Dateien geändert",
+		"%s followers":     "%s Follower",
+		"%s likes":         "%s Likes",
+		"%s online":        "%s online",
+		"%s replies":       "%s Antworten",
+		"%s reposts":       "%s Reposts",
+		"%s retweets":      "%s Retweets",
+		"%s total":         "%s insgesamt",
+
+		// Relative times
+		"%d days ago":    "vor %d Tagen",
+		"%d hours ago":   "vor %d Stunden",
+		"%d minutes ago": "vor %d Minuten",
+		"%d months ago":  "vor %d Monaten",
+		"%d years ago":   "vor %d Jahren",
+		"a day ago":      "vor einem Tag",
+		"a minute ago":   "vor einer Minute",
+		"a month ago":    "vor einem Monat",
+		"a year ago":     "vor einem Jahr",
+		"an hour ago":    "vor einer Stunde",
+		"just now":       "gerade eben",
+
+		// Twitch video types
+		"Past broadcast": "Vergangene Übertragung",
+
+		// Twitch schedules
+		"No upcoming streams": "Keine geplanten Streams",
+		"On vacation until":   "Im Urlaub bis",
+
+		// GitHub issue and pull request states
+		"Closed": "Geschlossen",
+		"Draft":  "Entwurf",
+		"Merged": "Gemergt",
+		"Open":   "Offen",
+
+		// Titles
+		"%s 7TV Emote":       "%s 7TV-Emote",
+		"%s BetterTTV Emote": "%s BetterTTV-Emote",
+		"Empty album":        "Leeres Album",
+		"FrankerFaceZ Emote": "FrankerFaceZ-Emote",
+		"Global":             "Globales",
+		"Media File":         "Mediendatei",
+		"PDF File":           "PDF-Datei",
+		"Private":            "Privates",
+		"Shared":             "Geteiltes",
+	},
+}
+
+var messageCatalog = buildCatalog()
+
+func buildCatalog() *catalog.Builder {
+	builder := catalog.NewBuilder(catalog.Fallback(English))
+
+	for lang, messages := range translations {
+		tag := language.MustParse(lang)
+		for key, message := range messages {
+			if err := builder.SetString(tag, key, message); err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	return builder
+}
@@ -0,0 +1,86 @@
+package i18n
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+var (
+	English = language.English
+	German  = language.German
+
+	// Languages tooltips can be generated in. The first language is used if none of the
+	// client's languages are supported.
+	Supported = []language.Tag{English, German}
+
+	matcher  = language.NewMatcher(Supported)
+	printers = make(map[language.Tag]*message.Printer, len(Supported))
+)
+
+func init() {
+	for _, lang := range Supported {
+		printers[lang] = message.NewPrinter(lang, message.Catalog(messageCatalog))
+	}
+}
+
+type contextKey struct{}
+
+// FromRequest returns the supported language best matching the request's Accept-Language header
+func FromRequest(r *http.Request) language.Tag {
+	if r == nil {
+		return English
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if err != nil || len(tags) == 0 {
+		return English
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return English
+	}
+
+	return Supported[index]
+}
+
+// OnContext returns a copy of ctx carrying the language tooltips should be generated in
+func OnContext(ctx context.Context, lang language.Tag) context.Context {
+	return context.WithValue(ctx, contextKey{}, lang)
+}
+
+// FromContext returns the language set by OnContext, or English if none was set
+func FromContext(ctx context.Context) language.Tag {
+	if lang, ok := ctx.Value(contextKey{}).(language.Tag); ok {
+		return lang
+	}
+
+	return English
+}
+
+// Printer returns the message printer of the language, formatting numbers and translating messages
+// from the catalog. Unsupported languages use English.
+func Printer(lang language.Tag) *message.Printer {
+	if printer, ok := printers[lang]; ok {
+		return printer
+	}
+
+	return printers[English]
+}
+
+// T translates the English message (a fmt format string) to the language
+func T(lang language.Tag, message string, args ...any) string {
+	return Printer(lang).Sprintf(message, args...)
+}
+
+// AcceptLanguage returns the Accept-Language header value used when loading pages for the language
+func AcceptLanguage(lang language.Tag) string {
+	if lang == English {
+		return "en-US, en;q=0.9, *;q=0.5"
+	}
+
+	return lang.String() + ", en;q=0.8, *;q=0.5"
+}
@@ -0,0 +1,119 @@
+package i18n_test
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Chatterino/api/pkg/i18n"
+	qt "github.com/frankban/quicktest"
+	"golang.org/x/text/language"
+)
+
+func TestFromRequest(t *testing.T) {
+	c := qt.New(t)
+	type testCase struct {
+		acceptLanguage string
+		expected       language.Tag
+	}
+	cases := []testCase{
+		{"", i18n.English},
+		{"en-US", i18n.English},
+		{"de", i18n.German},
+		{"de-AT, en;q=0.5", i18n.German},
+		{"fr, de;q=0.8", i18n.German},
+		{"fr", i18n.English},
+		{"not a language;;", i18n.English},
+	}
+
+	for _, tc := range cases {
+		c.Run(tc.acceptLanguage, func(c *qt.C) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("Accept-Language", tc.acceptLanguage)
+			c.Assert(i18n.FromRequest(r), qt.Equals, tc.expected)
+		})
+	}
+
+	c.Run("Nil request", func(c *qt.C) {
+		c.Assert(i18n.FromRequest(nil), qt.Equals, i18n.English)
+	})
+}
+
+func TestContext(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	c.Assert(i18n.FromContext(ctx), qt.Equals, i18n.English)
+	c.Assert(i18n.FromContext(i18n.OnContext(ctx, i18n.German)), qt.Equals, i18n.German)
+}
+
+func TestT(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(i18n.T(i18n.English, "Views"), qt.Equals, "Views")
+	c.Assert(i18n.T(i18n.German, "Views"), qt.Equals, "Aufrufe")
+	c.Assert(i18n.T(i18n.German, "%s likes", "5"), qt.Equals, "5 Likes")
+	c.Assert(i18n.T(i18n.German, "%d", 1234), qt.Equals, "1.234")
+	c.Assert(i18n.T(i18n.English, "%d", 1234), qt.Equals, "1,234")
+
+	// Messages missing from the catalog are used as-is
+	c.Assert(i18n.T(i18n.German, "Not translated"), qt.Equals, "Not translated")
+	// Unsupported languages use English
+	c.Assert(i18n.T(language.French, "Views"), qt.Equals, "Views")
+}
+
+func TestTemplate(t *testing.T) {
+	c := qt.New(t)
+
+	tmpl := i18n.MustTemplate("test", `<b>{{t "Views"}}:</b> {{.}}`)
+
+	type testCase struct {
+		lang     language.Tag
+		expected string
+	}
+	cases := []testCase{
+		{i18n.English, `<b>Views:</b> &lt;3`},
+		{i18n.German, `<b>Aufrufe:</b> &lt;3`},
+		{language.French, `<b>Views:</b> &lt;3`},
+	}
+
+	for _, tc := range cases {
+		c.Run(tc.lang.String(), func(c *qt.C) {
+			var buf bytes.Buffer
+			c.Assert(tmpl.Execute(&buf, tc.lang, "<3"), qt.IsNil)
+			c.Assert(buf.String(), qt.Equals, tc.expected)
+		})
+	}
+
+	c.Run("Text template", func(c *qt.C) {
+		tmpl := i18n.MustTextTemplate("test", `<b>{{t "Views"}}:</b> {{.}}`)
+
+		var buf bytes.Buffer
+		c.Assert(tmpl.Execute(&buf, i18n.German, "<3"), qt.IsNil)
+		c.Assert(buf.String(), qt.Equals, `<b>Aufrufe:</b> <3`)
+	})
+
+	c.Run("Parsed template", func(c *qt.C) {
+		tmpl, err := i18n.ParseTemplate("test", `{{t "Views"}}: {{lang}}`, func(lang language.Tag) map[string]any {
+			return map[string]any{
+				"lang": func() string { return lang.String() },
+			}
+		})
+		c.Assert(err, qt.IsNil)
+
+		var buf bytes.Buffer
+		c.Assert(tmpl.Execute(&buf, i18n.German, nil), qt.IsNil)
+		c.Assert(buf.String(), qt.Equals, `Aufrufe: de`)
+
+		_, err = i18n.ParseTemplate("test", `{{lang}}`, nil)
+		c.Assert(err, qt.IsNotNil)
+	})
+}
+
+func TestAcceptLanguage(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(i18n.AcceptLanguage(i18n.English), qt.Equals, "en-US, en;q=0.9, *;q=0.5")
+	c.Assert(i18n.AcceptLanguage(i18n.German), qt.Equals, "de, en;q=0.8, *;q=0.5")
+}
@@ -0,0 +1,85 @@
+package i18n
+
+import (
+	htmltemplate "html/template"
+	"io"
+	texttemplate "text/template"
+
+	"golang.org/x/text/language"
+)
+
+type executor interface {
+	Execute(w io.Writer, data any) error
+}
+
+// Template is a template parsed once for each supported language.
+// The template's "t" function translates its arguments using T, e.g. {{ t "%s likes" .Likes }}.
+type Template struct {
+	templates map[language.Tag]executor
+}
+
+// funcs returns the template functions for the language
+func funcs(lang language.Tag) map[string]any {
+	return map[string]any{
+		"t": func(message string, args ...any) string {
+			return T(lang, message, args...)
+		},
+	}
+}
+
+// MustTemplate parses an html/template for each supported language
+func MustTemplate(name string, text string) *Template {
+	t, err := ParseTemplate(name, text, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// ParseTemplate parses an html/template for each supported language, for templates that aren't
+// known at compile time. extraFuncs may add template functions for each language.
+func ParseTemplate(name string, text string, extraFuncs func(lang language.Tag) map[string]any) (*Template, error) {
+	t := &Template{
+		templates: make(map[language.Tag]executor, len(Supported)),
+	}
+
+	for _, lang := range Supported {
+		tmpl := htmltemplate.New(name).Funcs(funcs(lang))
+		if extraFuncs != nil {
+			tmpl = tmpl.Funcs(extraFuncs(lang))
+		}
+
+		parsed, err := tmpl.Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		t.templates[lang] = parsed
+	}
+
+	return t, nil
+}
+
+// MustTextTemplate parses a text/template for each supported language, for tooltips whose data
+// is escaped by the loader
+func MustTextTemplate(name string, text string) *Template {
+	t := &Template{
+		templates: make(map[language.Tag]executor, len(Supported)),
+	}
+
+	for _, lang := range Supported {
+		t.templates[lang] = texttemplate.Must(texttemplate.New(name).Funcs(funcs(lang)).Parse(text))
+	}
+
+	return t
+}
+
+// Execute applies the template of the language to data. Unsupported languages use English.
+func (t *Template) Execute(w io.Writer, lang language.Tag, data any) error {
+	tmpl, ok := t.templates[lang]
+	if !ok {
+		tmpl = t.templates[English]
+	}
+
+	return tmpl.Execute(w, data)
+}
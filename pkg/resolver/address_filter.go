@@ -0,0 +1,186 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/config"
+)
+
+// ErrForbiddenAddress is returned when a request would connect to an address that's not allowed
+// by the address filter, e.g. a hostname resolving to a loopback or private address
+var ErrForbiddenAddress = errors.New("forbidden address")
+
+// Addresses we never want to fetch links from, unless they're explicitly allowed in ssrf-allow-cidrs
+var defaultBlockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),      // "this" network
+	netip.MustParsePrefix("10.0.0.0/8"),     // private
+	netip.MustParsePrefix("100.64.0.0/10"),  // carrier-grade NAT
+	netip.MustParsePrefix("127.0.0.0/8"),    // loopback
+	netip.MustParsePrefix("169.254.0.0/16"), // link-local, includes cloud metadata endpoints
+	netip.MustParsePrefix("172.16.0.0/12"),  // private
+	netip.MustParsePrefix("192.0.0.0/24"),   // IETF protocol assignments
+	netip.MustParsePrefix("192.168.0.0/16"), // private
+	netip.MustParsePrefix("198.18.0.0/15"),  // benchmarking
+	netip.MustParsePrefix("224.0.0.0/4"),    // multicast
+	netip.MustParsePrefix("240.0.0.0/4"),    // reserved, includes broadcast
+	netip.MustParsePrefix("::/128"),         // unspecified
+	netip.MustParsePrefix("::1/128"),        // loopback
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64, can reach any IPv4 address through the gateway
+	netip.MustParsePrefix("64:ff9b:1::/48"), // local-use IPv4/IPv6 translation
+	netip.MustParsePrefix("2002::/16"),      // 6to4, embeds an IPv4 address
+	netip.MustParsePrefix("fc00::/7"),       // unique local addresses
+	netip.MustParsePrefix("fe80::/10"),      // link-local
+	netip.MustParsePrefix("ff00::/8"),       // multicast
+}
+
+type AddressFilter struct {
+	allowed []netip.Prefix
+	denied  []netip.Prefix
+}
+
+func containsAddr(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Allowed returns whether we're allowed to connect to the given address.
+// Allowed addresses take priority over denied addresses, which take priority over the default
+// blocked ranges.
+func (f *AddressFilter) Allowed(addr netip.Addr) bool {
+	// IPv4-mapped IPv6 addresses (::ffff:127.0.0.1) must be checked like the IPv4 address they map to
+	addr = addr.Unmap()
+
+	if containsAddr(f.allowed, addr) {
+		return true
+	}
+
+	if containsAddr(f.denied, addr) {
+		return false
+	}
+
+	return !containsAddr(defaultBlockedPrefixes, addr)
+}
+
+// control is used as the net.Dialer Control function, which is called with the resolved address
+// right before connecting. This makes sure every connection is checked, including connections made
+// while following redirects.
+func (f *AddressFilter) control(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+
+	if !f.Allowed(addr) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, addr)
+	}
+
+	return nil
+}
+
+func parsePrefixes(cidrs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			// Allow single addresses without a prefix length
+			addr, addrErr := netip.ParseAddr(cidr)
+			if addrErr != nil {
+				return nil, err
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	return prefixes, nil
+}
+
+func NewAddressFilter(allowedCIDRs []string, deniedCIDRs []string) (*AddressFilter, error) {
+	allowed, err := parsePrefixes(allowedCIDRs)
+	if err != nil {
+		return nil, err
+	}
+
+	denied, err := parsePrefixes(deniedCIDRs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AddressFilter{
+		allowed: allowed,
+		denied:  denied,
+	}, nil
+}
+
+// NewHardenedTransport returns a transport that refuses to connect to addresses not allowed by the filter.
+// Proxies from the environment aren't used, the filter would only check the address of the proxy.
+func NewHardenedTransport(filter *AddressFilter) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   filter.control,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = nil
+
+	return transport
+}
+
+// InitializeAddressFilter makes the shared HTTP client refuse to connect to loopback, private,
+// link-local and other internal addresses, to avoid links being used to probe our own network
+func InitializeAddressFilter(ctx context.Context, cfg config.APIConfig) {
+	log := logger.FromContext(ctx)
+
+	if !cfg.SSRFProtection {
+		log.Warnw("SSRF protection is disabled, links may be resolved to internal addresses")
+		return
+	}
+
+	filter, err := NewAddressFilter(cfg.SSRFAllowCIDRs, cfg.SSRFDenyCIDRs)
+	if err != nil {
+		log.Fatalw("Error parsing SSRF allow/deny CIDRs",
+			"error", err,
+		)
+	}
+
+	addressFilter = filter
+	httpClient.Transport = NewHardenedTransport(filter)
+}
+
+// IsForbiddenAddress returns whether the given literal IP address is not allowed by the address
+// filter. Hostnames are checked when connecting instead.
+func IsForbiddenAddress(host string) bool {
+	if addressFilter == nil {
+		return false
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+
+	return !addressFilter.Allowed(addr)
+}
+
+// Set by InitializeAddressFilter, nil if SSRF protection is disabled
+var addressFilter *AddressFilter
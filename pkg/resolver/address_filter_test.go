@@ -0,0 +1,125 @@
+package resolver
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestAddressFilterAllowed(t *testing.T) {
+	c := qt.New(t)
+
+	filter, err := NewAddressFilter([]string{"10.1.2.0/24", "192.168.5.5"}, []string{"203.0.113.0/24"})
+	c.Assert(err, qt.IsNil)
+
+	tests := []struct {
+		address  string
+		expected bool
+	}{
+		{"1.1.1.1", true},
+		{"2606:4700:4700::1111", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"10.0.0.1", false},
+		{"172.16.3.4", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"64:ff9b::7f00:1", false},
+		{"64:ff9b:1::a00:1", false},
+		{"2002:7f00:1::1", false},
+		{"10.1.2.3", true},
+		{"192.168.5.5", true},
+		{"192.168.5.6", false},
+		{"203.0.113.7", false},
+	}
+
+	for _, test := range tests {
+		c.Run(test.address, func(c *qt.C) {
+			c.Assert(filter.Allowed(netip.MustParseAddr(test.address)), qt.Equals, test.expected)
+		})
+	}
+}
+
+func TestNewAddressFilterInvalidCIDR(t *testing.T) {
+	c := qt.New(t)
+
+	_, err := NewAddressFilter([]string{"not a cidr"}, nil)
+	c.Assert(err, qt.IsNotNil)
+
+	_, err = NewAddressFilter(nil, []string{"10.0.0.0/33"})
+	c.Assert(err, qt.IsNotNil)
+}
+
+func TestHardenedTransport(t *testing.T) {
+	c := qt.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c.Run("loopback is blocked", func(c *qt.C) {
+		filter, err := NewAddressFilter(nil, nil)
+		c.Assert(err, qt.IsNil)
+
+		client := &http.Client{Transport: NewHardenedTransport(filter)}
+		_, err = client.Get(ts.URL)
+		c.Assert(errors.Is(err, ErrForbiddenAddress), qt.IsTrue)
+	})
+
+	c.Run("redirect to blocked address is blocked", func(c *qt.C) {
+		// Only the redirecting server is allowed, the address it redirects to is checked before connecting
+		filter, err := NewAddressFilter([]string{"127.0.0.1/32"}, nil)
+		c.Assert(err, qt.IsNil)
+
+		redirect := httptest.NewServer(http.RedirectHandler("http://127.0.0.2:1/", http.StatusFound))
+		defer redirect.Close()
+
+		client := &http.Client{Transport: NewHardenedTransport(filter)}
+		_, err = client.Get(redirect.URL)
+		c.Assert(errors.Is(err, ErrForbiddenAddress), qt.IsTrue)
+	})
+
+	c.Run("allowed loopback", func(c *qt.C) {
+		filter, err := NewAddressFilter([]string{"127.0.0.0/8"}, nil)
+		c.Assert(err, qt.IsNil)
+
+		client := &http.Client{Transport: NewHardenedTransport(filter)}
+		resp, err := client.Get(ts.URL)
+		c.Assert(err, qt.IsNil)
+		defer resp.Body.Close()
+		c.Assert(resp.StatusCode, qt.Equals, http.StatusOK)
+	})
+
+	c.Run("proxies from the environment are not used", func(c *qt.C) {
+		filter, err := NewAddressFilter(nil, nil)
+		c.Assert(err, qt.IsNil)
+
+		c.Assert(NewHardenedTransport(filter).Proxy, qt.IsNil)
+	})
+}
+
+func TestIsForbiddenAddress(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(IsForbiddenAddress("127.0.0.1"), qt.IsFalse)
+
+	filter, err := NewAddressFilter(nil, nil)
+	c.Assert(err, qt.IsNil)
+	addressFilter = filter
+	defer func() { addressFilter = nil }()
+
+	c.Assert(IsForbiddenAddress("127.0.0.1"), qt.IsTrue)
+	c.Assert(IsForbiddenAddress("1.1.1.1"), qt.IsFalse)
+	c.Assert(IsForbiddenAddress("example.com"), qt.IsFalse)
+}
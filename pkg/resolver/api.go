@@ -0,0 +1,106 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/i18n"
+)
+
+// Maximum size of API responses decoded by DecodeJSON
+const MaxAPIResponseSize = 5 * 1024 * 1024
+
+// JSONRequest is a GET request to a JSON API, made by RequestJSON
+type JSONRequest struct {
+	// Requests aren't limited by an upstream if it's nil
+	Upstream *Upstream
+
+	// Name of the API in error messages, e.g. "Kick API"
+	API string
+	URL string
+
+	// Sent in addition to "Accept: application/json" and the Accept-Language of the client, which
+	// they may override
+	Headers map[string]string
+
+	// Returned for 404 and 410 responses, and responses with one of NotFoundStatuses
+	NotFound         *Response
+	NotFoundStatuses []int
+
+	// CheckResponse handles API specific responses before their status code is checked, e.g. rate
+	// limits reported with a 403. If it returns false, the response is handled by RequestJSON.
+	CheckResponse func(resp *http.Response) (handled bool, response *Response, cacheDuration time.Duration, err error)
+}
+
+// DecodeJSON decodes at most MaxAPIResponseSize bytes of the body into v
+func DecodeJSON(body io.Reader, v any) error {
+	return json.NewDecoder(io.LimitReader(body, MaxAPIResponseSize)).Decode(v)
+}
+
+// RequestJSON requests an endpoint of a JSON API in the language of the client (see i18n.FromContext)
+// and decodes its response into v.
+// If the request didn't succeed, the loader should return what RequestJSON returned instead.
+func RequestJSON(ctx context.Context, r JSONRequest, v any) (*Response, time.Duration, error) {
+	log := logger.FromContext(ctx)
+
+	log.Debugw("[resolver] GET JSON",
+		"url", r.URL,
+	)
+
+	headers := map[string]string{
+		"Accept":          "application/json",
+		"Accept-Language": i18n.AcceptLanguage(i18n.FromContext(ctx)),
+	}
+	for key, value := range r.Headers {
+		headers[key] = value
+	}
+
+	resp, err := r.Upstream.Do(func() (*http.Response, error) {
+		return RequestGETWithHeaders(r.URL, headers)
+	})
+	if err != nil {
+		if errors.Is(err, ErrUpstreamUnavailable) {
+			// Don't cache anything, so stale entries can still be served
+			return nil, NoSpecialDur, err
+		}
+
+		return Errorf("%s request error: %s", r.API, err)
+	}
+	defer resp.Body.Close()
+
+	if r.CheckResponse != nil {
+		if handled, response, cacheDuration, err := r.CheckResponse(resp); handled {
+			return response, cacheDuration, err
+		}
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return nil, NoSpecialDur, fmt.Errorf("%w: %s rate limited", ErrUpstreamUnavailable, r.API)
+
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone || slices.Contains(r.NotFoundStatuses, resp.StatusCode):
+		return r.NotFound, NoSpecialDur, nil
+
+	case resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices:
+		return Errorf("%s returned status %d", r.API, resp.StatusCode)
+	}
+
+	// Some APIs serve an HTML page instead of JSON when they block a request
+	if contentType := resp.Header.Get("Content-Type"); strings.HasPrefix(contentType, "text/html") {
+		return Errorf("%s returned content type %s", r.API, contentType)
+	}
+
+	if err := DecodeJSON(resp.Body, v); err != nil {
+		return Errorf("%s unmarshal error: %s", r.API, err)
+	}
+
+	return nil, NoSpecialDur, nil
+}
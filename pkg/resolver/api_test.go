@@ -0,0 +1,140 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/i18n"
+	qt "github.com/frankban/quicktest"
+)
+
+func TestRequestJSON(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"accept":%q,"language":%q}`, r.Header.Get("Accept"), r.Header.Get("Accept-Language"))
+		case "/invalid":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"accept":`)
+		case "/html":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			fmt.Fprint(w, `<html></html>`)
+		case "/gone":
+			w.WriteHeader(http.StatusGone)
+		case "/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+		case "/ratelimited":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	notFound := &Response{
+		Status:  http.StatusNotFound,
+		Message: "Nothing found",
+	}
+
+	type document struct {
+		Accept   string `json:"accept"`
+		Language string `json:"language"`
+	}
+
+	request := func(path string) JSONRequest {
+		return JSONRequest{
+			API:      "Test API",
+			URL:      ts.URL + path,
+			NotFound: notFound,
+		}
+	}
+
+	c.Run("OK", func(c *qt.C) {
+		var v document
+		response, _, err := RequestJSON(ctx, request("/ok"), &v)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response, qt.IsNil)
+		c.Assert(v.Accept, qt.Equals, "application/json")
+		c.Assert(v.Language, qt.Equals, i18n.AcceptLanguage(i18n.English))
+	})
+
+	c.Run("Language of the client", func(c *qt.C) {
+		var v document
+		response, _, err := RequestJSON(i18n.OnContext(ctx, i18n.German), request("/ok"), &v)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response, qt.IsNil)
+		c.Assert(v.Language, qt.Equals, i18n.AcceptLanguage(i18n.German))
+	})
+
+	c.Run("Headers override accept", func(c *qt.C) {
+		r := request("/ok")
+		r.Headers = map[string]string{"Accept": "application/vnd.test+json"}
+
+		var v document
+		response, _, err := RequestJSON(ctx, r, &v)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response, qt.IsNil)
+		c.Assert(v.Accept, qt.Equals, "application/vnd.test+json")
+	})
+
+	c.Run("Not found", func(c *qt.C) {
+		response, _, err := RequestJSON(ctx, request("/gone"), &document{})
+		c.Assert(err, qt.IsNil)
+		c.Assert(response, qt.Equals, notFound)
+
+		r := request("/forbidden")
+		r.NotFoundStatuses = []int{http.StatusForbidden}
+		response, _, err = RequestJSON(ctx, r, &document{})
+		c.Assert(err, qt.IsNil)
+		c.Assert(response, qt.Equals, notFound)
+	})
+
+	c.Run("Errors", func(c *qt.C) {
+		tests := []struct {
+			path     string
+			expected string
+		}{
+			{"/forbidden", "Test API returned status 403"},
+			{"/error", "Test API returned status 500"},
+			{"/html", "Test API returned content type text/html; charset=utf-8"},
+			{"/invalid", "Test API unmarshal error: unexpected EOF"},
+		}
+
+		for _, test := range tests {
+			response, _, err := RequestJSON(ctx, request(test.path), &document{})
+			c.Assert(err, qt.IsNil)
+			c.Assert(response.Status, qt.Equals, http.StatusInternalServerError)
+			c.Assert(response.Message, qt.Equals, test.expected)
+		}
+	})
+
+	c.Run("Rate limited", func(c *qt.C) {
+		_, _, err := RequestJSON(ctx, request("/ratelimited"), &document{})
+		c.Assert(err, qt.ErrorIs, ErrUpstreamUnavailable)
+	})
+
+	c.Run("Check response", func(c *qt.C) {
+		r := request("/forbidden")
+		r.CheckResponse = func(resp *http.Response) (bool, *Response, time.Duration, error) {
+			return resp.StatusCode == http.StatusForbidden, nil, NoSpecialDur, ErrDontHandle
+		}
+
+		_, _, err := RequestJSON(ctx, r, &document{})
+		c.Assert(err, qt.ErrorIs, ErrDontHandle)
+
+		var v document
+		r.URL = ts.URL + "/ok"
+		response, _, err := RequestJSON(ctx, r, &v)
+		c.Assert(err, qt.IsNil)
+		c.Assert(response, qt.IsNil)
+	})
+}
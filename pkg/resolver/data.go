@@ -0,0 +1,54 @@
+package resolver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// DataMediaType can be sent in the Accept header to ask for structured tooltip data
+const DataMediaType = "application/vnd.chatterino.data+json"
+
+// WantsData returns whether the client asked for the structured tooltip data, either with the
+// data=true query parameter or by accepting DataMediaType
+func WantsData(r *http.Request) bool {
+	if r == nil {
+		return false
+	}
+
+	switch r.URL.Query().Get("data") {
+	case "1", "true":
+		return true
+	}
+
+	for _, accept := range r.Header.Values("Accept") {
+		if strings.Contains(accept, DataMediaType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WithoutData removes the structured tooltip data from the marshalled response, for clients that
+// didn't ask for it
+func WithoutData(payload []byte) []byte {
+	if !bytes.Contains(payload, []byte(`"data":`)) {
+		return payload
+	}
+
+	var response Response
+	if err := json.Unmarshal(payload, &response); err != nil || response.Data == nil {
+		return payload
+	}
+
+	response.Data = nil
+
+	stripped, err := json.Marshal(&response)
+	if err != nil {
+		return payload
+	}
+
+	return stripped
+}
@@ -0,0 +1,72 @@
+package resolver
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestWantsData(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		label    string
+		url      string
+		accept   string
+		expected bool
+	}{
+		{"No opt in", "/link_resolver/x", "", false},
+		{"Query true", "/link_resolver/x?data=true", "", true},
+		{"Query 1", "/link_resolver/x?data=1", "", true},
+		{"Query false", "/link_resolver/x?data=false", "", false},
+		{"Accept", "/link_resolver/x", "application/json, " + DataMediaType, true},
+		{"Other accept", "/link_resolver/x", "application/json", false},
+	}
+
+	for _, test := range tests {
+		c.Run(test.label, func(c *qt.C) {
+			r := httptest.NewRequest("GET", test.url, nil)
+			if test.accept != "" {
+				r.Header.Set("Accept", test.accept)
+			}
+			c.Assert(WantsData(r), qt.Equals, test.expected)
+		})
+	}
+
+	c.Run("Nil request", func(c *qt.C) {
+		c.Assert(WantsData(nil), qt.IsFalse)
+	})
+}
+
+func TestWithoutData(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		label    string
+		input    string
+		expected string
+	}{
+		{
+			"With data",
+			`{"status":200,"tooltip":"xD","data":{"kind":"video","title":"xD"}}`,
+			`{"status":200,"tooltip":"xD"}`,
+		},
+		{
+			"Without data",
+			`{"status":200,"tooltip":"\"data\":"}`,
+			`{"status":200,"tooltip":"\"data\":"}`,
+		},
+		{
+			"Not JSON",
+			`"data": xD`,
+			`"data": xD`,
+		},
+	}
+
+	for _, test := range tests {
+		c.Run(test.label, func(c *qt.C) {
+			c.Assert(string(WithoutData([]byte(test.input))), qt.Equals, test.expected)
+		})
+	}
+}
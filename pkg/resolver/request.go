@@ -9,6 +9,7 @@ import (
 
 	"github.com/Chatterino/api/internal/logger"
 	"github.com/Chatterino/api/internal/version"
+	"github.com/Chatterino/api/pkg/i18n"
 )
 
 var (
@@ -28,22 +29,24 @@ func RequestGET(ctx context.Context, url string) (response *http.Response, err e
 		return nil, err
 	}
 
-	// ensures websites return pages in english (e.g. twitter would return french preview
-	// when the request came from a french IP.)
-	req.Header.Add("Accept-Language", "en-US, en;q=0.9, *;q=0.5")
+	// ensures websites return pages in the language of the client instead of guessing it from our
+	// IP (e.g. twitter would return french previews when the request came from a french IP.)
+	req.Header.Add("Accept-Language", i18n.AcceptLanguage(i18n.FromContext(ctx)))
 	req.Header.Set("User-Agent", fmt.Sprintf("chatterino-api-cache/%s link-resolver", version.Version))
 
 	return httpClient.Do(req)
 }
 
+// RequestGETWithHeaders requests the URL in english. Callers localizing their responses set
+// Accept-Language in extraHeaders, which override the default headers (see RequestJSON).
 func RequestGETWithHeaders(url string, extraHeaders map[string]string) (response *http.Response, err error) {
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	// ensures websites return pages in english (e.g. twitter would return french preview
-	// when the request came from a french IP.)
+	// ensures websites return pages in english unless the caller asks for another language (e.g.
+	// twitter would return french previews when the request came from a french IP.)
 	req.Header.Add("Accept-Language", "en-US, en;q=0.9, *;q=0.5")
 	req.Header.Set("User-Agent", fmt.Sprintf("chatterino-api-cache/%s link-resolver", version.Version))
 
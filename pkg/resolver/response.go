@@ -8,6 +8,58 @@ type Response struct {
 	Tooltip   string `json:"tooltip,omitempty"`
 	Link      string `json:"link,omitempty"`
 
+	// Structured version of the tooltip, only sent to clients that ask for it (see WantsData)
+	Data *ResponseData `json:"data,omitempty"`
+
 	// Flag in the BTTV API to.. maybe signify that the link will download something? idk
 	// Download *bool  `json:"download,omitempty"`
 }
+
+// Kinds of ResponseData
+const (
+	DataKindArticle     = "article"
+	DataKindCategory    = "category"
+	DataKindChannel     = "channel"
+	DataKindClip        = "clip"
+	DataKindCommit      = "commit"
+	DataKindDocument    = "document"
+	DataKindEmote       = "emote"
+	DataKindIssue       = "issue"
+	DataKindLivestream  = "livestream"
+	DataKindMedia       = "media"
+	DataKindPlaylist    = "playlist"
+	DataKindPost        = "post"
+	DataKindPullRequest = "pull_request"
+	DataKindRelease     = "release"
+	DataKindRepository  = "repository"
+	DataKindSchedule    = "schedule"
+	DataKindUser        = "user"
+	DataKindVideo       = "video"
+)
+
+// ResponseData holds the information shown in a tooltip, allowing clients to build their own UI for it.
+// Which fields are set depends on the Kind and the resolver.
+type ResponseData struct {
+	Kind string `json:"kind"`
+
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Author      string `json:"author,omitempty"`
+
+	// In seconds
+	Duration  int64  `json:"duration,omitempty"`
+	Views     uint64 `json:"views,omitempty"`
+	Likes     uint64 `json:"likes,omitempty"`
+	Comments  uint64 `json:"comments,omitempty"`
+	Followers uint64 `json:"followers,omitempty"`
+	Count     uint64 `json:"count,omitempty"`
+
+	// RFC 3339 timestamp of when the content was created, or when the livestream started
+	Published string `json:"published,omitempty"`
+
+	Live bool `json:"live,omitempty"`
+	NSFW bool `json:"nsfw,omitempty"`
+
+	// Fields specific to the resolver, e.g. the provider of an emote
+	Fields map[string]string `json:"fields,omitempty"`
+}
@@ -19,6 +19,10 @@ var (
 
 	ForbiddenURLBytes = []byte(`{"status":403,"message":"Link forbidden"}`)
 
+	TimedOutBytes = []byte(`{"status":504,"message":"Could not fetch link info: Timed out"}`)
+
+	UpstreamUnavailableBytes = []byte(`{"status":503,"message":"Could not fetch link info: Service temporarily unavailable"}`)
+
 	// Dynamically created based on config
 	ResponseTooLarge []byte
 )
@@ -29,6 +33,12 @@ func ReturnInvalidURL() ([]byte, *int, *string, time.Duration, error) {
 	return InvalidURLBytes, &statusCode, &contentType, NoSpecialDur, nil
 }
 
+func ReturnForbiddenURL() ([]byte, *int, *string, time.Duration, error) {
+	statusCode := http.StatusForbidden
+	contentType := "application/json"
+	return ForbiddenURLBytes, &statusCode, &contentType, NoSpecialDur, nil
+}
+
 func WriteInvalidURL(w http.ResponseWriter) (int, error) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusBadRequest)
@@ -0,0 +1,344 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Chatterino/api/pkg/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ErrUpstreamUnavailable is returned by Upstream when a request is not made because the upstream is
+// rate limiting us or has been failing
+var ErrUpstreamUnavailable = errors.New("upstream unavailable")
+
+var (
+	upstreamCircuitState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "upstream_circuit_state",
+			Help: "State of the circuit breaker of the upstream API (0 = closed, 1 = half-open, 2 = open)",
+		},
+		[]string{"upstream"},
+	)
+	upstreamConsecutiveFailures = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "upstream_consecutive_failures",
+			Help: "Number of consecutive failed requests to the upstream API",
+		},
+		[]string{"upstream"},
+	)
+	upstreamRateLimitRemaining = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "upstream_rate_limit_remaining",
+			Help: "Remaining requests to the upstream API according to its last X-RateLimit-Remaining header",
+		},
+		[]string{"upstream"},
+	)
+	upstreamRejectedRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "upstream_rejected_requests_total",
+			Help: "Number of requests to the upstream API that were not made",
+		},
+		[]string{"upstream", "reason"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(upstreamCircuitState)
+	prometheus.MustRegister(upstreamConsecutiveFailures)
+	prometheus.MustRegister(upstreamRateLimitRemaining)
+	prometheus.MustRegister(upstreamRejectedRequests)
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitHalfOpen
+	circuitOpen
+)
+
+// Upstream limits the requests made to an upstream API, e.g. Discord or Twitter.
+//
+// Requests are rejected with ErrUpstreamUnavailable while:
+//   - the configured rate limit (upstream-rate-limits) is exceeded
+//   - the upstream told us to back off using Retry-After or X-RateLimit-* headers
+//   - the circuit is open after upstream-failure-threshold consecutive failures. After
+//     upstream-open-duration, a single request is let through to check if the upstream recovered.
+//
+// A nil *Upstream allows all requests.
+type Upstream struct {
+	name string
+
+	failureThreshold int
+	openDuration     time.Duration
+
+	// Token bucket, disabled if rate is 0
+	rate       float64
+	burst      float64
+	tokens     float64
+	lastRefill time.Time
+
+	mutex sync.Mutex
+
+	state     circuitState
+	failures  int
+	openUntil time.Time
+	// Set while the request checking if the upstream recovered is in flight
+	probing bool
+
+	// Set by Retry-After and X-RateLimit-* headers
+	blockedUntil time.Time
+
+	now func() time.Time
+}
+
+func NewUpstream(cfg config.APIConfig, name string) *Upstream {
+	u := &Upstream{
+		name: name,
+
+		failureThreshold: cfg.UpstreamFailureThreshold,
+		openDuration:     cfg.UpstreamOpenDuration,
+
+		rate: cfg.UpstreamRateLimits[name],
+
+		now: time.Now,
+	}
+
+	if u.failureThreshold <= 0 {
+		u.failureThreshold = 5
+	}
+	if u.openDuration <= 0 {
+		u.openDuration = 30 * time.Second
+	}
+
+	if u.rate > 0 {
+		u.burst = math.Max(1, math.Ceil(u.rate))
+		u.tokens = u.burst
+		u.lastRefill = u.now()
+	}
+
+	upstreamCircuitState.WithLabelValues(name).Set(float64(circuitClosed))
+	upstreamConsecutiveFailures.WithLabelValues(name).Set(0)
+
+	return u
+}
+
+func (u *Upstream) Name() string {
+	return u.name
+}
+
+func (u *Upstream) reject(reason string) error {
+	upstreamRejectedRequests.WithLabelValues(u.name, reason).Inc()
+	return fmt.Errorf("%w: %s %s", ErrUpstreamUnavailable, u.name, reason)
+}
+
+func (u *Upstream) setState(state circuitState) {
+	u.state = state
+	upstreamCircuitState.WithLabelValues(u.name).Set(float64(state))
+}
+
+// Allow returns ErrUpstreamUnavailable if no request should be made to the upstream right now.
+// If it returns nil, the outcome of the request must be recorded with Observe or ObserveStatus.
+func (u *Upstream) Allow() error {
+	if u == nil {
+		return nil
+	}
+
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	now := u.now()
+
+	if now.Before(u.blockedUntil) {
+		return u.reject("rate limited")
+	}
+
+	switch u.state {
+	case circuitOpen:
+		if now.Before(u.openUntil) {
+			return u.reject("circuit open")
+		}
+		u.setState(circuitHalfOpen)
+		fallthrough
+
+	case circuitHalfOpen:
+		if u.probing {
+			return u.reject("circuit open")
+		}
+	}
+
+	if u.rate > 0 {
+		u.tokens = math.Min(u.burst, u.tokens+now.Sub(u.lastRefill).Seconds()*u.rate)
+		u.lastRefill = now
+
+		if u.tokens < 1 {
+			return u.reject("rate limit exceeded")
+		}
+		u.tokens--
+	}
+
+	if u.state == circuitHalfOpen {
+		u.probing = true
+	}
+
+	return nil
+}
+
+// Release gives back a request allowed by Allow that wasn't made after all, without recording an
+// outcome. If the circuit is half-open, the next request checks whether the upstream recovered.
+func (u *Upstream) Release() {
+	if u == nil {
+		return
+	}
+
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.probing = false
+
+	if u.rate > 0 {
+		u.tokens = math.Min(u.burst, u.tokens+1)
+	}
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of seconds or an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return now.Add(time.Duration(seconds) * time.Second), true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return date, true
+	}
+
+	return time.Time{}, false
+}
+
+// parseRateLimitReset parses the time the rate limit resets at from X-RateLimit-Reset-After (seconds,
+// used by Discord) or X-RateLimit-Reset (a unix timestamp, or seconds for some APIs)
+func parseRateLimitReset(header http.Header, now time.Time) (time.Time, bool) {
+	if resetAfter, err := strconv.ParseFloat(header.Get("X-RateLimit-Reset-After"), 64); err == nil {
+		return now.Add(time.Duration(resetAfter * float64(time.Second))), true
+	}
+
+	reset, err := strconv.ParseFloat(header.Get("X-RateLimit-Reset"), 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	// Small values can't be unix timestamps, so they must be a number of seconds
+	if reset < 1e9 {
+		return now.Add(time.Duration(reset * float64(time.Second))), true
+	}
+
+	return time.Unix(0, int64(reset*float64(time.Second))), true
+}
+
+// Observe records the outcome of a request allowed by Allow
+func (u *Upstream) Observe(resp *http.Response, err error) {
+	if resp == nil {
+		u.ObserveStatus(0, nil, err)
+		return
+	}
+
+	u.ObserveStatus(resp.StatusCode, resp.Header, err)
+}
+
+// ObserveStatus records the outcome of a request allowed by Allow, for API clients that don't
+// expose the *http.Response. statusCode is 0 if the request failed without a response.
+func (u *Upstream) ObserveStatus(statusCode int, header http.Header, err error) {
+	if u == nil {
+		return
+	}
+
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	now := u.now()
+
+	u.probing = false
+
+	if header != nil {
+		if remaining, parseErr := strconv.Atoi(header.Get("X-RateLimit-Remaining")); parseErr == nil {
+			upstreamRateLimitRemaining.WithLabelValues(u.name).Set(float64(remaining))
+
+			if remaining <= 0 {
+				if reset, ok := parseRateLimitReset(header, now); ok && reset.After(u.blockedUntil) {
+					u.blockedUntil = reset
+				}
+			}
+		}
+
+		if statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable {
+			if retryAfter, ok := parseRetryAfter(header.Get("Retry-After"), now); ok && retryAfter.After(u.blockedUntil) {
+				u.blockedUntil = retryAfter
+			}
+		}
+	}
+
+	// Requests canceled by us say nothing about the upstream
+	failed := (err != nil && !errors.Is(err, context.Canceled)) || statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+	if !failed {
+		u.failures = 0
+		upstreamConsecutiveFailures.WithLabelValues(u.name).Set(0)
+		u.setState(circuitClosed)
+		return
+	}
+
+	u.failures++
+	upstreamConsecutiveFailures.WithLabelValues(u.name).Set(float64(u.failures))
+
+	if u.state == circuitHalfOpen || u.failures >= u.failureThreshold {
+		u.openUntil = now.Add(u.openDuration)
+		u.setState(circuitOpen)
+	}
+}
+
+// Do makes the request using do if the upstream is available, and records its outcome
+func (u *Upstream) Do(do func() (*http.Response, error)) (*http.Response, error) {
+	if err := u.Allow(); err != nil {
+		return nil, err
+	}
+
+	resp, err := do()
+	u.Observe(resp, err)
+
+	return resp, err
+}
+
+// RoundTripper wraps the given transport, recording the outcome of all requests made through it.
+// Useful for API client libraries accepting an *http.Client, which must call Allow before using the
+// library. A nil transport uses http.DefaultTransport.
+func (u *Upstream) RoundTripper(transport http.RoundTripper) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &upstreamRoundTripper{
+		upstream:  u,
+		transport: transport,
+	}
+}
+
+type upstreamRoundTripper struct {
+	upstream  *Upstream
+	transport http.RoundTripper
+}
+
+func (t *upstreamRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	t.upstream.Observe(resp, err)
+
+	return resp, err
+}
@@ -0,0 +1,205 @@
+package resolver
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Chatterino/api/pkg/config"
+	qt "github.com/frankban/quicktest"
+)
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func newTestUpstream(cfg config.APIConfig) (*Upstream, *testClock) {
+	clock := &testClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	u := NewUpstream(cfg, "test")
+	u.now = clock.Now
+	u.lastRefill = clock.now
+
+	return u, clock
+}
+
+func TestUpstreamCircuitBreaker(t *testing.T) {
+	c := qt.New(t)
+
+	u, clock := newTestUpstream(config.APIConfig{
+		UpstreamFailureThreshold: 3,
+		UpstreamOpenDuration:     time.Minute,
+	})
+
+	fail := func() {
+		c.Assert(u.Allow(), qt.IsNil)
+		u.ObserveStatus(http.StatusInternalServerError, nil, nil)
+	}
+
+	fail()
+	fail()
+	// A success resets the consecutive failures
+	c.Assert(u.Allow(), qt.IsNil)
+	u.ObserveStatus(http.StatusNotFound, nil, nil)
+	fail()
+	fail()
+	c.Assert(u.state, qt.Equals, circuitClosed)
+	fail()
+	c.Assert(u.state, qt.Equals, circuitOpen)
+
+	err := u.Allow()
+	c.Assert(errors.Is(err, ErrUpstreamUnavailable), qt.IsTrue)
+
+	clock.now = clock.now.Add(time.Minute)
+
+	// Only a single request checks if the upstream recovered
+	c.Assert(u.Allow(), qt.IsNil)
+	c.Assert(u.state, qt.Equals, circuitHalfOpen)
+	c.Assert(errors.Is(u.Allow(), ErrUpstreamUnavailable), qt.IsTrue)
+
+	// Still failing, open the circuit again
+	u.ObserveStatus(0, nil, errors.New("connection refused"))
+	c.Assert(u.state, qt.Equals, circuitOpen)
+	c.Assert(errors.Is(u.Allow(), ErrUpstreamUnavailable), qt.IsTrue)
+
+	clock.now = clock.now.Add(time.Minute)
+
+	// A probe that isn't made lets the next request check the upstream
+	c.Assert(u.Allow(), qt.IsNil)
+	u.Release()
+	c.Assert(u.state, qt.Equals, circuitHalfOpen)
+
+	c.Assert(u.Allow(), qt.IsNil)
+	u.ObserveStatus(http.StatusOK, nil, nil)
+	c.Assert(u.state, qt.Equals, circuitClosed)
+	c.Assert(u.Allow(), qt.IsNil)
+	c.Assert(u.Allow(), qt.IsNil)
+}
+
+func TestUpstreamRateLimitHeaders(t *testing.T) {
+	c := qt.New(t)
+
+	c.Run("Retry-After seconds", func(c *qt.C) {
+		u, clock := newTestUpstream(config.APIConfig{})
+
+		c.Assert(u.Allow(), qt.IsNil)
+		u.ObserveStatus(http.StatusTooManyRequests, http.Header{"Retry-After": {"30"}}, nil)
+
+		c.Assert(errors.Is(u.Allow(), ErrUpstreamUnavailable), qt.IsTrue)
+		clock.now = clock.now.Add(29 * time.Second)
+		c.Assert(errors.Is(u.Allow(), ErrUpstreamUnavailable), qt.IsTrue)
+		clock.now = clock.now.Add(time.Second)
+		c.Assert(u.Allow(), qt.IsNil)
+	})
+
+	c.Run("Retry-After date", func(c *qt.C) {
+		u, clock := newTestUpstream(config.APIConfig{})
+
+		c.Assert(u.Allow(), qt.IsNil)
+		u.ObserveStatus(http.StatusServiceUnavailable, http.Header{
+			"Retry-After": {clock.now.Add(time.Minute).Format(http.TimeFormat)},
+		}, nil)
+
+		clock.now = clock.now.Add(59 * time.Second)
+		c.Assert(errors.Is(u.Allow(), ErrUpstreamUnavailable), qt.IsTrue)
+		clock.now = clock.now.Add(time.Second)
+		c.Assert(u.Allow(), qt.IsNil)
+	})
+
+	c.Run("X-RateLimit-Reset timestamp", func(c *qt.C) {
+		u, clock := newTestUpstream(config.APIConfig{})
+
+		c.Assert(u.Allow(), qt.IsNil)
+		u.ObserveStatus(http.StatusOK, http.Header{
+			"X-Ratelimit-Remaining": {"1"},
+			"X-Ratelimit-Reset":     {strconv.FormatInt(clock.now.Add(time.Minute).Unix(), 10)},
+		}, nil)
+		c.Assert(u.Allow(), qt.IsNil)
+
+		u.ObserveStatus(http.StatusOK, http.Header{
+			"X-Ratelimit-Remaining": {"0"},
+			"X-Ratelimit-Reset":     {strconv.FormatInt(clock.now.Add(time.Minute).Unix(), 10)},
+		}, nil)
+		c.Assert(errors.Is(u.Allow(), ErrUpstreamUnavailable), qt.IsTrue)
+
+		clock.now = clock.now.Add(time.Minute)
+		c.Assert(u.Allow(), qt.IsNil)
+	})
+
+	c.Run("X-RateLimit-Reset-After", func(c *qt.C) {
+		u, clock := newTestUpstream(config.APIConfig{})
+
+		c.Assert(u.Allow(), qt.IsNil)
+		u.ObserveStatus(http.StatusOK, http.Header{
+			"X-Ratelimit-Remaining":   {"0"},
+			"X-Ratelimit-Reset":       {strconv.FormatInt(clock.now.Add(time.Hour).Unix(), 10)},
+			"X-Ratelimit-Reset-After": {"2.5"},
+		}, nil)
+
+		clock.now = clock.now.Add(2 * time.Second)
+		c.Assert(errors.Is(u.Allow(), ErrUpstreamUnavailable), qt.IsTrue)
+		clock.now = clock.now.Add(500 * time.Millisecond)
+		c.Assert(u.Allow(), qt.IsNil)
+	})
+}
+
+func TestUpstreamConfiguredRateLimit(t *testing.T) {
+	c := qt.New(t)
+
+	u, clock := newTestUpstream(config.APIConfig{
+		UpstreamRateLimits: map[string]float64{
+			"test": 2,
+		},
+	})
+
+	c.Assert(u.Allow(), qt.IsNil)
+	c.Assert(u.Allow(), qt.IsNil)
+	c.Assert(errors.Is(u.Allow(), ErrUpstreamUnavailable), qt.IsTrue)
+
+	clock.now = clock.now.Add(500 * time.Millisecond)
+	c.Assert(u.Allow(), qt.IsNil)
+	c.Assert(errors.Is(u.Allow(), ErrUpstreamUnavailable), qt.IsTrue)
+}
+
+func TestUpstreamDo(t *testing.T) {
+	c := qt.New(t)
+
+	c.Run("Nil upstream", func(c *qt.C) {
+		var u *Upstream
+
+		calls := 0
+		resp, err := u.Do(func() (*http.Response, error) {
+			calls++
+			return &http.Response{StatusCode: http.StatusOK}, nil
+		})
+		c.Assert(err, qt.IsNil)
+		c.Assert(resp.StatusCode, qt.Equals, http.StatusOK)
+		c.Assert(calls, qt.Equals, 1)
+	})
+
+	c.Run("Rejected", func(c *qt.C) {
+		u, _ := newTestUpstream(config.APIConfig{})
+
+		calls := 0
+		do := func() (*http.Response, error) {
+			calls++
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": {"60"}},
+			}, nil
+		}
+
+		resp, err := u.Do(do)
+		c.Assert(err, qt.IsNil)
+		c.Assert(resp.StatusCode, qt.Equals, http.StatusTooManyRequests)
+
+		_, err = u.Do(do)
+		c.Assert(errors.Is(err, ErrUpstreamUnavailable), qt.IsTrue)
+		c.Assert(calls, qt.Equals, 1)
+	})
+}
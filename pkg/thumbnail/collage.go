@@ -0,0 +1,278 @@
+package thumbnail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/resolver"
+	"github.com/Chatterino/api/pkg/utils"
+	"github.com/davidbyttow/govips/v2/vips"
+)
+
+// CollageLayout is how the images of a collage are arranged
+type CollageLayout int
+
+const (
+	// LayoutGrid crops the images to squares of the same size, and arranges them in rows of
+	// CollageOptions.Columns images
+	LayoutGrid CollageLayout = iota
+	// LayoutRow scales the images to the same height, and puts them next to each other
+	LayoutRow
+)
+
+const (
+	defaultCollageColumns   = 2
+	defaultCollageMaxImages = 4
+	// Used if neither CollageOptions.MaxImageSize nor max-content-length are set
+	defaultCollageMaxImageSize = 5 * 1024 * 1024
+)
+
+var (
+	errNoImagesDownloaded = errors.New("couldn't download any of the images")
+	errImageTooLarge      = errors.New("image too large")
+)
+
+type CollageOptions struct {
+	Layout CollageLayout
+
+	// Number of images per row of LayoutGrid, defaults to 2
+	Columns int
+
+	// Maximum number of images in the collage, defaults to 4. Further images are left out.
+	MaxImages int
+
+	// Maximum size of the downloaded images in bytes, defaults to max-content-length.
+	// Larger images are left out of the collage.
+	MaxImageSize uint64
+
+	// Maximum width and height of the collage, defaults to max-thumbnail-size
+	MaxSize uint
+}
+
+func (o CollageOptions) withDefaults() CollageOptions {
+	if o.Columns <= 0 {
+		o.Columns = defaultCollageColumns
+	}
+	if o.MaxImages <= 0 {
+		o.MaxImages = defaultCollageMaxImages
+	}
+	if o.MaxImageSize == 0 {
+		o.MaxImageSize = cfg.MaxContentLength
+	}
+	if o.MaxImageSize == 0 {
+		o.MaxImageSize = defaultCollageMaxImageSize
+	}
+	if o.MaxSize == 0 {
+		o.MaxSize = cfg.MaxThumbnailSize
+	}
+
+	return o
+}
+
+// downloadImage downloads an image, making sure it's not larger than maxSize
+func downloadImage(ctx context.Context, url string, maxSize uint64) ([]byte, error) {
+	resp, err := resolver.RequestGET(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("unhandled status code: %d", resp.StatusCode)
+	}
+
+	if contentType := resp.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "image/") {
+		return nil, fmt.Errorf("unsupported content type: %s", contentType)
+	}
+
+	if contentLength, err := strconv.ParseUint(resp.Header.Get("Content-Length"), 10, 64); err == nil && contentLength > maxSize {
+		return nil, errImageTooLarge
+	}
+
+	buf, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxSize)+1))
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(buf)) > maxSize {
+		return nil, errImageTooLarge
+	}
+
+	return buf, nil
+}
+
+// downloadImages downloads the images in parallel. Images that couldn't be downloaded are nil.
+func downloadImages(ctx context.Context, imageURLs []string, maxSize uint64) [][]byte {
+	log := logger.FromContext(ctx)
+
+	downloaded := make([][]byte, len(imageURLs))
+	wg := new(sync.WaitGroup)
+
+	for idx, url := range imageURLs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			buf, err := downloadImage(ctx, url, maxSize)
+			if err != nil {
+				log.Errorw("Couldn't download collage image",
+					"url", url,
+					"err", err,
+				)
+				return
+			}
+
+			downloaded[idx] = buf
+		}()
+	}
+
+	wg.Wait()
+
+	return downloaded
+}
+
+// BuildCollage downloads the images and composes them into a collage. Images that can't be
+// downloaded or decoded are left out. Returns the collage and its content type.
+func BuildCollage(ctx context.Context, imageURLs []string, options CollageOptions) ([]byte, string, error) {
+	log := logger.FromContext(ctx)
+
+	options = options.withDefaults()
+	if len(imageURLs) > options.MaxImages {
+		imageURLs = imageURLs[:options.MaxImages]
+	}
+
+	var images []*vips.ImageRef
+	defer func() {
+		for _, ref := range images {
+			ref.Close()
+		}
+	}()
+
+	for _, buf := range downloadImages(ctx, imageURLs, options.MaxImageSize) {
+		if buf == nil {
+			continue
+		}
+
+		ref, err := vips.NewImageFromBuffer(buf)
+		if err != nil {
+			log.Errorw("Couldn't convert buffer to vips.ImageRef",
+				"err", err,
+			)
+			continue
+		}
+
+		images = append(images, ref)
+	}
+
+	if len(images) == 0 {
+		return nil, "", errNoImagesDownloaded
+	}
+
+	stem, err := composeCollage(images, options)
+	if err != nil {
+		return nil, "", err
+	}
+
+	maxSize := int(options.MaxSize)
+	err = stem.ThumbnailWithSize(maxSize, maxSize, vips.InterestingNone, vips.SizeDown)
+	if err != nil {
+		return nil, "", fmt.Errorf("couldn't generate thumbnail: %w", err)
+	}
+
+	outputBuf, metaData, err := stem.ExportNative()
+	if err != nil {
+		return nil, "", fmt.Errorf("couldn't export collage: %w", err)
+	}
+
+	return outputBuf, utils.MimeType(metaData.Format), nil
+}
+
+// composeCollage arranges the images according to the layout. The first image becomes the collage.
+func composeCollage(images []*vips.ImageRef, options CollageOptions) (*vips.ImageRef, error) {
+	stem := images[0]
+
+	switch options.Layout {
+	case LayoutRow:
+		// Scale the images down to the height of the smallest one
+		smallestHeight := math.MaxInt
+		for _, ref := range images {
+			smallestHeight = min(smallestHeight, ref.Height())
+		}
+
+		for _, ref := range images {
+			if err := ref.ThumbnailWithSize(ref.Width(), smallestHeight, vips.InterestingNone, vips.SizeDown); err != nil {
+				return nil, fmt.Errorf("couldn't resize image: %w", err)
+			}
+		}
+
+		for _, ref := range images[1:] {
+			if err := stem.Join(ref, vips.DirectionHorizontal); err != nil {
+				return nil, fmt.Errorf("couldn't join images: %w", err)
+			}
+		}
+
+	default:
+		// Crop the images to squares of the smallest dimension found
+		smallestDimension := math.MaxInt
+		for _, ref := range images {
+			smallestDimension = min(smallestDimension, ref.Width(), ref.Height())
+		}
+
+		for _, ref := range images {
+			if err := ref.ThumbnailWithSize(smallestDimension, smallestDimension, vips.InterestingCentre, vips.SizeDown); err != nil {
+				return nil, fmt.Errorf("couldn't resize image: %w", err)
+			}
+		}
+
+		if err := stem.ArrayJoin(images[1:], min(options.Columns, len(images))); err != nil {
+			return nil, fmt.Errorf("couldn't join images: %w", err)
+		}
+	}
+
+	return stem, nil
+}
+
+// Collage is a collage thumbnail of the media of a link. It's stored in CollageCache as a dependent
+// value of the link's cache entry, so it's removed along with it.
+type Collage struct {
+	BaseURL      string
+	CollageCache cache.DependentCache
+	// Cache key of the link the collage belongs to
+	ParentKey  string
+	CollageKey string
+	Options    CollageOptions
+}
+
+// BuildThumbnailURL builds a collage of the images, stores it in the collage cache and returns the
+// URL it's served at. An empty string is returned if the collage couldn't be built.
+func (c *Collage) BuildThumbnailURL(ctx context.Context, imageURLs []string, r *http.Request) string {
+	log := logger.FromContext(ctx)
+
+	outputBuf, contentType, err := BuildCollage(ctx, imageURLs, c.Options)
+	if err != nil {
+		log.Errorw("Couldn't build collage",
+			"collageKey", c.CollageKey,
+			"err", err,
+		)
+		return ""
+	}
+
+	err = c.CollageCache.Insert(ctx, c.CollageKey, c.ParentKey, outputBuf, contentType)
+	if err != nil {
+		log.Errorw("Couldn't insert collage into cache",
+			"collageKey", c.CollageKey,
+			"err", err,
+		)
+		return ""
+	}
+
+	return utils.FormatGeneratedThumbnailURL(c.BaseURL, r, c.CollageKey)
+}
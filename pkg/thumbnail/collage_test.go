@@ -0,0 +1,174 @@
+package thumbnail
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Chatterino/api/internal/logger"
+	"github.com/Chatterino/api/pkg/cache"
+	"github.com/Chatterino/api/pkg/config"
+	qt "github.com/frankban/quicktest"
+)
+
+// testImageServer serves 10 byte "images" under /image, and counts the requests made to it
+func testImageServer(requests *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		switch r.URL.Path {
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("0123456789"))
+		case "/chunked":
+			// Flushing before writing the body makes the response chunked, without a Content-Length
+			w.Header().Set("Content-Type", "image/png")
+			w.(http.Flusher).Flush()
+			w.Write([]byte("0123456789"))
+		case "/page":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html></html>"))
+		default:
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		}
+	}))
+}
+
+func TestCollageOptions(t *testing.T) {
+	c := qt.New(t)
+
+	c.Run("Defaults", func(c *qt.C) {
+		c.Assert(CollageOptions{}.withDefaults(), qt.DeepEquals, CollageOptions{
+			Layout:       LayoutGrid,
+			Columns:      2,
+			MaxImages:    4,
+			MaxImageSize: defaultCollageMaxImageSize,
+		})
+	})
+
+	c.Run("Config", func(c *qt.C) {
+		cfg = config.APIConfig{
+			MaxContentLength: 1024,
+			MaxThumbnailSize: 300,
+		}
+		defer func() {
+			cfg = config.APIConfig{}
+		}()
+
+		options := CollageOptions{Layout: LayoutRow, MaxImages: 10}.withDefaults()
+		c.Assert(options, qt.DeepEquals, CollageOptions{
+			Layout:       LayoutRow,
+			Columns:      2,
+			MaxImages:    10,
+			MaxImageSize: 1024,
+			MaxSize:      300,
+		})
+	})
+}
+
+func TestDownloadImage(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	var requests atomic.Int32
+	ts := testImageServer(&requests)
+	defer ts.Close()
+
+	c.Run("Image", func(c *qt.C) {
+		buf, err := downloadImage(ctx, ts.URL+"/image", 10)
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(buf), qt.Equals, "0123456789")
+	})
+
+	c.Run("Too large", func(c *qt.C) {
+		_, err := downloadImage(ctx, ts.URL+"/image", 9)
+		c.Assert(err, qt.ErrorIs, errImageTooLarge)
+	})
+
+	c.Run("Too large without content length", func(c *qt.C) {
+		_, err := downloadImage(ctx, ts.URL+"/chunked", 9)
+		c.Assert(err, qt.ErrorIs, errImageTooLarge)
+
+		buf, err := downloadImage(ctx, ts.URL+"/chunked", 10)
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(buf), qt.Equals, "0123456789")
+	})
+
+	c.Run("Not an image", func(c *qt.C) {
+		_, err := downloadImage(ctx, ts.URL+"/page", 1024)
+		c.Assert(err, qt.ErrorMatches, "unsupported content type: text/html")
+	})
+
+	c.Run("Not found", func(c *qt.C) {
+		_, err := downloadImage(ctx, ts.URL+"/404", 1024)
+		c.Assert(err, qt.ErrorMatches, "unhandled status code: 404")
+	})
+}
+
+func TestDownloadImages(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	var requests atomic.Int32
+	ts := testImageServer(&requests)
+	defer ts.Close()
+
+	downloaded := downloadImages(ctx, []string{ts.URL + "/image", ts.URL + "/404", ts.URL + "/chunked"}, 1024)
+	c.Assert(downloaded, qt.HasLen, 3)
+	c.Assert(string(downloaded[0]), qt.Equals, "0123456789")
+	c.Assert(downloaded[1], qt.IsNil)
+	c.Assert(string(downloaded[2]), qt.Equals, "0123456789")
+}
+
+func TestBuildCollage(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	var requests atomic.Int32
+	ts := testImageServer(&requests)
+	defer ts.Close()
+
+	c.Run("No images", func(c *qt.C) {
+		_, _, err := BuildCollage(ctx, []string{ts.URL + "/404", ts.URL + "/page"}, CollageOptions{MaxSize: 300})
+		c.Assert(err, qt.ErrorIs, errNoImagesDownloaded)
+	})
+
+	c.Run("Max images", func(c *qt.C) {
+		requests.Store(0)
+
+		// The served images can't be decoded, but only the first ones are downloaded
+		imageURLs := strings.Split(strings.Repeat(ts.URL+"/image ", 6), " ")[:6]
+		_, _, err := BuildCollage(ctx, imageURLs, CollageOptions{MaxImages: 3, MaxSize: 300})
+		c.Assert(err, qt.ErrorIs, errNoImagesDownloaded)
+		c.Assert(requests.Load(), qt.Equals, int32(3))
+	})
+}
+
+func TestCollage(t *testing.T) {
+	ctx := logger.OnContext(context.Background(), logger.NewTest())
+	c := qt.New(t)
+
+	var requests atomic.Int32
+	ts := testImageServer(&requests)
+	defer ts.Close()
+
+	collageCache := cache.NewMemoryDependentCache(ctx, config.APIConfig{}, cache.NewPrefixKeyProvider("test:collage"))
+	collage := &Collage{
+		BaseURL:      "https://api.example.com",
+		CollageCache: collageCache,
+		ParentKey:    "test:post:1",
+		CollageKey:   "test:collage:1",
+		Options: CollageOptions{
+			MaxSize: 300,
+		},
+	}
+
+	// Nothing is stored if the collage can't be built
+	c.Assert(collage.BuildThumbnailURL(ctx, []string{ts.URL + "/404", ts.URL + "/404"}, nil), qt.Equals, "")
+	value, _, err := collageCache.Get(ctx, collage.CollageKey)
+	c.Assert(err, qt.IsNil)
+	c.Assert(value, qt.IsNil)
+}